@@ -5,10 +5,12 @@ import (
 	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems"
 	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 	"io"
 	"io/ioutil"
 	"log"
 	"math"
+	"net"
 	"os"
 	"strconv"
 	"time"
@@ -45,6 +47,14 @@ type _conn_commitServiceClient struct {
 	io.Closer
 	CommitServiceClient
 }
+type _conn_MetaServiceClient struct {
+	io.Closer
+	MetaServiceClient
+}
+type _conn_NodeServiceClient struct {
+	io.Closer
+	NodeServiceClient
+}
 type _conn_StorageServiceClient struct {
 	io.Closer
 	StorageServiceClient
@@ -67,6 +77,23 @@ func dial(address string) (*grpc.ClientConn, error) {
 func Close(closer interface{}) error {
 	return closer.(io.Closer).Close()
 }
+
+// CommitConflictsOf returns details of conflicts if Commit() is failed by conflicts.  Otherwise, it returns nil.
+func CommitConflictsOf(err error) *CommitConflicts {
+	for ; err != nil; err = xerrors.Unwrap(err) {
+		st, ok := status.FromError(err)
+		if !ok {
+			continue
+		}
+		for _, detail := range st.Details() {
+			if conflicts, ok := detail.(*CommitConflicts); ok {
+				return conflicts
+			}
+		}
+		return nil
+	}
+	return nil
+}
 func CommitService() (CommitServiceClient, error) {
 	cc, err := dial(controllerURI)
 	if err != nil {
@@ -77,8 +104,41 @@ func CommitService() (CommitServiceClient, error) {
 		CommitServiceClient: NewCommitServiceClient(cc),
 	}, nil
 }
+func MetaService() (MetaServiceClient, error) {
+	cc, err := dial(controllerURI)
+	if err != nil {
+		return nil, xerrors.Errorf("dial: %w", err)
+	}
+	return &_conn_MetaServiceClient{
+		Closer:            cc,
+		MetaServiceClient: NewMetaServiceClient(cc),
+	}, nil
+}
+func NodeService() (NodeServiceClient, error) {
+	cc, err := dial(controllerURI)
+	if err != nil {
+		return nil, xerrors.Errorf("dial: %w", err)
+	}
+	return &_conn_NodeServiceClient{
+		Closer:            cc,
+		NodeServiceClient: NewNodeServiceClient(cc),
+	}, nil
+}
 func StorageService() (StorageServiceClient, error) {
-	cc, err := dial(storageURI)
+	return StorageServiceAt(storageURI)
+}
+
+// StorageServiceOf connects to the storage node registered to the controller.
+func StorageServiceOf(node *Node) (StorageServiceClient, error) {
+	if len(node.GetAddress()) == 0 {
+		return nil, xerrors.Errorf("node %s does not have any address", node.GetName())
+	}
+	return StorageServiceAt(net.JoinHostPort(node.GetAddress()[0], strconv.Itoa(subsystems.StoragePort)))
+}
+
+// StorageServiceAt connects to the storage node at the address instead of the default storage node.
+func StorageServiceAt(address string) (StorageServiceClient, error) {
+	cc, err := dial(address)
 	if err != nil {
 		return nil, xerrors.Errorf("dial: %w", err)
 	}
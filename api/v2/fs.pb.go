@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,6 +26,103 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type ListCommitsOrder int32
+
+const (
+	// 左の親のみを辿り、新しい順に返す。
+	ListCommitsOrder_FirstParent ListCommitsOrder = 0
+	// 左右両方の親を辿り、子コミットを必ず親コミットより先に返す。
+	ListCommitsOrder_Topological ListCommitsOrder = 1
+	// 左右両方の親を辿り、作成日時の新しい順に返す。
+	ListCommitsOrder_Date ListCommitsOrder = 2
+)
+
+var ListCommitsOrder_name = map[int32]string{
+	0: "FirstParent",
+	1: "Topological",
+	2: "Date",
+}
+
+var ListCommitsOrder_value = map[string]int32{
+	"FirstParent": 0,
+	"Topological": 1,
+	"Date":        2,
+}
+
+func (x ListCommitsOrder) String() string {
+	return proto.EnumName(ListCommitsOrder_name, int32(x))
+}
+
+func (ListCommitsOrder) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{0}
+}
+
+type MergeFilter int32
+
+const (
+	// 全てのコミットを返す。
+	MergeFilter_AllCommits MergeFilter = 0
+	// マージコミットのみを返す。
+	MergeFilter_MergesOnly MergeFilter = 1
+	// マージコミット以外を返す。
+	MergeFilter_NoMerges MergeFilter = 2
+)
+
+var MergeFilter_name = map[int32]string{
+	0: "AllCommits",
+	1: "MergesOnly",
+	2: "NoMerges",
+}
+
+var MergeFilter_value = map[string]int32{
+	"AllCommits": 0,
+	"MergesOnly": 1,
+	"NoMerges":   2,
+}
+
+func (x MergeFilter) String() string {
+	return proto.EnumName(MergeFilter_name, int32(x))
+}
+
+func (MergeFilter) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{1}
+}
+
+type PathChangeType int32
+
+const (
+	// The path is created.
+	PathChangeType_Added PathChangeType = 0
+	// The path is removed.
+	PathChangeType_Deleted PathChangeType = 1
+	// The file is changed at the same path.
+	PathChangeType_Modified PathChangeType = 2
+	// The file is moved from oldPath.  It may be changed at the same time.
+	PathChangeType_Renamed PathChangeType = 3
+)
+
+var PathChangeType_name = map[int32]string{
+	0: "Added",
+	1: "Deleted",
+	2: "Modified",
+	3: "Renamed",
+}
+
+var PathChangeType_value = map[string]int32{
+	"Added":    0,
+	"Deleted":  1,
+	"Modified": 2,
+	"Renamed":  3,
+}
+
+func (x PathChangeType) String() string {
+	return proto.EnumName(PathChangeType_name, int32(x))
+}
+
+func (PathChangeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{2}
+}
+
 type CreateVolumeRequest struct {
 	Info                 *VolumeInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -178,7 +277,11 @@ type ListVolumesRequest struct {
 	// 個数制限を無効化することはできない。
 	Limit uint64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
 	// ページネーションされたときは、前回の最後の応答についていたnextの値を設定。
-	Next                 string   `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	Next string `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	// ラベルによる絞り込み条件。空の場合は全てのvolumeを返す。
+	// カンマ区切りで複数の条件を指定でき、全ての条件に一致したvolumeのみを返す。
+	// 条件の書式: "key=value", "key!=value", "key" (キーが存在する), "!key" (キーが存在しない)
+	LabelSelector        string   `protobuf:"bytes,3,opt,name=labelSelector,proto3" json:"labelSelector,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -223,6 +326,13 @@ func (m *ListVolumesRequest) GetNext() string {
 	return ""
 }
 
+func (m *ListVolumesRequest) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
 type ListVolumesResponse struct {
 	// streamの一番最後、かつ個数制限により応答できていないアイテムが存在する場合、この値が設定される。
 	// 次のVolumeService.List()のnext引数に設定すると、次のアイテムから列挙することが出来る。
@@ -376,375 +486,2638 @@ func (m *InspectVolumeResponse) GetInfo() *VolumeInfo {
 	return nil
 }
 
-type GetLastCommitRequest struct {
-	VolumeId             *VolumeID `protobuf:"bytes,1,opt,name=volumeId,proto3" json:"volumeId,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type UpdateVolumeRequest struct {
+	Id   *VolumeID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Info *VolumeInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	// 更新するフィールド名のリスト。空の場合はInvalidArgsを返す。
+	UpdateMask           []string `protobuf:"bytes,3,rep,name=updateMask,proto3" json:"updateMask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetLastCommitRequest) Reset()         { *m = GetLastCommitRequest{} }
-func (m *GetLastCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*GetLastCommitRequest) ProtoMessage()    {}
-func (*GetLastCommitRequest) Descriptor() ([]byte, []int) {
+func (m *UpdateVolumeRequest) Reset()         { *m = UpdateVolumeRequest{} }
+func (m *UpdateVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateVolumeRequest) ProtoMessage()    {}
+func (*UpdateVolumeRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_e604833c2b457e38, []int{8}
 }
 
-func (m *GetLastCommitRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetLastCommitRequest.Unmarshal(m, b)
+func (m *UpdateVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateVolumeRequest.Unmarshal(m, b)
 }
-func (m *GetLastCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetLastCommitRequest.Marshal(b, m, deterministic)
+func (m *UpdateVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateVolumeRequest.Marshal(b, m, deterministic)
 }
-func (m *GetLastCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetLastCommitRequest.Merge(m, src)
+func (m *UpdateVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateVolumeRequest.Merge(m, src)
 }
-func (m *GetLastCommitRequest) XXX_Size() int {
-	return xxx_messageInfo_GetLastCommitRequest.Size(m)
+func (m *UpdateVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateVolumeRequest.Size(m)
 }
-func (m *GetLastCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetLastCommitRequest.DiscardUnknown(m)
+func (m *UpdateVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateVolumeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetLastCommitRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateVolumeRequest proto.InternalMessageInfo
 
-func (m *GetLastCommitRequest) GetVolumeId() *VolumeID {
+func (m *UpdateVolumeRequest) GetId() *VolumeID {
 	if m != nil {
-		return m.VolumeId
+		return m.Id
 	}
 	return nil
 }
 
-type GetLastCommitResponse struct {
-	Id                   *CommitID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Info                 *CommitInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+func (m *UpdateVolumeRequest) GetInfo() *VolumeInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *UpdateVolumeRequest) GetUpdateMask() []string {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type UpdateVolumeResponse struct {
+	// 更新後のメタデータ。
+	Info                 *VolumeInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *GetLastCommitResponse) Reset()         { *m = GetLastCommitResponse{} }
-func (m *GetLastCommitResponse) String() string { return proto.CompactTextString(m) }
-func (*GetLastCommitResponse) ProtoMessage()    {}
-func (*GetLastCommitResponse) Descriptor() ([]byte, []int) {
+func (m *UpdateVolumeResponse) Reset()         { *m = UpdateVolumeResponse{} }
+func (m *UpdateVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateVolumeResponse) ProtoMessage()    {}
+func (*UpdateVolumeResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_e604833c2b457e38, []int{9}
 }
 
-func (m *GetLastCommitResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetLastCommitResponse.Unmarshal(m, b)
+func (m *UpdateVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateVolumeResponse.Unmarshal(m, b)
 }
-func (m *GetLastCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetLastCommitResponse.Marshal(b, m, deterministic)
+func (m *UpdateVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateVolumeResponse.Marshal(b, m, deterministic)
 }
-func (m *GetLastCommitResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetLastCommitResponse.Merge(m, src)
+func (m *UpdateVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateVolumeResponse.Merge(m, src)
 }
-func (m *GetLastCommitResponse) XXX_Size() int {
-	return xxx_messageInfo_GetLastCommitResponse.Size(m)
+func (m *UpdateVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateVolumeResponse.Size(m)
 }
-func (m *GetLastCommitResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetLastCommitResponse.DiscardUnknown(m)
+func (m *UpdateVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateVolumeResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetLastCommitResponse proto.InternalMessageInfo
-
-func (m *GetLastCommitResponse) GetId() *CommitID {
-	if m != nil {
-		return m.Id
-	}
-	return nil
-}
+var xxx_messageInfo_UpdateVolumeResponse proto.InternalMessageInfo
 
-func (m *GetLastCommitResponse) GetInfo() *CommitInfo {
+func (m *UpdateVolumeResponse) GetInfo() *VolumeInfo {
 	if m != nil {
 		return m.Info
 	}
 	return nil
 }
 
-type ListCommitsRequest struct {
-	// 一回のRPCリクエストに対して返答できる最大の個数。
-	// 0個の場合は、デフォルトの制限を適用。
-	// 個数制限を無効化することはできない。
-	Limit uint64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	// ページネーションされたときは、前回の最後の応答についていたnextの値を設定。
-	// 初回のリクエストの場合、空の文字列を指定。
-	Next string `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
-	// コミットの一覧を取得するvolume。
-	Id                   *VolumeID `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type ImportVolumeRequest struct {
+	Id                   *VolumeID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Info                 *VolumeInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *ListCommitsRequest) Reset()         { *m = ListCommitsRequest{} }
-func (m *ListCommitsRequest) String() string { return proto.CompactTextString(m) }
-func (*ListCommitsRequest) ProtoMessage()    {}
-func (*ListCommitsRequest) Descriptor() ([]byte, []int) {
+func (m *ImportVolumeRequest) Reset()         { *m = ImportVolumeRequest{} }
+func (m *ImportVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportVolumeRequest) ProtoMessage()    {}
+func (*ImportVolumeRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_e604833c2b457e38, []int{10}
 }
 
-func (m *ListCommitsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListCommitsRequest.Unmarshal(m, b)
+func (m *ImportVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportVolumeRequest.Unmarshal(m, b)
 }
-func (m *ListCommitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListCommitsRequest.Marshal(b, m, deterministic)
+func (m *ImportVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportVolumeRequest.Marshal(b, m, deterministic)
 }
-func (m *ListCommitsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListCommitsRequest.Merge(m, src)
+func (m *ImportVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportVolumeRequest.Merge(m, src)
 }
-func (m *ListCommitsRequest) XXX_Size() int {
-	return xxx_messageInfo_ListCommitsRequest.Size(m)
+func (m *ImportVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportVolumeRequest.Size(m)
 }
-func (m *ListCommitsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListCommitsRequest.DiscardUnknown(m)
+func (m *ImportVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportVolumeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListCommitsRequest proto.InternalMessageInfo
-
-func (m *ListCommitsRequest) GetLimit() uint64 {
-	if m != nil {
-		return m.Limit
-	}
-	return 0
-}
+var xxx_messageInfo_ImportVolumeRequest proto.InternalMessageInfo
 
-func (m *ListCommitsRequest) GetNext() string {
+func (m *ImportVolumeRequest) GetId() *VolumeID {
 	if m != nil {
-		return m.Next
+		return m.Id
 	}
-	return ""
+	return nil
 }
 
-func (m *ListCommitsRequest) GetId() *VolumeID {
+func (m *ImportVolumeRequest) GetInfo() *VolumeInfo {
 	if m != nil {
-		return m.Id
+		return m.Info
 	}
 	return nil
 }
 
-type ListCommitsResponse struct {
-	// streamの一番最後、かつ個数制限により応答できていないアイテムが存在する場合、この値が設定される。
-	// 次のCommitService.List()のnext引数に設定すると、次のアイテムから列挙することが出来る。
-	Next                 string    `protobuf:"bytes,1,opt,name=next,proto3" json:"next,omitempty"`
-	Id                   *CommitID `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type ImportVolumeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListCommitsResponse) Reset()         { *m = ListCommitsResponse{} }
-func (m *ListCommitsResponse) String() string { return proto.CompactTextString(m) }
-func (*ListCommitsResponse) ProtoMessage()    {}
-func (*ListCommitsResponse) Descriptor() ([]byte, []int) {
+func (m *ImportVolumeResponse) Reset()         { *m = ImportVolumeResponse{} }
+func (m *ImportVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportVolumeResponse) ProtoMessage()    {}
+func (*ImportVolumeResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_e604833c2b457e38, []int{11}
 }
 
-func (m *ListCommitsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListCommitsResponse.Unmarshal(m, b)
+func (m *ImportVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportVolumeResponse.Unmarshal(m, b)
 }
-func (m *ListCommitsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListCommitsResponse.Marshal(b, m, deterministic)
+func (m *ImportVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportVolumeResponse.Marshal(b, m, deterministic)
 }
-func (m *ListCommitsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListCommitsResponse.Merge(m, src)
+func (m *ImportVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportVolumeResponse.Merge(m, src)
 }
-func (m *ListCommitsResponse) XXX_Size() int {
-	return xxx_messageInfo_ListCommitsResponse.Size(m)
+func (m *ImportVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportVolumeResponse.Size(m)
 }
-func (m *ListCommitsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListCommitsResponse.DiscardUnknown(m)
+func (m *ImportVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportVolumeResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListCommitsResponse proto.InternalMessageInfo
+var xxx_messageInfo_ImportVolumeResponse proto.InternalMessageInfo
 
-func (m *ListCommitsResponse) GetNext() string {
+type SetRetentionPolicyRequest struct {
+	Id                   *VolumeID        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Policy               *RetentionPolicy `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *SetRetentionPolicyRequest) Reset()         { *m = SetRetentionPolicyRequest{} }
+func (m *SetRetentionPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*SetRetentionPolicyRequest) ProtoMessage()    {}
+func (*SetRetentionPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{12}
+}
+
+func (m *SetRetentionPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRetentionPolicyRequest.Unmarshal(m, b)
+}
+func (m *SetRetentionPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRetentionPolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *SetRetentionPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRetentionPolicyRequest.Merge(m, src)
+}
+func (m *SetRetentionPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_SetRetentionPolicyRequest.Size(m)
+}
+func (m *SetRetentionPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRetentionPolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRetentionPolicyRequest proto.InternalMessageInfo
+
+func (m *SetRetentionPolicyRequest) GetId() *VolumeID {
 	if m != nil {
-		return m.Next
+		return m.Id
 	}
-	return ""
+	return nil
 }
 
-func (m *ListCommitsResponse) GetId() *CommitID {
+func (m *SetRetentionPolicyRequest) GetPolicy() *RetentionPolicy {
 	if m != nil {
-		return m.Id
+		return m.Policy
 	}
 	return nil
 }
 
-type GetCommitRequest struct {
-	Id                   *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type SetRetentionPolicyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetCommitRequest) Reset()         { *m = GetCommitRequest{} }
-func (m *GetCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*GetCommitRequest) ProtoMessage()    {}
-func (*GetCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e604833c2b457e38, []int{12}
+func (m *SetRetentionPolicyResponse) Reset()         { *m = SetRetentionPolicyResponse{} }
+func (m *SetRetentionPolicyResponse) String() string { return proto.CompactTextString(m) }
+func (*SetRetentionPolicyResponse) ProtoMessage()    {}
+func (*SetRetentionPolicyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{13}
 }
 
-func (m *GetCommitRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetCommitRequest.Unmarshal(m, b)
+func (m *SetRetentionPolicyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRetentionPolicyResponse.Unmarshal(m, b)
 }
-func (m *GetCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetCommitRequest.Marshal(b, m, deterministic)
+func (m *SetRetentionPolicyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRetentionPolicyResponse.Marshal(b, m, deterministic)
 }
-func (m *GetCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetCommitRequest.Merge(m, src)
+func (m *SetRetentionPolicyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRetentionPolicyResponse.Merge(m, src)
 }
-func (m *GetCommitRequest) XXX_Size() int {
-	return xxx_messageInfo_GetCommitRequest.Size(m)
+func (m *SetRetentionPolicyResponse) XXX_Size() int {
+	return xxx_messageInfo_SetRetentionPolicyResponse.Size(m)
 }
-func (m *GetCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetCommitRequest.DiscardUnknown(m)
+func (m *SetRetentionPolicyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRetentionPolicyResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetCommitRequest proto.InternalMessageInfo
-
-func (m *GetCommitRequest) GetId() *CommitID {
-	if m != nil {
-		return m.Id
-	}
-	return nil
-}
+var xxx_messageInfo_SetRetentionPolicyResponse proto.InternalMessageInfo
 
-type GetCommitResponse struct {
-	Id                   *CommitID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Info                 *CommitInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+type PruneVolumeRequest struct {
+	Id *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// trueの場合は、削除対象のコミットを返すだけで、実際には削除しない。
+	DryRun               bool     `protobuf:"varint,2,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetCommitResponse) Reset()         { *m = GetCommitResponse{} }
-func (m *GetCommitResponse) String() string { return proto.CompactTextString(m) }
-func (*GetCommitResponse) ProtoMessage()    {}
-func (*GetCommitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e604833c2b457e38, []int{13}
+func (m *PruneVolumeRequest) Reset()         { *m = PruneVolumeRequest{} }
+func (m *PruneVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneVolumeRequest) ProtoMessage()    {}
+func (*PruneVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{14}
 }
 
-func (m *GetCommitResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetCommitResponse.Unmarshal(m, b)
+func (m *PruneVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneVolumeRequest.Unmarshal(m, b)
 }
-func (m *GetCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetCommitResponse.Marshal(b, m, deterministic)
+func (m *PruneVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneVolumeRequest.Marshal(b, m, deterministic)
 }
-func (m *GetCommitResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetCommitResponse.Merge(m, src)
+func (m *PruneVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneVolumeRequest.Merge(m, src)
 }
-func (m *GetCommitResponse) XXX_Size() int {
-	return xxx_messageInfo_GetCommitResponse.Size(m)
+func (m *PruneVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneVolumeRequest.Size(m)
 }
-func (m *GetCommitResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetCommitResponse.DiscardUnknown(m)
+func (m *PruneVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneVolumeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetCommitResponse proto.InternalMessageInfo
+var xxx_messageInfo_PruneVolumeRequest proto.InternalMessageInfo
 
-func (m *GetCommitResponse) GetId() *CommitID {
+func (m *PruneVolumeRequest) GetId() *VolumeID {
 	if m != nil {
 		return m.Id
 	}
 	return nil
 }
 
-func (m *GetCommitResponse) GetInfo() *CommitInfo {
+func (m *PruneVolumeRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type PruneVolumeResponse struct {
+	// 削除された（dryRunの場合は削除される予定の）コミット。
+	Deleted []*CommitID `protobuf:"bytes,1,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	// GCの対象として解放されたオブジェクト。dryRunの場合は常に空。
+	Released             []*ObjectKey `protobuf:"bytes,2,rep,name=released,proto3" json:"released,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PruneVolumeResponse) Reset()         { *m = PruneVolumeResponse{} }
+func (m *PruneVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneVolumeResponse) ProtoMessage()    {}
+func (*PruneVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{15}
+}
+
+func (m *PruneVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneVolumeResponse.Unmarshal(m, b)
+}
+func (m *PruneVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneVolumeResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneVolumeResponse.Merge(m, src)
+}
+func (m *PruneVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneVolumeResponse.Size(m)
+}
+func (m *PruneVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneVolumeResponse proto.InternalMessageInfo
+
+func (m *PruneVolumeResponse) GetDeleted() []*CommitID {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
+func (m *PruneVolumeResponse) GetReleased() []*ObjectKey {
+	if m != nil {
+		return m.Released
+	}
+	return nil
+}
+
+type FsckVolumeRequest struct {
+	Id                   *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *FsckVolumeRequest) Reset()         { *m = FsckVolumeRequest{} }
+func (m *FsckVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*FsckVolumeRequest) ProtoMessage()    {}
+func (*FsckVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{16}
+}
+
+func (m *FsckVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FsckVolumeRequest.Unmarshal(m, b)
+}
+func (m *FsckVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FsckVolumeRequest.Marshal(b, m, deterministic)
+}
+func (m *FsckVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckVolumeRequest.Merge(m, src)
+}
+func (m *FsckVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_FsckVolumeRequest.Size(m)
+}
+func (m *FsckVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FsckVolumeRequest proto.InternalMessageInfo
+
+func (m *FsckVolumeRequest) GetId() *VolumeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type FsckVolumeResponse struct {
+	// 検査したコミットの数。
+	CheckedCommits uint64 `protobuf:"varint,1,opt,name=checkedCommits,proto3" json:"checkedCommits,omitempty"`
+	// 問題が見つかったコミット。問題がなければ空。
+	Damaged              []*FsckResult `protobuf:"bytes,2,rep,name=damaged,proto3" json:"damaged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *FsckVolumeResponse) Reset()         { *m = FsckVolumeResponse{} }
+func (m *FsckVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*FsckVolumeResponse) ProtoMessage()    {}
+func (*FsckVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{17}
+}
+
+func (m *FsckVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FsckVolumeResponse.Unmarshal(m, b)
+}
+func (m *FsckVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FsckVolumeResponse.Marshal(b, m, deterministic)
+}
+func (m *FsckVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckVolumeResponse.Merge(m, src)
+}
+func (m *FsckVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_FsckVolumeResponse.Size(m)
+}
+func (m *FsckVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FsckVolumeResponse proto.InternalMessageInfo
+
+func (m *FsckVolumeResponse) GetCheckedCommits() uint64 {
+	if m != nil {
+		return m.CheckedCommits
+	}
+	return 0
+}
+
+func (m *FsckVolumeResponse) GetDamaged() []*FsckResult {
+	if m != nil {
+		return m.Damaged
+	}
+	return nil
+}
+
+type FsckResult struct {
+	Id *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 見つかった問題の説明。
+	Problems             []string `protobuf:"bytes,2,rep,name=problems,proto3" json:"problems,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FsckResult) Reset()         { *m = FsckResult{} }
+func (m *FsckResult) String() string { return proto.CompactTextString(m) }
+func (*FsckResult) ProtoMessage()    {}
+func (*FsckResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{18}
+}
+
+func (m *FsckResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FsckResult.Unmarshal(m, b)
+}
+func (m *FsckResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FsckResult.Marshal(b, m, deterministic)
+}
+func (m *FsckResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckResult.Merge(m, src)
+}
+func (m *FsckResult) XXX_Size() int {
+	return xxx_messageInfo_FsckResult.Size(m)
+}
+func (m *FsckResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FsckResult proto.InternalMessageInfo
+
+func (m *FsckResult) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *FsckResult) GetProblems() []string {
+	if m != nil {
+		return m.Problems
+	}
+	return nil
+}
+
+type ForkVolumeRequest struct {
+	// 元のコミット。ref名でも指定できる。
+	Src                  *CommitID   `protobuf:"bytes,1,opt,name=src,proto3" json:"src,omitempty"`
+	Info                 *VolumeInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ForkVolumeRequest) Reset()         { *m = ForkVolumeRequest{} }
+func (m *ForkVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*ForkVolumeRequest) ProtoMessage()    {}
+func (*ForkVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{19}
+}
+
+func (m *ForkVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForkVolumeRequest.Unmarshal(m, b)
+}
+func (m *ForkVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForkVolumeRequest.Marshal(b, m, deterministic)
+}
+func (m *ForkVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForkVolumeRequest.Merge(m, src)
+}
+func (m *ForkVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_ForkVolumeRequest.Size(m)
+}
+func (m *ForkVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForkVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForkVolumeRequest proto.InternalMessageInfo
+
+func (m *ForkVolumeRequest) GetSrc() *CommitID {
+	if m != nil {
+		return m.Src
+	}
+	return nil
+}
+
+func (m *ForkVolumeRequest) GetInfo() *VolumeInfo {
 	if m != nil {
 		return m.Info
 	}
 	return nil
 }
 
-type CommitRequest struct {
-	Info                 *CommitInfo `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
-	Id                   *VolumeID   `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+type ForkVolumeResponse struct {
+	Id *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 新しいvolumeの最初のコミット。
+	Commit               *CommitID `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ForkVolumeResponse) Reset()         { *m = ForkVolumeResponse{} }
+func (m *ForkVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*ForkVolumeResponse) ProtoMessage()    {}
+func (*ForkVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{20}
+}
+
+func (m *ForkVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForkVolumeResponse.Unmarshal(m, b)
+}
+func (m *ForkVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForkVolumeResponse.Marshal(b, m, deterministic)
+}
+func (m *ForkVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForkVolumeResponse.Merge(m, src)
+}
+func (m *ForkVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_ForkVolumeResponse.Size(m)
+}
+func (m *ForkVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForkVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForkVolumeResponse proto.InternalMessageInfo
+
+func (m *ForkVolumeResponse) GetId() *VolumeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ForkVolumeResponse) GetCommit() *CommitID {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
+type GetLastCommitRequest struct {
+	VolumeId             *VolumeID `protobuf:"bytes,1,opt,name=volumeId,proto3" json:"volumeId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetLastCommitRequest) Reset()         { *m = GetLastCommitRequest{} }
+func (m *GetLastCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLastCommitRequest) ProtoMessage()    {}
+func (*GetLastCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{21}
+}
+
+func (m *GetLastCommitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLastCommitRequest.Unmarshal(m, b)
+}
+func (m *GetLastCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLastCommitRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLastCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLastCommitRequest.Merge(m, src)
+}
+func (m *GetLastCommitRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLastCommitRequest.Size(m)
+}
+func (m *GetLastCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLastCommitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLastCommitRequest proto.InternalMessageInfo
+
+func (m *GetLastCommitRequest) GetVolumeId() *VolumeID {
+	if m != nil {
+		return m.VolumeId
+	}
+	return nil
+}
+
+type GetLastCommitResponse struct {
+	Id                   *CommitID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Info                 *CommitInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetLastCommitResponse) Reset()         { *m = GetLastCommitResponse{} }
+func (m *GetLastCommitResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLastCommitResponse) ProtoMessage()    {}
+func (*GetLastCommitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{22}
+}
+
+func (m *GetLastCommitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLastCommitResponse.Unmarshal(m, b)
+}
+func (m *GetLastCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLastCommitResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLastCommitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLastCommitResponse.Merge(m, src)
+}
+func (m *GetLastCommitResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLastCommitResponse.Size(m)
+}
+func (m *GetLastCommitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLastCommitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLastCommitResponse proto.InternalMessageInfo
+
+func (m *GetLastCommitResponse) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *GetLastCommitResponse) GetInfo() *CommitInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+type ListCommitsRequest struct {
+	// 一回のRPCリクエストに対して返答できる最大の個数。
+	// 0個の場合は、デフォルトの制限を適用。
+	// 個数制限を無効化することはできない。
+	Limit uint64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// ページネーションされたときは、前回の最後の応答についていたnextの値を設定。
+	// 初回のリクエストの場合、空の文字列を指定。
+	// nextを指定した場合、refは無視される。それ以外の引数は、前回のリクエストと同じ値を指定すること。
+	Next string `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	// コミットの一覧を取得するvolume。
+	Id *VolumeID `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// 指定した場合は、refが指すコミットから履歴を辿る。
+	// 指定しない場合は、volumeの最新コミットから辿る。
+	Ref string `protobuf:"bytes,4,opt,name=ref,proto3" json:"ref,omitempty"`
+	// 履歴の辿り方と、コミットを返す順番。
+	Order ListCommitsOrder `protobuf:"varint,5,opt,name=order,proto3,enum=elton.v2.ListCommitsOrder" json:"order,omitempty"`
+	// 指定した場合は、作成日時がsince以降のコミットのみを返す。
+	Since *timestamp.Timestamp `protobuf:"bytes,6,opt,name=since,proto3" json:"since,omitempty"`
+	// 指定した場合は、作成日時がuntil以前のコミットのみを返す。
+	Until *timestamp.Timestamp `protobuf:"bytes,7,opt,name=until,proto3" json:"until,omitempty"`
+	// 指定した場合は、このコミットから辿れるコミット (このコミット自身を含む) のみを返す。ref名でも指定できる。
+	AncestorOf *CommitID `protobuf:"bytes,8,opt,name=ancestorOf,proto3" json:"ancestorOf,omitempty"`
+	// 指定した場合は、このコミットを祖先に持つコミット (このコミット自身を含む) のみを返す。ref名でも指定できる。
+	DescendantOf *CommitID `protobuf:"bytes,9,opt,name=descendantOf,proto3" json:"descendantOf,omitempty"`
+	// マージコミットによる絞り込み。
+	Merges               MergeFilter `protobuf:"varint,10,opt,name=merges,proto3,enum=elton.v2.MergeFilter" json:"merges,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *CommitRequest) Reset()         { *m = CommitRequest{} }
-func (m *CommitRequest) String() string { return proto.CompactTextString(m) }
-func (*CommitRequest) ProtoMessage()    {}
-func (*CommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e604833c2b457e38, []int{14}
+func (m *ListCommitsRequest) Reset()         { *m = ListCommitsRequest{} }
+func (m *ListCommitsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCommitsRequest) ProtoMessage()    {}
+func (*ListCommitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{23}
+}
+
+func (m *ListCommitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommitsRequest.Unmarshal(m, b)
+}
+func (m *ListCommitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommitsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCommitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommitsRequest.Merge(m, src)
+}
+func (m *ListCommitsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCommitsRequest.Size(m)
+}
+func (m *ListCommitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommitsRequest proto.InternalMessageInfo
+
+func (m *ListCommitsRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListCommitsRequest) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+func (m *ListCommitsRequest) GetId() *VolumeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ListCommitsRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *ListCommitsRequest) GetOrder() ListCommitsOrder {
+	if m != nil {
+		return m.Order
+	}
+	return ListCommitsOrder_FirstParent
+}
+
+func (m *ListCommitsRequest) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *ListCommitsRequest) GetUntil() *timestamp.Timestamp {
+	if m != nil {
+		return m.Until
+	}
+	return nil
+}
+
+func (m *ListCommitsRequest) GetAncestorOf() *CommitID {
+	if m != nil {
+		return m.AncestorOf
+	}
+	return nil
+}
+
+func (m *ListCommitsRequest) GetDescendantOf() *CommitID {
+	if m != nil {
+		return m.DescendantOf
+	}
+	return nil
+}
+
+func (m *ListCommitsRequest) GetMerges() MergeFilter {
+	if m != nil {
+		return m.Merges
+	}
+	return MergeFilter_AllCommits
+}
+
+type ListCommitsResponse struct {
+	// streamの一番最後、かつ個数制限により応答できていないアイテムが存在する場合、この値が設定される。
+	// 次のCommitService.List()のnext引数に設定すると、次のアイテムから列挙することが出来る。
+	Next string    `protobuf:"bytes,1,opt,name=next,proto3" json:"next,omitempty"`
+	Id   *CommitID `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// 親コミットのID。left, rightの順に格納される。最初のコミットの場合は空。
+	Parents              []*CommitID          `protobuf:"bytes,3,rep,name=parents,proto3" json:"parents,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListCommitsResponse) Reset()         { *m = ListCommitsResponse{} }
+func (m *ListCommitsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCommitsResponse) ProtoMessage()    {}
+func (*ListCommitsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{24}
+}
+
+func (m *ListCommitsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommitsResponse.Unmarshal(m, b)
+}
+func (m *ListCommitsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommitsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListCommitsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommitsResponse.Merge(m, src)
+}
+func (m *ListCommitsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCommitsResponse.Size(m)
+}
+func (m *ListCommitsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommitsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommitsResponse proto.InternalMessageInfo
+
+func (m *ListCommitsResponse) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+func (m *ListCommitsResponse) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ListCommitsResponse) GetParents() []*CommitID {
+	if m != nil {
+		return m.Parents
+	}
+	return nil
+}
+
+func (m *ListCommitsResponse) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type GetCommitRequest struct {
+	Id                   *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetCommitRequest) Reset()         { *m = GetCommitRequest{} }
+func (m *GetCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCommitRequest) ProtoMessage()    {}
+func (*GetCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{25}
+}
+
+func (m *GetCommitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCommitRequest.Unmarshal(m, b)
+}
+func (m *GetCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCommitRequest.Marshal(b, m, deterministic)
+}
+func (m *GetCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCommitRequest.Merge(m, src)
+}
+func (m *GetCommitRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCommitRequest.Size(m)
+}
+func (m *GetCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCommitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCommitRequest proto.InternalMessageInfo
+
+func (m *GetCommitRequest) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type GetCommitResponse struct {
+	Id                   *CommitID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Info                 *CommitInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetCommitResponse) Reset()         { *m = GetCommitResponse{} }
+func (m *GetCommitResponse) String() string { return proto.CompactTextString(m) }
+func (*GetCommitResponse) ProtoMessage()    {}
+func (*GetCommitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{26}
+}
+
+func (m *GetCommitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCommitResponse.Unmarshal(m, b)
+}
+func (m *GetCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCommitResponse.Marshal(b, m, deterministic)
+}
+func (m *GetCommitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCommitResponse.Merge(m, src)
+}
+func (m *GetCommitResponse) XXX_Size() int {
+	return xxx_messageInfo_GetCommitResponse.Size(m)
+}
+func (m *GetCommitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCommitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCommitResponse proto.InternalMessageInfo
+
+func (m *GetCommitResponse) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *GetCommitResponse) GetInfo() *CommitInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+type DiffCommitsRequest struct {
+	// 比較元のコミット。
+	A *CommitID `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	// 比較先のコミット。
+	B *CommitID `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	// 指定した場合は、このパス以下の変更のみを返す。
+	Path                 string   `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiffCommitsRequest) Reset()         { *m = DiffCommitsRequest{} }
+func (m *DiffCommitsRequest) String() string { return proto.CompactTextString(m) }
+func (*DiffCommitsRequest) ProtoMessage()    {}
+func (*DiffCommitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{27}
+}
+
+func (m *DiffCommitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiffCommitsRequest.Unmarshal(m, b)
+}
+func (m *DiffCommitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiffCommitsRequest.Marshal(b, m, deterministic)
+}
+func (m *DiffCommitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffCommitsRequest.Merge(m, src)
+}
+func (m *DiffCommitsRequest) XXX_Size() int {
+	return xxx_messageInfo_DiffCommitsRequest.Size(m)
+}
+func (m *DiffCommitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffCommitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiffCommitsRequest proto.InternalMessageInfo
+
+func (m *DiffCommitsRequest) GetA() *CommitID {
+	if m != nil {
+		return m.A
+	}
+	return nil
+}
+
+func (m *DiffCommitsRequest) GetB() *CommitID {
+	if m != nil {
+		return m.B
+	}
+	return nil
+}
+
+func (m *DiffCommitsRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type DiffCommitsResponse struct {
+	Change               *PathChange `protobuf:"bytes,1,opt,name=change,proto3" json:"change,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DiffCommitsResponse) Reset()         { *m = DiffCommitsResponse{} }
+func (m *DiffCommitsResponse) String() string { return proto.CompactTextString(m) }
+func (*DiffCommitsResponse) ProtoMessage()    {}
+func (*DiffCommitsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{28}
+}
+
+func (m *DiffCommitsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiffCommitsResponse.Unmarshal(m, b)
+}
+func (m *DiffCommitsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiffCommitsResponse.Marshal(b, m, deterministic)
+}
+func (m *DiffCommitsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffCommitsResponse.Merge(m, src)
+}
+func (m *DiffCommitsResponse) XXX_Size() int {
+	return xxx_messageInfo_DiffCommitsResponse.Size(m)
+}
+func (m *DiffCommitsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffCommitsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiffCommitsResponse proto.InternalMessageInfo
+
+func (m *DiffCommitsResponse) GetChange() *PathChange {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+// パス単位の変更。
+type PathChange struct {
+	Type PathChangeType `protobuf:"varint,1,opt,name=type,proto3,enum=elton.v2.PathChangeType" json:"type,omitempty"`
+	// 変更後のパス。Deletedの場合は変更前のパス。
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Renamedの場合のみ、変更前のパスを設定する。
+	OldPath string `protobuf:"bytes,3,opt,name=oldPath,proto3" json:"oldPath,omitempty"`
+	// 変更前と変更後のinode。Addedの場合はoldFile、Deletedの場合はnewFileが設定されない。
+	// ディレクトリのentriesは含まない。
+	OldFile *File `protobuf:"bytes,4,opt,name=oldFile,proto3" json:"oldFile,omitempty"`
+	NewFile *File `protobuf:"bytes,5,opt,name=newFile,proto3" json:"newFile,omitempty"`
+	// ファイルの内容が変更された。
+	ContentChanged bool `protobuf:"varint,6,opt,name=contentChanged,proto3" json:"contentChanged,omitempty"`
+	// パーミッションが変更された。
+	ModeChanged bool `protobuf:"varint,7,opt,name=modeChanged,proto3" json:"modeChanged,omitempty"`
+	// 所有者またはグループが変更された。
+	OwnerChanged         bool     `protobuf:"varint,8,opt,name=ownerChanged,proto3" json:"ownerChanged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PathChange) Reset()         { *m = PathChange{} }
+func (m *PathChange) String() string { return proto.CompactTextString(m) }
+func (*PathChange) ProtoMessage()    {}
+func (*PathChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{29}
+}
+
+func (m *PathChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PathChange.Unmarshal(m, b)
+}
+func (m *PathChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PathChange.Marshal(b, m, deterministic)
+}
+func (m *PathChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PathChange.Merge(m, src)
+}
+func (m *PathChange) XXX_Size() int {
+	return xxx_messageInfo_PathChange.Size(m)
+}
+func (m *PathChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_PathChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PathChange proto.InternalMessageInfo
+
+func (m *PathChange) GetType() PathChangeType {
+	if m != nil {
+		return m.Type
+	}
+	return PathChangeType_Added
+}
+
+func (m *PathChange) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *PathChange) GetOldPath() string {
+	if m != nil {
+		return m.OldPath
+	}
+	return ""
+}
+
+func (m *PathChange) GetOldFile() *File {
+	if m != nil {
+		return m.OldFile
+	}
+	return nil
+}
+
+func (m *PathChange) GetNewFile() *File {
+	if m != nil {
+		return m.NewFile
+	}
+	return nil
+}
+
+func (m *PathChange) GetContentChanged() bool {
+	if m != nil {
+		return m.ContentChanged
+	}
+	return false
+}
+
+func (m *PathChange) GetModeChanged() bool {
+	if m != nil {
+		return m.ModeChanged
+	}
+	return false
+}
+
+func (m *PathChange) GetOwnerChanged() bool {
+	if m != nil {
+		return m.OwnerChanged
+	}
+	return false
+}
+
+type LookupPathRequest struct {
+	Id *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "/"から始まる絶対パス。
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LookupPathRequest) Reset()         { *m = LookupPathRequest{} }
+func (m *LookupPathRequest) String() string { return proto.CompactTextString(m) }
+func (*LookupPathRequest) ProtoMessage()    {}
+func (*LookupPathRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{30}
+}
+
+func (m *LookupPathRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LookupPathRequest.Unmarshal(m, b)
+}
+func (m *LookupPathRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LookupPathRequest.Marshal(b, m, deterministic)
+}
+func (m *LookupPathRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LookupPathRequest.Merge(m, src)
+}
+func (m *LookupPathRequest) XXX_Size() int {
+	return xxx_messageInfo_LookupPathRequest.Size(m)
+}
+func (m *LookupPathRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LookupPathRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LookupPathRequest proto.InternalMessageInfo
+
+func (m *LookupPathRequest) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *LookupPathRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type LookupPathResponse struct {
+	// 解決したコミット。
+	Id                   *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ino                  uint64    `protobuf:"varint,2,opt,name=ino,proto3" json:"ino,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *LookupPathResponse) Reset()         { *m = LookupPathResponse{} }
+func (m *LookupPathResponse) String() string { return proto.CompactTextString(m) }
+func (*LookupPathResponse) ProtoMessage()    {}
+func (*LookupPathResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{31}
+}
+
+func (m *LookupPathResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LookupPathResponse.Unmarshal(m, b)
+}
+func (m *LookupPathResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LookupPathResponse.Marshal(b, m, deterministic)
+}
+func (m *LookupPathResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LookupPathResponse.Merge(m, src)
+}
+func (m *LookupPathResponse) XXX_Size() int {
+	return xxx_messageInfo_LookupPathResponse.Size(m)
+}
+func (m *LookupPathResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LookupPathResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LookupPathResponse proto.InternalMessageInfo
+
+func (m *LookupPathResponse) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *LookupPathResponse) GetIno() uint64 {
+	if m != nil {
+		return m.Ino
+	}
+	return 0
+}
+
+type ReadDirRequest struct {
+	Id *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "/"から始まる絶対パス。inoを指定した場合は無視される。
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// 一回のRPCリクエストに対して返答できる最大の個数。
+	// 0個の場合は、デフォルトの制限を適用。
+	Limit uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// ページネーションされたときは、前回の最後の応答についていたnextの値を設定。
+	// 他の引数は、前回のリクエストと同じ値を指定すること。
+	Next string `protobuf:"bytes,4,opt,name=next,proto3" json:"next,omitempty"`
+	// 指定した場合は、パスの代わりにinode番号でディレクトリを指定する。
+	Ino                  uint64   `protobuf:"varint,5,opt,name=ino,proto3" json:"ino,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadDirRequest) Reset()         { *m = ReadDirRequest{} }
+func (m *ReadDirRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadDirRequest) ProtoMessage()    {}
+func (*ReadDirRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{32}
+}
+
+func (m *ReadDirRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadDirRequest.Unmarshal(m, b)
+}
+func (m *ReadDirRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadDirRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadDirRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadDirRequest.Merge(m, src)
+}
+func (m *ReadDirRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadDirRequest.Size(m)
+}
+func (m *ReadDirRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadDirRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadDirRequest proto.InternalMessageInfo
+
+func (m *ReadDirRequest) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ReadDirRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ReadDirRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ReadDirRequest) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+func (m *ReadDirRequest) GetIno() uint64 {
+	if m != nil {
+		return m.Ino
+	}
+	return 0
+}
+
+type ReadDirResponse struct {
+	// streamの一番最後、かつ個数制限により応答できていないエントリが存在する場合、この値が設定される。
+	Next string `protobuf:"bytes,1,opt,name=next,proto3" json:"next,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Ino  uint64 `protobuf:"varint,3,opt,name=ino,proto3" json:"ino,omitempty"`
+	// エントリが指すinode。ディレクトリのエントリは含まない。
+	File                 *File    `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadDirResponse) Reset()         { *m = ReadDirResponse{} }
+func (m *ReadDirResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadDirResponse) ProtoMessage()    {}
+func (*ReadDirResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{33}
+}
+
+func (m *ReadDirResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadDirResponse.Unmarshal(m, b)
+}
+func (m *ReadDirResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadDirResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadDirResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadDirResponse.Merge(m, src)
+}
+func (m *ReadDirResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadDirResponse.Size(m)
+}
+func (m *ReadDirResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadDirResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadDirResponse proto.InternalMessageInfo
+
+func (m *ReadDirResponse) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+func (m *ReadDirResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReadDirResponse) GetIno() uint64 {
+	if m != nil {
+		return m.Ino
+	}
+	return 0
+}
+
+func (m *ReadDirResponse) GetFile() *File {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+type StatPathRequest struct {
+	Id *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "/"から始まる絶対パス。inoを指定した場合は無視される。
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// 指定した場合は、パスの代わりにinode番号で検索する。
+	Ino                  uint64   `protobuf:"varint,3,opt,name=ino,proto3" json:"ino,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatPathRequest) Reset()         { *m = StatPathRequest{} }
+func (m *StatPathRequest) String() string { return proto.CompactTextString(m) }
+func (*StatPathRequest) ProtoMessage()    {}
+func (*StatPathRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{34}
+}
+
+func (m *StatPathRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatPathRequest.Unmarshal(m, b)
+}
+func (m *StatPathRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatPathRequest.Marshal(b, m, deterministic)
+}
+func (m *StatPathRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatPathRequest.Merge(m, src)
+}
+func (m *StatPathRequest) XXX_Size() int {
+	return xxx_messageInfo_StatPathRequest.Size(m)
+}
+func (m *StatPathRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatPathRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatPathRequest proto.InternalMessageInfo
+
+func (m *StatPathRequest) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *StatPathRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *StatPathRequest) GetIno() uint64 {
+	if m != nil {
+		return m.Ino
+	}
+	return 0
+}
+
+type StatPathResponse struct {
+	// 解決したコミット。
+	Id  *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ino uint64    `protobuf:"varint,2,opt,name=ino,proto3" json:"ino,omitempty"`
+	// ディレクトリのエントリは含まない。
+	File *File `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	// ディレクトリの場合のみ、エントリの数を設定する。
+	Entries              uint64   `protobuf:"varint,4,opt,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatPathResponse) Reset()         { *m = StatPathResponse{} }
+func (m *StatPathResponse) String() string { return proto.CompactTextString(m) }
+func (*StatPathResponse) ProtoMessage()    {}
+func (*StatPathResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{35}
+}
+
+func (m *StatPathResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatPathResponse.Unmarshal(m, b)
+}
+func (m *StatPathResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatPathResponse.Marshal(b, m, deterministic)
+}
+func (m *StatPathResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatPathResponse.Merge(m, src)
+}
+func (m *StatPathResponse) XXX_Size() int {
+	return xxx_messageInfo_StatPathResponse.Size(m)
+}
+func (m *StatPathResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatPathResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatPathResponse proto.InternalMessageInfo
+
+func (m *StatPathResponse) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *StatPathResponse) GetIno() uint64 {
+	if m != nil {
+		return m.Ino
+	}
+	return 0
+}
+
+func (m *StatPathResponse) GetFile() *File {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *StatPathResponse) GetEntries() uint64 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+type CommitRequest struct {
+	Info *CommitInfo `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+	Id   *VolumeID   `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	// コミット先のbranch名。
+	// 指定しない場合は、volumeの最新コミット (latest) を進める。
+	Branch string `protobuf:"bytes,6,opt,name=branch,proto3" json:"branch,omitempty"`
+	// trueの場合は自動マージを行わない。
+	// 親コミットがlatest (またはbranchの先頭) でなければ、コミットを保存せずにAbortedを返す。
+	NoMerge              bool     `protobuf:"varint,7,opt,name=noMerge,proto3" json:"noMerge,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitRequest) Reset()         { *m = CommitRequest{} }
+func (m *CommitRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitRequest) ProtoMessage()    {}
+func (*CommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{36}
+}
+
+func (m *CommitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitRequest.Unmarshal(m, b)
+}
+func (m *CommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitRequest.Marshal(b, m, deterministic)
+}
+func (m *CommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitRequest.Merge(m, src)
+}
+func (m *CommitRequest) XXX_Size() int {
+	return xxx_messageInfo_CommitRequest.Size(m)
+}
+func (m *CommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitRequest proto.InternalMessageInfo
+
+func (m *CommitRequest) GetInfo() *CommitInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *CommitRequest) GetId() *VolumeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *CommitRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *CommitRequest) GetNoMerge() bool {
+	if m != nil {
+		return m.NoMerge
+	}
+	return false
+}
+
+type CommitResponse struct {
+	Id                   *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CommitResponse) Reset()         { *m = CommitResponse{} }
+func (m *CommitResponse) String() string { return proto.CompactTextString(m) }
+func (*CommitResponse) ProtoMessage()    {}
+func (*CommitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{37}
+}
+
+func (m *CommitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitResponse.Unmarshal(m, b)
+}
+func (m *CommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitResponse.Marshal(b, m, deterministic)
+}
+func (m *CommitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitResponse.Merge(m, src)
+}
+func (m *CommitResponse) XXX_Size() int {
+	return xxx_messageInfo_CommitResponse.Size(m)
+}
+func (m *CommitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitResponse proto.InternalMessageInfo
+
+func (m *CommitResponse) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+// 自動マージが競合により失敗した場合に、Commitのエラーのdetailsに含まれる。
+type CommitConflicts struct {
+	Conflicts            []*CommitConflict `protobuf:"bytes,1,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CommitConflicts) Reset()         { *m = CommitConflicts{} }
+func (m *CommitConflicts) String() string { return proto.CompactTextString(m) }
+func (*CommitConflicts) ProtoMessage()    {}
+func (*CommitConflicts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{38}
+}
+
+func (m *CommitConflicts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitConflicts.Unmarshal(m, b)
+}
+func (m *CommitConflicts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitConflicts.Marshal(b, m, deterministic)
+}
+func (m *CommitConflicts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitConflicts.Merge(m, src)
+}
+func (m *CommitConflicts) XXX_Size() int {
+	return xxx_messageInfo_CommitConflicts.Size(m)
+}
+func (m *CommitConflicts) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitConflicts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitConflicts proto.InternalMessageInfo
+
+func (m *CommitConflicts) GetConflicts() []*CommitConflict {
+	if m != nil {
+		return m.Conflicts
+	}
+	return nil
+}
+
+// latestツリーとcurrentツリーで競合した変更。
+type CommitConflict struct {
+	// 競合の種類。"mod-del"のように、latestツリーとcurrentツリーでの変更の種類を表す。
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// 競合したinode番号。ディレクトリエントリの競合の場合は、ディレクトリのinode番号。
+	Ino uint64 `protobuf:"varint,2,opt,name=ino,proto3" json:"ino,omitempty"`
+	// ディレクトリエントリの競合の場合のみ設定する。
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// それぞれのツリーで競合したファイルのinode番号。
+	// ディレクトリエントリの競合の場合は、エントリが指しているinode番号。削除された場合は0。
+	LatestIno  uint64 `protobuf:"varint,4,opt,name=latestIno,proto3" json:"latestIno,omitempty"`
+	CurrentIno uint64 `protobuf:"varint,5,opt,name=currentIno,proto3" json:"currentIno,omitempty"`
+	// それぞれのツリーで競合したファイルのパス。削除された場合は空。
+	LatestPaths  []string `protobuf:"bytes,6,rep,name=latestPaths,proto3" json:"latestPaths,omitempty"`
+	CurrentPaths []string `protobuf:"bytes,7,rep,name=currentPaths,proto3" json:"currentPaths,omitempty"`
+	// それぞれのツリーで競合したファイル。削除された場合はnull。
+	LatestFile           *File    `protobuf:"bytes,8,opt,name=latestFile,proto3" json:"latestFile,omitempty"`
+	CurrentFile          *File    `protobuf:"bytes,9,opt,name=currentFile,proto3" json:"currentFile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitConflict) Reset()         { *m = CommitConflict{} }
+func (m *CommitConflict) String() string { return proto.CompactTextString(m) }
+func (*CommitConflict) ProtoMessage()    {}
+func (*CommitConflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{39}
+}
+
+func (m *CommitConflict) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitConflict.Unmarshal(m, b)
+}
+func (m *CommitConflict) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitConflict.Marshal(b, m, deterministic)
+}
+func (m *CommitConflict) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitConflict.Merge(m, src)
+}
+func (m *CommitConflict) XXX_Size() int {
+	return xxx_messageInfo_CommitConflict.Size(m)
+}
+func (m *CommitConflict) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitConflict.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitConflict proto.InternalMessageInfo
+
+func (m *CommitConflict) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *CommitConflict) GetIno() uint64 {
+	if m != nil {
+		return m.Ino
+	}
+	return 0
+}
+
+func (m *CommitConflict) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CommitConflict) GetLatestIno() uint64 {
+	if m != nil {
+		return m.LatestIno
+	}
+	return 0
+}
+
+func (m *CommitConflict) GetCurrentIno() uint64 {
+	if m != nil {
+		return m.CurrentIno
+	}
+	return 0
+}
+
+func (m *CommitConflict) GetLatestPaths() []string {
+	if m != nil {
+		return m.LatestPaths
+	}
+	return nil
+}
+
+func (m *CommitConflict) GetCurrentPaths() []string {
+	if m != nil {
+		return m.CurrentPaths
+	}
+	return nil
+}
+
+func (m *CommitConflict) GetLatestFile() *File {
+	if m != nil {
+		return m.LatestFile
+	}
+	return nil
+}
+
+func (m *CommitConflict) GetCurrentFile() *File {
+	if m != nil {
+		return m.CurrentFile
+	}
+	return nil
+}
+
+type ImportCommitRequest struct {
+	Id                   *CommitID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Info                 *CommitInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ImportCommitRequest) Reset()         { *m = ImportCommitRequest{} }
+func (m *ImportCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportCommitRequest) ProtoMessage()    {}
+func (*ImportCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{40}
+}
+
+func (m *ImportCommitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportCommitRequest.Unmarshal(m, b)
+}
+func (m *ImportCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportCommitRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportCommitRequest.Merge(m, src)
+}
+func (m *ImportCommitRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportCommitRequest.Size(m)
+}
+func (m *ImportCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportCommitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportCommitRequest proto.InternalMessageInfo
+
+func (m *ImportCommitRequest) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ImportCommitRequest) GetInfo() *CommitInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+type ImportCommitResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportCommitResponse) Reset()         { *m = ImportCommitResponse{} }
+func (m *ImportCommitResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportCommitResponse) ProtoMessage()    {}
+func (*ImportCommitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{41}
+}
+
+func (m *ImportCommitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportCommitResponse.Unmarshal(m, b)
+}
+func (m *ImportCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportCommitResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportCommitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportCommitResponse.Merge(m, src)
+}
+func (m *ImportCommitResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportCommitResponse.Size(m)
+}
+func (m *ImportCommitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportCommitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportCommitResponse proto.InternalMessageInfo
+
+type CommitQueueStatusRequest struct {
+	// 指定した場合は、指定したvolumeの状態のみを返す。
+	Id                   *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CommitQueueStatusRequest) Reset()         { *m = CommitQueueStatusRequest{} }
+func (m *CommitQueueStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitQueueStatusRequest) ProtoMessage()    {}
+func (*CommitQueueStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{42}
+}
+
+func (m *CommitQueueStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitQueueStatusRequest.Unmarshal(m, b)
+}
+func (m *CommitQueueStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitQueueStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *CommitQueueStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitQueueStatusRequest.Merge(m, src)
+}
+func (m *CommitQueueStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_CommitQueueStatusRequest.Size(m)
+}
+func (m *CommitQueueStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitQueueStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitQueueStatusRequest proto.InternalMessageInfo
+
+func (m *CommitQueueStatusRequest) GetId() *VolumeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type CommitQueueStatusResponse struct {
+	Queues               []*CommitQueueStatus `protobuf:"bytes,1,rep,name=queues,proto3" json:"queues,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CommitQueueStatusResponse) Reset()         { *m = CommitQueueStatusResponse{} }
+func (m *CommitQueueStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*CommitQueueStatusResponse) ProtoMessage()    {}
+func (*CommitQueueStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{43}
+}
+
+func (m *CommitQueueStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitQueueStatusResponse.Unmarshal(m, b)
+}
+func (m *CommitQueueStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitQueueStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *CommitQueueStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitQueueStatusResponse.Merge(m, src)
+}
+func (m *CommitQueueStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_CommitQueueStatusResponse.Size(m)
+}
+func (m *CommitQueueStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitQueueStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitQueueStatusResponse proto.InternalMessageInfo
+
+func (m *CommitQueueStatusResponse) GetQueues() []*CommitQueueStatus {
+	if m != nil {
+		return m.Queues
+	}
+	return nil
+}
+
+// volumeのコミットキューの状態。カウンタはコントローラの起動時からの値。
+type CommitQueueStatus struct {
+	Id *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 処理中および待機中のコミットの数。
+	Depth uint64 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	// depthの最大値。
+	MaxDepth uint64 `protobuf:"varint,3,opt,name=maxDepth,proto3" json:"maxDepth,omitempty"`
+	// 保存に成功したコミットの数。
+	Commits uint64 `protobuf:"varint,4,opt,name=commits,proto3" json:"commits,omitempty"`
+	// 自動マージを行ったコミットの数。
+	Merges uint64 `protobuf:"varint,5,opt,name=merges,proto3" json:"merges,omitempty"`
+	// headが他のコミットにより更新されたために、やり直した回数。
+	Retries uint64 `protobuf:"varint,6,opt,name=retries,proto3" json:"retries,omitempty"`
+	// 失敗したコミットの数。
+	Failures uint64 `protobuf:"varint,7,opt,name=failures,proto3" json:"failures,omitempty"`
+	// マージにかかった時間。
+	TotalMergeTime       *duration.Duration `protobuf:"bytes,8,opt,name=totalMergeTime,proto3" json:"totalMergeTime,omitempty"`
+	MaxMergeTime         *duration.Duration `protobuf:"bytes,9,opt,name=maxMergeTime,proto3" json:"maxMergeTime,omitempty"`
+	LastMergeTime        *duration.Duration `protobuf:"bytes,10,opt,name=lastMergeTime,proto3" json:"lastMergeTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *CommitQueueStatus) Reset()         { *m = CommitQueueStatus{} }
+func (m *CommitQueueStatus) String() string { return proto.CompactTextString(m) }
+func (*CommitQueueStatus) ProtoMessage()    {}
+func (*CommitQueueStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{44}
+}
+
+func (m *CommitQueueStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitQueueStatus.Unmarshal(m, b)
+}
+func (m *CommitQueueStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitQueueStatus.Marshal(b, m, deterministic)
+}
+func (m *CommitQueueStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitQueueStatus.Merge(m, src)
+}
+func (m *CommitQueueStatus) XXX_Size() int {
+	return xxx_messageInfo_CommitQueueStatus.Size(m)
+}
+func (m *CommitQueueStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitQueueStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitQueueStatus proto.InternalMessageInfo
+
+func (m *CommitQueueStatus) GetId() *VolumeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *CommitQueueStatus) GetDepth() uint64 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *CommitQueueStatus) GetMaxDepth() uint64 {
+	if m != nil {
+		return m.MaxDepth
+	}
+	return 0
+}
+
+func (m *CommitQueueStatus) GetCommits() uint64 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *CommitQueueStatus) GetMerges() uint64 {
+	if m != nil {
+		return m.Merges
+	}
+	return 0
+}
+
+func (m *CommitQueueStatus) GetRetries() uint64 {
+	if m != nil {
+		return m.Retries
+	}
+	return 0
+}
+
+func (m *CommitQueueStatus) GetFailures() uint64 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *CommitQueueStatus) GetTotalMergeTime() *duration.Duration {
+	if m != nil {
+		return m.TotalMergeTime
+	}
+	return nil
+}
+
+func (m *CommitQueueStatus) GetMaxMergeTime() *duration.Duration {
+	if m != nil {
+		return m.MaxMergeTime
+	}
+	return nil
+}
+
+func (m *CommitQueueStatus) GetLastMergeTime() *duration.Duration {
+	if m != nil {
+		return m.LastMergeTime
+	}
+	return nil
+}
+
+type WatchCommitsRequest struct {
+	Id *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// クライアントが最後に受信した最新コミット。
+	Since *CommitID `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	// 監視するbranch。空の場合はvolumeの最新コミットを監視する。
+	Branch               string   `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchCommitsRequest) Reset()         { *m = WatchCommitsRequest{} }
+func (m *WatchCommitsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchCommitsRequest) ProtoMessage()    {}
+func (*WatchCommitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{45}
+}
+
+func (m *WatchCommitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchCommitsRequest.Unmarshal(m, b)
+}
+func (m *WatchCommitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchCommitsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchCommitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchCommitsRequest.Merge(m, src)
+}
+func (m *WatchCommitsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchCommitsRequest.Size(m)
+}
+func (m *WatchCommitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchCommitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchCommitsRequest proto.InternalMessageInfo
+
+func (m *WatchCommitsRequest) GetId() *VolumeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *WatchCommitsRequest) GetSince() *CommitID {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *WatchCommitsRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+type WatchCommitsResponse struct {
+	Id                   *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *WatchCommitsResponse) Reset()         { *m = WatchCommitsResponse{} }
+func (m *WatchCommitsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchCommitsResponse) ProtoMessage()    {}
+func (*WatchCommitsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{46}
+}
+
+func (m *WatchCommitsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchCommitsResponse.Unmarshal(m, b)
+}
+func (m *WatchCommitsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchCommitsResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchCommitsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchCommitsResponse.Merge(m, src)
+}
+func (m *WatchCommitsResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchCommitsResponse.Size(m)
+}
+func (m *WatchCommitsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchCommitsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchCommitsResponse proto.InternalMessageInfo
+
+func (m *WatchCommitsResponse) GetId() *CommitID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type CreateRefRequest struct {
+	Id                   *RefID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ref                  *Ref     `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRefRequest) Reset()         { *m = CreateRefRequest{} }
+func (m *CreateRefRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRefRequest) ProtoMessage()    {}
+func (*CreateRefRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{47}
+}
+
+func (m *CreateRefRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRefRequest.Unmarshal(m, b)
+}
+func (m *CreateRefRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRefRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRefRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRefRequest.Merge(m, src)
+}
+func (m *CreateRefRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRefRequest.Size(m)
+}
+func (m *CreateRefRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRefRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRefRequest proto.InternalMessageInfo
+
+func (m *CreateRefRequest) GetId() *RefID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *CreateRefRequest) GetRef() *Ref {
+	if m != nil {
+		return m.Ref
+	}
+	return nil
+}
+
+type CreateRefResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRefResponse) Reset()         { *m = CreateRefResponse{} }
+func (m *CreateRefResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateRefResponse) ProtoMessage()    {}
+func (*CreateRefResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{48}
+}
+
+func (m *CreateRefResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRefResponse.Unmarshal(m, b)
+}
+func (m *CreateRefResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRefResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateRefResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRefResponse.Merge(m, src)
+}
+func (m *CreateRefResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateRefResponse.Size(m)
+}
+func (m *CreateRefResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRefResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRefResponse proto.InternalMessageInfo
+
+type GetRefRequest struct {
+	Id                   *RefID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRefRequest) Reset()         { *m = GetRefRequest{} }
+func (m *GetRefRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRefRequest) ProtoMessage()    {}
+func (*GetRefRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{49}
+}
+
+func (m *GetRefRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRefRequest.Unmarshal(m, b)
+}
+func (m *GetRefRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRefRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRefRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRefRequest.Merge(m, src)
+}
+func (m *GetRefRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRefRequest.Size(m)
+}
+func (m *GetRefRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRefRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRefRequest proto.InternalMessageInfo
+
+func (m *GetRefRequest) GetId() *RefID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type GetRefResponse struct {
+	Id                   *RefID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ref                  *Ref     `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRefResponse) Reset()         { *m = GetRefResponse{} }
+func (m *GetRefResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRefResponse) ProtoMessage()    {}
+func (*GetRefResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{50}
+}
+
+func (m *GetRefResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRefResponse.Unmarshal(m, b)
+}
+func (m *GetRefResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRefResponse.Marshal(b, m, deterministic)
+}
+func (m *GetRefResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRefResponse.Merge(m, src)
+}
+func (m *GetRefResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRefResponse.Size(m)
+}
+func (m *GetRefResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRefResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRefResponse proto.InternalMessageInfo
+
+func (m *GetRefResponse) GetId() *RefID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *GetRefResponse) GetRef() *Ref {
+	if m != nil {
+		return m.Ref
+	}
+	return nil
+}
+
+type ListRefsRequest struct {
+	Id                   *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListRefsRequest) Reset()         { *m = ListRefsRequest{} }
+func (m *ListRefsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRefsRequest) ProtoMessage()    {}
+func (*ListRefsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{51}
+}
+
+func (m *ListRefsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRefsRequest.Unmarshal(m, b)
+}
+func (m *ListRefsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRefsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRefsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRefsRequest.Merge(m, src)
+}
+func (m *ListRefsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRefsRequest.Size(m)
+}
+func (m *ListRefsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRefsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRefsRequest proto.InternalMessageInfo
+
+func (m *ListRefsRequest) GetId() *VolumeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type ListRefsResponse struct {
+	Id                   *RefID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ref                  *Ref     `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRefsResponse) Reset()         { *m = ListRefsResponse{} }
+func (m *ListRefsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRefsResponse) ProtoMessage()    {}
+func (*ListRefsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{52}
+}
+
+func (m *ListRefsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRefsResponse.Unmarshal(m, b)
+}
+func (m *ListRefsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRefsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRefsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRefsResponse.Merge(m, src)
+}
+func (m *ListRefsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRefsResponse.Size(m)
+}
+func (m *ListRefsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRefsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRefsResponse proto.InternalMessageInfo
+
+func (m *ListRefsResponse) GetId() *RefID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ListRefsResponse) GetRef() *Ref {
+	if m != nil {
+		return m.Ref
+	}
+	return nil
+}
+
+type MoveRefRequest struct {
+	Id                   *RefID    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Commit               *CommitID `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *MoveRefRequest) Reset()         { *m = MoveRefRequest{} }
+func (m *MoveRefRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveRefRequest) ProtoMessage()    {}
+func (*MoveRefRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{53}
+}
+
+func (m *MoveRefRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveRefRequest.Unmarshal(m, b)
+}
+func (m *MoveRefRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveRefRequest.Marshal(b, m, deterministic)
+}
+func (m *MoveRefRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveRefRequest.Merge(m, src)
+}
+func (m *MoveRefRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveRefRequest.Size(m)
+}
+func (m *MoveRefRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveRefRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveRefRequest proto.InternalMessageInfo
+
+func (m *MoveRefRequest) GetId() *RefID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *MoveRefRequest) GetCommit() *CommitID {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
+type MoveRefResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveRefResponse) Reset()         { *m = MoveRefResponse{} }
+func (m *MoveRefResponse) String() string { return proto.CompactTextString(m) }
+func (*MoveRefResponse) ProtoMessage()    {}
+func (*MoveRefResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{54}
+}
+
+func (m *MoveRefResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveRefResponse.Unmarshal(m, b)
+}
+func (m *MoveRefResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveRefResponse.Marshal(b, m, deterministic)
+}
+func (m *MoveRefResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveRefResponse.Merge(m, src)
+}
+func (m *MoveRefResponse) XXX_Size() int {
+	return xxx_messageInfo_MoveRefResponse.Size(m)
+}
+func (m *MoveRefResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveRefResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveRefResponse proto.InternalMessageInfo
+
+type UpdateRefRequest struct {
+	Id *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 変更するbranch名。指定しない場合は、volumeの最新コミット (latest) を変更する。
+	Branch               string    `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
+	Expected             *CommitID `protobuf:"bytes,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	New                  *CommitID `protobuf:"bytes,4,opt,name=new,proto3" json:"new,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *UpdateRefRequest) Reset()         { *m = UpdateRefRequest{} }
+func (m *UpdateRefRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRefRequest) ProtoMessage()    {}
+func (*UpdateRefRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{55}
 }
 
-func (m *CommitRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CommitRequest.Unmarshal(m, b)
+func (m *UpdateRefRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRefRequest.Unmarshal(m, b)
 }
-func (m *CommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CommitRequest.Marshal(b, m, deterministic)
+func (m *UpdateRefRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRefRequest.Marshal(b, m, deterministic)
 }
-func (m *CommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommitRequest.Merge(m, src)
+func (m *UpdateRefRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRefRequest.Merge(m, src)
 }
-func (m *CommitRequest) XXX_Size() int {
-	return xxx_messageInfo_CommitRequest.Size(m)
+func (m *UpdateRefRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRefRequest.Size(m)
 }
-func (m *CommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommitRequest.DiscardUnknown(m)
+func (m *UpdateRefRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRefRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommitRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateRefRequest proto.InternalMessageInfo
 
-func (m *CommitRequest) GetInfo() *CommitInfo {
+func (m *UpdateRefRequest) GetId() *VolumeID {
 	if m != nil {
-		return m.Info
+		return m.Id
 	}
 	return nil
 }
 
-func (m *CommitRequest) GetId() *VolumeID {
+func (m *UpdateRefRequest) GetBranch() string {
 	if m != nil {
-		return m.Id
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *UpdateRefRequest) GetExpected() *CommitID {
+	if m != nil {
+		return m.Expected
 	}
 	return nil
 }
 
-type CommitResponse struct {
-	Id                   *CommitID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *UpdateRefRequest) GetNew() *CommitID {
+	if m != nil {
+		return m.New
+	}
+	return nil
 }
 
-func (m *CommitResponse) Reset()         { *m = CommitResponse{} }
-func (m *CommitResponse) String() string { return proto.CompactTextString(m) }
-func (*CommitResponse) ProtoMessage()    {}
-func (*CommitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e604833c2b457e38, []int{15}
+type UpdateRefResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CommitResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CommitResponse.Unmarshal(m, b)
+func (m *UpdateRefResponse) Reset()         { *m = UpdateRefResponse{} }
+func (m *UpdateRefResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateRefResponse) ProtoMessage()    {}
+func (*UpdateRefResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{56}
 }
-func (m *CommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CommitResponse.Marshal(b, m, deterministic)
+
+func (m *UpdateRefResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRefResponse.Unmarshal(m, b)
 }
-func (m *CommitResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommitResponse.Merge(m, src)
+func (m *UpdateRefResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRefResponse.Marshal(b, m, deterministic)
 }
-func (m *CommitResponse) XXX_Size() int {
-	return xxx_messageInfo_CommitResponse.Size(m)
+func (m *UpdateRefResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRefResponse.Merge(m, src)
 }
-func (m *CommitResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommitResponse.DiscardUnknown(m)
+func (m *UpdateRefResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateRefResponse.Size(m)
+}
+func (m *UpdateRefResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRefResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommitResponse proto.InternalMessageInfo
+var xxx_messageInfo_UpdateRefResponse proto.InternalMessageInfo
 
-func (m *CommitResponse) GetId() *CommitID {
+type DeleteRefRequest struct {
+	Id                   *RefID   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRefRequest) Reset()         { *m = DeleteRefRequest{} }
+func (m *DeleteRefRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRefRequest) ProtoMessage()    {}
+func (*DeleteRefRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{57}
+}
+
+func (m *DeleteRefRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRefRequest.Unmarshal(m, b)
+}
+func (m *DeleteRefRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRefRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRefRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRefRequest.Merge(m, src)
+}
+func (m *DeleteRefRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRefRequest.Size(m)
+}
+func (m *DeleteRefRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRefRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRefRequest proto.InternalMessageInfo
+
+func (m *DeleteRefRequest) GetId() *RefID {
 	if m != nil {
 		return m.Id
 	}
 	return nil
 }
 
+type DeleteRefResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRefResponse) Reset()         { *m = DeleteRefResponse{} }
+func (m *DeleteRefResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteRefResponse) ProtoMessage()    {}
+func (*DeleteRefResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e604833c2b457e38, []int{58}
+}
+
+func (m *DeleteRefResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRefResponse.Unmarshal(m, b)
+}
+func (m *DeleteRefResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRefResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteRefResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRefResponse.Merge(m, src)
+}
+func (m *DeleteRefResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteRefResponse.Size(m)
+}
+func (m *DeleteRefResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRefResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRefResponse proto.InternalMessageInfo
+
 func init() {
+	proto.RegisterEnum("elton.v2.ListCommitsOrder", ListCommitsOrder_name, ListCommitsOrder_value)
+	proto.RegisterEnum("elton.v2.MergeFilter", MergeFilter_name, MergeFilter_value)
+	proto.RegisterEnum("elton.v2.PathChangeType", PathChangeType_name, PathChangeType_value)
 	proto.RegisterType((*CreateVolumeRequest)(nil), "elton.v2.CreateVolumeRequest")
 	proto.RegisterType((*CreateVolumeResponse)(nil), "elton.v2.CreateVolumeResponse")
 	proto.RegisterType((*DeleteVolumeRequest)(nil), "elton.v2.DeleteVolumeRequest")
@@ -753,53 +3126,201 @@ func init() {
 	proto.RegisterType((*ListVolumesResponse)(nil), "elton.v2.ListVolumesResponse")
 	proto.RegisterType((*InspectVolumeRequest)(nil), "elton.v2.InspectVolumeRequest")
 	proto.RegisterType((*InspectVolumeResponse)(nil), "elton.v2.InspectVolumeResponse")
+	proto.RegisterType((*UpdateVolumeRequest)(nil), "elton.v2.UpdateVolumeRequest")
+	proto.RegisterType((*UpdateVolumeResponse)(nil), "elton.v2.UpdateVolumeResponse")
+	proto.RegisterType((*ImportVolumeRequest)(nil), "elton.v2.ImportVolumeRequest")
+	proto.RegisterType((*ImportVolumeResponse)(nil), "elton.v2.ImportVolumeResponse")
+	proto.RegisterType((*SetRetentionPolicyRequest)(nil), "elton.v2.SetRetentionPolicyRequest")
+	proto.RegisterType((*SetRetentionPolicyResponse)(nil), "elton.v2.SetRetentionPolicyResponse")
+	proto.RegisterType((*PruneVolumeRequest)(nil), "elton.v2.PruneVolumeRequest")
+	proto.RegisterType((*PruneVolumeResponse)(nil), "elton.v2.PruneVolumeResponse")
+	proto.RegisterType((*FsckVolumeRequest)(nil), "elton.v2.FsckVolumeRequest")
+	proto.RegisterType((*FsckVolumeResponse)(nil), "elton.v2.FsckVolumeResponse")
+	proto.RegisterType((*FsckResult)(nil), "elton.v2.FsckResult")
+	proto.RegisterType((*ForkVolumeRequest)(nil), "elton.v2.ForkVolumeRequest")
+	proto.RegisterType((*ForkVolumeResponse)(nil), "elton.v2.ForkVolumeResponse")
 	proto.RegisterType((*GetLastCommitRequest)(nil), "elton.v2.GetLastCommitRequest")
 	proto.RegisterType((*GetLastCommitResponse)(nil), "elton.v2.GetLastCommitResponse")
 	proto.RegisterType((*ListCommitsRequest)(nil), "elton.v2.ListCommitsRequest")
 	proto.RegisterType((*ListCommitsResponse)(nil), "elton.v2.ListCommitsResponse")
 	proto.RegisterType((*GetCommitRequest)(nil), "elton.v2.GetCommitRequest")
 	proto.RegisterType((*GetCommitResponse)(nil), "elton.v2.GetCommitResponse")
+	proto.RegisterType((*DiffCommitsRequest)(nil), "elton.v2.DiffCommitsRequest")
+	proto.RegisterType((*DiffCommitsResponse)(nil), "elton.v2.DiffCommitsResponse")
+	proto.RegisterType((*PathChange)(nil), "elton.v2.PathChange")
+	proto.RegisterType((*LookupPathRequest)(nil), "elton.v2.LookupPathRequest")
+	proto.RegisterType((*LookupPathResponse)(nil), "elton.v2.LookupPathResponse")
+	proto.RegisterType((*ReadDirRequest)(nil), "elton.v2.ReadDirRequest")
+	proto.RegisterType((*ReadDirResponse)(nil), "elton.v2.ReadDirResponse")
+	proto.RegisterType((*StatPathRequest)(nil), "elton.v2.StatPathRequest")
+	proto.RegisterType((*StatPathResponse)(nil), "elton.v2.StatPathResponse")
 	proto.RegisterType((*CommitRequest)(nil), "elton.v2.CommitRequest")
 	proto.RegisterType((*CommitResponse)(nil), "elton.v2.CommitResponse")
+	proto.RegisterType((*CommitConflicts)(nil), "elton.v2.CommitConflicts")
+	proto.RegisterType((*CommitConflict)(nil), "elton.v2.CommitConflict")
+	proto.RegisterType((*ImportCommitRequest)(nil), "elton.v2.ImportCommitRequest")
+	proto.RegisterType((*ImportCommitResponse)(nil), "elton.v2.ImportCommitResponse")
+	proto.RegisterType((*CommitQueueStatusRequest)(nil), "elton.v2.CommitQueueStatusRequest")
+	proto.RegisterType((*CommitQueueStatusResponse)(nil), "elton.v2.CommitQueueStatusResponse")
+	proto.RegisterType((*CommitQueueStatus)(nil), "elton.v2.CommitQueueStatus")
+	proto.RegisterType((*WatchCommitsRequest)(nil), "elton.v2.WatchCommitsRequest")
+	proto.RegisterType((*WatchCommitsResponse)(nil), "elton.v2.WatchCommitsResponse")
+	proto.RegisterType((*CreateRefRequest)(nil), "elton.v2.CreateRefRequest")
+	proto.RegisterType((*CreateRefResponse)(nil), "elton.v2.CreateRefResponse")
+	proto.RegisterType((*GetRefRequest)(nil), "elton.v2.GetRefRequest")
+	proto.RegisterType((*GetRefResponse)(nil), "elton.v2.GetRefResponse")
+	proto.RegisterType((*ListRefsRequest)(nil), "elton.v2.ListRefsRequest")
+	proto.RegisterType((*ListRefsResponse)(nil), "elton.v2.ListRefsResponse")
+	proto.RegisterType((*MoveRefRequest)(nil), "elton.v2.MoveRefRequest")
+	proto.RegisterType((*MoveRefResponse)(nil), "elton.v2.MoveRefResponse")
+	proto.RegisterType((*UpdateRefRequest)(nil), "elton.v2.UpdateRefRequest")
+	proto.RegisterType((*UpdateRefResponse)(nil), "elton.v2.UpdateRefResponse")
+	proto.RegisterType((*DeleteRefRequest)(nil), "elton.v2.DeleteRefRequest")
+	proto.RegisterType((*DeleteRefResponse)(nil), "elton.v2.DeleteRefResponse")
 }
 
 func init() { proto.RegisterFile("fs.proto", fileDescriptor_e604833c2b457e38) }
 
 var fileDescriptor_e604833c2b457e38 = []byte{
-	// 527 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0x95, 0x1d, 0xa7, 0x72, 0x27, 0x0d, 0x0a, 0x1b, 0x03, 0x91, 0xa1, 0x80, 0xf6, 0xd4, 0x93,
-	0x55, 0x05, 0x84, 0x04, 0x48, 0x70, 0x68, 0x44, 0xd5, 0x28, 0x41, 0x95, 0x91, 0xb8, 0xbb, 0xed,
-	0x44, 0xb2, 0x14, 0x7b, 0x43, 0xbc, 0x0d, 0xf0, 0x5b, 0xfc, 0x07, 0xff, 0x84, 0xf0, 0xae, 0x9d,
-	0xdd, 0x8d, 0xed, 0xc4, 0x87, 0xde, 0x92, 0xdd, 0x79, 0x6f, 0xde, 0xbc, 0x9d, 0x19, 0x83, 0xbb,
-	0xc8, 0x82, 0xd5, 0x9a, 0x71, 0x46, 0x5c, 0x5c, 0x72, 0x96, 0x06, 0x9b, 0xb1, 0xdf, 0xe3, 0xbf,
-	0x57, 0x28, 0x8f, 0xe9, 0x67, 0x18, 0x5e, 0xac, 0x31, 0xe2, 0xf8, 0x9d, 0x2d, 0xef, 0x13, 0x0c,
-	0xf1, 0xc7, 0x3d, 0x66, 0x9c, 0x9c, 0x81, 0x13, 0xa7, 0x0b, 0x36, 0xb2, 0x5f, 0x5b, 0x67, 0xbd,
-	0xb1, 0x17, 0x14, 0xe0, 0x40, 0x84, 0x5d, 0xa5, 0x0b, 0x16, 0xe6, 0x11, 0xf4, 0x03, 0x78, 0x3a,
-	0x41, 0xb6, 0x62, 0x69, 0x86, 0x84, 0x82, 0x1d, 0xdf, 0x8d, 0xac, 0x1c, 0x4f, 0x76, 0xf0, 0x93,
-	0xd0, 0x8e, 0xef, 0xe8, 0x7b, 0x18, 0x4e, 0x70, 0x89, 0x66, 0xf2, 0x43, 0xa0, 0x4f, 0xc1, 0xd3,
-	0xa1, 0x22, 0x2d, 0xfd, 0x04, 0x64, 0x16, 0x67, 0x5c, 0x9c, 0x66, 0x05, 0xa3, 0x07, 0xdd, 0x65,
-	0x9c, 0xc4, 0x3c, 0x27, 0x75, 0x42, 0xf1, 0x87, 0x10, 0x70, 0x52, 0xfc, 0xc5, 0xf3, 0x22, 0x8f,
-	0xc3, 0xfc, 0x37, 0xfd, 0x09, 0x43, 0x0d, 0x2f, 0xab, 0x29, 0x42, 0xad, 0x6d, 0xa8, 0x94, 0x69,
-	0x37, 0xc9, 0x2c, 0x7d, 0xec, 0xec, 0xf5, 0xf1, 0x2b, 0x78, 0x57, 0x69, 0xb6, 0xc2, 0x5b, 0xde,
-	0xda, 0x8c, 0x5c, 0x5d, 0x94, 0x60, 0x59, 0x48, 0x94, 0x20, 0x45, 0x78, 0x62, 0xf0, 0x1d, 0xfe,
-	0x30, 0x2d, 0x9e, 0xff, 0x0b, 0x78, 0x97, 0xc8, 0x67, 0x51, 0xc6, 0x2f, 0x58, 0x92, 0xc4, 0xbc,
-	0x90, 0x1d, 0x80, 0xbb, 0x11, 0xb1, 0x4d, 0xb9, 0xca, 0x98, 0xff, 0x72, 0x0d, 0x9e, 0x66, 0xb9,
-	0x22, 0x6a, 0x9f, 0x5c, 0x19, 0xb5, 0x95, 0x7b, 0x23, 0xda, 0x43, 0x9c, 0xb7, 0x6f, 0x0f, 0xa9,
-	0xa6, 0xd3, 0xd8, 0x9a, 0x73, 0xd1, 0x42, 0x65, 0x8e, 0xf6, 0x2d, 0xa4, 0x16, 0x47, 0xdf, 0xc1,
-	0xe0, 0x12, 0x0d, 0x77, 0x0f, 0x30, 0x85, 0x46, 0xf0, 0x58, 0xc1, 0x3d, 0x88, 0x9b, 0x0c, 0xfa,
-	0xba, 0xae, 0xda, 0x76, 0x37, 0xa1, 0x52, 0x48, 0xb7, 0xc9, 0xc8, 0xa9, 0xe3, 0x5a, 0x03, 0x7b,
-	0xea, 0xb8, 0xf6, 0xa0, 0x33, 0x75, 0x5c, 0x67, 0xd0, 0xa5, 0x6f, 0xe1, 0x51, 0xfb, 0x82, 0xc6,
-	0x7f, 0x6d, 0xe8, 0x0b, 0xe2, 0x6f, 0xb8, 0xde, 0xc4, 0xb7, 0x48, 0xe6, 0x70, 0xa2, 0x2e, 0x2d,
-	0x72, 0xaa, 0x20, 0x77, 0xb7, 0xa1, 0xff, 0xb2, 0xee, 0x5a, 0x8a, 0x98, 0xc3, 0x89, 0xba, 0x8c,
-	0x54, 0xba, 0x8a, 0xfd, 0xa6, 0xd2, 0x55, 0xed, 0x30, 0x32, 0x83, 0x9e, 0xb2, 0x83, 0xc8, 0x8b,
-	0x6d, 0xf8, 0xee, 0x6a, 0xf3, 0x4f, 0x6b, 0x6e, 0x05, 0xd7, 0xb9, 0x45, 0xae, 0xa1, 0xaf, 0x2d,
-	0x02, 0xa2, 0xa4, 0xaf, 0xda, 0x38, 0xfe, 0xab, 0xda, 0x7b, 0xc1, 0x39, 0xfe, 0x63, 0x17, 0xef,
-	0x5e, 0xf8, 0x79, 0x0d, 0x7d, 0x6d, 0x7a, 0xd5, 0x1c, 0x55, 0xeb, 0x41, 0xcd, 0x51, 0x3d, 0xf6,
-	0xd2, 0x03, 0x39, 0x44, 0xa6, 0x07, 0xfa, 0xfc, 0x9a, 0x1e, 0x18, 0x93, 0x77, 0x6e, 0x91, 0x09,
-	0x1c, 0x97, 0xb3, 0x40, 0x7c, 0x2d, 0xb7, 0xae, 0xeb, 0x79, 0xe5, 0x9d, 0xd4, 0xf4, 0x11, 0x8e,
-	0x24, 0xc5, 0x33, 0xb3, 0xd3, 0x0a, 0xfc, 0x68, 0xf7, 0x42, 0x80, 0x6f, 0x8e, 0xf2, 0xef, 0xed,
-	0x9b, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x63, 0x95, 0xde, 0x55, 0x92, 0x07, 0x00, 0x00,
+	// 2205 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x5f, 0x53, 0xdc, 0xc8,
+	0x11, 0xb7, 0x76, 0x97, 0x5d, 0x6d, 0x03, 0x8b, 0x98, 0xe5, 0x2e, 0x42, 0x60, 0xa0, 0x74, 0xae,
+	0x14, 0xe5, 0x72, 0xd6, 0x04, 0x27, 0xce, 0x9f, 0xab, 0xf8, 0x8e, 0x62, 0x0b, 0x0a, 0x0e, 0x0e,
+	0x22, 0x93, 0xe4, 0x21, 0x49, 0xa5, 0x84, 0x34, 0x0b, 0x8a, 0xb5, 0xd2, 0x9e, 0x34, 0x6b, 0x9b,
+	0xca, 0x63, 0x52, 0xf9, 0x04, 0xf9, 0x02, 0xa9, 0x4a, 0x5e, 0xee, 0x29, 0x2f, 0xf9, 0x3e, 0xf9,
+	0x28, 0xa9, 0xf9, 0x23, 0x69, 0xa4, 0x95, 0x16, 0x64, 0xdf, 0xbd, 0x69, 0xa6, 0x7f, 0xdd, 0xf3,
+	0x9b, 0x99, 0x9e, 0x9e, 0x9e, 0x16, 0xa8, 0xa3, 0x78, 0x30, 0x89, 0x42, 0x12, 0x22, 0x15, 0xfb,
+	0x24, 0x0c, 0x06, 0x6f, 0xf7, 0x8d, 0xad, 0x9b, 0x30, 0xbc, 0xf1, 0xf1, 0x73, 0xd6, 0x7f, 0x3d,
+	0x1d, 0x3d, 0x77, 0xa7, 0x91, 0x4d, 0xbc, 0x30, 0xe0, 0x48, 0x63, 0xbb, 0x28, 0x27, 0xde, 0x18,
+	0xc7, 0xc4, 0x1e, 0x4f, 0x04, 0x60, 0x91, 0xdc, 0x4d, 0xb0, 0xb0, 0x6b, 0x7e, 0x01, 0xfd, 0xc3,
+	0x08, 0xdb, 0x04, 0xff, 0x36, 0xf4, 0xa7, 0x63, 0x6c, 0xe1, 0x6f, 0xa6, 0x38, 0x26, 0x68, 0x17,
+	0x5a, 0x5e, 0x30, 0x0a, 0xf5, 0xc6, 0x8e, 0xb2, 0xbb, 0xb8, 0xbf, 0x36, 0x48, 0x46, 0x1f, 0x70,
+	0xd8, 0x49, 0x30, 0x0a, 0x2d, 0x86, 0x30, 0x7f, 0x09, 0x6b, 0x79, 0x03, 0xf1, 0x24, 0x0c, 0x62,
+	0x8c, 0x4c, 0x68, 0x78, 0xae, 0xae, 0x30, 0x7d, 0x34, 0xa3, 0x3f, 0xb4, 0x1a, 0x9e, 0x6b, 0xfe,
+	0x02, 0xfa, 0x43, 0xec, 0xe3, 0xe2, 0xe0, 0x0f, 0x51, 0xfd, 0x14, 0xd6, 0xf2, 0xaa, 0x7c, 0x58,
+	0xd3, 0x05, 0x74, 0xe6, 0xc5, 0x84, 0xf7, 0xc6, 0x89, 0xc5, 0x35, 0x58, 0xf0, 0xbd, 0xb1, 0x47,
+	0x98, 0xd1, 0x96, 0xc5, 0x1b, 0x08, 0x41, 0x2b, 0xc0, 0xef, 0x09, 0x9b, 0x64, 0xd7, 0x62, 0xdf,
+	0xe8, 0x09, 0x2c, 0xfb, 0xf6, 0x35, 0xf6, 0x5f, 0x63, 0x1f, 0x3b, 0x24, 0x8c, 0xf4, 0x26, 0x13,
+	0xe6, 0x3b, 0xcd, 0x77, 0xd0, 0xcf, 0x8d, 0x22, 0xe6, 0x9c, 0x18, 0x54, 0x24, 0x83, 0x7c, 0x32,
+	0x8d, 0x79, 0x93, 0x49, 0x57, 0xbb, 0x79, 0xef, 0x6a, 0x7f, 0x0d, 0x6b, 0x27, 0x41, 0x3c, 0xc1,
+	0x0e, 0xa9, 0xbd, 0x64, 0x8c, 0x9d, 0x3d, 0xc6, 0xe9, 0x74, 0xed, 0x31, 0x36, 0x31, 0x7c, 0x52,
+	0xb0, 0xf7, 0xf0, 0xed, 0xab, 0xe1, 0x24, 0x7f, 0x55, 0xa0, 0xff, 0x9b, 0x89, 0x6b, 0x7f, 0xc0,
+	0x4e, 0x3f, 0x7c, 0x14, 0xb4, 0x05, 0x30, 0x65, 0x83, 0x9c, 0xdb, 0xf1, 0x1b, 0xbd, 0xb9, 0xd3,
+	0xdc, 0xed, 0x5a, 0x52, 0x8f, 0xf9, 0x25, 0xac, 0xe5, 0x49, 0x88, 0xb9, 0x26, 0x23, 0x28, 0xf7,
+	0xce, 0xc3, 0x81, 0xfe, 0xc9, 0x78, 0x12, 0x46, 0xe4, 0x7b, 0x9c, 0x06, 0x75, 0xed, 0xfc, 0x20,
+	0xc2, 0xb5, 0x23, 0x58, 0x7f, 0x8d, 0x89, 0x85, 0x09, 0x0e, 0xe8, 0x71, 0xbf, 0x0c, 0x7d, 0xcf,
+	0xb9, 0xab, 0x43, 0xe1, 0xc7, 0xd0, 0x9e, 0x30, 0x25, 0x41, 0x62, 0x3d, 0xc3, 0x15, 0xad, 0x0a,
+	0xa0, 0xb9, 0x09, 0x46, 0xd9, 0x98, 0x82, 0xd1, 0x25, 0xa0, 0xcb, 0x68, 0x1a, 0x7c, 0xc0, 0xa6,
+	0x7e, 0x0a, 0x6d, 0x37, 0xba, 0xb3, 0xa6, 0x01, 0xa3, 0xa2, 0x5a, 0xa2, 0x65, 0x12, 0xe8, 0xe7,
+	0x2c, 0x8a, 0x1d, 0x7a, 0x06, 0x1d, 0x97, 0x9d, 0x76, 0x6a, 0xb7, 0x99, 0xb7, 0x7b, 0x18, 0x8e,
+	0xc7, 0x1e, 0x39, 0x19, 0x5a, 0x09, 0x04, 0x3d, 0x07, 0x35, 0xc2, 0x3e, 0xb6, 0x63, 0x4c, 0x0f,
+	0x1e, 0x85, 0xf7, 0x33, 0xf8, 0xc5, 0xf5, 0x9f, 0xb1, 0x43, 0xbe, 0xc2, 0x77, 0x56, 0x0a, 0x32,
+	0x7f, 0x06, 0xab, 0x47, 0xb1, 0xf3, 0xa6, 0x7e, 0x14, 0xf2, 0x01, 0xc9, 0x8a, 0x82, 0xed, 0x0f,
+	0xa1, 0xe7, 0xdc, 0x62, 0xe7, 0x0d, 0x76, 0x39, 0xb7, 0x58, 0x84, 0x9d, 0x42, 0x2f, 0x1a, 0x40,
+	0xc7, 0xb5, 0xc7, 0xf6, 0x4d, 0x4a, 0x53, 0xf2, 0x0a, 0x6a, 0xd6, 0xc2, 0xf1, 0xd4, 0x27, 0x56,
+	0x02, 0x32, 0xcf, 0x00, 0xb2, 0xee, 0x2a, 0x7e, 0xe9, 0x72, 0xd0, 0x65, 0x36, 0x40, 0x9d, 0x44,
+	0xe1, 0xb5, 0x8f, 0xc7, 0x31, 0x1b, 0xa2, 0x6b, 0xa5, 0x6d, 0xd3, 0x81, 0xd5, 0xa3, 0x30, 0x2a,
+	0x4c, 0xfa, 0x09, 0x34, 0xe3, 0xc8, 0x99, 0x63, 0x95, 0x8a, 0x6b, 0xf8, 0xb2, 0x0b, 0x48, 0x1e,
+	0xa4, 0x46, 0x70, 0x79, 0x0a, 0x6d, 0x87, 0x0d, 0xaa, 0x37, 0x2a, 0xc9, 0x08, 0x84, 0x79, 0x04,
+	0x6b, 0xc7, 0x98, 0x9c, 0xd9, 0x31, 0xe1, 0xa2, 0x64, 0x36, 0x03, 0x50, 0xdf, 0x72, 0x9b, 0xf3,
+	0x46, 0x4b, 0x31, 0x34, 0x1a, 0x16, 0xec, 0xcc, 0x27, 0x9c, 0x5b, 0xeb, 0xca, 0x45, 0x11, 0xa8,
+	0x6c, 0x51, 0xfe, 0xdd, 0xe4, 0x97, 0x14, 0x17, 0x7c, 0xc0, 0x25, 0xc5, 0xe9, 0x34, 0xe7, 0xae,
+	0x9f, 0x06, 0xcd, 0x08, 0x8f, 0xf4, 0x16, 0x53, 0xa3, 0x9f, 0x68, 0x0f, 0x16, 0xc2, 0xc8, 0xc5,
+	0x91, 0xbe, 0xb0, 0xa3, 0xec, 0xf6, 0xf6, 0x8d, 0x4c, 0x51, 0x22, 0x73, 0x41, 0x11, 0x16, 0x07,
+	0x52, 0x8d, 0xd8, 0x0b, 0x1c, 0xac, 0xb7, 0xd9, 0x50, 0xc6, 0x80, 0xa7, 0x16, 0x83, 0x24, 0xb5,
+	0x18, 0x5c, 0x25, 0xa9, 0x85, 0xc5, 0x81, 0x54, 0x63, 0x1a, 0x10, 0xcf, 0xd7, 0x3b, 0xf7, 0x6b,
+	0x30, 0x20, 0xda, 0x07, 0xb0, 0x03, 0x07, 0xc7, 0x24, 0x8c, 0x2e, 0x46, 0xba, 0x5a, 0xb9, 0xc4,
+	0x12, 0x0a, 0xbd, 0x84, 0x25, 0x17, 0xc7, 0x0e, 0x0e, 0x5c, 0x3b, 0x20, 0x17, 0x23, 0xbd, 0x5b,
+	0xa9, 0x95, 0xc3, 0xa1, 0x1f, 0x41, 0x7b, 0x8c, 0xa3, 0x1b, 0x1c, 0xeb, 0xc0, 0x96, 0xe0, 0x93,
+	0x4c, 0xe3, 0x9c, 0xf6, 0x1f, 0x79, 0x3e, 0xc1, 0x91, 0x25, 0x40, 0xe6, 0x7f, 0x15, 0x7e, 0xcd,
+	0xa7, 0xfb, 0x54, 0xff, 0x9a, 0xcf, 0x79, 0xc8, 0x33, 0xe8, 0x4c, 0xec, 0x08, 0x07, 0x24, 0x66,
+	0x97, 0x53, 0x45, 0x14, 0x13, 0x10, 0xf4, 0x73, 0xe8, 0x3a, 0x2c, 0xb1, 0x72, 0x0f, 0x88, 0xde,
+	0xba, 0x77, 0x39, 0x33, 0xb0, 0xf9, 0x12, 0xb4, 0x63, 0x5c, 0x38, 0x0a, 0x0f, 0xf0, 0x60, 0xd3,
+	0x86, 0x55, 0x49, 0xef, 0x7b, 0x71, 0x7d, 0x1f, 0xd0, 0xd0, 0x1b, 0x8d, 0x0a, 0x9e, 0xbf, 0x03,
+	0x8a, 0x3d, 0x67, 0x08, 0xc5, 0xa6, 0x88, 0xeb, 0x39, 0xab, 0xab, 0x5c, 0xd3, 0x4d, 0x99, 0xd8,
+	0xe4, 0x56, 0xe4, 0x6b, 0xec, 0xdb, 0x3c, 0x84, 0x7e, 0x6e, 0xb4, 0xf4, 0x36, 0x69, 0x3b, 0xb7,
+	0x76, 0x70, 0x83, 0x67, 0x6f, 0xfc, 0x4b, 0x9b, 0xdc, 0x1e, 0x32, 0x99, 0x25, 0x30, 0xe6, 0xb7,
+	0x0d, 0x80, 0xac, 0x1b, 0x3d, 0x83, 0x16, 0xcd, 0x9f, 0x99, 0x6a, 0x6f, 0x5f, 0x2f, 0x53, 0xbd,
+	0xba, 0x9b, 0x60, 0x8b, 0xa1, 0x52, 0x56, 0x8d, 0x8c, 0x15, 0xd2, 0xa1, 0x13, 0xfa, 0xee, 0x65,
+	0x46, 0x36, 0x69, 0xa2, 0x5d, 0x26, 0x39, 0xf2, 0x7c, 0x2c, 0x36, 0xbc, 0x27, 0x5d, 0x08, 0x9e,
+	0x8f, 0xad, 0x44, 0x4c, 0x91, 0x01, 0x7e, 0xc7, 0x90, 0x0b, 0xe5, 0x48, 0x21, 0x66, 0x97, 0x51,
+	0x18, 0x10, 0x1c, 0x10, 0x4e, 0xce, 0x65, 0x87, 0x59, 0xb5, 0x0a, 0xbd, 0x68, 0x07, 0x16, 0xc7,
+	0xa1, 0x8b, 0x13, 0x50, 0x87, 0x81, 0xe4, 0x2e, 0x64, 0xc2, 0x52, 0xf8, 0x2e, 0xc0, 0x51, 0x02,
+	0x51, 0x19, 0x24, 0xd7, 0x67, 0x7e, 0x05, 0xab, 0x67, 0x61, 0xf8, 0x66, 0x3a, 0xa1, 0xf3, 0xa9,
+	0xe1, 0x7b, 0x65, 0x0b, 0x65, 0x9e, 0x02, 0x92, 0x8d, 0xd5, 0x70, 0x48, 0x0d, 0x9a, 0x5e, 0xc0,
+	0xfd, 0xb1, 0x65, 0xd1, 0x4f, 0xf3, 0x6f, 0x0a, 0xf4, 0x2c, 0x6c, 0xbb, 0x43, 0x2f, 0xfa, 0x48,
+	0x5a, 0x59, 0x9c, 0x6e, 0x96, 0xc5, 0xe9, 0x96, 0x14, 0x14, 0x04, 0x8d, 0x85, 0x8c, 0x46, 0x08,
+	0x2b, 0x29, 0x8b, 0x39, 0xd1, 0xa4, 0x24, 0x55, 0x4f, 0x8c, 0x35, 0x53, 0x63, 0xc8, 0x84, 0xd6,
+	0xa8, 0xda, 0x57, 0x98, 0xcc, 0xfc, 0x3d, 0xac, 0xbc, 0x26, 0x36, 0xf9, 0x0e, 0xb6, 0x63, 0x96,
+	0x80, 0xf9, 0x77, 0x05, 0xb4, 0xcc, 0xfa, 0xc7, 0xec, 0x4f, 0x3a, 0x97, 0x66, 0xf5, 0x5c, 0xe8,
+	0xc1, 0xc1, 0x01, 0x89, 0x3c, 0x1c, 0xb3, 0x29, 0xb7, 0xac, 0xa4, 0x69, 0xfe, 0x53, 0x81, 0xe5,
+	0x7c, 0xbc, 0xab, 0x7c, 0x52, 0x15, 0x43, 0x92, 0xe0, 0xbb, 0x70, 0x5f, 0xba, 0x7a, 0x1d, 0xd9,
+	0x81, 0x73, 0xcb, 0x0e, 0x4f, 0xd7, 0x12, 0x2d, 0xca, 0x28, 0x08, 0xd9, 0xd5, 0x21, 0x0e, 0x4c,
+	0xd2, 0x3c, 0x6d, 0xa9, 0x8a, 0xd6, 0x38, 0x6d, 0xa9, 0x0d, 0xad, 0x79, 0xda, 0x52, 0x5b, 0xda,
+	0x82, 0xf9, 0x13, 0xe8, 0xd5, 0x0f, 0xad, 0xe6, 0x09, 0xac, 0xf0, 0xf6, 0x61, 0x18, 0x8c, 0x7c,
+	0xcf, 0x21, 0x31, 0x7a, 0x09, 0x5d, 0x27, 0x69, 0x88, 0x74, 0x58, 0x2f, 0x6a, 0x27, 0x68, 0x2b,
+	0x83, 0x9a, 0xff, 0x69, 0x40, 0x2f, 0x2f, 0xa5, 0xdb, 0x9c, 0x06, 0xb3, 0xae, 0x08, 0x59, 0xb3,
+	0x7b, 0x93, 0x78, 0x63, 0x53, 0xf2, 0xc6, 0x4d, 0xe8, 0xfa, 0x36, 0xc1, 0x31, 0x39, 0x09, 0x42,
+	0xb1, 0x1b, 0x59, 0x07, 0x7d, 0x89, 0x39, 0xd3, 0x88, 0xde, 0x63, 0x27, 0xa9, 0xff, 0x4b, 0x3d,
+	0x34, 0xd8, 0x70, 0x30, 0xf5, 0x9c, 0x58, 0x6f, 0xb3, 0xd4, 0x54, 0xee, 0xa2, 0xc1, 0x46, 0xe0,
+	0x39, 0xa4, 0xc3, 0x20, 0xb9, 0x3e, 0x34, 0x00, 0xe0, 0x2a, 0x2c, 0x0e, 0xaa, 0xa5, 0x9e, 0x23,
+	0x21, 0xd0, 0x1e, 0x2c, 0x0a, 0x7d, 0xa6, 0xd0, 0x2d, 0x55, 0x90, 0x21, 0xd9, 0x7b, 0xaf, 0xf6,
+	0x65, 0x5a, 0xe3, 0x4e, 0x4c, 0xdf, 0x7b, 0x79, 0xf7, 0x30, 0x5f, 0x81, 0xce, 0x7b, 0x7e, 0x3d,
+	0xc5, 0x53, 0x4c, 0xcf, 0xd9, 0x34, 0xae, 0xf3, 0x38, 0xb9, 0x84, 0xf5, 0x12, 0x7d, 0xe1, 0x7b,
+	0x2f, 0xa0, 0xfd, 0x0d, 0xed, 0x4e, 0x3c, 0x68, 0xa3, 0x48, 0x50, 0x56, 0x12, 0x50, 0xf3, 0x1f,
+	0x4d, 0x58, 0x9d, 0x91, 0x3e, 0x28, 0x9b, 0x5f, 0x83, 0x05, 0x17, 0x4f, 0x44, 0x40, 0x69, 0x59,
+	0xbc, 0x41, 0x9f, 0x27, 0x63, 0xfb, 0xfd, 0x90, 0x09, 0x78, 0x58, 0x49, 0xdb, 0xf4, 0x68, 0x39,
+	0xe2, 0xf5, 0x24, 0x0e, 0xbb, 0x68, 0xd2, 0xc3, 0x28, 0xb2, 0x38, 0xee, 0x58, 0xa2, 0x45, 0x35,
+	0x22, 0xcc, 0xc3, 0x43, 0x9b, 0x6b, 0x88, 0x26, 0x1d, 0x67, 0x64, 0x7b, 0xfe, 0x34, 0xc2, 0x31,
+	0x3b, 0xa7, 0x2d, 0x2b, 0x6d, 0xa3, 0x03, 0xe8, 0x91, 0x90, 0xd8, 0x3e, 0x3b, 0xb6, 0x34, 0x9d,
+	0x12, 0x8e, 0xb4, 0x3e, 0x93, 0x6b, 0x0d, 0x45, 0x9d, 0xcd, 0x2a, 0x28, 0xa0, 0x5f, 0xc1, 0xd2,
+	0xd8, 0x7e, 0x9f, 0x19, 0xe8, 0xde, 0x67, 0x20, 0x07, 0x47, 0x5f, 0xd0, 0x92, 0x53, 0x4c, 0x32,
+	0x7d, 0xb8, 0x4f, 0x3f, 0x8f, 0x37, 0xff, 0x02, 0xfd, 0xdf, 0xd9, 0xc4, 0xb9, 0x2d, 0x64, 0x55,
+	0x0f, 0xab, 0x4a, 0x88, 0x0c, 0xbf, 0x3a, 0xb7, 0xe2, 0x00, 0x29, 0x04, 0x36, 0xe5, 0x10, 0x48,
+	0xeb, 0x7f, 0xf9, 0xc1, 0x6b, 0x04, 0xb7, 0x2b, 0xd0, 0x78, 0xed, 0xd0, 0xc2, 0xa3, 0x84, 0xf5,
+	0xb6, 0xa4, 0xb7, 0x92, 0xe9, 0x59, 0x78, 0x24, 0x28, 0x6f, 0xf3, 0x87, 0x0d, 0x27, 0xbc, 0x9c,
+	0x43, 0xb0, 0x77, 0x8e, 0xd9, 0x87, 0x55, 0xc9, 0xaa, 0x38, 0x4c, 0x7b, 0xb0, 0x7c, 0x8c, 0x49,
+	0x8d, 0x71, 0x4c, 0x0b, 0x7a, 0x89, 0x86, 0x98, 0xd2, 0xc7, 0x53, 0xfb, 0x29, 0xac, 0xd0, 0x07,
+	0x85, 0x85, 0x47, 0xb5, 0x4e, 0xf2, 0x15, 0x68, 0x99, 0xda, 0x77, 0x46, 0xe6, 0x8f, 0xd0, 0x3b,
+	0x0f, 0xdf, 0xd6, 0x5a, 0xfb, 0x3a, 0x8f, 0xf2, 0x55, 0x58, 0x49, 0xcd, 0x8b, 0x4d, 0xf8, 0x97,
+	0x02, 0x1a, 0xaf, 0xc0, 0x49, 0x83, 0x3e, 0xb0, 0x5c, 0x24, 0x9c, 0xaf, 0x91, 0xbb, 0x7f, 0x07,
+	0xa0, 0xe2, 0xf7, 0xb4, 0x7a, 0x89, 0x4b, 0x9e, 0xc3, 0x29, 0xa3, 0x14, 0x43, 0xcb, 0x1b, 0x01,
+	0x7e, 0xa7, 0xb7, 0x2a, 0xa1, 0x54, 0x4c, 0x1d, 0x48, 0x62, 0x29, 0xb8, 0xbf, 0x00, 0x8d, 0x17,
+	0x9c, 0xeb, 0xf8, 0x50, 0x1f, 0x56, 0x25, 0x25, 0x6e, 0xe9, 0xe9, 0x2b, 0xbe, 0x9b, 0xf2, 0x83,
+	0x1b, 0xad, 0xc0, 0xe2, 0x91, 0x17, 0xd1, 0xcb, 0x8f, 0xde, 0x3d, 0xda, 0x23, 0xda, 0x71, 0x15,
+	0x4e, 0x42, 0x3f, 0xbc, 0xf1, 0x1c, 0xdb, 0xd7, 0x14, 0xa4, 0x42, 0x6b, 0x68, 0x13, 0xac, 0x35,
+	0x9e, 0x7e, 0x0e, 0x8b, 0xd2, 0x6b, 0x15, 0xf5, 0x00, 0x0e, 0x7c, 0x5f, 0x58, 0xd3, 0x1e, 0xd1,
+	0x36, 0x13, 0xc7, 0x17, 0x81, 0x7f, 0xa7, 0x29, 0x68, 0x09, 0xd4, 0xaf, 0x79, 0x52, 0x12, 0x6b,
+	0x8d, 0xa7, 0x87, 0xd0, 0xcb, 0x3f, 0x54, 0x50, 0x17, 0x16, 0x0e, 0x5c, 0x17, 0xbb, 0xda, 0x23,
+	0xb4, 0x08, 0x1d, 0x4e, 0xd7, 0xe5, 0x7a, 0xe7, 0xa1, 0xeb, 0x8d, 0x3c, 0xec, 0x6a, 0x0d, 0x2a,
+	0xb2, 0x30, 0xbd, 0xf9, 0x5d, 0xad, 0xb9, 0xff, 0x6d, 0x1b, 0x96, 0xf9, 0xfe, 0xbc, 0xc6, 0xd1,
+	0x5b, 0xcf, 0xc1, 0xe8, 0x1c, 0x96, 0xe4, 0xbf, 0x00, 0xe8, 0xb1, 0xb4, 0xb6, 0xb3, 0xbf, 0x17,
+	0x8c, 0xad, 0x2a, 0xb1, 0x70, 0xee, 0x73, 0x58, 0x92, 0xab, 0xfb, 0xb2, 0xb9, 0x92, 0x1f, 0x06,
+	0xc6, 0x56, 0x95, 0x58, 0x98, 0x3b, 0x83, 0x45, 0xa9, 0x5c, 0x8f, 0x36, 0xf3, 0x95, 0x8f, 0xfc,
+	0xbf, 0x02, 0xe3, 0x71, 0x85, 0x94, 0xdb, 0xda, 0x53, 0xd0, 0x25, 0x2c, 0xe7, 0x6a, 0xe6, 0x48,
+	0x1a, 0xbe, 0xac, 0x38, 0x6f, 0x6c, 0x57, 0xca, 0xb3, 0xe9, 0xca, 0x85, 0x69, 0x79, 0xba, 0x25,
+	0x55, 0x73, 0x63, 0xab, 0x4a, 0x9c, 0x99, 0x93, 0x0b, 0xc8, 0xb2, 0xb9, 0x92, 0xea, 0xb5, 0xb1,
+	0x55, 0x25, 0x16, 0xe6, 0xfe, 0x04, 0x68, 0xb6, 0x06, 0x8c, 0x3e, 0xcb, 0xb4, 0x2a, 0xab, 0xd2,
+	0xc6, 0x93, 0xf9, 0x20, 0x31, 0xc0, 0x29, 0x2c, 0x4a, 0x45, 0x5f, 0x79, 0x7b, 0x66, 0xab, 0xcb,
+	0xc6, 0xe3, 0x0a, 0xa9, 0xb0, 0x75, 0x0c, 0x90, 0x15, 0x1c, 0x91, 0x94, 0xd5, 0xcc, 0xd4, 0x3a,
+	0x8d, 0xcd, 0x72, 0xa1, 0x64, 0x28, 0x76, 0xca, 0x0c, 0xc5, 0xce, 0x1c, 0x43, 0x33, 0xd5, 0xe0,
+	0xfd, 0xff, 0x75, 0x93, 0xb7, 0x49, 0x72, 0x58, 0x2e, 0x61, 0x39, 0x57, 0x66, 0x94, 0x1d, 0xa8,
+	0xac, 0x8e, 0x69, 0x6c, 0x57, 0xca, 0xf3, 0x0e, 0x9e, 0x14, 0x96, 0x37, 0x4b, 0x4b, 0x7b, 0x15,
+	0x0e, 0x5e, 0xb8, 0xb8, 0xf7, 0x14, 0x34, 0x84, 0x6e, 0x5a, 0x07, 0x42, 0x46, 0x6e, 0xec, 0x3c,
+	0xaf, 0x8d, 0x52, 0x59, 0xc6, 0x49, 0x2a, 0xbe, 0xc8, 0x9c, 0x66, 0x2b, 0x40, 0xc6, 0xe3, 0x0a,
+	0x69, 0xca, 0xe9, 0x18, 0x20, 0xab, 0x05, 0xc8, 0xdb, 0x31, 0x53, 0x6e, 0x30, 0x36, 0xcb, 0x85,
+	0x82, 0xd6, 0x97, 0xd0, 0x11, 0x2f, 0x70, 0xa4, 0xcb, 0x21, 0x5b, 0x2e, 0x0d, 0x18, 0xeb, 0x25,
+	0x92, 0x94, 0xca, 0x01, 0xa8, 0xc9, 0xa3, 0x17, 0x49, 0xc0, 0xc2, 0x33, 0xdb, 0x30, 0xca, 0x44,
+	0x82, 0xc4, 0xe7, 0xd0, 0x16, 0xcb, 0xfb, 0x83, 0xe2, 0x25, 0x94, 0xa8, 0xeb, 0xb3, 0x02, 0xa1,
+	0xfc, 0x87, 0xd2, 0x24, 0x7c, 0x5e, 0xfe, 0x2e, 0x4c, 0x7e, 0x36, 0x17, 0x53, 0x0c, 0x1e, 0x82,
+	0xe0, 0x4c, 0xf0, 0xc8, 0xd3, 0xdc, 0xaa, 0x12, 0x0b, 0x73, 0x17, 0xb0, 0x24, 0xa7, 0x87, 0xb2,
+	0xb9, 0x92, 0x9c, 0xd5, 0xd8, 0xaa, 0x12, 0xcb, 0xce, 0x99, 0x66, 0x77, 0xb2, 0x73, 0x16, 0x13,
+	0x49, 0x63, 0xa3, 0x54, 0x96, 0x6d, 0x00, 0x4f, 0xee, 0xe4, 0x0d, 0xc8, 0x25, 0x88, 0x86, 0x3e,
+	0x2b, 0x10, 0xca, 0x87, 0xa0, 0x26, 0xe9, 0x98, 0xec, 0x00, 0x85, 0xcc, 0xce, 0x30, 0xca, 0x44,
+	0xe9, 0x3c, 0x5e, 0x41, 0x47, 0xa4, 0x47, 0xb2, 0x1f, 0xe6, 0x13, 0x32, 0x63, 0xbd, 0x44, 0x22,
+	0x48, 0x0c, 0xa1, 0x9b, 0x26, 0x29, 0xf2, 0x3a, 0x14, 0xf3, 0x2b, 0x63, 0xa3, 0x54, 0x96, 0x59,
+	0x49, 0x13, 0x14, 0xd9, 0x4a, 0x31, 0xd5, 0x31, 0x36, 0x4a, 0x65, 0xdc, 0xca, 0x75, 0x9b, 0x3d,
+	0x51, 0x5e, 0xfc, 0x7f, 0x00, 0x3b, 0x08, 0x6f, 0xdd, 0xaf, 0x20, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -815,8 +3336,7 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type VolumeServiceClient interface {
 	// 新しいvolumeを作成する。
-	// volumeのメタデータは作成時に設定する。現時点では、作成時に設定したメタ―データの更新はできないが、今後のアップデートでメタデータ更新APIを
-	// 作成するかもしれない。
+	// volumeのメタデータは作成時に設定する。作成後のメタデータの更新はUpdateVolumeで行う。
 	//
 	// Error:
 	// - AlreadyExists: If volume name or volume ID is already exists.
@@ -847,6 +3367,60 @@ type VolumeServiceClient interface {
 	// - InvalidArgs
 	// - Internal
 	InspectVolume(ctx context.Context, in *InspectVolumeRequest, opts ...grpc.CallOption) (*InspectVolumeResponse, error)
+	// volumeのメタデータを更新する。
+	// updateMaskで指定したフィールドのみ更新する。name, labels, description, ownerを指定できる。
+	// nameを変更した場合は、名前のインデックスも同時に更新される。
+	//
+	// Error:
+	// - NotFound: If specified volume is not found.
+	// - AlreadyExists: If new volume name is already exists.
+	// - InvalidArgs
+	// - Internal
+	UpdateVolume(ctx context.Context, in *UpdateVolumeRequest, opts ...grpc.CallOption) (*UpdateVolumeResponse, error)
+	// 他のクラスタからエクスポートされたvolumeを、同じIDで作成する。
+	// CreateVolumeとは異なり、最初のコミットは作成しない。コミットはImportCommitで追加する。
+	//
+	// Error:
+	// - AlreadyExists: If volume name or volume ID is already exists.
+	// - InvalidArgs
+	// - Internal
+	ImportVolume(ctx context.Context, in *ImportVolumeRequest, opts ...grpc.CallOption) (*ImportVolumeResponse, error)
+	// volumeのコミット保持ポリシーを変更する。
+	// 変更したポリシーは、次回の削除処理から適用される。
+	//
+	// Error:
+	// - NotFound: If specified volume is not found.
+	// - InvalidArgs
+	// - Internal
+	SetRetentionPolicy(ctx context.Context, in *SetRetentionPolicyRequest, opts ...grpc.CallOption) (*SetRetentionPolicyResponse, error)
+	// 保持ポリシーに従って、期限切れのコミットを削除する。
+	// 削除されたコミットの子コミットは、削除されたコミットの親に付け替えられる。
+	// 削除されたコミットだけが参照していたオブジェクトは、GCの対象として解放される。
+	//
+	// Error:
+	// - NotFound: If specified volume is not found.
+	// - InvalidArgs
+	// - Internal
+	PruneVolume(ctx context.Context, in *PruneVolumeRequest, opts ...grpc.CallOption) (*PruneVolumeResponse, error)
+	// 指定したコミットから新しいvolumeを作成する。
+	// 新しいvolumeの最初のコミットは、元のコミットのツリーをそのまま引き継ぐ。ファイルの内容はオブジェクトを共有するため、コピーは発生しない。
+	// 最初のコミットのforkedFromに元のコミットIDが記録される。
+	//
+	// Error:
+	// - NotFound: If source commit is not found.
+	// - AlreadyExists: If volume name is already exists.
+	// - InvalidArgs
+	// - Internal
+	ForkVolume(ctx context.Context, in *ForkVolumeRequest, opts ...grpc.CallOption) (*ForkVolumeResponse, error)
+	// volumeの全てのコミットを検査し、壊れているコミットを報告する。
+	// ツリーの整合性 (ディレクトリエントリの参照先、ディレクトリの循環、到達不能なinodeなど) と、親コミットの存在を確認する。
+	// 壊れているコミットを見つけても修復はしない。
+	//
+	// Error:
+	// - NotFound: If specified volume is not found.
+	// - InvalidArgs
+	// - Internal
+	FsckVolume(ctx context.Context, in *FsckVolumeRequest, opts ...grpc.CallOption) (*FsckVolumeResponse, error)
 }
 
 type volumeServiceClient struct {
@@ -916,11 +3490,64 @@ func (c *volumeServiceClient) InspectVolume(ctx context.Context, in *InspectVolu
 	return out, nil
 }
 
+func (c *volumeServiceClient) UpdateVolume(ctx context.Context, in *UpdateVolumeRequest, opts ...grpc.CallOption) (*UpdateVolumeResponse, error) {
+	out := new(UpdateVolumeResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.VolumeService/UpdateVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServiceClient) ImportVolume(ctx context.Context, in *ImportVolumeRequest, opts ...grpc.CallOption) (*ImportVolumeResponse, error) {
+	out := new(ImportVolumeResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.VolumeService/ImportVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServiceClient) SetRetentionPolicy(ctx context.Context, in *SetRetentionPolicyRequest, opts ...grpc.CallOption) (*SetRetentionPolicyResponse, error) {
+	out := new(SetRetentionPolicyResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.VolumeService/SetRetentionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServiceClient) PruneVolume(ctx context.Context, in *PruneVolumeRequest, opts ...grpc.CallOption) (*PruneVolumeResponse, error) {
+	out := new(PruneVolumeResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.VolumeService/PruneVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServiceClient) ForkVolume(ctx context.Context, in *ForkVolumeRequest, opts ...grpc.CallOption) (*ForkVolumeResponse, error) {
+	out := new(ForkVolumeResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.VolumeService/ForkVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServiceClient) FsckVolume(ctx context.Context, in *FsckVolumeRequest, opts ...grpc.CallOption) (*FsckVolumeResponse, error) {
+	out := new(FsckVolumeResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.VolumeService/FsckVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VolumeServiceServer is the server API for VolumeService service.
 type VolumeServiceServer interface {
 	// 新しいvolumeを作成する。
-	// volumeのメタデータは作成時に設定する。現時点では、作成時に設定したメタ―データの更新はできないが、今後のアップデートでメタデータ更新APIを
-	// 作成するかもしれない。
+	// volumeのメタデータは作成時に設定する。作成後のメタデータの更新はUpdateVolumeで行う。
 	//
 	// Error:
 	// - AlreadyExists: If volume name or volume ID is already exists.
@@ -951,6 +3578,60 @@ type VolumeServiceServer interface {
 	// - InvalidArgs
 	// - Internal
 	InspectVolume(context.Context, *InspectVolumeRequest) (*InspectVolumeResponse, error)
+	// volumeのメタデータを更新する。
+	// updateMaskで指定したフィールドのみ更新する。name, labels, description, ownerを指定できる。
+	// nameを変更した場合は、名前のインデックスも同時に更新される。
+	//
+	// Error:
+	// - NotFound: If specified volume is not found.
+	// - AlreadyExists: If new volume name is already exists.
+	// - InvalidArgs
+	// - Internal
+	UpdateVolume(context.Context, *UpdateVolumeRequest) (*UpdateVolumeResponse, error)
+	// 他のクラスタからエクスポートされたvolumeを、同じIDで作成する。
+	// CreateVolumeとは異なり、最初のコミットは作成しない。コミットはImportCommitで追加する。
+	//
+	// Error:
+	// - AlreadyExists: If volume name or volume ID is already exists.
+	// - InvalidArgs
+	// - Internal
+	ImportVolume(context.Context, *ImportVolumeRequest) (*ImportVolumeResponse, error)
+	// volumeのコミット保持ポリシーを変更する。
+	// 変更したポリシーは、次回の削除処理から適用される。
+	//
+	// Error:
+	// - NotFound: If specified volume is not found.
+	// - InvalidArgs
+	// - Internal
+	SetRetentionPolicy(context.Context, *SetRetentionPolicyRequest) (*SetRetentionPolicyResponse, error)
+	// 保持ポリシーに従って、期限切れのコミットを削除する。
+	// 削除されたコミットの子コミットは、削除されたコミットの親に付け替えられる。
+	// 削除されたコミットだけが参照していたオブジェクトは、GCの対象として解放される。
+	//
+	// Error:
+	// - NotFound: If specified volume is not found.
+	// - InvalidArgs
+	// - Internal
+	PruneVolume(context.Context, *PruneVolumeRequest) (*PruneVolumeResponse, error)
+	// 指定したコミットから新しいvolumeを作成する。
+	// 新しいvolumeの最初のコミットは、元のコミットのツリーをそのまま引き継ぐ。ファイルの内容はオブジェクトを共有するため、コピーは発生しない。
+	// 最初のコミットのforkedFromに元のコミットIDが記録される。
+	//
+	// Error:
+	// - NotFound: If source commit is not found.
+	// - AlreadyExists: If volume name is already exists.
+	// - InvalidArgs
+	// - Internal
+	ForkVolume(context.Context, *ForkVolumeRequest) (*ForkVolumeResponse, error)
+	// volumeの全てのコミットを検査し、壊れているコミットを報告する。
+	// ツリーの整合性 (ディレクトリエントリの参照先、ディレクトリの循環、到達不能なinodeなど) と、親コミットの存在を確認する。
+	// 壊れているコミットを見つけても修復はしない。
+	//
+	// Error:
+	// - NotFound: If specified volume is not found.
+	// - InvalidArgs
+	// - Internal
+	FsckVolume(context.Context, *FsckVolumeRequest) (*FsckVolumeResponse, error)
 }
 
 // UnimplementedVolumeServiceServer can be embedded to have forward compatible implementations.
@@ -969,6 +3650,24 @@ func (*UnimplementedVolumeServiceServer) ListVolumes(req *ListVolumesRequest, sr
 func (*UnimplementedVolumeServiceServer) InspectVolume(ctx context.Context, req *InspectVolumeRequest) (*InspectVolumeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InspectVolume not implemented")
 }
+func (*UnimplementedVolumeServiceServer) UpdateVolume(ctx context.Context, req *UpdateVolumeRequest) (*UpdateVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVolume not implemented")
+}
+func (*UnimplementedVolumeServiceServer) ImportVolume(ctx context.Context, req *ImportVolumeRequest) (*ImportVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportVolume not implemented")
+}
+func (*UnimplementedVolumeServiceServer) SetRetentionPolicy(ctx context.Context, req *SetRetentionPolicyRequest) (*SetRetentionPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRetentionPolicy not implemented")
+}
+func (*UnimplementedVolumeServiceServer) PruneVolume(ctx context.Context, req *PruneVolumeRequest) (*PruneVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneVolume not implemented")
+}
+func (*UnimplementedVolumeServiceServer) ForkVolume(ctx context.Context, req *ForkVolumeRequest) (*ForkVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForkVolume not implemented")
+}
+func (*UnimplementedVolumeServiceServer) FsckVolume(ctx context.Context, req *FsckVolumeRequest) (*FsckVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FsckVolume not implemented")
+}
 
 func RegisterVolumeServiceServer(s *grpc.Server, srv VolumeServiceServer) {
 	s.RegisterService(&_VolumeService_serviceDesc, srv)
@@ -1027,24 +3726,132 @@ type volumeServiceListVolumesServer struct {
 	grpc.ServerStream
 }
 
-func (x *volumeServiceListVolumesServer) Send(m *ListVolumesResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (x *volumeServiceListVolumesServer) Send(m *ListVolumesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VolumeService_InspectVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).InspectVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.VolumeService/InspectVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).InspectVolume(ctx, req.(*InspectVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeService_UpdateVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).UpdateVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.VolumeService/UpdateVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).UpdateVolume(ctx, req.(*UpdateVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeService_ImportVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).ImportVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.VolumeService/ImportVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).ImportVolume(ctx, req.(*ImportVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeService_SetRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRetentionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).SetRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.VolumeService/SetRetentionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).SetRetentionPolicy(ctx, req.(*SetRetentionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeService_PruneVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).PruneVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.VolumeService/PruneVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).PruneVolume(ctx, req.(*PruneVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeService_ForkVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForkVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).ForkVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.VolumeService/ForkVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).ForkVolume(ctx, req.(*ForkVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeService_InspectVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InspectVolumeRequest)
+func _VolumeService_FsckVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FsckVolumeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServiceServer).InspectVolume(ctx, in)
+		return srv.(VolumeServiceServer).FsckVolume(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/elton.v2.VolumeService/InspectVolume",
+		FullMethod: "/elton.v2.VolumeService/FsckVolume",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServiceServer).InspectVolume(ctx, req.(*InspectVolumeRequest))
+		return srv.(VolumeServiceServer).FsckVolume(ctx, req.(*FsckVolumeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1065,6 +3872,30 @@ var _VolumeService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "InspectVolume",
 			Handler:    _VolumeService_InspectVolume_Handler,
 		},
+		{
+			MethodName: "UpdateVolume",
+			Handler:    _VolumeService_UpdateVolume_Handler,
+		},
+		{
+			MethodName: "ImportVolume",
+			Handler:    _VolumeService_ImportVolume_Handler,
+		},
+		{
+			MethodName: "SetRetentionPolicy",
+			Handler:    _VolumeService_SetRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "PruneVolume",
+			Handler:    _VolumeService_PruneVolume_Handler,
+		},
+		{
+			MethodName: "ForkVolume",
+			Handler:    _VolumeService_ForkVolume_Handler,
+		},
+		{
+			MethodName: "FsckVolume",
+			Handler:    _VolumeService_FsckVolume_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1089,6 +3920,11 @@ type CommitServiceClient interface {
 	// コミットの履歴を取得する。
 	// 一回のレスポンスで返す個数指定と、ページネーションの設定が行える。
 	// 詳細な使い方は、引数とレスポンスのデータ型のコメントを参照。
+	//
+	// Error:
+	// - InvalidArgs: If "next" parameter is not valid.
+	// - FailedPrecondition: If the commit that "next" parameter points to was deleted.
+	// - Internal
 	ListCommits(ctx context.Context, in *ListCommitsRequest, opts ...grpc.CallOption) (CommitService_ListCommitsClient, error)
 	// 指定したIDのコミットを取得する。
 	//
@@ -1097,13 +3933,125 @@ type CommitServiceClient interface {
 	// - NotFound: If volume or commit is not found.
 	// - Internal
 	GetCommit(ctx context.Context, in *GetCommitRequest, opts ...grpc.CallOption) (*GetCommitResponse, error)
+	// 2つのコミットのツリーを比較し、パスごとの変更をパス順に返す。
+	// aからbへの変更を返す。コミットはref名でも指定できる。
+	// pathを指定した場合は、そのパス以下の変更のみを返す。
+	//
+	// Error:
+	// - InvalidArgument: If "a" or "b" parameter is null.
+	// - NotFound: If volume, ref or commit is not found.
+	// - Internal
+	DiffCommits(ctx context.Context, in *DiffCommitsRequest, opts ...grpc.CallOption) (CommitService_DiffCommitsClient, error)
+	// コミットのツリーでパスを解決し、inode番号を返す。
+	// ツリー全体を取得せずに、特定のファイルを参照するために使う。コミットはref名でも指定できる。
+	//
+	// Error:
+	// - InvalidArgument: If "id" parameter is null or path is not absolute.
+	// - NotFound: If commit or path is not found.
+	// - FailedPrecondition: If a component of the path is not a directory.
+	// - Internal
+	LookupPath(ctx context.Context, in *LookupPathRequest, opts ...grpc.CallOption) (*LookupPathResponse, error)
+	// ディレクトリのエントリを名前順に列挙する。
+	// 一回のレスポンスで返す個数指定と、ページネーションの設定が行える。
+	//
+	// Error:
+	// - InvalidArgument: If "id" parameter is null or path is not absolute.
+	// - NotFound: If commit, path or inode is not found.
+	// - FailedPrecondition: If path is not a directory, or "next" parameter is not valid.
+	// - Internal
+	ReadDir(ctx context.Context, in *ReadDirRequest, opts ...grpc.CallOption) (CommitService_ReadDirClient, error)
+	// パスまたはinode番号が指すファイルの属性を取得する。ディレクトリのエントリは含まない。
+	//
+	// Error:
+	// - InvalidArgument: If "id" parameter is null or path is not absolute.
+	// - NotFound: If commit, path or inode is not found.
+	// - FailedPrecondition: If a component of the path is not a directory.
+	// - Internal
+	StatPath(ctx context.Context, in *StatPathRequest, opts ...grpc.CallOption) (*StatPathResponse, error)
 	// コミットを作成する。
+	// 親コミットがlatest (またはbranchの先頭) でなければ、自動的にマージする。noMergeを指定した場合はマージせずに失敗する。
 	//
 	// Error:
 	// - InvalidArgument: If trying cross-volume commit or parent id combination
 	//                    is invalid.
+	// - Aborted: If noMerge is specified and the latest commit has been updated.
+	//            If merge is failed by conflicts, details contain CommitConflicts.
 	// - Internal
 	Commit(ctx context.Context, in *CommitRequest, opts ...grpc.CallOption) (*CommitResponse, error)
+	// volumeごとのコミットキューの状態を取得する。
+	// 同じvolumeへのコミットは到着順に1つずつ処理される。idを指定しない場合は、全てのvolumeの状態を返す。
+	//
+	// Error:
+	// - Internal
+	CommitQueueStatus(ctx context.Context, in *CommitQueueStatusRequest, opts ...grpc.CallOption) (*CommitQueueStatusResponse, error)
+	// 他のクラスタからエクスポートされたコミットを、同じIDで保存する。
+	// マージは行わない。親コミットは事前にインポートしておく必要がある。
+	//
+	// Error:
+	// - AlreadyExists: If specified commit is already exists.
+	// - InvalidArgument: If trying cross-volume commit or parent id combination
+	//                    is invalid.
+	// - Internal
+	ImportCommit(ctx context.Context, in *ImportCommitRequest, opts ...grpc.CallOption) (*ImportCommitResponse, error)
+	// volumeの最新コミットの変更を監視する。branchを指定した場合は、branchの指すコミットの変更を監視する。
+	// 最新コミットが進むたびに、新しい最新コミットのIDを送信する。受信が遅れた場合は、途中のコミットが省略されて最新のコミットのみが送信される。
+	// sinceを指定した場合は、sinceより後に作成されたコミットを古い順に送信してから監視を始める。再接続時は最後に受信したコミットを
+	// 指定することで、取りこぼしなく監視を再開できる。ただし、UpdateRefなどで最新コミットがsinceの子孫以外に変更された場合や、
+	// sinceより後のコミットが多すぎる場合は、現在の最新コミットのみを送信する。sinceを指定しない場合は、現在の最新コミットを最初に送信する。
+	// volumeまたはbranchが削除された場合は、NotFoundでstreamを終了する。
+	//
+	// Error:
+	// - NotFound: If volume or branch is not found or deleted.
+	// - InvalidArgument: If since is not a commit of the volume.
+	// - Internal
+	WatchCommits(ctx context.Context, in *WatchCommitsRequest, opts ...grpc.CallOption) (CommitService_WatchCommitsClient, error)
+	// refを作成する。refの指すコミットにref名を指定した場合は、解決したコミットを指す。
+	//
+	// Error:
+	// - AlreadyExists: If specified ref is already exists.
+	// - NotFound: If volume or commit is not found.
+	// - InvalidArgument: If ref name is invalid.
+	// - Internal
+	CreateRef(ctx context.Context, in *CreateRefRequest, opts ...grpc.CallOption) (*CreateRefResponse, error)
+	// refを取得する。
+	//
+	// Error:
+	// - NotFound: If specified ref is not found.
+	// - InvalidArgument
+	// - Internal
+	GetRef(ctx context.Context, in *GetRefRequest, opts ...grpc.CallOption) (*GetRefResponse, error)
+	// 指定したvolumeのrefを名前順に列挙する。
+	//
+	// Error:
+	// - InvalidArgument
+	// - Internal
+	ListRefs(ctx context.Context, in *ListRefsRequest, opts ...grpc.CallOption) (CommitService_ListRefsClient, error)
+	// branchが指すコミットを変更する。tagは変更できない。
+	//
+	// Error:
+	// - NotFound: If ref or commit is not found.
+	// - FailedPrecondition: If specified ref is a tag.
+	// - InvalidArgument
+	// - Internal
+	MoveRef(ctx context.Context, in *MoveRefRequest, opts ...grpc.CallOption) (*MoveRefResponse, error)
+	// volumeの最新コミット (latest) またはbranchが指すコミットを、expectedからnewに変更する。
+	// 現在のコミットがexpectedと異なる場合は変更せずにAbortedを返す。クライアントは最新のコミットを取得してから、リトライやrebaseを行う。
+	// branchを指定しない場合は、latestを変更する。
+	//
+	// Error:
+	// - Aborted: If the head does not point to expected.
+	// - NotFound: If volume, branch or commit is not found.
+	// - FailedPrecondition: If specified ref is a tag.
+	// - InvalidArgument
+	// - Internal
+	UpdateRef(ctx context.Context, in *UpdateRefRequest, opts ...grpc.CallOption) (*UpdateRefResponse, error)
+	// refを削除する。refが指していたコミットは削除しない。
+	//
+	// Error:
+	// - NotFound: If specified ref is not found.
+	// - InvalidArgument
+	// - Internal
+	DeleteRef(ctx context.Context, in *DeleteRefRequest, opts ...grpc.CallOption) (*DeleteRefResponse, error)
 }
 
 type commitServiceClient struct {
@@ -1164,6 +4112,88 @@ func (c *commitServiceClient) GetCommit(ctx context.Context, in *GetCommitReques
 	return out, nil
 }
 
+func (c *commitServiceClient) DiffCommits(ctx context.Context, in *DiffCommitsRequest, opts ...grpc.CallOption) (CommitService_DiffCommitsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CommitService_serviceDesc.Streams[1], "/elton.v2.CommitService/DiffCommits", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &commitServiceDiffCommitsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CommitService_DiffCommitsClient interface {
+	Recv() (*DiffCommitsResponse, error)
+	grpc.ClientStream
+}
+
+type commitServiceDiffCommitsClient struct {
+	grpc.ClientStream
+}
+
+func (x *commitServiceDiffCommitsClient) Recv() (*DiffCommitsResponse, error) {
+	m := new(DiffCommitsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *commitServiceClient) LookupPath(ctx context.Context, in *LookupPathRequest, opts ...grpc.CallOption) (*LookupPathResponse, error) {
+	out := new(LookupPathResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.CommitService/LookupPath", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commitServiceClient) ReadDir(ctx context.Context, in *ReadDirRequest, opts ...grpc.CallOption) (CommitService_ReadDirClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CommitService_serviceDesc.Streams[2], "/elton.v2.CommitService/ReadDir", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &commitServiceReadDirClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CommitService_ReadDirClient interface {
+	Recv() (*ReadDirResponse, error)
+	grpc.ClientStream
+}
+
+type commitServiceReadDirClient struct {
+	grpc.ClientStream
+}
+
+func (x *commitServiceReadDirClient) Recv() (*ReadDirResponse, error) {
+	m := new(ReadDirResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *commitServiceClient) StatPath(ctx context.Context, in *StatPathRequest, opts ...grpc.CallOption) (*StatPathResponse, error) {
+	out := new(StatPathResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.CommitService/StatPath", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *commitServiceClient) Commit(ctx context.Context, in *CommitRequest, opts ...grpc.CallOption) (*CommitResponse, error) {
 	out := new(CommitResponse)
 	err := c.cc.Invoke(ctx, "/elton.v2.CommitService/Commit", in, out, opts...)
@@ -1173,32 +4203,276 @@ func (c *commitServiceClient) Commit(ctx context.Context, in *CommitRequest, opt
 	return out, nil
 }
 
+func (c *commitServiceClient) CommitQueueStatus(ctx context.Context, in *CommitQueueStatusRequest, opts ...grpc.CallOption) (*CommitQueueStatusResponse, error) {
+	out := new(CommitQueueStatusResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.CommitService/CommitQueueStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commitServiceClient) ImportCommit(ctx context.Context, in *ImportCommitRequest, opts ...grpc.CallOption) (*ImportCommitResponse, error) {
+	out := new(ImportCommitResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.CommitService/ImportCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commitServiceClient) WatchCommits(ctx context.Context, in *WatchCommitsRequest, opts ...grpc.CallOption) (CommitService_WatchCommitsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CommitService_serviceDesc.Streams[3], "/elton.v2.CommitService/WatchCommits", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &commitServiceWatchCommitsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CommitService_WatchCommitsClient interface {
+	Recv() (*WatchCommitsResponse, error)
+	grpc.ClientStream
+}
+
+type commitServiceWatchCommitsClient struct {
+	grpc.ClientStream
+}
+
+func (x *commitServiceWatchCommitsClient) Recv() (*WatchCommitsResponse, error) {
+	m := new(WatchCommitsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *commitServiceClient) CreateRef(ctx context.Context, in *CreateRefRequest, opts ...grpc.CallOption) (*CreateRefResponse, error) {
+	out := new(CreateRefResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.CommitService/CreateRef", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commitServiceClient) GetRef(ctx context.Context, in *GetRefRequest, opts ...grpc.CallOption) (*GetRefResponse, error) {
+	out := new(GetRefResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.CommitService/GetRef", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commitServiceClient) ListRefs(ctx context.Context, in *ListRefsRequest, opts ...grpc.CallOption) (CommitService_ListRefsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CommitService_serviceDesc.Streams[4], "/elton.v2.CommitService/ListRefs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &commitServiceListRefsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CommitService_ListRefsClient interface {
+	Recv() (*ListRefsResponse, error)
+	grpc.ClientStream
+}
+
+type commitServiceListRefsClient struct {
+	grpc.ClientStream
+}
+
+func (x *commitServiceListRefsClient) Recv() (*ListRefsResponse, error) {
+	m := new(ListRefsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *commitServiceClient) MoveRef(ctx context.Context, in *MoveRefRequest, opts ...grpc.CallOption) (*MoveRefResponse, error) {
+	out := new(MoveRefResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.CommitService/MoveRef", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commitServiceClient) UpdateRef(ctx context.Context, in *UpdateRefRequest, opts ...grpc.CallOption) (*UpdateRefResponse, error) {
+	out := new(UpdateRefResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.CommitService/UpdateRef", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commitServiceClient) DeleteRef(ctx context.Context, in *DeleteRefRequest, opts ...grpc.CallOption) (*DeleteRefResponse, error) {
+	out := new(DeleteRefResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.CommitService/DeleteRef", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommitServiceServer is the server API for CommitService service.
 type CommitServiceServer interface {
 	// 指定したvolume内の最新のコミットを取得する。
 	//
 	// Error:
-	// - NotFound: If volume has no commit.
+	// - NotFound: If volume has no commit.
+	// - Internal
+	GetLastCommit(context.Context, *GetLastCommitRequest) (*GetLastCommitResponse, error)
+	// コミットの履歴を取得する。
+	// 一回のレスポンスで返す個数指定と、ページネーションの設定が行える。
+	// 詳細な使い方は、引数とレスポンスのデータ型のコメントを参照。
+	//
+	// Error:
+	// - InvalidArgs: If "next" parameter is not valid.
+	// - FailedPrecondition: If the commit that "next" parameter points to was deleted.
+	// - Internal
+	ListCommits(*ListCommitsRequest, CommitService_ListCommitsServer) error
+	// 指定したIDのコミットを取得する。
+	//
+	// Error:
+	// - InvalidArgument: If "id" parameter is null.
+	// - NotFound: If volume or commit is not found.
+	// - Internal
+	GetCommit(context.Context, *GetCommitRequest) (*GetCommitResponse, error)
+	// 2つのコミットのツリーを比較し、パスごとの変更をパス順に返す。
+	// aからbへの変更を返す。コミットはref名でも指定できる。
+	// pathを指定した場合は、そのパス以下の変更のみを返す。
+	//
+	// Error:
+	// - InvalidArgument: If "a" or "b" parameter is null.
+	// - NotFound: If volume, ref or commit is not found.
+	// - Internal
+	DiffCommits(*DiffCommitsRequest, CommitService_DiffCommitsServer) error
+	// コミットのツリーでパスを解決し、inode番号を返す。
+	// ツリー全体を取得せずに、特定のファイルを参照するために使う。コミットはref名でも指定できる。
+	//
+	// Error:
+	// - InvalidArgument: If "id" parameter is null or path is not absolute.
+	// - NotFound: If commit or path is not found.
+	// - FailedPrecondition: If a component of the path is not a directory.
+	// - Internal
+	LookupPath(context.Context, *LookupPathRequest) (*LookupPathResponse, error)
+	// ディレクトリのエントリを名前順に列挙する。
+	// 一回のレスポンスで返す個数指定と、ページネーションの設定が行える。
+	//
+	// Error:
+	// - InvalidArgument: If "id" parameter is null or path is not absolute.
+	// - NotFound: If commit, path or inode is not found.
+	// - FailedPrecondition: If path is not a directory, or "next" parameter is not valid.
 	// - Internal
-	GetLastCommit(context.Context, *GetLastCommitRequest) (*GetLastCommitResponse, error)
-	// コミットの履歴を取得する。
-	// 一回のレスポンスで返す個数指定と、ページネーションの設定が行える。
-	// 詳細な使い方は、引数とレスポンスのデータ型のコメントを参照。
-	ListCommits(*ListCommitsRequest, CommitService_ListCommitsServer) error
-	// 指定したIDのコミットを取得する。
+	ReadDir(*ReadDirRequest, CommitService_ReadDirServer) error
+	// パスまたはinode番号が指すファイルの属性を取得する。ディレクトリのエントリは含まない。
 	//
 	// Error:
-	// - InvalidArgument: If "id" parameter is null.
-	// - NotFound: If volume or commit is not found.
+	// - InvalidArgument: If "id" parameter is null or path is not absolute.
+	// - NotFound: If commit, path or inode is not found.
+	// - FailedPrecondition: If a component of the path is not a directory.
 	// - Internal
-	GetCommit(context.Context, *GetCommitRequest) (*GetCommitResponse, error)
+	StatPath(context.Context, *StatPathRequest) (*StatPathResponse, error)
 	// コミットを作成する。
+	// 親コミットがlatest (またはbranchの先頭) でなければ、自動的にマージする。noMergeを指定した場合はマージせずに失敗する。
 	//
 	// Error:
 	// - InvalidArgument: If trying cross-volume commit or parent id combination
 	//                    is invalid.
+	// - Aborted: If noMerge is specified and the latest commit has been updated.
+	//            If merge is failed by conflicts, details contain CommitConflicts.
 	// - Internal
 	Commit(context.Context, *CommitRequest) (*CommitResponse, error)
+	// volumeごとのコミットキューの状態を取得する。
+	// 同じvolumeへのコミットは到着順に1つずつ処理される。idを指定しない場合は、全てのvolumeの状態を返す。
+	//
+	// Error:
+	// - Internal
+	CommitQueueStatus(context.Context, *CommitQueueStatusRequest) (*CommitQueueStatusResponse, error)
+	// 他のクラスタからエクスポートされたコミットを、同じIDで保存する。
+	// マージは行わない。親コミットは事前にインポートしておく必要がある。
+	//
+	// Error:
+	// - AlreadyExists: If specified commit is already exists.
+	// - InvalidArgument: If trying cross-volume commit or parent id combination
+	//                    is invalid.
+	// - Internal
+	ImportCommit(context.Context, *ImportCommitRequest) (*ImportCommitResponse, error)
+	// volumeの最新コミットの変更を監視する。branchを指定した場合は、branchの指すコミットの変更を監視する。
+	// 最新コミットが進むたびに、新しい最新コミットのIDを送信する。受信が遅れた場合は、途中のコミットが省略されて最新のコミットのみが送信される。
+	// sinceを指定した場合は、sinceより後に作成されたコミットを古い順に送信してから監視を始める。再接続時は最後に受信したコミットを
+	// 指定することで、取りこぼしなく監視を再開できる。ただし、UpdateRefなどで最新コミットがsinceの子孫以外に変更された場合や、
+	// sinceより後のコミットが多すぎる場合は、現在の最新コミットのみを送信する。sinceを指定しない場合は、現在の最新コミットを最初に送信する。
+	// volumeまたはbranchが削除された場合は、NotFoundでstreamを終了する。
+	//
+	// Error:
+	// - NotFound: If volume or branch is not found or deleted.
+	// - InvalidArgument: If since is not a commit of the volume.
+	// - Internal
+	WatchCommits(*WatchCommitsRequest, CommitService_WatchCommitsServer) error
+	// refを作成する。refの指すコミットにref名を指定した場合は、解決したコミットを指す。
+	//
+	// Error:
+	// - AlreadyExists: If specified ref is already exists.
+	// - NotFound: If volume or commit is not found.
+	// - InvalidArgument: If ref name is invalid.
+	// - Internal
+	CreateRef(context.Context, *CreateRefRequest) (*CreateRefResponse, error)
+	// refを取得する。
+	//
+	// Error:
+	// - NotFound: If specified ref is not found.
+	// - InvalidArgument
+	// - Internal
+	GetRef(context.Context, *GetRefRequest) (*GetRefResponse, error)
+	// 指定したvolumeのrefを名前順に列挙する。
+	//
+	// Error:
+	// - InvalidArgument
+	// - Internal
+	ListRefs(*ListRefsRequest, CommitService_ListRefsServer) error
+	// branchが指すコミットを変更する。tagは変更できない。
+	//
+	// Error:
+	// - NotFound: If ref or commit is not found.
+	// - FailedPrecondition: If specified ref is a tag.
+	// - InvalidArgument
+	// - Internal
+	MoveRef(context.Context, *MoveRefRequest) (*MoveRefResponse, error)
+	// volumeの最新コミット (latest) またはbranchが指すコミットを、expectedからnewに変更する。
+	// 現在のコミットがexpectedと異なる場合は変更せずにAbortedを返す。クライアントは最新のコミットを取得してから、リトライやrebaseを行う。
+	// branchを指定しない場合は、latestを変更する。
+	//
+	// Error:
+	// - Aborted: If the head does not point to expected.
+	// - NotFound: If volume, branch or commit is not found.
+	// - FailedPrecondition: If specified ref is a tag.
+	// - InvalidArgument
+	// - Internal
+	UpdateRef(context.Context, *UpdateRefRequest) (*UpdateRefResponse, error)
+	// refを削除する。refが指していたコミットは削除しない。
+	//
+	// Error:
+	// - NotFound: If specified ref is not found.
+	// - InvalidArgument
+	// - Internal
+	DeleteRef(context.Context, *DeleteRefRequest) (*DeleteRefResponse, error)
 }
 
 // UnimplementedCommitServiceServer can be embedded to have forward compatible implementations.
@@ -1214,9 +4488,48 @@ func (*UnimplementedCommitServiceServer) ListCommits(req *ListCommitsRequest, sr
 func (*UnimplementedCommitServiceServer) GetCommit(ctx context.Context, req *GetCommitRequest) (*GetCommitResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCommit not implemented")
 }
+func (*UnimplementedCommitServiceServer) DiffCommits(req *DiffCommitsRequest, srv CommitService_DiffCommitsServer) error {
+	return status.Errorf(codes.Unimplemented, "method DiffCommits not implemented")
+}
+func (*UnimplementedCommitServiceServer) LookupPath(ctx context.Context, req *LookupPathRequest) (*LookupPathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupPath not implemented")
+}
+func (*UnimplementedCommitServiceServer) ReadDir(req *ReadDirRequest, srv CommitService_ReadDirServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadDir not implemented")
+}
+func (*UnimplementedCommitServiceServer) StatPath(ctx context.Context, req *StatPathRequest) (*StatPathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatPath not implemented")
+}
 func (*UnimplementedCommitServiceServer) Commit(ctx context.Context, req *CommitRequest) (*CommitResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Commit not implemented")
 }
+func (*UnimplementedCommitServiceServer) CommitQueueStatus(ctx context.Context, req *CommitQueueStatusRequest) (*CommitQueueStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitQueueStatus not implemented")
+}
+func (*UnimplementedCommitServiceServer) ImportCommit(ctx context.Context, req *ImportCommitRequest) (*ImportCommitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportCommit not implemented")
+}
+func (*UnimplementedCommitServiceServer) WatchCommits(req *WatchCommitsRequest, srv CommitService_WatchCommitsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCommits not implemented")
+}
+func (*UnimplementedCommitServiceServer) CreateRef(ctx context.Context, req *CreateRefRequest) (*CreateRefResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRef not implemented")
+}
+func (*UnimplementedCommitServiceServer) GetRef(ctx context.Context, req *GetRefRequest) (*GetRefResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRef not implemented")
+}
+func (*UnimplementedCommitServiceServer) ListRefs(req *ListRefsRequest, srv CommitService_ListRefsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListRefs not implemented")
+}
+func (*UnimplementedCommitServiceServer) MoveRef(ctx context.Context, req *MoveRefRequest) (*MoveRefResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveRef not implemented")
+}
+func (*UnimplementedCommitServiceServer) UpdateRef(ctx context.Context, req *UpdateRefRequest) (*UpdateRefResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRef not implemented")
+}
+func (*UnimplementedCommitServiceServer) DeleteRef(ctx context.Context, req *DeleteRefRequest) (*DeleteRefResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRef not implemented")
+}
 
 func RegisterCommitServiceServer(s *grpc.Server, srv CommitServiceServer) {
 	s.RegisterService(&_CommitService_serviceDesc, srv)
@@ -1279,6 +4592,84 @@ func _CommitService_GetCommit_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommitService_DiffCommits_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DiffCommitsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommitServiceServer).DiffCommits(m, &commitServiceDiffCommitsServer{stream})
+}
+
+type CommitService_DiffCommitsServer interface {
+	Send(*DiffCommitsResponse) error
+	grpc.ServerStream
+}
+
+type commitServiceDiffCommitsServer struct {
+	grpc.ServerStream
+}
+
+func (x *commitServiceDiffCommitsServer) Send(m *DiffCommitsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CommitService_LookupPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupPathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommitServiceServer).LookupPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.CommitService/LookupPath",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommitServiceServer).LookupPath(ctx, req.(*LookupPathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommitService_ReadDir_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadDirRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommitServiceServer).ReadDir(m, &commitServiceReadDirServer{stream})
+}
+
+type CommitService_ReadDirServer interface {
+	Send(*ReadDirResponse) error
+	grpc.ServerStream
+}
+
+type commitServiceReadDirServer struct {
+	grpc.ServerStream
+}
+
+func (x *commitServiceReadDirServer) Send(m *ReadDirResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CommitService_StatPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatPathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommitServiceServer).StatPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.CommitService/StatPath",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommitServiceServer).StatPath(ctx, req.(*StatPathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CommitService_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CommitRequest)
 	if err := dec(in); err != nil {
@@ -1297,6 +4688,174 @@ func _CommitService_Commit_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommitService_CommitQueueStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitQueueStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommitServiceServer).CommitQueueStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.CommitService/CommitQueueStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommitServiceServer).CommitQueueStatus(ctx, req.(*CommitQueueStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommitService_ImportCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommitServiceServer).ImportCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.CommitService/ImportCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommitServiceServer).ImportCommit(ctx, req.(*ImportCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommitService_WatchCommits_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCommitsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommitServiceServer).WatchCommits(m, &commitServiceWatchCommitsServer{stream})
+}
+
+type CommitService_WatchCommitsServer interface {
+	Send(*WatchCommitsResponse) error
+	grpc.ServerStream
+}
+
+type commitServiceWatchCommitsServer struct {
+	grpc.ServerStream
+}
+
+func (x *commitServiceWatchCommitsServer) Send(m *WatchCommitsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CommitService_CreateRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommitServiceServer).CreateRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.CommitService/CreateRef",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommitServiceServer).CreateRef(ctx, req.(*CreateRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommitService_GetRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommitServiceServer).GetRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.CommitService/GetRef",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommitServiceServer).GetRef(ctx, req.(*GetRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommitService_ListRefs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRefsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommitServiceServer).ListRefs(m, &commitServiceListRefsServer{stream})
+}
+
+type CommitService_ListRefsServer interface {
+	Send(*ListRefsResponse) error
+	grpc.ServerStream
+}
+
+type commitServiceListRefsServer struct {
+	grpc.ServerStream
+}
+
+func (x *commitServiceListRefsServer) Send(m *ListRefsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CommitService_MoveRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommitServiceServer).MoveRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.CommitService/MoveRef",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommitServiceServer).MoveRef(ctx, req.(*MoveRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommitService_UpdateRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommitServiceServer).UpdateRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.CommitService/UpdateRef",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommitServiceServer).UpdateRef(ctx, req.(*UpdateRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommitService_DeleteRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommitServiceServer).DeleteRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.CommitService/DeleteRef",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommitServiceServer).DeleteRef(ctx, req.(*DeleteRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _CommitService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "elton.v2.CommitService",
 	HandlerType: (*CommitServiceServer)(nil),
@@ -1309,10 +4868,46 @@ var _CommitService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetCommit",
 			Handler:    _CommitService_GetCommit_Handler,
 		},
+		{
+			MethodName: "LookupPath",
+			Handler:    _CommitService_LookupPath_Handler,
+		},
+		{
+			MethodName: "StatPath",
+			Handler:    _CommitService_StatPath_Handler,
+		},
 		{
 			MethodName: "Commit",
 			Handler:    _CommitService_Commit_Handler,
 		},
+		{
+			MethodName: "CommitQueueStatus",
+			Handler:    _CommitService_CommitQueueStatus_Handler,
+		},
+		{
+			MethodName: "ImportCommit",
+			Handler:    _CommitService_ImportCommit_Handler,
+		},
+		{
+			MethodName: "CreateRef",
+			Handler:    _CommitService_CreateRef_Handler,
+		},
+		{
+			MethodName: "GetRef",
+			Handler:    _CommitService_GetRef_Handler,
+		},
+		{
+			MethodName: "MoveRef",
+			Handler:    _CommitService_MoveRef_Handler,
+		},
+		{
+			MethodName: "UpdateRef",
+			Handler:    _CommitService_UpdateRef_Handler,
+		},
+		{
+			MethodName: "DeleteRef",
+			Handler:    _CommitService_DeleteRef_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1320,6 +4915,26 @@ var _CommitService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _CommitService_ListCommits_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DiffCommits",
+			Handler:       _CommitService_DiffCommits_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadDir",
+			Handler:       _CommitService_ReadDir_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchCommits",
+			Handler:       _CommitService_WatchCommits_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListRefs",
+			Handler:       _CommitService_ListRefs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "fs.proto",
 }
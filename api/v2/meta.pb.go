@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -113,12 +114,15 @@ func (m *GetMetaResponse) GetBody() *Property {
 }
 
 type SetMetaRequest struct {
-	Key                  *PropertyID `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Body                 *Property   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
-	MustCreate           bool        `protobuf:"varint,4,opt,name=mustCreate,proto3" json:"mustCreate,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Key        *PropertyID `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Body       *Property   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	MustCreate bool        `protobuf:"varint,4,opt,name=mustCreate,proto3" json:"mustCreate,omitempty"`
+	// Time to live.  If it is specified, body.expireAt is overwritten by the
+	// controller.
+	Ttl                  *duration.Duration `protobuf:"bytes,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *SetMetaRequest) Reset()         { *m = SetMetaRequest{} }
@@ -167,6 +171,13 @@ func (m *SetMetaRequest) GetMustCreate() bool {
 	return false
 }
 
+func (m *SetMetaRequest) GetTtl() *duration.Duration {
+	if m != nil {
+		return m.Ttl
+	}
+	return nil
+}
+
 type SetMetaResponse struct {
 	// Requested key.
 	Key *PropertyID `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -224,34 +235,321 @@ func (m *SetMetaResponse) GetCreated() bool {
 	return false
 }
 
+type DeleteMetaRequest struct {
+	Key                  *PropertyID `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DeleteMetaRequest) Reset()         { *m = DeleteMetaRequest{} }
+func (m *DeleteMetaRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteMetaRequest) ProtoMessage()    {}
+func (*DeleteMetaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{4}
+}
+
+func (m *DeleteMetaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMetaRequest.Unmarshal(m, b)
+}
+func (m *DeleteMetaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMetaRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteMetaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMetaRequest.Merge(m, src)
+}
+func (m *DeleteMetaRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteMetaRequest.Size(m)
+}
+func (m *DeleteMetaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMetaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMetaRequest proto.InternalMessageInfo
+
+func (m *DeleteMetaRequest) GetKey() *PropertyID {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type DeleteMetaResponse struct {
+	// Requested key.
+	Key *PropertyID `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Deleted property value.
+	OldBody              *Property `protobuf:"bytes,2,opt,name=oldBody,proto3" json:"oldBody,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *DeleteMetaResponse) Reset()         { *m = DeleteMetaResponse{} }
+func (m *DeleteMetaResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteMetaResponse) ProtoMessage()    {}
+func (*DeleteMetaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{5}
+}
+
+func (m *DeleteMetaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMetaResponse.Unmarshal(m, b)
+}
+func (m *DeleteMetaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMetaResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteMetaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMetaResponse.Merge(m, src)
+}
+func (m *DeleteMetaResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteMetaResponse.Size(m)
+}
+func (m *DeleteMetaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMetaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMetaResponse proto.InternalMessageInfo
+
+func (m *DeleteMetaResponse) GetKey() *PropertyID {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *DeleteMetaResponse) GetOldBody() *Property {
+	if m != nil {
+		return m.OldBody
+	}
+	return nil
+}
+
+type ListMetaRequest struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListMetaRequest) Reset()         { *m = ListMetaRequest{} }
+func (m *ListMetaRequest) String() string { return proto.CompactTextString(m) }
+func (*ListMetaRequest) ProtoMessage()    {}
+func (*ListMetaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{6}
+}
+
+func (m *ListMetaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMetaRequest.Unmarshal(m, b)
+}
+func (m *ListMetaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMetaRequest.Marshal(b, m, deterministic)
+}
+func (m *ListMetaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMetaRequest.Merge(m, src)
+}
+func (m *ListMetaRequest) XXX_Size() int {
+	return xxx_messageInfo_ListMetaRequest.Size(m)
+}
+func (m *ListMetaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMetaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMetaRequest proto.InternalMessageInfo
+
+func (m *ListMetaRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+type ListMetaResponse struct {
+	Key                  *PropertyID `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Body                 *Property   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListMetaResponse) Reset()         { *m = ListMetaResponse{} }
+func (m *ListMetaResponse) String() string { return proto.CompactTextString(m) }
+func (*ListMetaResponse) ProtoMessage()    {}
+func (*ListMetaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{7}
+}
+
+func (m *ListMetaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMetaResponse.Unmarshal(m, b)
+}
+func (m *ListMetaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMetaResponse.Marshal(b, m, deterministic)
+}
+func (m *ListMetaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMetaResponse.Merge(m, src)
+}
+func (m *ListMetaResponse) XXX_Size() int {
+	return xxx_messageInfo_ListMetaResponse.Size(m)
+}
+func (m *ListMetaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMetaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMetaResponse proto.InternalMessageInfo
+
+func (m *ListMetaResponse) GetKey() *PropertyID {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ListMetaResponse) GetBody() *Property {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+type WatchMetaRequest struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchMetaRequest) Reset()         { *m = WatchMetaRequest{} }
+func (m *WatchMetaRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchMetaRequest) ProtoMessage()    {}
+func (*WatchMetaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{8}
+}
+
+func (m *WatchMetaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchMetaRequest.Unmarshal(m, b)
+}
+func (m *WatchMetaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchMetaRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchMetaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchMetaRequest.Merge(m, src)
+}
+func (m *WatchMetaRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchMetaRequest.Size(m)
+}
+func (m *WatchMetaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchMetaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchMetaRequest proto.InternalMessageInfo
+
+func (m *WatchMetaRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+type WatchMetaResponse struct {
+	Key *PropertyID `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// New property value.  If the property is deleted, it is the deleted value
+	// or null.
+	Body                 *Property `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	Deleted              bool      `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *WatchMetaResponse) Reset()         { *m = WatchMetaResponse{} }
+func (m *WatchMetaResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchMetaResponse) ProtoMessage()    {}
+func (*WatchMetaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{9}
+}
+
+func (m *WatchMetaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchMetaResponse.Unmarshal(m, b)
+}
+func (m *WatchMetaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchMetaResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchMetaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchMetaResponse.Merge(m, src)
+}
+func (m *WatchMetaResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchMetaResponse.Size(m)
+}
+func (m *WatchMetaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchMetaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchMetaResponse proto.InternalMessageInfo
+
+func (m *WatchMetaResponse) GetKey() *PropertyID {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *WatchMetaResponse) GetBody() *Property {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *WatchMetaResponse) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*GetMetaRequest)(nil), "elton.v2.GetMetaRequest")
 	proto.RegisterType((*GetMetaResponse)(nil), "elton.v2.GetMetaResponse")
 	proto.RegisterType((*SetMetaRequest)(nil), "elton.v2.SetMetaRequest")
 	proto.RegisterType((*SetMetaResponse)(nil), "elton.v2.SetMetaResponse")
+	proto.RegisterType((*DeleteMetaRequest)(nil), "elton.v2.DeleteMetaRequest")
+	proto.RegisterType((*DeleteMetaResponse)(nil), "elton.v2.DeleteMetaResponse")
+	proto.RegisterType((*ListMetaRequest)(nil), "elton.v2.ListMetaRequest")
+	proto.RegisterType((*ListMetaResponse)(nil), "elton.v2.ListMetaResponse")
+	proto.RegisterType((*WatchMetaRequest)(nil), "elton.v2.WatchMetaRequest")
+	proto.RegisterType((*WatchMetaResponse)(nil), "elton.v2.WatchMetaResponse")
 }
 
 func init() { proto.RegisterFile("meta.proto", fileDescriptor_3b5ea8fe65782bcc) }
 
 var fileDescriptor_3b5ea8fe65782bcc = []byte{
-	// 263 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xca, 0x4d, 0x2d, 0x49,
-	0xd4, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x48, 0xcd, 0x29, 0xc9, 0xcf, 0xd3, 0x2b, 0x33,
-	0x92, 0xe2, 0x2e, 0xa9, 0x2c, 0x48, 0x2d, 0x86, 0x08, 0x2b, 0x59, 0x70, 0xf1, 0xb9, 0xa7, 0x96,
-	0xf8, 0xa6, 0x96, 0x24, 0x06, 0xa5, 0x16, 0x96, 0xa6, 0x16, 0x97, 0x08, 0xa9, 0x71, 0x31, 0x67,
-	0xa7, 0x56, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x1b, 0x89, 0xe8, 0xc1, 0xb4, 0xe9, 0x05, 0x14,
-	0xe5, 0x17, 0xa4, 0x16, 0x95, 0x54, 0x7a, 0xba, 0x04, 0x81, 0x14, 0x28, 0x25, 0x72, 0xf1, 0xc3,
-	0x75, 0x16, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x12, 0xab, 0x55, 0x48, 0x8d, 0x8b, 0x25, 0x29, 0x3f,
-	0xa5, 0x52, 0x82, 0x09, 0xac, 0x50, 0x08, 0x53, 0x61, 0x10, 0x58, 0x5e, 0xa9, 0x8d, 0x91, 0x8b,
-	0x2f, 0x98, 0x2c, 0xd7, 0x11, 0x6b, 0x85, 0x90, 0x1c, 0x17, 0x57, 0x6e, 0x69, 0x71, 0x89, 0x73,
-	0x51, 0x6a, 0x62, 0x49, 0xaa, 0x04, 0x8b, 0x02, 0xa3, 0x06, 0x47, 0x10, 0x92, 0x88, 0x17, 0x0b,
-	0x07, 0xb3, 0x00, 0x8b, 0x52, 0x3b, 0x23, 0x17, 0x7f, 0x30, 0x99, 0x9e, 0xd5, 0xe1, 0x62, 0xcf,
-	0xcf, 0x49, 0x71, 0xc2, 0xef, 0x18, 0x98, 0x12, 0x21, 0x09, 0x2e, 0xf6, 0x64, 0xb0, 0xcd, 0x29,
-	0x50, 0xc7, 0xc0, 0xb8, 0x10, 0x97, 0x18, 0xf5, 0x32, 0x72, 0x71, 0x83, 0x9c, 0x11, 0x9c, 0x5a,
-	0x54, 0x96, 0x99, 0x9c, 0x2a, 0x64, 0xc7, 0xc5, 0x0e, 0x8d, 0x05, 0x21, 0x09, 0x84, 0xb9, 0xa8,
-	0x51, 0x2a, 0x25, 0x89, 0x45, 0x06, 0xea, 0x0b, 0x3b, 0x2e, 0xf6, 0x60, 0x4c, 0xfd, 0xc1, 0x38,
-	0xf5, 0xa3, 0x85, 0x42, 0x12, 0x1b, 0x38, 0x19, 0x19, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xdb,
-	0x76, 0xce, 0xe2, 0x6b, 0x02, 0x00, 0x00,
+	// 435 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x53, 0x4d, 0xab, 0xd3, 0x50,
+	0x10, 0x25, 0x2f, 0xf1, 0x25, 0x6f, 0x0a, 0xaf, 0x7d, 0x17, 0x91, 0xbc, 0x54, 0x4a, 0xc9, 0xa2,
+	0xd4, 0x0f, 0x52, 0xa9, 0x1b, 0x41, 0xe8, 0xc2, 0x16, 0x8b, 0xa2, 0x20, 0xc9, 0xc2, 0x75, 0xd2,
+	0x4c, 0x6b, 0x34, 0xed, 0x8d, 0xc9, 0x4d, 0x31, 0x0b, 0xd7, 0xfe, 0x20, 0x7f, 0x92, 0x7f, 0x44,
+	0x72, 0x73, 0xd3, 0xa4, 0x6d, 0x94, 0x52, 0xe8, 0x72, 0xee, 0x9c, 0x39, 0x73, 0xee, 0x9c, 0x19,
+	0x80, 0x35, 0x32, 0xd7, 0x8a, 0x62, 0xca, 0x28, 0xd1, 0x30, 0x64, 0x74, 0x63, 0x6d, 0xc7, 0x46,
+	0x6f, 0x45, 0xe9, 0x2a, 0xc4, 0x11, 0x7f, 0xf7, 0xd2, 0xe5, 0xc8, 0x4f, 0x63, 0x97, 0x05, 0x74,
+	0x53, 0x20, 0x8d, 0x16, 0xcb, 0x22, 0x4c, 0x8a, 0xc0, 0x7c, 0x05, 0xb7, 0x73, 0x64, 0x1f, 0x91,
+	0xb9, 0x36, 0x7e, 0x4f, 0x31, 0x61, 0x64, 0x00, 0xf2, 0x37, 0xcc, 0x74, 0xa9, 0x2f, 0x0d, 0x5b,
+	0xe3, 0x87, 0x56, 0x49, 0x6b, 0x7d, 0x8a, 0x69, 0x84, 0x31, 0xcb, 0xde, 0xcd, 0xec, 0x1c, 0x60,
+	0xba, 0xd0, 0xde, 0x55, 0x26, 0x11, 0xdd, 0x24, 0x78, 0x6a, 0x29, 0x19, 0x80, 0xe2, 0x51, 0x3f,
+	0xd3, 0xaf, 0x38, 0x90, 0x1c, 0x03, 0x6d, 0x9e, 0x37, 0x7f, 0x4b, 0x70, 0xeb, 0x9c, 0xa5, 0xee,
+	0xd4, 0x16, 0xa4, 0x07, 0xb0, 0x4e, 0x13, 0x36, 0x8d, 0xd1, 0x65, 0xa8, 0x2b, 0x7d, 0x69, 0xa8,
+	0xd9, 0xb5, 0x17, 0xf2, 0x0c, 0x64, 0xc6, 0x42, 0xfd, 0x01, 0xa7, 0xb9, 0xb7, 0x8a, 0xd1, 0x5a,
+	0xe5, 0x68, 0xad, 0x99, 0x18, 0xad, 0x9d, 0xa3, 0xde, 0x2b, 0x9a, 0xdc, 0x51, 0xcc, 0x5f, 0x12,
+	0xb4, 0x9d, 0x33, 0x27, 0xf3, 0x1c, 0x54, 0x1a, 0xfa, 0x6f, 0xfe, 0xaf, 0xbc, 0x84, 0x10, 0x1d,
+	0xd4, 0x05, 0x97, 0xe9, 0x0b, 0xe5, 0x65, 0x28, 0x94, 0xbc, 0x86, 0xbb, 0x19, 0x86, 0xc8, 0xf0,
+	0x1c, 0x7f, 0xbf, 0x02, 0xa9, 0x17, 0x5f, 0xf2, 0x23, 0xe6, 0x13, 0x68, 0x7f, 0x08, 0x92, 0x3d,
+	0xa3, 0x1f, 0xc1, 0x75, 0x14, 0xe3, 0x32, 0xf8, 0xc1, 0x7b, 0xdd, 0xd8, 0x22, 0x32, 0x3d, 0xe8,
+	0x54, 0xd0, 0x0b, 0xed, 0xdd, 0x53, 0xe8, 0x7c, 0x76, 0xd9, 0xe2, 0xcb, 0x29, 0x7a, 0x7e, 0xc2,
+	0x5d, 0x0d, 0x7b, 0x19, 0x41, 0xb9, 0xd1, 0x3e, 0xf7, 0xc2, 0xd7, 0xe5, 0xc2, 0x68, 0x11, 0x8e,
+	0xff, 0x5c, 0x41, 0x2b, 0x6f, 0xed, 0x60, 0xbc, 0x0d, 0x16, 0x48, 0x26, 0xa0, 0x8a, 0xab, 0x24,
+	0x7a, 0x45, 0xb7, 0x7f, 0xe2, 0xc6, 0x7d, 0x43, 0x46, 0x28, 0x9f, 0x80, 0xea, 0x1c, 0xd7, 0x3b,
+	0xff, 0xac, 0x3f, 0x5c, 0xf4, 0x39, 0x40, 0xb5, 0x35, 0xa4, 0x5b, 0x01, 0x8f, 0x16, 0xd1, 0x78,
+	0xdc, 0x9c, 0x14, 0x44, 0x53, 0xd0, 0x4a, 0x9f, 0x49, 0xad, 0xdf, 0xc1, 0x9a, 0x18, 0x46, 0x53,
+	0xaa, 0xa0, 0x78, 0x21, 0x91, 0xb7, 0x70, 0xb3, 0x33, 0x87, 0xd4, 0xa0, 0x87, 0xee, 0x1a, 0xdd,
+	0xc6, 0x5c, 0xc9, 0xe3, 0x5d, 0xf3, 0x83, 0x7f, 0xf9, 0x77, 0x00, 0x97, 0xcc, 0xcb, 0x7c, 0x71,
+	0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -277,6 +575,24 @@ type MetaServiceClient interface {
 	// - AlreadyExists: Failed to create the new property.
 	// - Unauthenticated: Failed to replacement the exists property.
 	SetMeta(ctx context.Context, in *SetMetaRequest, opts ...grpc.CallOption) (*SetMetaResponse, error)
+	// Delete a property.
+	//
+	// Error:
+	// - NotFound: If property not found.
+	// - Unauthenticated: Failed to delete the property that is not allowed
+	//                    replacement.
+	DeleteMeta(ctx context.Context, in *DeleteMetaRequest, opts ...grpc.CallOption) (*DeleteMetaResponse, error)
+	// List properties whose key starts with the prefix in ascending order of
+	// the key.  Expired properties are not listed.
+	ListMeta(ctx context.Context, in *ListMetaRequest, opts ...grpc.CallOption) (MetaService_ListMetaClient, error)
+	// Watch changes of properties whose key starts with the prefix.  An event is
+	// sent when a property is set, deleted or expired.  Expiration events may be
+	// delayed until the controller removes expired properties.
+	//
+	// Error:
+	// - ResourceExhausted: If the client is too slow to receive events.  Client
+	//                      should list properties and watch again.
+	WatchMeta(ctx context.Context, in *WatchMetaRequest, opts ...grpc.CallOption) (MetaService_WatchMetaClient, error)
 }
 
 type metaServiceClient struct {
@@ -305,6 +621,79 @@ func (c *metaServiceClient) SetMeta(ctx context.Context, in *SetMetaRequest, opt
 	return out, nil
 }
 
+func (c *metaServiceClient) DeleteMeta(ctx context.Context, in *DeleteMetaRequest, opts ...grpc.CallOption) (*DeleteMetaResponse, error) {
+	out := new(DeleteMetaResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.MetaService/DeleteMeta", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metaServiceClient) ListMeta(ctx context.Context, in *ListMetaRequest, opts ...grpc.CallOption) (MetaService_ListMetaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_MetaService_serviceDesc.Streams[0], "/elton.v2.MetaService/ListMeta", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &metaServiceListMetaClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MetaService_ListMetaClient interface {
+	Recv() (*ListMetaResponse, error)
+	grpc.ClientStream
+}
+
+type metaServiceListMetaClient struct {
+	grpc.ClientStream
+}
+
+func (x *metaServiceListMetaClient) Recv() (*ListMetaResponse, error) {
+	m := new(ListMetaResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *metaServiceClient) WatchMeta(ctx context.Context, in *WatchMetaRequest, opts ...grpc.CallOption) (MetaService_WatchMetaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_MetaService_serviceDesc.Streams[1], "/elton.v2.MetaService/WatchMeta", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &metaServiceWatchMetaClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MetaService_WatchMetaClient interface {
+	Recv() (*WatchMetaResponse, error)
+	grpc.ClientStream
+}
+
+type metaServiceWatchMetaClient struct {
+	grpc.ClientStream
+}
+
+func (x *metaServiceWatchMetaClient) Recv() (*WatchMetaResponse, error) {
+	m := new(WatchMetaResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // MetaServiceServer is the server API for MetaService service.
 type MetaServiceServer interface {
 	// Get a property value.
@@ -318,6 +707,24 @@ type MetaServiceServer interface {
 	// - AlreadyExists: Failed to create the new property.
 	// - Unauthenticated: Failed to replacement the exists property.
 	SetMeta(context.Context, *SetMetaRequest) (*SetMetaResponse, error)
+	// Delete a property.
+	//
+	// Error:
+	// - NotFound: If property not found.
+	// - Unauthenticated: Failed to delete the property that is not allowed
+	//                    replacement.
+	DeleteMeta(context.Context, *DeleteMetaRequest) (*DeleteMetaResponse, error)
+	// List properties whose key starts with the prefix in ascending order of
+	// the key.  Expired properties are not listed.
+	ListMeta(*ListMetaRequest, MetaService_ListMetaServer) error
+	// Watch changes of properties whose key starts with the prefix.  An event is
+	// sent when a property is set, deleted or expired.  Expiration events may be
+	// delayed until the controller removes expired properties.
+	//
+	// Error:
+	// - ResourceExhausted: If the client is too slow to receive events.  Client
+	//                      should list properties and watch again.
+	WatchMeta(*WatchMetaRequest, MetaService_WatchMetaServer) error
 }
 
 // UnimplementedMetaServiceServer can be embedded to have forward compatible implementations.
@@ -330,6 +737,15 @@ func (*UnimplementedMetaServiceServer) GetMeta(ctx context.Context, req *GetMeta
 func (*UnimplementedMetaServiceServer) SetMeta(ctx context.Context, req *SetMetaRequest) (*SetMetaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetMeta not implemented")
 }
+func (*UnimplementedMetaServiceServer) DeleteMeta(ctx context.Context, req *DeleteMetaRequest) (*DeleteMetaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMeta not implemented")
+}
+func (*UnimplementedMetaServiceServer) ListMeta(req *ListMetaRequest, srv MetaService_ListMetaServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListMeta not implemented")
+}
+func (*UnimplementedMetaServiceServer) WatchMeta(req *WatchMetaRequest, srv MetaService_WatchMetaServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMeta not implemented")
+}
 
 func RegisterMetaServiceServer(s *grpc.Server, srv MetaServiceServer) {
 	s.RegisterService(&_MetaService_serviceDesc, srv)
@@ -371,6 +787,66 @@ func _MetaService_SetMeta_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetaService_DeleteMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetaServiceServer).DeleteMeta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.MetaService/DeleteMeta",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetaServiceServer).DeleteMeta(ctx, req.(*DeleteMetaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetaService_ListMeta_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListMetaRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MetaServiceServer).ListMeta(m, &metaServiceListMetaServer{stream})
+}
+
+type MetaService_ListMetaServer interface {
+	Send(*ListMetaResponse) error
+	grpc.ServerStream
+}
+
+type metaServiceListMetaServer struct {
+	grpc.ServerStream
+}
+
+func (x *metaServiceListMetaServer) Send(m *ListMetaResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MetaService_WatchMeta_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMetaRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MetaServiceServer).WatchMeta(m, &metaServiceWatchMetaServer{stream})
+}
+
+type MetaService_WatchMetaServer interface {
+	Send(*WatchMetaResponse) error
+	grpc.ServerStream
+}
+
+type metaServiceWatchMetaServer struct {
+	grpc.ServerStream
+}
+
+func (x *metaServiceWatchMetaServer) Send(m *WatchMetaResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _MetaService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "elton.v2.MetaService",
 	HandlerType: (*MetaServiceServer)(nil),
@@ -383,7 +859,22 @@ var _MetaService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetMeta",
 			Handler:    _MetaService_SetMeta_Handler,
 		},
+		{
+			MethodName: "DeleteMeta",
+			Handler:    _MetaService_DeleteMeta_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListMeta",
+			Handler:       _MetaService_ListMeta_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchMeta",
+			Handler:       _MetaService_WatchMeta_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "meta.proto",
 }
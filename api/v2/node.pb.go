@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -103,7 +104,9 @@ func (m *RegisterNodeResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_RegisterNodeResponse proto.InternalMessageInfo
 
 type UnregisterNodeRequest struct {
-	Id                   *NodeID  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id *NodeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// trueの場合は、退避が完了していないストレージノードも削除する。ノード上のオブジェクトは失われる可能性がある。
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -141,6 +144,13 @@ func (m *UnregisterNodeRequest) GetId() *NodeID {
 	return nil
 }
 
+func (m *UnregisterNodeRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 type UnregisterNodeResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -173,7 +183,11 @@ func (m *UnregisterNodeResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_UnregisterNodeResponse proto.InternalMessageInfo
 
 type PingNodeRequest struct {
-	Id                   *NodeID  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id *NodeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Uptime in seconds.
+	Uptime uint64 `protobuf:"varint,2,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	// Current node information.  If it is empty, the node information is not updated.
+	Node                 *Node    `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -211,6 +225,20 @@ func (m *PingNodeRequest) GetId() *NodeID {
 	return nil
 }
 
+func (m *PingNodeRequest) GetUptime() uint64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *PingNodeRequest) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
 type PingNodeResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -274,11 +302,12 @@ func (m *ListNodesRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_ListNodesRequest proto.InternalMessageInfo
 
 type ListNodesResponse struct {
-	Id                   *NodeID  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Node                 *Node    `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id                   *NodeID      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Node                 *Node        `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Liveness             NodeLiveness `protobuf:"varint,3,opt,name=liveness,proto3,enum=elton.v2.NodeLiveness" json:"liveness,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *ListNodesResponse) Reset()         { *m = ListNodesResponse{} }
@@ -320,6 +349,619 @@ func (m *ListNodesResponse) GetNode() *Node {
 	return nil
 }
 
+func (m *ListNodesResponse) GetLiveness() NodeLiveness {
+	if m != nil {
+		return m.Liveness
+	}
+	return NodeLiveness_UnknownLiveness
+}
+
+type PlaceObjectRequest struct {
+	// Number of replicas.  If it is zero, one node is selected.
+	Replicas uint32 `protobuf:"varint,1,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	// Size of the object in bytes.  Nodes that do not have enough free space are not selected.
+	Size uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Nodes that should not be selected (e.g. nodes that already have the object).
+	Exclude              []*NodeID `protobuf:"bytes,3,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PlaceObjectRequest) Reset()         { *m = PlaceObjectRequest{} }
+func (m *PlaceObjectRequest) String() string { return proto.CompactTextString(m) }
+func (*PlaceObjectRequest) ProtoMessage()    {}
+func (*PlaceObjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{8}
+}
+
+func (m *PlaceObjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlaceObjectRequest.Unmarshal(m, b)
+}
+func (m *PlaceObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlaceObjectRequest.Marshal(b, m, deterministic)
+}
+func (m *PlaceObjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlaceObjectRequest.Merge(m, src)
+}
+func (m *PlaceObjectRequest) XXX_Size() int {
+	return xxx_messageInfo_PlaceObjectRequest.Size(m)
+}
+func (m *PlaceObjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlaceObjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlaceObjectRequest proto.InternalMessageInfo
+
+func (m *PlaceObjectRequest) GetReplicas() uint32 {
+	if m != nil {
+		return m.Replicas
+	}
+	return 0
+}
+
+func (m *PlaceObjectRequest) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *PlaceObjectRequest) GetExclude() []*NodeID {
+	if m != nil {
+		return m.Exclude
+	}
+	return nil
+}
+
+type PlaceObjectResponse struct {
+	// Selected nodes.  The first node is the most preferred.
+	Nodes                []*PlacedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PlaceObjectResponse) Reset()         { *m = PlaceObjectResponse{} }
+func (m *PlaceObjectResponse) String() string { return proto.CompactTextString(m) }
+func (*PlaceObjectResponse) ProtoMessage()    {}
+func (*PlaceObjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{9}
+}
+
+func (m *PlaceObjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlaceObjectResponse.Unmarshal(m, b)
+}
+func (m *PlaceObjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlaceObjectResponse.Marshal(b, m, deterministic)
+}
+func (m *PlaceObjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlaceObjectResponse.Merge(m, src)
+}
+func (m *PlaceObjectResponse) XXX_Size() int {
+	return xxx_messageInfo_PlaceObjectResponse.Size(m)
+}
+func (m *PlaceObjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlaceObjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlaceObjectResponse proto.InternalMessageInfo
+
+func (m *PlaceObjectResponse) GetNodes() []*PlacedNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type PlacedNode struct {
+	Id                   *NodeID  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Node                 *Node    `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlacedNode) Reset()         { *m = PlacedNode{} }
+func (m *PlacedNode) String() string { return proto.CompactTextString(m) }
+func (*PlacedNode) ProtoMessage()    {}
+func (*PlacedNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{10}
+}
+
+func (m *PlacedNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlacedNode.Unmarshal(m, b)
+}
+func (m *PlacedNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlacedNode.Marshal(b, m, deterministic)
+}
+func (m *PlacedNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlacedNode.Merge(m, src)
+}
+func (m *PlacedNode) XXX_Size() int {
+	return xxx_messageInfo_PlacedNode.Size(m)
+}
+func (m *PlacedNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlacedNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlacedNode proto.InternalMessageInfo
+
+func (m *PlacedNode) GetId() *NodeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *PlacedNode) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+type DrainNodeRequest struct {
+	Id                   *NodeID  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Unregister           bool     `protobuf:"varint,2,opt,name=unregister,proto3" json:"unregister,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainNodeRequest) Reset()         { *m = DrainNodeRequest{} }
+func (m *DrainNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*DrainNodeRequest) ProtoMessage()    {}
+func (*DrainNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{11}
+}
+
+func (m *DrainNodeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainNodeRequest.Unmarshal(m, b)
+}
+func (m *DrainNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainNodeRequest.Marshal(b, m, deterministic)
+}
+func (m *DrainNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainNodeRequest.Merge(m, src)
+}
+func (m *DrainNodeRequest) XXX_Size() int {
+	return xxx_messageInfo_DrainNodeRequest.Size(m)
+}
+func (m *DrainNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainNodeRequest proto.InternalMessageInfo
+
+func (m *DrainNodeRequest) GetId() *NodeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *DrainNodeRequest) GetUnregister() bool {
+	if m != nil {
+		return m.Unregister
+	}
+	return false
+}
+
+type DrainNodeResponse struct {
+	Status               *DrainStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *DrainNodeResponse) Reset()         { *m = DrainNodeResponse{} }
+func (m *DrainNodeResponse) String() string { return proto.CompactTextString(m) }
+func (*DrainNodeResponse) ProtoMessage()    {}
+func (*DrainNodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{12}
+}
+
+func (m *DrainNodeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainNodeResponse.Unmarshal(m, b)
+}
+func (m *DrainNodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainNodeResponse.Marshal(b, m, deterministic)
+}
+func (m *DrainNodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainNodeResponse.Merge(m, src)
+}
+func (m *DrainNodeResponse) XXX_Size() int {
+	return xxx_messageInfo_DrainNodeResponse.Size(m)
+}
+func (m *DrainNodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainNodeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainNodeResponse proto.InternalMessageInfo
+
+func (m *DrainNodeResponse) GetStatus() *DrainStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type LocateObjectRequest struct {
+	Key                  *ObjectKey `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *LocateObjectRequest) Reset()         { *m = LocateObjectRequest{} }
+func (m *LocateObjectRequest) String() string { return proto.CompactTextString(m) }
+func (*LocateObjectRequest) ProtoMessage()    {}
+func (*LocateObjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{13}
+}
+
+func (m *LocateObjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocateObjectRequest.Unmarshal(m, b)
+}
+func (m *LocateObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocateObjectRequest.Marshal(b, m, deterministic)
+}
+func (m *LocateObjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocateObjectRequest.Merge(m, src)
+}
+func (m *LocateObjectRequest) XXX_Size() int {
+	return xxx_messageInfo_LocateObjectRequest.Size(m)
+}
+func (m *LocateObjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocateObjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocateObjectRequest proto.InternalMessageInfo
+
+func (m *LocateObjectRequest) GetKey() *ObjectKey {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type LocateObjectResponse struct {
+	Nodes                []*PlacedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *LocateObjectResponse) Reset()         { *m = LocateObjectResponse{} }
+func (m *LocateObjectResponse) String() string { return proto.CompactTextString(m) }
+func (*LocateObjectResponse) ProtoMessage()    {}
+func (*LocateObjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{14}
+}
+
+func (m *LocateObjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocateObjectResponse.Unmarshal(m, b)
+}
+func (m *LocateObjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocateObjectResponse.Marshal(b, m, deterministic)
+}
+func (m *LocateObjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocateObjectResponse.Merge(m, src)
+}
+func (m *LocateObjectResponse) XXX_Size() int {
+	return xxx_messageInfo_LocateObjectResponse.Size(m)
+}
+func (m *LocateObjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocateObjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocateObjectResponse proto.InternalMessageInfo
+
+func (m *LocateObjectResponse) GetNodes() []*PlacedNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type RebalanceStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebalanceStatusRequest) Reset()         { *m = RebalanceStatusRequest{} }
+func (m *RebalanceStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*RebalanceStatusRequest) ProtoMessage()    {}
+func (*RebalanceStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{15}
+}
+
+func (m *RebalanceStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebalanceStatusRequest.Unmarshal(m, b)
+}
+func (m *RebalanceStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebalanceStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *RebalanceStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebalanceStatusRequest.Merge(m, src)
+}
+func (m *RebalanceStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_RebalanceStatusRequest.Size(m)
+}
+func (m *RebalanceStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebalanceStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebalanceStatusRequest proto.InternalMessageInfo
+
+type RebalanceStatusResponse struct {
+	Status               *RebalanceStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RebalanceStatusResponse) Reset()         { *m = RebalanceStatusResponse{} }
+func (m *RebalanceStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*RebalanceStatusResponse) ProtoMessage()    {}
+func (*RebalanceStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{16}
+}
+
+func (m *RebalanceStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebalanceStatusResponse.Unmarshal(m, b)
+}
+func (m *RebalanceStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebalanceStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *RebalanceStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebalanceStatusResponse.Merge(m, src)
+}
+func (m *RebalanceStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_RebalanceStatusResponse.Size(m)
+}
+func (m *RebalanceStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebalanceStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebalanceStatusResponse proto.InternalMessageInfo
+
+func (m *RebalanceStatusResponse) GetStatus() *RebalanceStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type PauseRebalanceRequest struct {
+	// Pause if true, resume if false.
+	Paused               bool     `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseRebalanceRequest) Reset()         { *m = PauseRebalanceRequest{} }
+func (m *PauseRebalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*PauseRebalanceRequest) ProtoMessage()    {}
+func (*PauseRebalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{17}
+}
+
+func (m *PauseRebalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseRebalanceRequest.Unmarshal(m, b)
+}
+func (m *PauseRebalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseRebalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *PauseRebalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseRebalanceRequest.Merge(m, src)
+}
+func (m *PauseRebalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_PauseRebalanceRequest.Size(m)
+}
+func (m *PauseRebalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseRebalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseRebalanceRequest proto.InternalMessageInfo
+
+func (m *PauseRebalanceRequest) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+type PauseRebalanceResponse struct {
+	Status               *RebalanceStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PauseRebalanceResponse) Reset()         { *m = PauseRebalanceResponse{} }
+func (m *PauseRebalanceResponse) String() string { return proto.CompactTextString(m) }
+func (*PauseRebalanceResponse) ProtoMessage()    {}
+func (*PauseRebalanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{18}
+}
+
+func (m *PauseRebalanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseRebalanceResponse.Unmarshal(m, b)
+}
+func (m *PauseRebalanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseRebalanceResponse.Marshal(b, m, deterministic)
+}
+func (m *PauseRebalanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseRebalanceResponse.Merge(m, src)
+}
+func (m *PauseRebalanceResponse) XXX_Size() int {
+	return xxx_messageInfo_PauseRebalanceResponse.Size(m)
+}
+func (m *PauseRebalanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseRebalanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseRebalanceResponse proto.InternalMessageInfo
+
+func (m *PauseRebalanceResponse) GetStatus() *RebalanceStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type RebalanceStatus struct {
+	Paused bool `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	// True if usage of all storage nodes is close to the target at the last run.
+	Balanced bool `protobuf:"varint,2,opt,name=balanced,proto3" json:"balanced,omitempty"`
+	// Total number of moved objects since the controller started.
+	MovedObjects uint64               `protobuf:"varint,3,opt,name=movedObjects,proto3" json:"movedObjects,omitempty"`
+	MovedBytes   uint64               `protobuf:"varint,4,opt,name=movedBytes,proto3" json:"movedBytes,omitempty"`
+	LastRunAt    *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastRunAt,proto3" json:"lastRunAt,omitempty"`
+	// Error of the last run.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// Target usage ratio (0.0-1.0).  It is the average usage of all storage nodes.
+	TargetUsage float64 `protobuf:"fixed64,7,opt,name=targetUsage,proto3" json:"targetUsage,omitempty"`
+	// Usage of storage nodes at the last run.
+	Nodes                []*NodeUsage `protobuf:"bytes,8,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RebalanceStatus) Reset()         { *m = RebalanceStatus{} }
+func (m *RebalanceStatus) String() string { return proto.CompactTextString(m) }
+func (*RebalanceStatus) ProtoMessage()    {}
+func (*RebalanceStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{19}
+}
+
+func (m *RebalanceStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebalanceStatus.Unmarshal(m, b)
+}
+func (m *RebalanceStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebalanceStatus.Marshal(b, m, deterministic)
+}
+func (m *RebalanceStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebalanceStatus.Merge(m, src)
+}
+func (m *RebalanceStatus) XXX_Size() int {
+	return xxx_messageInfo_RebalanceStatus.Size(m)
+}
+func (m *RebalanceStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebalanceStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebalanceStatus proto.InternalMessageInfo
+
+func (m *RebalanceStatus) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *RebalanceStatus) GetBalanced() bool {
+	if m != nil {
+		return m.Balanced
+	}
+	return false
+}
+
+func (m *RebalanceStatus) GetMovedObjects() uint64 {
+	if m != nil {
+		return m.MovedObjects
+	}
+	return 0
+}
+
+func (m *RebalanceStatus) GetMovedBytes() uint64 {
+	if m != nil {
+		return m.MovedBytes
+	}
+	return 0
+}
+
+func (m *RebalanceStatus) GetLastRunAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastRunAt
+	}
+	return nil
+}
+
+func (m *RebalanceStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *RebalanceStatus) GetTargetUsage() float64 {
+	if m != nil {
+		return m.TargetUsage
+	}
+	return 0
+}
+
+func (m *RebalanceStatus) GetNodes() []*NodeUsage {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type NodeUsage struct {
+	Id *NodeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Usage ratio (0.0-1.0).
+	Usage                float64  `protobuf:"fixed64,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeUsage) Reset()         { *m = NodeUsage{} }
+func (m *NodeUsage) String() string { return proto.CompactTextString(m) }
+func (*NodeUsage) ProtoMessage()    {}
+func (*NodeUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{20}
+}
+
+func (m *NodeUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeUsage.Unmarshal(m, b)
+}
+func (m *NodeUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeUsage.Marshal(b, m, deterministic)
+}
+func (m *NodeUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeUsage.Merge(m, src)
+}
+func (m *NodeUsage) XXX_Size() int {
+	return xxx_messageInfo_NodeUsage.Size(m)
+}
+func (m *NodeUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeUsage proto.InternalMessageInfo
+
+func (m *NodeUsage) GetId() *NodeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *NodeUsage) GetUsage() float64 {
+	if m != nil {
+		return m.Usage
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*RegisterNodeRequest)(nil), "elton.v2.RegisterNodeRequest")
 	proto.RegisterType((*RegisterNodeResponse)(nil), "elton.v2.RegisterNodeResponse")
@@ -329,30 +971,75 @@ func init() {
 	proto.RegisterType((*PingNodeResponse)(nil), "elton.v2.PingNodeResponse")
 	proto.RegisterType((*ListNodesRequest)(nil), "elton.v2.ListNodesRequest")
 	proto.RegisterType((*ListNodesResponse)(nil), "elton.v2.ListNodesResponse")
+	proto.RegisterType((*PlaceObjectRequest)(nil), "elton.v2.PlaceObjectRequest")
+	proto.RegisterType((*PlaceObjectResponse)(nil), "elton.v2.PlaceObjectResponse")
+	proto.RegisterType((*PlacedNode)(nil), "elton.v2.PlacedNode")
+	proto.RegisterType((*DrainNodeRequest)(nil), "elton.v2.DrainNodeRequest")
+	proto.RegisterType((*DrainNodeResponse)(nil), "elton.v2.DrainNodeResponse")
+	proto.RegisterType((*LocateObjectRequest)(nil), "elton.v2.LocateObjectRequest")
+	proto.RegisterType((*LocateObjectResponse)(nil), "elton.v2.LocateObjectResponse")
+	proto.RegisterType((*RebalanceStatusRequest)(nil), "elton.v2.RebalanceStatusRequest")
+	proto.RegisterType((*RebalanceStatusResponse)(nil), "elton.v2.RebalanceStatusResponse")
+	proto.RegisterType((*PauseRebalanceRequest)(nil), "elton.v2.PauseRebalanceRequest")
+	proto.RegisterType((*PauseRebalanceResponse)(nil), "elton.v2.PauseRebalanceResponse")
+	proto.RegisterType((*RebalanceStatus)(nil), "elton.v2.RebalanceStatus")
+	proto.RegisterType((*NodeUsage)(nil), "elton.v2.NodeUsage")
 }
 
 func init() { proto.RegisterFile("node.proto", fileDescriptor_0c843d59d2d938e7) }
 
 var fileDescriptor_0c843d59d2d938e7 = []byte{
-	// 281 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x92, 0x4f, 0x4b, 0xc4, 0x30,
-	0x10, 0xc5, 0x69, 0x59, 0x44, 0xa7, 0xb2, 0xd6, 0x51, 0x97, 0x1a, 0x51, 0x4b, 0x4e, 0x9e, 0x8a,
-	0x74, 0x4f, 0x1e, 0xbc, 0x89, 0x20, 0xa8, 0x48, 0x17, 0x0f, 0xe2, 0x4d, 0x3b, 0x2c, 0x01, 0x49,
-	0x6a, 0x13, 0x17, 0xfc, 0x66, 0x7e, 0x3c, 0x49, 0x1b, 0xed, 0x1f, 0xda, 0x83, 0xb2, 0xc7, 0xcc,
-	0x7b, 0xef, 0x97, 0xf0, 0x26, 0x00, 0x52, 0xe5, 0x94, 0x14, 0xa5, 0x32, 0x0a, 0x37, 0xe9, 0xcd,
-	0x28, 0x99, 0xac, 0x52, 0x16, 0x98, 0xcf, 0x82, 0x74, 0x3d, 0xe6, 0xcf, 0xb0, 0x97, 0xd1, 0x52,
-	0x68, 0x43, 0xe5, 0xbd, 0xca, 0x29, 0xa3, 0xf7, 0x0f, 0xd2, 0x06, 0x63, 0xf0, 0x45, 0x1e, 0x79,
-	0xb1, 0x77, 0x16, 0xa4, 0x61, 0xf2, 0x13, 0x4d, 0xac, 0xe5, 0xe6, 0x2a, 0xf3, 0x45, 0x8e, 0x1c,
-	0x26, 0x96, 0x1e, 0xf9, 0x95, 0x67, 0xda, 0xf5, 0x64, 0x95, 0xc6, 0x67, 0xb0, 0xdf, 0x85, 0xeb,
-	0x42, 0x49, 0x4d, 0xfc, 0x02, 0x0e, 0x1e, 0x65, 0xf9, 0x9f, 0x6b, 0x79, 0x04, 0xb3, 0x7e, 0xd4,
-	0x41, 0xe7, 0xb0, 0xf3, 0x20, 0xe4, 0xf2, 0x6f, 0x38, 0x84, 0xb0, 0x09, 0x39, 0x10, 0x42, 0x78,
-	0x2b, 0xb4, 0xb1, 0x33, 0xed, 0x48, 0xfc, 0x09, 0x76, 0x5b, 0xb3, 0xda, 0xb8, 0x9e, 0x92, 0xd2,
-	0x2f, 0x1f, 0x02, 0x7b, 0x5c, 0x50, 0xb9, 0x12, 0xaf, 0x84, 0x77, 0xb0, 0xdd, 0x2e, 0x0d, 0x8f,
-	0x9b, 0xd4, 0xc0, 0xa6, 0xd8, 0xc9, 0x98, 0xec, 0x1e, 0xb9, 0x80, 0x69, 0xb7, 0x30, 0x3c, 0x6d,
-	0x12, 0x83, 0x5b, 0x60, 0xf1, 0xb8, 0xc1, 0x41, 0x2f, 0x61, 0x62, 0x6b, 0xc3, 0xc3, 0xc6, 0xd9,
-	0xeb, 0x9e, 0xb1, 0x21, 0xc9, 0xc5, 0xaf, 0x61, 0xeb, 0xb7, 0x4d, 0x6c, 0x19, 0xfb, 0xb5, 0xb3,
-	0xa3, 0x41, 0xad, 0xa6, 0x9c, 0x7b, 0x2f, 0x1b, 0xd5, 0x1f, 0x9e, 0x7f, 0x07, 0x00, 0x00, 0xff,
-	0xff, 0xbd, 0xe5, 0x9d, 0x36, 0xe8, 0x02, 0x00, 0x00,
+	// 789 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdb, 0x4e, 0xdb, 0x4a,
+	0x14, 0x55, 0xae, 0x24, 0x3b, 0x1c, 0x08, 0x93, 0x90, 0xe3, 0x63, 0x0e, 0xe0, 0x63, 0xe9, 0x48,
+	0x14, 0xa9, 0xa1, 0x4d, 0x5f, 0xfa, 0xd0, 0x3e, 0x40, 0x51, 0xa5, 0x96, 0x50, 0xd0, 0x00, 0x7d,
+	0xe9, 0x93, 0xe3, 0x0c, 0x91, 0x5b, 0x63, 0xbb, 0x9e, 0x71, 0xd4, 0xf4, 0x1f, 0xfa, 0x1b, 0xfd,
+	0x94, 0x7e, 0x57, 0x35, 0x17, 0x5f, 0x89, 0x51, 0x41, 0xbc, 0x79, 0xef, 0xbd, 0xbc, 0xd6, 0xec,
+	0x99, 0x3d, 0x6b, 0x00, 0x3c, 0x7f, 0x4a, 0x86, 0x41, 0xe8, 0x33, 0x1f, 0xb5, 0x88, 0xcb, 0x7c,
+	0x6f, 0x38, 0x1f, 0xe9, 0xbb, 0x33, 0xdf, 0x9f, 0xb9, 0xe4, 0x40, 0xe4, 0x27, 0xd1, 0xf5, 0x01,
+	0x73, 0x6e, 0x08, 0x65, 0xd6, 0x4d, 0x20, 0xa1, 0x7a, 0x87, 0x2d, 0x02, 0x42, 0x65, 0x60, 0x7e,
+	0x82, 0x1e, 0x26, 0x33, 0x87, 0x32, 0x12, 0x7e, 0xf0, 0xa7, 0x04, 0x93, 0xaf, 0x11, 0xa1, 0x0c,
+	0x19, 0x50, 0x75, 0xa6, 0x5a, 0xc5, 0xa8, 0xec, 0x75, 0x46, 0xdd, 0x61, 0xcc, 0x3d, 0xe4, 0x90,
+	0x77, 0xc7, 0xb8, 0xea, 0x4c, 0x91, 0x09, 0x75, 0x2e, 0xaf, 0x55, 0x05, 0x66, 0x2d, 0x8f, 0xc1,
+	0xa2, 0x66, 0x0e, 0xa0, 0x9f, 0x27, 0xa7, 0x81, 0xef, 0x51, 0x62, 0x9e, 0xc1, 0xe6, 0x95, 0x17,
+	0x3e, 0x48, 0xb6, 0x0f, 0x8d, 0x6b, 0x3f, 0xb4, 0xa5, 0x6e, 0x0b, 0xcb, 0xc0, 0xd4, 0x60, 0x50,
+	0x24, 0x54, 0x52, 0x3e, 0xac, 0x9f, 0x3b, 0xde, 0xec, 0x7e, 0x22, 0x03, 0x68, 0x46, 0x01, 0xdf,
+	0x36, 0xa1, 0x52, 0xc7, 0x2a, 0x4a, 0x7a, 0xae, 0xdd, 0xd1, 0x33, 0x82, 0x6e, 0x2a, 0xa8, 0x16,
+	0x81, 0xa0, 0x3b, 0x76, 0x28, 0xe3, 0x39, 0xaa, 0x56, 0x61, 0xfe, 0xa8, 0xc0, 0x46, 0x26, 0x29,
+	0x91, 0x8f, 0xb3, 0xef, 0x68, 0x04, 0x2d, 0xd7, 0x99, 0x13, 0x8f, 0x50, 0x2a, 0xd6, 0xba, 0x36,
+	0x1a, 0xe4, 0x71, 0x63, 0x55, 0xc5, 0x09, 0xce, 0x0c, 0x00, 0x9d, 0xbb, 0x96, 0x4d, 0xce, 0x26,
+	0x9f, 0x89, 0xcd, 0xe2, 0xbd, 0xd2, 0xa1, 0x15, 0x92, 0xc0, 0x75, 0x6c, 0x8b, 0x8a, 0x55, 0xfd,
+	0x85, 0x93, 0x18, 0x21, 0xa8, 0x53, 0xe7, 0x7b, 0xbc, 0x47, 0xe2, 0x1b, 0xed, 0xc3, 0x0a, 0xf9,
+	0x66, 0xbb, 0x91, 0xd8, 0xa4, 0xda, 0xd2, 0x26, 0x62, 0x80, 0x79, 0x08, 0xbd, 0x9c, 0xa2, 0xda,
+	0x82, 0x7d, 0x68, 0xf0, 0x26, 0xb8, 0x1e, 0x27, 0xe8, 0xa7, 0x04, 0x02, 0x3d, 0x15, 0x7d, 0x4a,
+	0x88, 0x89, 0x01, 0xd2, 0xe4, 0x23, 0x0d, 0xed, 0x25, 0x74, 0x8f, 0x43, 0xcb, 0xf1, 0xee, 0x37,
+	0x32, 0x3b, 0x00, 0x51, 0x32, 0x81, 0x6a, 0x38, 0x33, 0x19, 0xf3, 0x08, 0x36, 0x32, 0xac, 0xaa,
+	0xd5, 0xa7, 0xd0, 0xa4, 0xcc, 0x62, 0x11, 0x55, 0xd4, 0x9b, 0x29, 0xb5, 0x00, 0x5f, 0x88, 0x22,
+	0x56, 0x20, 0xf3, 0x15, 0xf4, 0xc6, 0xbe, 0x6d, 0xb1, 0xc2, 0x19, 0xfd, 0x0f, 0xb5, 0x2f, 0x64,
+	0xa1, 0x28, 0x7a, 0x29, 0x85, 0x44, 0x9d, 0x90, 0x05, 0xe6, 0x75, 0xf3, 0x08, 0xfa, 0xf9, 0xbf,
+	0x1f, 0xb0, 0xdf, 0x1a, 0x0c, 0x30, 0x99, 0x58, 0xae, 0xe5, 0xd9, 0x44, 0x2d, 0x4e, 0x8d, 0xf3,
+	0x18, 0xfe, 0xbe, 0x55, 0x51, 0x02, 0xcf, 0x0b, 0x5d, 0xfe, 0x93, 0x2a, 0x14, 0x7f, 0x89, 0x3b,
+	0x3d, 0x80, 0xcd, 0x73, 0x2b, 0xa2, 0x24, 0xa9, 0xc7, 0xbd, 0x0e, 0xa0, 0x19, 0xf0, 0x82, 0x3c,
+	0x8c, 0x16, 0x56, 0x91, 0x79, 0x02, 0x83, 0xe2, 0x0f, 0x0f, 0x57, 0xff, 0x59, 0x85, 0xf5, 0x42,
+	0xad, 0x4c, 0x98, 0x5f, 0x10, 0x05, 0x9c, 0xaa, 0x53, 0x4f, 0x62, 0x64, 0xc2, 0xea, 0x8d, 0x3f,
+	0x27, 0x53, 0xb9, 0xe1, 0xf2, 0x2a, 0xd6, 0x71, 0x2e, 0xc7, 0xe7, 0x46, 0xc4, 0x47, 0x0b, 0x46,
+	0xa8, 0x56, 0x17, 0x88, 0x4c, 0x06, 0xbd, 0x84, 0xb6, 0x6b, 0x51, 0x86, 0x23, 0xef, 0x90, 0x69,
+	0x0d, 0xd1, 0x81, 0x3e, 0x94, 0x0e, 0x3f, 0x8c, 0x1d, 0x7e, 0x78, 0x19, 0x3b, 0x3c, 0x4e, 0xc1,
+	0xdc, 0x29, 0x49, 0x18, 0xfa, 0xa1, 0xd6, 0x34, 0x2a, 0x7b, 0x6d, 0x2c, 0x03, 0x64, 0x40, 0x87,
+	0x59, 0xe1, 0x8c, 0xb0, 0x2b, 0x6a, 0xcd, 0x88, 0xb6, 0x62, 0x54, 0xf6, 0x2a, 0x38, 0x9b, 0x42,
+	0x4f, 0xe2, 0x79, 0x68, 0x19, 0xb5, 0xfc, 0x40, 0xf1, 0x49, 0x10, 0x98, 0x78, 0x1c, 0xde, 0x40,
+	0x3b, 0xc9, 0xfd, 0x99, 0x77, 0x47, 0x42, 0xb5, 0x2a, 0x54, 0x65, 0x30, 0xfa, 0xd5, 0x80, 0x0e,
+	0x07, 0x5d, 0x90, 0x70, 0xee, 0xd8, 0x04, 0x9d, 0xc2, 0x6a, 0xf6, 0xd1, 0x40, 0xdb, 0xd9, 0x03,
+	0xbb, 0xf5, 0x64, 0xe8, 0x3b, 0x65, 0x65, 0x75, 0xfe, 0x17, 0xb0, 0x96, 0x7f, 0x1a, 0xd0, 0x6e,
+	0xfa, 0xc7, 0xd2, 0x57, 0x48, 0x37, 0xca, 0x01, 0x8a, 0xf4, 0x35, 0xd4, 0xb9, 0xc9, 0xa3, 0xcc,
+	0x30, 0x15, 0x5e, 0x19, 0x5d, 0x5f, 0x56, 0x52, 0xbf, 0xbf, 0x85, 0x76, 0x62, 0xfd, 0x28, 0x03,
+	0x2c, 0x3e, 0x12, 0xfa, 0xd6, 0xd2, 0x9a, 0x64, 0x79, 0x56, 0x41, 0xef, 0xa1, 0x93, 0x71, 0x50,
+	0xf4, 0x6f, 0xe1, 0xea, 0xe6, 0x6c, 0x42, 0xdf, 0x2e, 0xa9, 0xaa, 0x35, 0x1d, 0x43, 0x3b, 0x31,
+	0xa8, 0xec, 0x9a, 0x8a, 0x5e, 0xa8, 0x6f, 0x2d, 0xad, 0x29, 0x96, 0x53, 0x58, 0xcd, 0x9a, 0x4c,
+	0xf6, 0xf0, 0x96, 0x58, 0x97, 0xbe, 0x53, 0x56, 0x56, 0x74, 0x1f, 0x6f, 0x5f, 0x44, 0xa3, 0xfc,
+	0xfe, 0x2a, 0xd2, 0xff, 0xee, 0x40, 0xa4, 0x43, 0x91, 0xb7, 0x8b, 0xec, 0x50, 0x2c, 0x75, 0x1e,
+	0xdd, 0x28, 0x07, 0x48, 0xd2, 0x49, 0x53, 0xdc, 0xc7, 0x17, 0xbf, 0x07, 0x00, 0x35, 0xf2, 0x2a,
+	0x03, 0x97, 0x09, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -375,24 +1062,61 @@ type NodeServiceClient interface {
 	// - Internal
 	RegisterNode(ctx context.Context, in *RegisterNodeRequest, opts ...grpc.CallOption) (*RegisterNodeResponse, error)
 	// クラスタから脱退するときに呼び出すAPI。
-	// ノードの退避処理を行い、ノードの情報を削除する。
+	// ノードの情報を直ちに削除する。退避処理は行わないため、ストレージノードを取り除く場合は先にDrainNodeを呼び出すこと。
+	// storageロールのノードは、退避が完了するまで削除できない。forceを指定した場合は、退避の状態に関わらず削除する。
 	//
 	// Error:
 	// - NotFound: If specified NodeID is not found.
+	// - FailedPrecondition: If the storage node is not drained.
 	// - Internal
 	UnregisterNode(ctx context.Context, in *UnregisterNodeRequest, opts ...grpc.CallOption) (*UnregisterNodeResponse, error)
 	// ノードが生存していることをcontrollerに通知する。
+	// controllerは受信時刻とノードの稼働時間を記録する。一定時間pingが届かないノードは、Suspect、Deadの順に状態が変わる。
+	// nodeを指定した場合は、ノードの情報 (address, name, roles, capacity, labels, version) も更新する。
 	//
 	// Error:
 	// - NotFound: If specified NodeId is not found.
 	// - Internal
 	Ping(ctx context.Context, in *PingNodeRequest, opts ...grpc.CallOption) (*PingNodeResponse, error)
-	// 全ノードの一覧を取得する。
+	// 全ノードの一覧を取得する。各ノードの生存状態も返す。
 	//
 	// Error:
 	// - Aborted: If interrupt of the nodes listing task.
 	// - Internal
 	ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (NodeService_ListNodesClient, error)
+	// 新しいオブジェクトを保存するストレージノードを選ぶ。
+	// 生存しているstorageロールのノードから空き容量が足りるノードを選ぶ。レプリカは可能な限り異なる障害ドメイン (zone, rack) に配置する。
+	//
+	// Error:
+	// - ResourceExhausted: If there are not enough storage nodes.
+	// - Internal
+	PlaceObject(ctx context.Context, in *PlaceObjectRequest, opts ...grpc.CallOption) (*PlaceObjectResponse, error)
+	// ノードの退避処理を開始する。
+	// ノードをDraining状態にして、保存されているオブジェクトを他のストレージノードへバックグラウンドでコピーする。
+	// 進捗はNode.drainに記録され、ListNodesで確認できる。中断した場合は、コピー済みのオブジェクトの次から再開する。
+	// unregisterを指定すると、全てのオブジェクトをコピーした後にノードの情報を削除する。
+	//
+	// Error:
+	// - NotFound: If specified NodeID is not found.
+	// - Internal
+	DrainNode(ctx context.Context, in *DrainNodeRequest, opts ...grpc.CallOption) (*DrainNodeResponse, error)
+	// オブジェクトを保存しているストレージノードを取得する。
+	// 退避や再配置によって移動したオブジェクトの場所のみ記録されている。
+	//
+	// Error:
+	// - NotFound: If the location of the object is not recorded.
+	// - Internal
+	LocateObject(ctx context.Context, in *LocateObjectRequest, opts ...grpc.CallOption) (*LocateObjectResponse, error)
+	// オブジェクトの再配置の状態を取得する。
+	//
+	// Error:
+	// - Internal
+	RebalanceStatus(ctx context.Context, in *RebalanceStatusRequest, opts ...grpc.CallOption) (*RebalanceStatusResponse, error)
+	// オブジェクトの再配置を一時停止または再開する。
+	//
+	// Error:
+	// - Internal
+	PauseRebalance(ctx context.Context, in *PauseRebalanceRequest, opts ...grpc.CallOption) (*PauseRebalanceResponse, error)
 }
 
 type nodeServiceClient struct {
@@ -462,6 +1186,51 @@ func (x *nodeServiceListNodesClient) Recv() (*ListNodesResponse, error) {
 	return m, nil
 }
 
+func (c *nodeServiceClient) PlaceObject(ctx context.Context, in *PlaceObjectRequest, opts ...grpc.CallOption) (*PlaceObjectResponse, error) {
+	out := new(PlaceObjectResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.NodeService/PlaceObject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) DrainNode(ctx context.Context, in *DrainNodeRequest, opts ...grpc.CallOption) (*DrainNodeResponse, error) {
+	out := new(DrainNodeResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.NodeService/DrainNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) LocateObject(ctx context.Context, in *LocateObjectRequest, opts ...grpc.CallOption) (*LocateObjectResponse, error) {
+	out := new(LocateObjectResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.NodeService/LocateObject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) RebalanceStatus(ctx context.Context, in *RebalanceStatusRequest, opts ...grpc.CallOption) (*RebalanceStatusResponse, error) {
+	out := new(RebalanceStatusResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.NodeService/RebalanceStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) PauseRebalance(ctx context.Context, in *PauseRebalanceRequest, opts ...grpc.CallOption) (*PauseRebalanceResponse, error) {
+	out := new(PauseRebalanceResponse)
+	err := c.cc.Invoke(ctx, "/elton.v2.NodeService/PauseRebalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeServiceServer is the server API for NodeService service.
 type NodeServiceServer interface {
 	// クラスタに参加するときや、ノードの構成変更をしたときに呼び出すAPI。
@@ -472,24 +1241,61 @@ type NodeServiceServer interface {
 	// - Internal
 	RegisterNode(context.Context, *RegisterNodeRequest) (*RegisterNodeResponse, error)
 	// クラスタから脱退するときに呼び出すAPI。
-	// ノードの退避処理を行い、ノードの情報を削除する。
+	// ノードの情報を直ちに削除する。退避処理は行わないため、ストレージノードを取り除く場合は先にDrainNodeを呼び出すこと。
+	// storageロールのノードは、退避が完了するまで削除できない。forceを指定した場合は、退避の状態に関わらず削除する。
 	//
 	// Error:
 	// - NotFound: If specified NodeID is not found.
+	// - FailedPrecondition: If the storage node is not drained.
 	// - Internal
 	UnregisterNode(context.Context, *UnregisterNodeRequest) (*UnregisterNodeResponse, error)
 	// ノードが生存していることをcontrollerに通知する。
+	// controllerは受信時刻とノードの稼働時間を記録する。一定時間pingが届かないノードは、Suspect、Deadの順に状態が変わる。
+	// nodeを指定した場合は、ノードの情報 (address, name, roles, capacity, labels, version) も更新する。
 	//
 	// Error:
 	// - NotFound: If specified NodeId is not found.
 	// - Internal
 	Ping(context.Context, *PingNodeRequest) (*PingNodeResponse, error)
-	// 全ノードの一覧を取得する。
+	// 全ノードの一覧を取得する。各ノードの生存状態も返す。
 	//
 	// Error:
 	// - Aborted: If interrupt of the nodes listing task.
 	// - Internal
 	ListNodes(*ListNodesRequest, NodeService_ListNodesServer) error
+	// 新しいオブジェクトを保存するストレージノードを選ぶ。
+	// 生存しているstorageロールのノードから空き容量が足りるノードを選ぶ。レプリカは可能な限り異なる障害ドメイン (zone, rack) に配置する。
+	//
+	// Error:
+	// - ResourceExhausted: If there are not enough storage nodes.
+	// - Internal
+	PlaceObject(context.Context, *PlaceObjectRequest) (*PlaceObjectResponse, error)
+	// ノードの退避処理を開始する。
+	// ノードをDraining状態にして、保存されているオブジェクトを他のストレージノードへバックグラウンドでコピーする。
+	// 進捗はNode.drainに記録され、ListNodesで確認できる。中断した場合は、コピー済みのオブジェクトの次から再開する。
+	// unregisterを指定すると、全てのオブジェクトをコピーした後にノードの情報を削除する。
+	//
+	// Error:
+	// - NotFound: If specified NodeID is not found.
+	// - Internal
+	DrainNode(context.Context, *DrainNodeRequest) (*DrainNodeResponse, error)
+	// オブジェクトを保存しているストレージノードを取得する。
+	// 退避や再配置によって移動したオブジェクトの場所のみ記録されている。
+	//
+	// Error:
+	// - NotFound: If the location of the object is not recorded.
+	// - Internal
+	LocateObject(context.Context, *LocateObjectRequest) (*LocateObjectResponse, error)
+	// オブジェクトの再配置の状態を取得する。
+	//
+	// Error:
+	// - Internal
+	RebalanceStatus(context.Context, *RebalanceStatusRequest) (*RebalanceStatusResponse, error)
+	// オブジェクトの再配置を一時停止または再開する。
+	//
+	// Error:
+	// - Internal
+	PauseRebalance(context.Context, *PauseRebalanceRequest) (*PauseRebalanceResponse, error)
 }
 
 // UnimplementedNodeServiceServer can be embedded to have forward compatible implementations.
@@ -508,6 +1314,21 @@ func (*UnimplementedNodeServiceServer) Ping(ctx context.Context, req *PingNodeRe
 func (*UnimplementedNodeServiceServer) ListNodes(req *ListNodesRequest, srv NodeService_ListNodesServer) error {
 	return status.Errorf(codes.Unimplemented, "method ListNodes not implemented")
 }
+func (*UnimplementedNodeServiceServer) PlaceObject(ctx context.Context, req *PlaceObjectRequest) (*PlaceObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceObject not implemented")
+}
+func (*UnimplementedNodeServiceServer) DrainNode(ctx context.Context, req *DrainNodeRequest) (*DrainNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainNode not implemented")
+}
+func (*UnimplementedNodeServiceServer) LocateObject(ctx context.Context, req *LocateObjectRequest) (*LocateObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LocateObject not implemented")
+}
+func (*UnimplementedNodeServiceServer) RebalanceStatus(ctx context.Context, req *RebalanceStatusRequest) (*RebalanceStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebalanceStatus not implemented")
+}
+func (*UnimplementedNodeServiceServer) PauseRebalance(ctx context.Context, req *PauseRebalanceRequest) (*PauseRebalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseRebalance not implemented")
+}
 
 func RegisterNodeServiceServer(s *grpc.Server, srv NodeServiceServer) {
 	s.RegisterService(&_NodeService_serviceDesc, srv)
@@ -588,6 +1409,96 @@ func (x *nodeServiceListNodesServer) Send(m *ListNodesResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _NodeService_PlaceObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).PlaceObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.NodeService/PlaceObject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).PlaceObject(ctx, req.(*PlaceObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_DrainNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).DrainNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.NodeService/DrainNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).DrainNode(ctx, req.(*DrainNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_LocateObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocateObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).LocateObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.NodeService/LocateObject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).LocateObject(ctx, req.(*LocateObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_RebalanceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).RebalanceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.NodeService/RebalanceStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).RebalanceStatus(ctx, req.(*RebalanceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_PauseRebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).PauseRebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elton.v2.NodeService/PauseRebalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).PauseRebalance(ctx, req.(*PauseRebalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NodeService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "elton.v2.NodeService",
 	HandlerType: (*NodeServiceServer)(nil),
@@ -604,6 +1515,26 @@ var _NodeService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _NodeService_Ping_Handler,
 		},
+		{
+			MethodName: "PlaceObject",
+			Handler:    _NodeService_PlaceObject_Handler,
+		},
+		{
+			MethodName: "DrainNode",
+			Handler:    _NodeService_DrainNode_Handler,
+		},
+		{
+			MethodName: "LocateObject",
+			Handler:    _NodeService_LocateObject_Handler,
+		},
+		{
+			MethodName: "RebalanceStatus",
+			Handler:    _NodeService_RebalanceStatus_Handler,
+		},
+		{
+			MethodName: "PauseRebalance",
+			Handler:    _NodeService_PauseRebalance_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -25,10 +25,13 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type CreateObjectRequest struct {
-	Body                 *ObjectBody `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Body *ObjectBody `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	// If key is specified, the object is saved with the key instead of the
+	// generated key.  It is used to import objects from other clusters.
+	Key                  *ObjectKey `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *CreateObjectRequest) Reset()         { *m = CreateObjectRequest{} }
@@ -63,6 +66,13 @@ func (m *CreateObjectRequest) GetBody() *ObjectBody {
 	return nil
 }
 
+func (m *CreateObjectRequest) GetKey() *ObjectKey {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
 type CreateObjectResponse struct {
 	Key                  *ObjectKey `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -282,6 +292,85 @@ func (m *DeleteObjectResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeleteObjectResponse proto.InternalMessageInfo
 
+type ListObjectsRequest struct {
+	// If it is not empty, only keys greater than after are returned.
+	After                string   `protobuf:"bytes,1,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListObjectsRequest) Reset()         { *m = ListObjectsRequest{} }
+func (m *ListObjectsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListObjectsRequest) ProtoMessage()    {}
+func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{6}
+}
+
+func (m *ListObjectsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListObjectsRequest.Unmarshal(m, b)
+}
+func (m *ListObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListObjectsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListObjectsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListObjectsRequest.Merge(m, src)
+}
+func (m *ListObjectsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListObjectsRequest.Size(m)
+}
+func (m *ListObjectsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListObjectsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListObjectsRequest proto.InternalMessageInfo
+
+func (m *ListObjectsRequest) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+type ListObjectsResponse struct {
+	Key                  *ObjectKey `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListObjectsResponse) Reset()         { *m = ListObjectsResponse{} }
+func (m *ListObjectsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListObjectsResponse) ProtoMessage()    {}
+func (*ListObjectsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{7}
+}
+
+func (m *ListObjectsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListObjectsResponse.Unmarshal(m, b)
+}
+func (m *ListObjectsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListObjectsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListObjectsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListObjectsResponse.Merge(m, src)
+}
+func (m *ListObjectsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListObjectsResponse.Size(m)
+}
+func (m *ListObjectsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListObjectsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListObjectsResponse proto.InternalMessageInfo
+
+func (m *ListObjectsResponse) GetKey() *ObjectKey {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*CreateObjectRequest)(nil), "elton.v2.CreateObjectRequest")
 	proto.RegisterType((*CreateObjectResponse)(nil), "elton.v2.CreateObjectResponse")
@@ -289,31 +378,37 @@ func init() {
 	proto.RegisterType((*GetObjectResponse)(nil), "elton.v2.GetObjectResponse")
 	proto.RegisterType((*DeleteObjectRequest)(nil), "elton.v2.DeleteObjectRequest")
 	proto.RegisterType((*DeleteObjectResponse)(nil), "elton.v2.DeleteObjectResponse")
+	proto.RegisterType((*ListObjectsRequest)(nil), "elton.v2.ListObjectsRequest")
+	proto.RegisterType((*ListObjectsResponse)(nil), "elton.v2.ListObjectsResponse")
 }
 
 func init() { proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
 
 var fileDescriptor_0d2c4ccf1453ffdb = []byte{
-	// 302 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0xd1, 0x4a, 0xc3, 0x40,
-	0x10, 0x24, 0x36, 0x14, 0xdd, 0x56, 0xd1, 0x4b, 0x28, 0x21, 0xa2, 0x48, 0x40, 0xe8, 0x53, 0x1e,
-	0xe2, 0xab, 0x22, 0x68, 0x41, 0x44, 0x8a, 0x90, 0x7e, 0x41, 0xd3, 0x6e, 0x24, 0x5a, 0xb2, 0x31,
-	0x77, 0x16, 0xce, 0x8f, 0xf0, 0x6b, 0xfd, 0x00, 0xe9, 0x25, 0xda, 0x4b, 0x38, 0x21, 0x7d, 0x4b,
-	0x76, 0x67, 0x67, 0x67, 0x67, 0x0e, 0x0e, 0xb9, 0xa0, 0x72, 0xfe, 0x82, 0x61, 0x51, 0x92, 0x20,
-	0xb6, 0x8f, 0x2b, 0x41, 0x79, 0xb8, 0x8e, 0xfc, 0x81, 0x90, 0x05, 0xf2, 0xaa, 0x1c, 0xdc, 0x82,
-	0x73, 0x5f, 0xe2, 0x5c, 0xe0, 0x73, 0xf2, 0x8a, 0x0b, 0x11, 0xe3, 0xfb, 0x07, 0x72, 0xc1, 0xc6,
-	0x60, 0x27, 0xb4, 0x94, 0xde, 0xde, 0x85, 0x35, 0x1e, 0x44, 0x6e, 0xf8, 0x3b, 0x1c, 0x56, 0xb0,
-	0x3b, 0x5a, 0xca, 0x58, 0x21, 0x82, 0x1b, 0x70, 0x9b, 0x04, 0xbc, 0xa0, 0x9c, 0x23, 0xbb, 0x84,
-	0xde, 0x1b, 0x4a, 0xcf, 0x52, 0x04, 0x4e, 0x9b, 0xe0, 0x09, 0x65, 0xbc, 0xe9, 0x07, 0x08, 0xc7,
-	0x0f, 0x28, 0x9a, 0xcb, 0xbb, 0x8d, 0xb2, 0x11, 0xf4, 0x29, 0x4d, 0x39, 0x0a, 0xa5, 0xd2, 0x8e,
-	0xeb, 0x3f, 0xc6, 0xc0, 0xe6, 0xd9, 0x27, 0x7a, 0x3d, 0x55, 0x55, 0xdf, 0xc1, 0x97, 0x05, 0x27,
-	0xda, 0x9e, 0x9d, 0x34, 0x76, 0x37, 0x63, 0x83, 0xcc, 0xf2, 0x94, 0xd4, 0x6a, 0x03, 0xf2, 0x31,
-	0x4f, 0x29, 0x56, 0x88, 0xe0, 0x1a, 0x9c, 0x09, 0xae, 0xb0, 0xed, 0x7b, 0x47, 0xd7, 0x46, 0xe0,
-	0x36, 0xa7, 0xab, 0x83, 0xa2, 0x6f, 0x0b, 0x8e, 0x66, 0x55, 0xec, 0x33, 0x2c, 0xd7, 0xd9, 0x02,
-	0xd9, 0x14, 0x86, 0x7a, 0x3e, 0xec, 0x6c, 0x4b, 0x6a, 0x08, 0xde, 0x3f, 0xff, 0xaf, 0x5d, 0x5b,
-	0x36, 0x81, 0x83, 0x3f, 0x1f, 0x99, 0xbf, 0x05, 0xb7, 0x43, 0xf4, 0x4f, 0x8d, 0xbd, 0x9a, 0x65,
-	0x0a, 0x43, 0x5d, 0xbf, 0x2e, 0xca, 0xe0, 0x8a, 0x2e, 0xca, 0x74, 0x76, 0xd2, 0x57, 0x6f, 0xf9,
-	0xea, 0x27, 0x00, 0x00, 0xff, 0xff, 0xb3, 0x83, 0x28, 0x89, 0xf3, 0x02, 0x00, 0x00,
+	// 353 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xdf, 0x4a, 0xfb, 0x30,
+	0x14, 0xa6, 0x5b, 0x7f, 0xe3, 0xb7, 0xb3, 0x29, 0x9a, 0x95, 0x31, 0xa2, 0x1b, 0x52, 0x10, 0x86,
+	0x17, 0x45, 0xe6, 0xad, 0xde, 0xe8, 0x40, 0xc4, 0x0d, 0x21, 0x7b, 0x82, 0xfd, 0x39, 0x91, 0xea,
+	0x68, 0x66, 0x13, 0x07, 0xf5, 0x21, 0x7c, 0x28, 0x9f, 0x4c, 0x96, 0x74, 0xae, 0xad, 0x2d, 0xae,
+	0x77, 0xcb, 0xbe, 0x3f, 0xf9, 0xce, 0x97, 0x53, 0x38, 0x90, 0x4a, 0x84, 0xd3, 0x67, 0xf4, 0x56,
+	0xa1, 0x50, 0x82, 0xfc, 0xc7, 0xa5, 0x12, 0x81, 0xb7, 0x1e, 0xd0, 0x86, 0x8a, 0x56, 0x28, 0xcd,
+	0xdf, 0x2e, 0x87, 0xd6, 0x5d, 0x88, 0x53, 0x85, 0x4f, 0xb3, 0x17, 0x9c, 0x2b, 0x86, 0x6f, 0xef,
+	0x28, 0x15, 0xe9, 0x83, 0x3d, 0x13, 0x8b, 0xa8, 0x53, 0x39, 0xb3, 0xfa, 0x8d, 0x81, 0xe3, 0x6d,
+	0xc5, 0x9e, 0xa1, 0xdd, 0x8a, 0x45, 0xc4, 0x34, 0x83, 0x9c, 0x43, 0xf5, 0x15, 0xa3, 0x4e, 0x55,
+	0x13, 0x5b, 0x59, 0xe2, 0x23, 0x46, 0x6c, 0x83, 0xbb, 0x37, 0xe0, 0xa4, 0xef, 0x91, 0x2b, 0x11,
+	0x48, 0xdc, 0xca, 0xad, 0x3f, 0xe4, 0x08, 0x47, 0xf7, 0xa8, 0xd2, 0x19, 0xf7, 0x93, 0x92, 0x36,
+	0xd4, 0x04, 0xe7, 0x12, 0x95, 0x1e, 0xc6, 0x66, 0xf1, 0x89, 0x10, 0xb0, 0xa5, 0xff, 0x81, 0x3a,
+	0xb9, 0xcd, 0xf4, 0x6f, 0xf7, 0xd3, 0x82, 0xe3, 0xc4, 0x3d, 0xa5, 0x32, 0x96, 0xe8, 0xac, 0x0f,
+	0xb6, 0x1f, 0x70, 0xd1, 0xa9, 0xe6, 0x33, 0x1f, 0x02, 0x2e, 0x98, 0x66, 0xb8, 0xd7, 0xd0, 0x1a,
+	0xe2, 0x12, 0xb3, 0xcf, 0xb3, 0x67, 0x6b, 0x6d, 0x70, 0xd2, 0x6a, 0x33, 0x90, 0x7b, 0x01, 0x64,
+	0xe4, 0xcb, 0x78, 0x4c, 0xb9, 0x35, 0x75, 0xe0, 0xdf, 0x94, 0x2b, 0x0c, 0xb5, 0x6d, 0x9d, 0x99,
+	0xc3, 0x26, 0x41, 0x8a, 0x5b, 0xaa, 0x93, 0xc1, 0x57, 0x05, 0x0e, 0x27, 0x66, 0x0f, 0x27, 0x18,
+	0xae, 0xfd, 0x39, 0x92, 0x31, 0x34, 0x93, 0x9b, 0x40, 0xba, 0x3b, 0x71, 0xce, 0x26, 0xd2, 0x5e,
+	0x11, 0x1c, 0x07, 0x19, 0x42, 0xfd, 0xe7, 0xc5, 0x08, 0xdd, 0x91, 0xb3, 0xeb, 0x42, 0x4f, 0x72,
+	0xb1, 0xd8, 0x65, 0x0c, 0xcd, 0x64, 0x53, 0xc9, 0x50, 0x39, 0xfd, 0xd3, 0x5e, 0x11, 0x1c, 0xdb,
+	0x8d, 0xa0, 0x91, 0x28, 0x8d, 0x9c, 0xee, 0xe8, 0xbf, 0x7b, 0xa7, 0xdd, 0x02, 0xd4, 0x78, 0x5d,
+	0x5a, 0xb3, 0x9a, 0xfe, 0x54, 0xaf, 0xbe, 0x07, 0x00, 0x03, 0x4b, 0xc3, 0x1c, 0xd2, 0x03, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -332,7 +427,7 @@ type StorageServiceClient interface {
 	//
 	// Error:
 	// - InvalidArgument: If specified object is invalid.
-	// - AlreadyExists: ???  TODO
+	// - AlreadyExists: If key is specified and the object is already exists.
 	CreateObject(ctx context.Context, in *CreateObjectRequest, opts ...grpc.CallOption) (*CreateObjectResponse, error)
 	// Get an object.
 	//
@@ -346,6 +441,11 @@ type StorageServiceClient interface {
 	// - InvalidArgument
 	// - Internal
 	DeleteObject(ctx context.Context, in *DeleteObjectRequest, opts ...grpc.CallOption) (*DeleteObjectResponse, error)
+	// List keys of stored objects in ascending order.
+	//
+	// Error:
+	// - Internal
+	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (StorageService_ListObjectsClient, error)
 }
 
 type storageServiceClient struct {
@@ -383,13 +483,45 @@ func (c *storageServiceClient) DeleteObject(ctx context.Context, in *DeleteObjec
 	return out, nil
 }
 
+func (c *storageServiceClient) ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (StorageService_ListObjectsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StorageService_serviceDesc.Streams[0], "/elton.v2.StorageService/ListObjects", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceListObjectsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_ListObjectsClient interface {
+	Recv() (*ListObjectsResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceListObjectsClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceListObjectsClient) Recv() (*ListObjectsResponse, error) {
+	m := new(ListObjectsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // StorageServiceServer is the server API for StorageService service.
 type StorageServiceServer interface {
 	// Create and save an object.
 	//
 	// Error:
 	// - InvalidArgument: If specified object is invalid.
-	// - AlreadyExists: ???  TODO
+	// - AlreadyExists: If key is specified and the object is already exists.
 	CreateObject(context.Context, *CreateObjectRequest) (*CreateObjectResponse, error)
 	// Get an object.
 	//
@@ -403,6 +535,11 @@ type StorageServiceServer interface {
 	// - InvalidArgument
 	// - Internal
 	DeleteObject(context.Context, *DeleteObjectRequest) (*DeleteObjectResponse, error)
+	// List keys of stored objects in ascending order.
+	//
+	// Error:
+	// - Internal
+	ListObjects(*ListObjectsRequest, StorageService_ListObjectsServer) error
 }
 
 // UnimplementedStorageServiceServer can be embedded to have forward compatible implementations.
@@ -418,6 +555,9 @@ func (*UnimplementedStorageServiceServer) GetObject(ctx context.Context, req *Ge
 func (*UnimplementedStorageServiceServer) DeleteObject(ctx context.Context, req *DeleteObjectRequest) (*DeleteObjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteObject not implemented")
 }
+func (*UnimplementedStorageServiceServer) ListObjects(req *ListObjectsRequest, srv StorageService_ListObjectsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListObjects not implemented")
+}
 
 func RegisterStorageServiceServer(s *grpc.Server, srv StorageServiceServer) {
 	s.RegisterService(&_StorageService_serviceDesc, srv)
@@ -477,6 +617,27 @@ func _StorageService_DeleteObject_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageService_ListObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListObjectsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).ListObjects(m, &storageServiceListObjectsServer{stream})
+}
+
+type StorageService_ListObjectsServer interface {
+	Send(*ListObjectsResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceListObjectsServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceListObjectsServer) Send(m *ListObjectsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _StorageService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "elton.v2.StorageService",
 	HandlerType: (*StorageServiceServer)(nil),
@@ -494,6 +655,12 @@ var _StorageService_serviceDesc = grpc.ServiceDesc{
 			Handler:    _StorageService_DeleteObject_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListObjects",
+			Handler:       _StorageService_ListObjects_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "storage.proto",
 }
@@ -5,6 +5,7 @@ import (
 	"golang.org/x/sys/unix"
 	"golang.org/x/xerrors"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -77,8 +78,13 @@ func (id *CommitID) ConvertString() string {
 	if id == nil {
 		return "<nil>"
 	}
+	if id.GetRef() != "" {
+		return fmt.Sprintf("%s/%s", id.GetId().GetId(), id.GetRef())
+	}
 	return fmt.Sprintf("%s/%d", id.GetId().GetId(), id.GetNumber())
 }
+
+// ParseCommitID parses "VOLUME_ID/NUMBER" or "VOLUME_ID/REF_NAME" format string.
 func ParseCommitID(s string) (*CommitID, error) {
 	parts := strings.SplitN(s, "/", 2)
 	if len(parts) != 2 {
@@ -88,7 +94,16 @@ func ParseCommitID(s string) (*CommitID, error) {
 	id := parts[0]
 	num, err := strconv.ParseUint(parts[1], 10, 64)
 	if err != nil {
-		return nil, xerrors.Errorf("invalid commit id: %w", err)
+		if ValidateRefName(parts[1]) != nil {
+			return nil, xerrors.Errorf("invalid commit id: %w", err)
+		}
+		// Commit is specified by ref name.
+		return &CommitID{
+			Id: &VolumeID{
+				Id: id,
+			},
+			Ref: parts[1],
+		}, nil
 	}
 	return &CommitID{
 		Id: &VolumeID{
@@ -98,21 +113,276 @@ func ParseCommitID(s string) (*CommitID, error) {
 	}, nil
 }
 
-func (t *Tree) FastValidate() error {
-	if t == nil {
-		return xerrors.New("tree is nil")
+// ValidateRefName checks whether the name is valid for ref name.
+// Ref name must not be a number to distinguish from the commit number.
+func ValidateRefName(name string) error {
+	if name == "" {
+		return xerrors.New("empty ref name")
 	}
-	if len(t.GetInodes()) == 0 {
-		return xerrors.New("t.Inodes is empty")
+	if len(name) > 255 {
+		return xerrors.Errorf("too long ref name: %s", name)
 	}
-	inodes := t.GetInodes()
-	if inodes[t.GetRootIno()] == nil {
-		return xerrors.New("root inode is not found")
+	if name == "." || name == ".." || strings.HasPrefix(name, "-") {
+		return xerrors.Errorf("invalid ref name: %s", name)
+	}
+	if _, err := strconv.ParseUint(name, 10, 64); err == nil {
+		return xerrors.Errorf("ref name must not be a number: %s", name)
 	}
+	for _, c := range name {
+		if c <= ' ' || c == 0x7f || strings.ContainsRune("/:~^?*[\\", c) {
+			return xerrors.Errorf("invalid character in ref name: %s", name)
+		}
+	}
+	return nil
+}
+func (id *RefID) ConvertString() string {
+	if id == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%s/%s", id.GetId().GetId(), id.GetName())
+}
 
-	// TODO: ちゃんとチェックする
+// ValidateLabels checks whether keys and values of labels are valid.
+// Key must be 1-63 characters consisting of alphanumerics, '-', '_', '.' and '/'.  Value must be shorter than 256
+// characters and must not contain control characters.
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if key == "" || len(key) > 63 {
+			return xerrors.Errorf("invalid label key length: %q", key)
+		}
+		for _, c := range key {
+			isAlnum := ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+			if !isAlnum && !strings.ContainsRune("-_./", c) {
+				return xerrors.Errorf("invalid character in label key: %q", key)
+			}
+		}
+		if len(value) > 255 {
+			return xerrors.Errorf("too long label value: key=%s", key)
+		}
+		for _, c := range value {
+			if c < ' ' || c == 0x7f {
+				return xerrors.Errorf("invalid character in label value: key=%s", key)
+			}
+		}
+	}
 	return nil
 }
+
+// LabelSelector is a parsed label selector.  Volume matches the selector if it satisfies all requirements.
+type LabelSelector []labelRequirement
+type labelRequirement struct {
+	key   string
+	value string
+	op    labelOperator
+}
+type labelOperator int
+
+const (
+	labelEquals labelOperator = iota
+	labelNotEquals
+	labelExists
+	labelNotExists
+)
+
+// ParseLabelSelector parses comma separated requirements.  Supported formats are "key=value", "key==value",
+// "key!=value", "key" and "!key".  Empty string matches all labels.
+func ParseLabelSelector(s string) (LabelSelector, error) {
+	var selector LabelSelector
+	if strings.TrimSpace(s) == "" {
+		return selector, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		var req labelRequirement
+		switch {
+		case part == "":
+			return nil, xerrors.Errorf("empty requirement: %q", s)
+		case strings.Contains(part, "!="):
+			kv := strings.SplitN(part, "!=", 2)
+			req = labelRequirement{key: kv[0], value: kv[1], op: labelNotEquals}
+		case strings.Contains(part, "=="):
+			kv := strings.SplitN(part, "==", 2)
+			req = labelRequirement{key: kv[0], value: kv[1], op: labelEquals}
+		case strings.Contains(part, "="):
+			kv := strings.SplitN(part, "=", 2)
+			req = labelRequirement{key: kv[0], value: kv[1], op: labelEquals}
+		case strings.HasPrefix(part, "!"):
+			req = labelRequirement{key: part[1:], op: labelNotExists}
+		default:
+			req = labelRequirement{key: part, op: labelExists}
+		}
+		req.key = strings.TrimSpace(req.key)
+		req.value = strings.TrimSpace(req.value)
+		if err := ValidateLabels(map[string]string{req.key: req.value}); err != nil {
+			return nil, xerrors.Errorf("invalid requirement %q: %w", part, err)
+		}
+		selector = append(selector, req)
+	}
+	return selector, nil
+}
+
+// Matches returns true if labels satisfy all requirements.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		value, ok := labels[req.key]
+		switch req.op {
+		case labelEquals:
+			if !ok || value != req.value {
+				return false
+			}
+		case labelNotEquals:
+			if ok && value == req.value {
+				return false
+			}
+		case labelExists:
+			if !ok {
+				return false
+			}
+		case labelNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Validate checks the tree and returns an error describing the first problem found by Check().
+func (t *Tree) Validate() error {
+	problems := t.Check()
+	switch len(problems) {
+	case 0:
+		return nil
+	case 1:
+		return xerrors.New(problems[0])
+	default:
+		return xerrors.Errorf("%s (and %d more problems)", problems[0], len(problems)-1)
+	}
+}
+
+// Check verifies consistency of the tree and returns all problems.  It checks the following:
+//   - Every directory entry points to an existing inode.
+//   - Directories are linked from exactly one directory entry, except the root directory that has no links.  So
+//     there are no directory cycles.
+//   - Non-directory inodes have no directory entries.
+//   - Regular files have a valid content reference.
+//   - All inodes are reachable from the root directory.
+func (t *Tree) Check() []string {
+	if t == nil {
+		return []string{"tree is nil"}
+	}
+	inodes := t.GetInodes()
+	if len(inodes) == 0 {
+		return []string{"t.Inodes is empty"}
+	}
+	root := inodes[t.GetRootIno()]
+	if root == nil {
+		return []string{"root inode is not found"}
+	}
+	if root.GetFileType() != FileType_Directory {
+		return []string{fmt.Sprintf("root inode is not a directory: ino=%d", t.GetRootIno())}
+	}
+
+	inos := make([]uint64, 0, len(inodes))
+	for ino := range inodes {
+		inos = append(inos, ino)
+	}
+	sort.Slice(inos, func(i, j int) bool { return inos[i] < inos[j] })
+
+	var problems []string
+	dirLinks := map[uint64]int{}
+	dirParent := map[uint64]uint64{}
+	for _, ino := range inos {
+		f := inodes[ino]
+		if f == nil {
+			problems = append(problems, fmt.Sprintf("inode is null: ino=%d", ino))
+			continue
+		}
+		switch f.GetFileType() {
+		case FileType_Directory:
+			names := make([]string, 0, len(f.GetEntries()))
+			for name := range f.GetEntries() {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				child := f.GetEntries()[name]
+				cf, ok := inodes[child]
+				if !ok {
+					problems = append(problems, fmt.Sprintf("entry points to missing inode: ino=%d name=%q child=%d", ino, name, child))
+					continue
+				}
+				if cf.GetFileType() == FileType_Directory {
+					dirLinks[child]++
+					dirParent[child] = ino
+				}
+			}
+		case FileType_Regular:
+			if f.GetContentRef().GetKey().Empty() {
+				problems = append(problems, fmt.Sprintf("regular file has no content: ino=%d", ino))
+			}
+			fallthrough
+		default:
+			if len(f.GetEntries()) > 0 {
+				problems = append(problems, fmt.Sprintf("non-directory inode has entries: ino=%d", ino))
+			}
+		}
+	}
+	if dirLinks[t.GetRootIno()] > 0 {
+		problems = append(problems, fmt.Sprintf("root directory is linked from other directories: ino=%d", t.GetRootIno()))
+	}
+	for _, ino := range inos {
+		if ino != t.GetRootIno() && dirLinks[ino] > 1 {
+			problems = append(problems, fmt.Sprintf("directory has multiple links: ino=%d links=%d", ino, dirLinks[ino]))
+		}
+	}
+
+	// Find unreachable inodes.  Directories linked from unreachable directories, including directory cycles, are
+	// also unreachable.
+	reachable := map[uint64]bool{}
+	queue := []uint64{t.GetRootIno()}
+	reachable[t.GetRootIno()] = true
+	for len(queue) > 0 {
+		dir := inodes[queue[0]]
+		queue = queue[1:]
+		for _, child := range dir.GetEntries() {
+			cf := inodes[child]
+			if cf == nil || reachable[child] {
+				continue
+			}
+			reachable[child] = true
+			if cf.GetFileType() == FileType_Directory {
+				queue = append(queue, child)
+			}
+		}
+	}
+	for _, ino := range inos {
+		if !reachable[ino] && inodes[ino] != nil {
+			problems = append(problems, fmt.Sprintf("inode is unreachable from the root: ino=%d", ino))
+		}
+	}
+	for _, ino := range inos {
+		if !reachable[ino] && inodes[ino].GetFileType() == FileType_Directory && inDirCycle(dirParent, ino) {
+			problems = append(problems, fmt.Sprintf("directory cycle: ino=%d", ino))
+		}
+	}
+	return problems
+}
+
+// inDirCycle reports whether the directory is an ancestor of itself.  parents maps a directory to one of its parents.
+func inDirCycle(parents map[uint64]uint64, ino uint64) bool {
+	visited := map[uint64]bool{}
+	for p, ok := parents[ino]; ok; p, ok = parents[p] {
+		if p == ino {
+			return true
+		}
+		if visited[p] {
+			return false
+		}
+		visited[p] = true
+	}
+	return false
+}
 func (t *Tree) DeepCopy() *Tree {
 	inodes := make(map[uint64]*File, len(t.GetInodes()))
 	for ino, f := range t.GetInodes() {
@@ -166,6 +436,39 @@ func (t *Tree) NextIno(trees ...*Tree) uint64 {
 	}
 }
 
+// Paths returns all absolute paths that refer the inode in sorted order.  If the inode is not reachable from the root
+// directory, it returns nil.  It walks the whole tree, so use AllPaths() to get paths of many inodes.
+func (t *Tree) Paths(ino uint64) []string {
+	return t.AllPaths()[ino]
+}
+
+// AllPaths returns absolute paths of all inodes reachable from the root directory.  Paths of each inode are sorted.
+func (t *Tree) AllPaths() map[uint64][]string {
+	paths := map[uint64][]string{
+		t.GetRootIno(): {"/"},
+	}
+	visited := map[uint64]bool{}
+	var walk func(dirIno uint64, dirPath string)
+	walk = func(dirIno uint64, dirPath string) {
+		if visited[dirIno] {
+			return
+		}
+		visited[dirIno] = true
+		for name, child := range t.GetInodes()[dirIno].GetEntries() {
+			p := dirPath + "/" + name
+			paths[child] = append(paths[child], p)
+			if t.GetInodes()[child].GetFileType() == FileType_Directory {
+				walk(child, p)
+			}
+		}
+	}
+	walk(t.GetRootIno(), "")
+	for _, ps := range paths {
+		sort.Strings(ps)
+	}
+	return paths
+}
+
 type comparableFile struct {
 	ContentRef     string
 	FileType       FileType
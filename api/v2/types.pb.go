@@ -6,6 +6,7 @@ package elton_v2
 import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	math "math"
 )
@@ -21,6 +22,139 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Liveness of the node.  It is computed by the controller from Node.lastSeen.
+type NodeLiveness int32
+
+const (
+	// The node has never been seen.
+	NodeLiveness_UnknownLiveness NodeLiveness = 0
+	NodeLiveness_Alive           NodeLiveness = 1
+	// The node does not send ping for a while.  It may be dead.
+	NodeLiveness_Suspect NodeLiveness = 2
+	NodeLiveness_Dead    NodeLiveness = 3
+)
+
+var NodeLiveness_name = map[int32]string{
+	0: "UnknownLiveness",
+	1: "Alive",
+	2: "Suspect",
+	3: "Dead",
+}
+
+var NodeLiveness_value = map[string]int32{
+	"UnknownLiveness": 0,
+	"Alive":           1,
+	"Suspect":         2,
+	"Dead":            3,
+}
+
+func (x NodeLiveness) String() string {
+	return proto.EnumName(NodeLiveness_name, int32(x))
+}
+
+func (NodeLiveness) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{0}
+}
+
+type DrainPhase int32
+
+const (
+	DrainPhase_NotDraining DrainPhase = 0
+	// Objects are being copied to other storage nodes.  New objects are not placed on the node.
+	DrainPhase_Draining DrainPhase = 1
+	// All objects are copied.
+	DrainPhase_Drained DrainPhase = 2
+)
+
+var DrainPhase_name = map[int32]string{
+	0: "NotDraining",
+	1: "Draining",
+	2: "Drained",
+}
+
+var DrainPhase_value = map[string]int32{
+	"NotDraining": 0,
+	"Draining":    1,
+	"Drained":     2,
+}
+
+func (x DrainPhase) String() string {
+	return proto.EnumName(DrainPhase_name, int32(x))
+}
+
+func (DrainPhase) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{1}
+}
+
+// How to resolve conflicts when merging concurrent commits.
+type MergePolicy int32
+
+const (
+	// Reject the commit if any conflict is detected.
+	MergePolicy_RejectConflict MergePolicy = 0
+	// The change with the newer mtime wins.  If mtimes are the same, the current commit wins.
+	MergePolicy_LastWriterWins MergePolicy = 1
+	// The change in the commit being committed wins.
+	MergePolicy_CurrentWins MergePolicy = 2
+	// The change in the latest commit of the volume wins.
+	MergePolicy_LatestWins MergePolicy = 3
+	// The latest commit keeps the original name.  The file in the current commit is renamed to
+	// "name.conflict-<node>".  If a file is deleted on one side and modified on the other side, the modified file
+	// is kept.
+	MergePolicy_KeepBoth MergePolicy = 4
+)
+
+var MergePolicy_name = map[int32]string{
+	0: "RejectConflict",
+	1: "LastWriterWins",
+	2: "CurrentWins",
+	3: "LatestWins",
+	4: "KeepBoth",
+}
+
+var MergePolicy_value = map[string]int32{
+	"RejectConflict": 0,
+	"LastWriterWins": 1,
+	"CurrentWins":    2,
+	"LatestWins":     3,
+	"KeepBoth":       4,
+}
+
+func (x MergePolicy) String() string {
+	return proto.EnumName(MergePolicy_name, int32(x))
+}
+
+func (MergePolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{2}
+}
+
+type RefType int32
+
+const (
+	// Branch advances when a new commit is created on it.
+	RefType_Branch RefType = 0
+	// Tag is immutable.  It always points to the same commit.
+	RefType_Tag RefType = 1
+)
+
+var RefType_name = map[int32]string{
+	0: "Branch",
+	1: "Tag",
+}
+
+var RefType_value = map[string]int32{
+	"Branch": 0,
+	"Tag":    1,
+}
+
+func (x RefType) String() string {
+	return proto.EnumName(RefType_name, int32(x))
+}
+
+func (RefType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{3}
+}
+
 type FileType int32
 
 const (
@@ -58,7 +192,7 @@ func (x FileType) String() string {
 }
 
 func (FileType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{0}
+	return fileDescriptor_d938547f84707355, []int{4}
 }
 
 // Identify the object.
@@ -260,11 +394,14 @@ func (m *PropertyID) GetId() string {
 }
 
 type Property struct {
-	Body                 string   `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
-	AllowReplace         bool     `protobuf:"varint,2,opt,name=allowReplace,proto3" json:"allowReplace,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Body         string `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	AllowReplace bool   `protobuf:"varint,2,opt,name=allowReplace,proto3" json:"allowReplace,omitempty"`
+	// Expiration time.  If it is null, the property never expires.
+	// Expired properties are treated as not exist.
+	ExpireAt             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *Property) Reset()         { *m = Property{} }
@@ -306,6 +443,13 @@ func (m *Property) GetAllowReplace() bool {
 	return false
 }
 
+func (m *Property) GetExpireAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpireAt
+	}
+	return nil
+}
+
 // Identify the node.
 type NodeID struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -351,11 +495,23 @@ type Node struct {
 	Address []string `protobuf:"bytes,1,rep,name=address,proto3" json:"address,omitempty"`
 	// Human readable name.
 	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	// Uptime
-	Uptime               uint64   `protobuf:"varint,3,opt,name=uptime,proto3" json:"uptime,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// Uptime in seconds reported by the node.
+	Uptime uint64 `protobuf:"varint,3,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	// The time when the controller received the last ping or registration.
+	LastSeen *timestamp.Timestamp `protobuf:"bytes,4,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	// Roles of eltond running on the node (e.g. "controller", "storage").
+	Roles []string `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles,omitempty"`
+	// Disk usage of the object storage.  It is empty if the node does not have the storage role.
+	Capacity *NodeCapacity `protobuf:"bytes,6,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// Arbitrary labels such as zone or rack.
+	Labels map[string]string `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Version of eltond.
+	Version string `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	// Progress of evacuating objects from the node.  It is managed by the controller.
+	Drain                *DrainStatus `protobuf:"bytes,9,opt,name=drain,proto3" json:"drain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Node) Reset()         { *m = Node{} }
@@ -404,6 +560,202 @@ func (m *Node) GetUptime() uint64 {
 	return 0
 }
 
+func (m *Node) GetLastSeen() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeen
+	}
+	return nil
+}
+
+func (m *Node) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *Node) GetCapacity() *NodeCapacity {
+	if m != nil {
+		return m.Capacity
+	}
+	return nil
+}
+
+func (m *Node) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Node) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Node) GetDrain() *DrainStatus {
+	if m != nil {
+		return m.Drain
+	}
+	return nil
+}
+
+type NodeCapacity struct {
+	TotalBytes uint64 `protobuf:"varint,1,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
+	FreeBytes  uint64 `protobuf:"varint,2,opt,name=freeBytes,proto3" json:"freeBytes,omitempty"`
+	// Number of objects stored on the node.
+	Objects              uint64   `protobuf:"varint,3,opt,name=objects,proto3" json:"objects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeCapacity) Reset()         { *m = NodeCapacity{} }
+func (m *NodeCapacity) String() string { return proto.CompactTextString(m) }
+func (*NodeCapacity) ProtoMessage()    {}
+func (*NodeCapacity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{7}
+}
+
+func (m *NodeCapacity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeCapacity.Unmarshal(m, b)
+}
+func (m *NodeCapacity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeCapacity.Marshal(b, m, deterministic)
+}
+func (m *NodeCapacity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeCapacity.Merge(m, src)
+}
+func (m *NodeCapacity) XXX_Size() int {
+	return xxx_messageInfo_NodeCapacity.Size(m)
+}
+func (m *NodeCapacity) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeCapacity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeCapacity proto.InternalMessageInfo
+
+func (m *NodeCapacity) GetTotalBytes() uint64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+func (m *NodeCapacity) GetFreeBytes() uint64 {
+	if m != nil {
+		return m.FreeBytes
+	}
+	return 0
+}
+
+func (m *NodeCapacity) GetObjects() uint64 {
+	if m != nil {
+		return m.Objects
+	}
+	return 0
+}
+
+type DrainStatus struct {
+	Phase DrainPhase `protobuf:"varint,1,opt,name=phase,proto3,enum=elton.v2.DrainPhase" json:"phase,omitempty"`
+	// Unregister the node after all objects are copied.
+	Unregister bool `protobuf:"varint,2,opt,name=unregister,proto3" json:"unregister,omitempty"`
+	// Objects up to lastKey are already copied.  Draining is resumed from the next key.
+	LastKey       string               `protobuf:"bytes,3,opt,name=lastKey,proto3" json:"lastKey,omitempty"`
+	CopiedObjects uint64               `protobuf:"varint,4,opt,name=copiedObjects,proto3" json:"copiedObjects,omitempty"`
+	CopiedBytes   uint64               `protobuf:"varint,5,opt,name=copiedBytes,proto3" json:"copiedBytes,omitempty"`
+	StartedAt     *timestamp.Timestamp `protobuf:"bytes,6,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	UpdatedAt     *timestamp.Timestamp `protobuf:"bytes,7,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// The last error.  Draining is retried until it succeeds.
+	Error                string   `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainStatus) Reset()         { *m = DrainStatus{} }
+func (m *DrainStatus) String() string { return proto.CompactTextString(m) }
+func (*DrainStatus) ProtoMessage()    {}
+func (*DrainStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{8}
+}
+
+func (m *DrainStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainStatus.Unmarshal(m, b)
+}
+func (m *DrainStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainStatus.Marshal(b, m, deterministic)
+}
+func (m *DrainStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainStatus.Merge(m, src)
+}
+func (m *DrainStatus) XXX_Size() int {
+	return xxx_messageInfo_DrainStatus.Size(m)
+}
+func (m *DrainStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainStatus proto.InternalMessageInfo
+
+func (m *DrainStatus) GetPhase() DrainPhase {
+	if m != nil {
+		return m.Phase
+	}
+	return DrainPhase_NotDraining
+}
+
+func (m *DrainStatus) GetUnregister() bool {
+	if m != nil {
+		return m.Unregister
+	}
+	return false
+}
+
+func (m *DrainStatus) GetLastKey() string {
+	if m != nil {
+		return m.LastKey
+	}
+	return ""
+}
+
+func (m *DrainStatus) GetCopiedObjects() uint64 {
+	if m != nil {
+		return m.CopiedObjects
+	}
+	return 0
+}
+
+func (m *DrainStatus) GetCopiedBytes() uint64 {
+	if m != nil {
+		return m.CopiedBytes
+	}
+	return 0
+}
+
+func (m *DrainStatus) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *DrainStatus) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *DrainStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 // Identify the volume.
 type VolumeID struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -416,7 +768,7 @@ func (m *VolumeID) Reset()         { *m = VolumeID{} }
 func (m *VolumeID) String() string { return proto.CompactTextString(m) }
 func (*VolumeID) ProtoMessage()    {}
 func (*VolumeID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{7}
+	return fileDescriptor_d938547f84707355, []int{9}
 }
 
 func (m *VolumeID) XXX_Unmarshal(b []byte) error {
@@ -446,17 +798,29 @@ func (m *VolumeID) GetId() string {
 
 // Metadata for the volume.
 type VolumeInfo struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 古いコミットを削除するためのポリシー。
+	// 指定しない場合は、全てのコミットを保持する。
+	Retention *RetentionPolicy `protobuf:"bytes,2,opt,name=retention,proto3" json:"retention,omitempty"`
+	// ボリュームを分類するための任意のラベル。ListVolumesのlabelSelectorで絞り込みに使用する。
+	Labels      map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Description string            `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// ボリュームの所有者。現時点ではアクセス制御には使用しない。
+	Owner string `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	// ボリュームの作成日時。作成時にサーバが設定し、変更はできない。
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// 並行して作成されたコミットをマージするときに、競合を解決する方法。
+	MergePolicy          MergePolicy `protobuf:"varint,7,opt,name=mergePolicy,proto3,enum=elton.v2.MergePolicy" json:"mergePolicy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *VolumeInfo) Reset()         { *m = VolumeInfo{} }
 func (m *VolumeInfo) String() string { return proto.CompactTextString(m) }
 func (*VolumeInfo) ProtoMessage()    {}
 func (*VolumeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{8}
+	return fileDescriptor_d938547f84707355, []int{10}
 }
 
 func (m *VolumeInfo) XXX_Unmarshal(b []byte) error {
@@ -484,20 +848,126 @@ func (m *VolumeInfo) GetName() string {
 	return ""
 }
 
+func (m *VolumeInfo) GetRetention() *RetentionPolicy {
+	if m != nil {
+		return m.Retention
+	}
+	return nil
+}
+
+func (m *VolumeInfo) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *VolumeInfo) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *VolumeInfo) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *VolumeInfo) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *VolumeInfo) GetMergePolicy() MergePolicy {
+	if m != nil {
+		return m.MergePolicy
+	}
+	return MergePolicy_RejectConflict
+}
+
+// Retention policy of commits in the volume.
+// A commit is kept if it matches any rule.  The latest commit, commits pointed by refs and merge commits are always kept.
+// If all rules are zero value, all commits are kept.
+type RetentionPolicy struct {
+	// Keep the last N commits.
+	KeepLast uint32 `protobuf:"varint,1,opt,name=keepLast,proto3" json:"keepLast,omitempty"`
+	// Keep the newest commit of each day for the last N days.
+	KeepDaily uint32 `protobuf:"varint,2,opt,name=keepDaily,proto3" json:"keepDaily,omitempty"`
+	// Keep commits younger than the duration.
+	KeepWithin           *duration.Duration `protobuf:"bytes,3,opt,name=keepWithin,proto3" json:"keepWithin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *RetentionPolicy) Reset()         { *m = RetentionPolicy{} }
+func (m *RetentionPolicy) String() string { return proto.CompactTextString(m) }
+func (*RetentionPolicy) ProtoMessage()    {}
+func (*RetentionPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{11}
+}
+
+func (m *RetentionPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetentionPolicy.Unmarshal(m, b)
+}
+func (m *RetentionPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetentionPolicy.Marshal(b, m, deterministic)
+}
+func (m *RetentionPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetentionPolicy.Merge(m, src)
+}
+func (m *RetentionPolicy) XXX_Size() int {
+	return xxx_messageInfo_RetentionPolicy.Size(m)
+}
+func (m *RetentionPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetentionPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetentionPolicy proto.InternalMessageInfo
+
+func (m *RetentionPolicy) GetKeepLast() uint32 {
+	if m != nil {
+		return m.KeepLast
+	}
+	return 0
+}
+
+func (m *RetentionPolicy) GetKeepDaily() uint32 {
+	if m != nil {
+		return m.KeepDaily
+	}
+	return 0
+}
+
+func (m *RetentionPolicy) GetKeepWithin() *duration.Duration {
+	if m != nil {
+		return m.KeepWithin
+	}
+	return nil
+}
+
 // Identify the commit.
 type CommitID struct {
-	Id                   *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Number               uint64    `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Id     *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number uint64    `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	// ref名でコミットを指定する場合に設定する。numberよりも優先される。
+	// サーバはrefを解決して、numberを設定したCommitIDを返す。
+	Ref                  string   `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CommitID) Reset()         { *m = CommitID{} }
 func (m *CommitID) String() string { return proto.CompactTextString(m) }
 func (*CommitID) ProtoMessage()    {}
 func (*CommitID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{9}
+	return fileDescriptor_d938547f84707355, []int{12}
 }
 
 func (m *CommitID) XXX_Unmarshal(b []byte) error {
@@ -532,6 +1002,110 @@ func (m *CommitID) GetNumber() uint64 {
 	return 0
 }
 
+func (m *CommitID) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+// Identify the ref in the volume.
+type RefID struct {
+	Id                   *VolumeID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RefID) Reset()         { *m = RefID{} }
+func (m *RefID) String() string { return proto.CompactTextString(m) }
+func (*RefID) ProtoMessage()    {}
+func (*RefID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{13}
+}
+
+func (m *RefID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefID.Unmarshal(m, b)
+}
+func (m *RefID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefID.Marshal(b, m, deterministic)
+}
+func (m *RefID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefID.Merge(m, src)
+}
+func (m *RefID) XXX_Size() int {
+	return xxx_messageInfo_RefID.Size(m)
+}
+func (m *RefID) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefID proto.InternalMessageInfo
+
+func (m *RefID) GetId() *VolumeID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RefID) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// Ref is a named pointer to the commit.
+type Ref struct {
+	Type RefType `protobuf:"varint,1,opt,name=type,proto3,enum=elton.v2.RefType" json:"type,omitempty"`
+	// The commit that ref points to.  It never contains the ref name.
+	Commit               *CommitID `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Ref) Reset()         { *m = Ref{} }
+func (m *Ref) String() string { return proto.CompactTextString(m) }
+func (*Ref) ProtoMessage()    {}
+func (*Ref) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{14}
+}
+
+func (m *Ref) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ref.Unmarshal(m, b)
+}
+func (m *Ref) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ref.Marshal(b, m, deterministic)
+}
+func (m *Ref) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ref.Merge(m, src)
+}
+func (m *Ref) XXX_Size() int {
+	return xxx_messageInfo_Ref.Size(m)
+}
+func (m *Ref) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ref.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ref proto.InternalMessageInfo
+
+func (m *Ref) GetType() RefType {
+	if m != nil {
+		return m.Type
+	}
+	return RefType_Branch
+}
+
+func (m *Ref) GetCommit() *CommitID {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
 // TODO: rename
 type CommitInfo struct {
 	CreatedAt *timestamp.Timestamp `protobuf:"bytes,1,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
@@ -540,18 +1114,24 @@ type CommitInfo struct {
 	LeftParentID *CommitID `protobuf:"bytes,2,opt,name=leftParentID,proto3" json:"leftParentID,omitempty"`
 	// nil以外の場合は、このコミットはマージコミット。
 	// もう一つの親コミットIDを指定する。
-	RightParentID        *CommitID `protobuf:"bytes,4,opt,name=rightParentID,proto3" json:"rightParentID,omitempty"`
-	Tree                 *Tree     `protobuf:"bytes,5,opt,name=tree,proto3" json:"tree,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	RightParentID *CommitID `protobuf:"bytes,4,opt,name=rightParentID,proto3" json:"rightParentID,omitempty"`
+	Tree          *Tree     `protobuf:"bytes,5,opt,name=tree,proto3" json:"tree,omitempty"`
+	// フォークされたvolumeの最初のコミットのみ設定される。
+	// フォーク元のコミットIDを指す。
+	ForkedFrom *CommitID `protobuf:"bytes,6,opt,name=forkedFrom,proto3" json:"forkedFrom,omitempty"`
+	// コミットを作成したノードの名前。
+	// マージポリシーがKeepBothの場合に、競合したファイルの名前に使用する。
+	Node                 string   `protobuf:"bytes,7,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CommitInfo) Reset()         { *m = CommitInfo{} }
 func (m *CommitInfo) String() string { return proto.CompactTextString(m) }
 func (*CommitInfo) ProtoMessage()    {}
 func (*CommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{10}
+	return fileDescriptor_d938547f84707355, []int{15}
 }
 
 func (m *CommitInfo) XXX_Unmarshal(b []byte) error {
@@ -600,6 +1180,20 @@ func (m *CommitInfo) GetTree() *Tree {
 	return nil
 }
 
+func (m *CommitInfo) GetForkedFrom() *CommitID {
+	if m != nil {
+		return m.ForkedFrom
+	}
+	return nil
+}
+
+func (m *CommitInfo) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
 // Tree keeps encoded data of directory tree structure in the commit.
 type Tree struct {
 	RootIno              uint64           `protobuf:"varint,3,opt,name=root_ino,json=rootIno,proto3" json:"root_ino,omitempty"`
@@ -613,7 +1207,7 @@ func (m *Tree) Reset()         { *m = Tree{} }
 func (m *Tree) String() string { return proto.CompactTextString(m) }
 func (*Tree) ProtoMessage()    {}
 func (*Tree) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{11}
+	return fileDescriptor_d938547f84707355, []int{16}
 }
 
 func (m *Tree) XXX_Unmarshal(b []byte) error {
@@ -676,7 +1270,7 @@ func (m *File) Reset()         { *m = File{} }
 func (m *File) String() string { return proto.CompactTextString(m) }
 func (*File) ProtoMessage()    {}
 func (*File) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{12}
+	return fileDescriptor_d938547f84707355, []int{17}
 }
 
 func (m *File) XXX_Unmarshal(b []byte) error {
@@ -786,7 +1380,7 @@ func (m *FileContentRef) Reset()         { *m = FileContentRef{} }
 func (m *FileContentRef) String() string { return proto.CompactTextString(m) }
 func (*FileContentRef) ProtoMessage()    {}
 func (*FileContentRef) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{13}
+	return fileDescriptor_d938547f84707355, []int{18}
 }
 
 func (m *FileContentRef) XXX_Unmarshal(b []byte) error {
@@ -815,6 +1409,10 @@ func (m *FileContentRef) GetKey() *ObjectKey {
 }
 
 func init() {
+	proto.RegisterEnum("elton.v2.NodeLiveness", NodeLiveness_name, NodeLiveness_value)
+	proto.RegisterEnum("elton.v2.DrainPhase", DrainPhase_name, DrainPhase_value)
+	proto.RegisterEnum("elton.v2.MergePolicy", MergePolicy_name, MergePolicy_value)
+	proto.RegisterEnum("elton.v2.RefType", RefType_name, RefType_value)
 	proto.RegisterEnum("elton.v2.FileType", FileType_name, FileType_value)
 	proto.RegisterType((*ObjectKey)(nil), "elton.v2.ObjectKey")
 	proto.RegisterType((*ObjectInfo)(nil), "elton.v2.ObjectInfo")
@@ -823,9 +1421,16 @@ func init() {
 	proto.RegisterType((*Property)(nil), "elton.v2.Property")
 	proto.RegisterType((*NodeID)(nil), "elton.v2.NodeID")
 	proto.RegisterType((*Node)(nil), "elton.v2.Node")
+	proto.RegisterMapType((map[string]string)(nil), "elton.v2.Node.LabelsEntry")
+	proto.RegisterType((*NodeCapacity)(nil), "elton.v2.NodeCapacity")
+	proto.RegisterType((*DrainStatus)(nil), "elton.v2.DrainStatus")
 	proto.RegisterType((*VolumeID)(nil), "elton.v2.VolumeID")
 	proto.RegisterType((*VolumeInfo)(nil), "elton.v2.VolumeInfo")
+	proto.RegisterMapType((map[string]string)(nil), "elton.v2.VolumeInfo.LabelsEntry")
+	proto.RegisterType((*RetentionPolicy)(nil), "elton.v2.RetentionPolicy")
 	proto.RegisterType((*CommitID)(nil), "elton.v2.CommitID")
+	proto.RegisterType((*RefID)(nil), "elton.v2.RefID")
+	proto.RegisterType((*Ref)(nil), "elton.v2.Ref")
 	proto.RegisterType((*CommitInfo)(nil), "elton.v2.CommitInfo")
 	proto.RegisterType((*Tree)(nil), "elton.v2.Tree")
 	proto.RegisterMapType((map[uint64]*File)(nil), "elton.v2.Tree.InodesEntry")
@@ -837,56 +1442,98 @@ func init() {
 func init() { proto.RegisterFile("types.proto", fileDescriptor_d938547f84707355) }
 
 var fileDescriptor_d938547f84707355 = []byte{
-	// 813 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x6d, 0x8b, 0xdb, 0x46,
-	0x10, 0xae, 0xec, 0xb5, 0x2d, 0x8f, 0xed, 0x8b, 0xd8, 0x84, 0xa2, 0x3a, 0x85, 0x1a, 0x91, 0xc2,
-	0xd1, 0x0f, 0x4a, 0x71, 0x69, 0x7b, 0xe4, 0x53, 0x73, 0xe7, 0x18, 0x7c, 0x0d, 0x4d, 0xd8, 0x1c,
-	0xfd, 0x5a, 0x64, 0x69, 0x6c, 0x2b, 0x96, 0xb4, 0x66, 0xb5, 0xbe, 0xa0, 0xfe, 0x8e, 0xfe, 0x8c,
-	0xfe, 0xaa, 0x42, 0xff, 0x47, 0xd9, 0x17, 0xd9, 0x12, 0xbd, 0x72, 0xf4, 0xd3, 0xed, 0xcc, 0x33,
-	0xcf, 0xcc, 0xa3, 0x99, 0xe7, 0x0c, 0x23, 0x59, 0x1d, 0xb0, 0x0c, 0x0f, 0x82, 0x4b, 0x4e, 0x5d,
-	0xcc, 0x24, 0x2f, 0xc2, 0xfb, 0xf9, 0xf4, 0xab, 0x2d, 0xe7, 0xdb, 0x0c, 0x5f, 0xea, 0xfc, 0xfa,
-	0xb8, 0x79, 0x29, 0xd3, 0x1c, 0x4b, 0x19, 0xe5, 0x07, 0x53, 0x1a, 0x3c, 0x87, 0xe1, 0xbb, 0xf5,
-	0x47, 0x8c, 0xe5, 0xcf, 0x58, 0xd1, 0x0b, 0xe8, 0xa4, 0x89, 0xef, 0xcc, 0x9c, 0xcb, 0x21, 0xeb,
-	0xa4, 0x49, 0xf0, 0x87, 0x03, 0x60, 0xd0, 0x55, 0xb1, 0xe1, 0x94, 0x02, 0xd9, 0x45, 0xe5, 0x4e,
-	0x17, 0x8c, 0x99, 0x7e, 0xd3, 0x17, 0x30, 0x51, 0x7f, 0x5f, 0x67, 0x5b, 0x2e, 0x52, 0xb9, 0xcb,
-	0x7d, 0xa2, 0xd9, 0xed, 0x24, 0xbd, 0x82, 0x61, 0x2c, 0x30, 0x92, 0x98, 0xbc, 0x96, 0x7e, 0x67,
-	0xe6, 0x5c, 0x8e, 0xe6, 0xd3, 0xd0, 0x48, 0x0b, 0x6b, 0x69, 0xe1, 0x5d, 0x2d, 0x8d, 0x9d, 0x8b,
-	0xd5, 0xcc, 0x32, 0xfd, 0x1d, 0xfd, 0xee, 0xcc, 0xb9, 0x24, 0x4c, 0xbf, 0x83, 0x9f, 0x6a, 0x55,
-	0xd7, 0x3c, 0xa9, 0xe8, 0x14, 0xdc, 0x98, 0x17, 0x12, 0x0b, 0x59, 0x5a, 0x65, 0xa7, 0x98, 0x7e,
-	0x0e, 0x7d, 0xbe, 0xd9, 0x94, 0x68, 0x86, 0x12, 0x66, 0xa3, 0xe0, 0x4b, 0x80, 0xf7, 0x82, 0x1f,
-	0x50, 0xc8, 0x6a, 0xb5, 0xf8, 0xd7, 0x67, 0x5f, 0x83, 0x5b, 0xa3, 0x6a, 0xfe, 0x9a, 0x27, 0x95,
-	0x45, 0xf5, 0x9b, 0x06, 0x30, 0x8e, 0xb2, 0x8c, 0x7f, 0x62, 0x78, 0xc8, 0xa2, 0x18, 0x75, 0x6f,
-	0x97, 0xb5, 0x72, 0x81, 0x0f, 0xfd, 0x5f, 0x78, 0x82, 0x0f, 0x74, 0x7f, 0x0b, 0x44, 0x21, 0xd4,
-	0x87, 0x41, 0x94, 0x24, 0x02, 0x4b, 0x25, 0xbb, 0x7b, 0x39, 0x64, 0x75, 0xa8, 0x66, 0x16, 0x51,
-	0x6e, 0xfa, 0x0e, 0x99, 0x7e, 0xab, 0x2f, 0x39, 0x1e, 0xd4, 0xf1, 0xec, 0x26, 0x6c, 0x14, 0x4c,
-	0xc1, 0xfd, 0x95, 0x67, 0xc7, 0xfc, 0xa1, 0x49, 0x33, 0x00, 0x8b, 0xd9, 0xeb, 0xe9, 0xae, 0xce,
-	0xb9, 0x6b, 0xb0, 0x04, 0xf7, 0x86, 0xe7, 0x79, 0x2a, 0x57, 0x0b, 0x1a, 0x9c, 0xd8, 0xa3, 0x39,
-	0x0d, 0x6b, 0x07, 0x85, 0x75, 0x77, 0xd5, 0x51, 0xa9, 0x28, 0x8e, 0xf9, 0x1a, 0x45, 0xbd, 0x4f,
-	0x13, 0x05, 0x7f, 0x39, 0x00, 0xb6, 0x91, 0x1a, 0xd5, 0x3a, 0xb7, 0xf3, 0x7f, 0xce, 0xfd, 0x03,
-	0x8c, 0x33, 0xdc, 0xc8, 0xf7, 0x91, 0xc0, 0x42, 0xae, 0x16, 0xd6, 0x2b, 0x0d, 0x39, 0xb5, 0x5c,
-	0xd6, 0xaa, 0xa3, 0x57, 0x30, 0x11, 0xe9, 0x76, 0x77, 0x26, 0x92, 0xff, 0x24, 0xb6, 0x0b, 0x69,
-	0x00, 0x44, 0x0a, 0x44, 0xbf, 0xa7, 0x09, 0x17, 0x67, 0xc2, 0x9d, 0x40, 0x64, 0x1a, 0xbb, 0x25,
-	0x6e, 0xd7, 0x23, 0xc1, 0x9f, 0x0e, 0x10, 0x95, 0xa4, 0x5f, 0x80, 0x2b, 0x38, 0x97, 0xbf, 0xa5,
-	0x05, 0xb7, 0xd7, 0x18, 0xa8, 0x78, 0x55, 0x70, 0x3a, 0x87, 0x7e, 0x5a, 0xf0, 0x04, 0x4b, 0x9f,
-	0xcc, 0xba, 0xfa, 0xb3, 0x5b, 0xfd, 0xc2, 0x95, 0x06, 0xdf, 0x14, 0x52, 0x54, 0xcc, 0x56, 0x4e,
-	0x57, 0x30, 0x6a, 0xa4, 0xa9, 0x07, 0xdd, 0x3d, 0x1a, 0xc3, 0x11, 0xa6, 0x9e, 0xf4, 0x05, 0xf4,
-	0xee, 0xa3, 0xec, 0x88, 0x76, 0x1b, 0x0d, 0x8d, 0xcb, 0x34, 0x43, 0x66, 0xc0, 0x57, 0x9d, 0x2b,
-	0xe7, 0x96, 0xb8, 0x8e, 0xd7, 0xb9, 0x25, 0x6e, 0xc7, 0xeb, 0x06, 0x7f, 0x77, 0x81, 0x28, 0x9c,
-	0x5e, 0x01, 0xd8, 0x7f, 0x08, 0x86, 0x1b, 0x7b, 0x0e, 0xbf, 0xdd, 0xe3, 0xe6, 0x84, 0xb3, 0x46,
-	0x2d, 0x0d, 0xc1, 0xdd, 0xa4, 0x19, 0xde, 0x55, 0x07, 0x33, 0xfb, 0xa2, 0xb9, 0xd0, 0xa5, 0x45,
-	0xd8, 0xa9, 0x46, 0x59, 0x2c, 0xe7, 0x89, 0xb1, 0xe8, 0x84, 0xe9, 0x37, 0x7d, 0x06, 0x3d, 0xfe,
-	0xa9, 0x40, 0xa1, 0x2f, 0x32, 0x61, 0x26, 0x50, 0xd9, 0xad, 0xe0, 0xc7, 0x83, 0x5e, 0xfb, 0x84,
-	0x99, 0x80, 0x7e, 0x0b, 0xbd, 0x48, 0x7b, 0xbc, 0xff, 0xa8, 0x67, 0x4c, 0xa1, 0x62, 0xe4, 0x9a,
-	0x31, 0x78, 0x9c, 0x91, 0xd7, 0x8c, 0x58, 0x33, 0xdc, 0xc7, 0x19, 0xba, 0x50, 0x69, 0xcd, 0xa3,
-	0x8f, 0x5c, 0xf8, 0x43, 0xa3, 0x55, 0x07, 0x3a, 0x9b, 0x16, 0x5c, 0xf8, 0x60, 0xb3, 0x2a, 0xa0,
-	0xdf, 0xc3, 0x00, 0x0b, 0x29, 0x52, 0x2c, 0xfd, 0x91, 0x36, 0xc0, 0xf3, 0xf6, 0xc2, 0xc2, 0x37,
-	0x06, 0x35, 0x0e, 0xa8, 0x6b, 0xa7, 0xaf, 0x60, 0xdc, 0x04, 0x9a, 0x1e, 0x18, 0x1a, 0x0f, 0x3c,
-	0x6b, 0x7a, 0x80, 0x34, 0x6e, 0x1e, 0xfc, 0x08, 0x17, 0xed, 0x13, 0xd2, 0xaf, 0xcf, 0xec, 0xd1,
-	0xfc, 0xe9, 0x59, 0xc0, 0xe9, 0x87, 0x5e, 0xb7, 0xfc, 0x46, 0x82, 0x5b, 0xdf, 0x90, 0x8e, 0x60,
-	0xc0, 0x70, 0x7b, 0xcc, 0x22, 0xe1, 0x7d, 0x46, 0x27, 0x30, 0x5c, 0xa4, 0x02, 0x63, 0xc9, 0x45,
-	0xe5, 0x39, 0xd4, 0x83, 0xf1, 0x87, 0x2a, 0x5f, 0xf3, 0x2c, 0x8d, 0xdf, 0xa6, 0xc5, 0xde, 0xeb,
-	0x50, 0x17, 0xc8, 0x72, 0xb5, 0x7c, 0xe7, 0x75, 0xe9, 0x53, 0x78, 0x72, 0xb3, 0x8b, 0x44, 0x14,
-	0x4b, 0x14, 0x0b, 0xbc, 0x4f, 0x63, 0xf4, 0x08, 0x7d, 0x02, 0xa3, 0xeb, 0x8c, 0xc7, 0x7b, 0x9b,
-	0xe8, 0x51, 0x80, 0xfe, 0x07, 0x1e, 0xef, 0x51, 0x7a, 0xfd, 0x75, 0x5f, 0x2f, 0xfa, 0xbb, 0x7f,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0x3b, 0xe3, 0x27, 0x79, 0xb1, 0x06, 0x00, 0x00,
+	// 1474 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xef, 0x6e, 0xdb, 0xc8,
+	0x11, 0xb7, 0x44, 0x4a, 0xa2, 0x46, 0xb6, 0xc2, 0xee, 0x5d, 0x0f, 0x8c, 0xee, 0x90, 0x1a, 0xc4,
+	0x1d, 0x60, 0xb8, 0x80, 0xae, 0x50, 0xd1, 0x8b, 0xef, 0xbe, 0xb4, 0xb6, 0x15, 0x03, 0x4a, 0xdc,
+	0x8b, 0xb1, 0x76, 0x9b, 0xfb, 0x56, 0xd0, 0xe4, 0x48, 0xda, 0x98, 0xe2, 0x12, 0xcb, 0x95, 0x53,
+	0xe5, 0x73, 0xd1, 0x27, 0xe8, 0x53, 0x14, 0x7d, 0x8d, 0x3e, 0x4a, 0x1f, 0xa2, 0xdf, 0x8a, 0xd9,
+	0x25, 0x25, 0xca, 0x71, 0xaa, 0x06, 0xfd, 0xc4, 0x9d, 0x99, 0xdf, 0xfc, 0xdd, 0x99, 0xe1, 0x42,
+	0x4f, 0xaf, 0x72, 0x2c, 0x86, 0xb9, 0x92, 0x5a, 0x32, 0x0f, 0x53, 0x2d, 0xb3, 0xe1, 0xfd, 0x68,
+	0xf0, 0x6c, 0x26, 0xe5, 0x2c, 0xc5, 0x6f, 0x0d, 0xff, 0x76, 0x39, 0xfd, 0x36, 0x59, 0xaa, 0x48,
+	0x0b, 0x99, 0x59, 0xe4, 0xe0, 0x17, 0x0f, 0xe5, 0x5a, 0x2c, 0xb0, 0xd0, 0xd1, 0x22, 0xb7, 0x80,
+	0xf0, 0x4b, 0xe8, 0xbe, 0xbe, 0x7d, 0x8b, 0xb1, 0x7e, 0x85, 0x2b, 0xd6, 0x87, 0xa6, 0x48, 0x82,
+	0xc6, 0x61, 0xe3, 0xa8, 0xcb, 0x9b, 0x22, 0x09, 0xff, 0xd6, 0x00, 0xb0, 0xd2, 0x49, 0x36, 0x95,
+	0x8c, 0x81, 0x3b, 0x8f, 0x8a, 0xb9, 0x01, 0xec, 0x73, 0x73, 0x66, 0x5f, 0xc3, 0x01, 0x7d, 0x4f,
+	0xd3, 0x99, 0x54, 0x42, 0xcf, 0x17, 0x81, 0x6b, 0xb4, 0xb7, 0x99, 0xec, 0x04, 0xba, 0xb1, 0xc2,
+	0x48, 0x63, 0x72, 0xaa, 0x83, 0xe6, 0x61, 0xe3, 0xa8, 0x37, 0x1a, 0x0c, 0x6d, 0x68, 0xc3, 0x2a,
+	0xb4, 0xe1, 0x4d, 0x15, 0x1a, 0xdf, 0x80, 0xc9, 0x67, 0x21, 0xde, 0x63, 0xe0, 0x1c, 0x36, 0x8e,
+	0x5c, 0x6e, 0xce, 0xe1, 0xef, 0xaa, 0xa8, 0xce, 0x64, 0xb2, 0x62, 0x03, 0xf0, 0x62, 0x99, 0x69,
+	0xcc, 0x74, 0x51, 0x46, 0xb6, 0xa6, 0xd9, 0x17, 0xd0, 0x96, 0xd3, 0x69, 0x81, 0xd6, 0xa9, 0xcb,
+	0x4b, 0x2a, 0xfc, 0x0a, 0xe0, 0x4a, 0xc9, 0x1c, 0x95, 0x5e, 0x4d, 0xc6, 0x1f, 0xa4, 0xfd, 0x1e,
+	0xbc, 0x4a, 0x4a, 0xfe, 0x6f, 0x65, 0xb2, 0x2a, 0xa5, 0xe6, 0xcc, 0x42, 0xd8, 0x8f, 0xd2, 0x54,
+	0xbe, 0xe3, 0x98, 0xa7, 0x51, 0x8c, 0xc6, 0xb6, 0xc7, 0xb7, 0x78, 0xec, 0x3b, 0xf0, 0xf0, 0xcf,
+	0xb9, 0x50, 0x78, 0xaa, 0x03, 0x67, 0x67, 0xc2, 0x6b, 0x6c, 0x18, 0x40, 0xfb, 0x47, 0x99, 0xe0,
+	0x23, 0x51, 0xfd, 0xc5, 0x01, 0x97, 0x44, 0x2c, 0x80, 0x4e, 0x94, 0x24, 0x0a, 0x0b, 0xca, 0xd7,
+	0x39, 0xea, 0xf2, 0x8a, 0xa4, 0x60, 0xb3, 0x68, 0x61, 0x03, 0xea, 0x72, 0x73, 0xa6, 0x12, 0x2c,
+	0x73, 0xba, 0xf5, 0xb2, 0x84, 0x25, 0x45, 0x01, 0xa6, 0x51, 0xa1, 0xaf, 0x11, 0xb3, 0xc0, 0xdd,
+	0x1d, 0x60, 0x85, 0x65, 0x9f, 0x43, 0x4b, 0xc9, 0x14, 0x8b, 0xa0, 0x65, 0x7c, 0x5b, 0x82, 0x8d,
+	0xc0, 0x8b, 0xa3, 0x3c, 0x8a, 0x85, 0x5e, 0x05, 0x6d, 0x63, 0xed, 0x8b, 0x61, 0xd5, 0xa4, 0x43,
+	0x8a, 0xfa, 0xbc, 0x94, 0xf2, 0x35, 0x8e, 0x8d, 0xa0, 0x9d, 0x46, 0xb7, 0x98, 0x16, 0x41, 0xe7,
+	0xd0, 0x31, 0xfe, 0xb7, 0x34, 0x86, 0x97, 0x46, 0xf8, 0x22, 0xd3, 0x6a, 0xc5, 0x4b, 0x24, 0xe5,
+	0x7e, 0x8f, 0xaa, 0x10, 0x32, 0x0b, 0x3c, 0x93, 0x64, 0x45, 0xb2, 0x5f, 0x42, 0x2b, 0x51, 0x91,
+	0xc8, 0x82, 0xae, 0x71, 0xff, 0xf3, 0x8d, 0xb1, 0x31, 0xb1, 0xaf, 0x75, 0xa4, 0x97, 0x05, 0xb7,
+	0x98, 0xc1, 0xf7, 0xd0, 0xab, 0x59, 0x67, 0x3e, 0x38, 0x77, 0x58, 0xdd, 0x31, 0x1d, 0x29, 0xcb,
+	0xfb, 0x28, 0x5d, 0x56, 0xa5, 0xb4, 0xc4, 0x0f, 0xcd, 0x93, 0x46, 0x38, 0x85, 0xfd, 0x7a, 0x3e,
+	0xec, 0x19, 0x80, 0x96, 0x3a, 0x4a, 0xcf, 0x56, 0x1a, 0x6d, 0x03, 0xba, 0xbc, 0xc6, 0x61, 0x5f,
+	0x41, 0x77, 0xaa, 0x10, 0xad, 0xd8, 0x76, 0xe1, 0x86, 0x41, 0xf9, 0x48, 0xd3, 0xca, 0x45, 0x79,
+	0x3d, 0x15, 0x19, 0xfe, 0xb3, 0x09, 0xbd, 0x5a, 0xe4, 0xec, 0x18, 0x5a, 0xf9, 0x3c, 0x2a, 0xd0,
+	0xb8, 0xe8, 0x8f, 0x3e, 0x7f, 0x90, 0xdf, 0x15, 0xc9, 0xb8, 0x85, 0x50, 0x4c, 0xcb, 0x4c, 0xe1,
+	0x4c, 0x14, 0x1a, 0x55, 0xd9, 0x9e, 0x35, 0x0e, 0x79, 0xa5, 0xfb, 0x7c, 0x85, 0x2b, 0xe3, 0xb5,
+	0xcb, 0x2b, 0x92, 0xc6, 0x39, 0x96, 0xb9, 0xc0, 0xe4, 0x75, 0x19, 0x95, 0x6b, 0xa2, 0xda, 0x66,
+	0xb2, 0x43, 0xe8, 0x59, 0x86, 0xcd, 0xaa, 0x65, 0x30, 0x75, 0x16, 0x0d, 0x7c, 0xa1, 0x23, 0x65,
+	0x07, 0xbe, 0xbd, 0x7b, 0xe0, 0xd7, 0x60, 0xd2, 0x5c, 0xe6, 0x49, 0xb9, 0x2a, 0x3a, 0xbb, 0x35,
+	0xd7, 0x60, 0xba, 0x33, 0x54, 0x4a, 0xaa, 0xb2, 0x33, 0x2c, 0x11, 0x0e, 0xc0, 0xfb, 0xa3, 0x4c,
+	0x97, 0x8b, 0xc7, 0x46, 0xea, 0xdf, 0x4d, 0x80, 0x52, 0x58, 0xee, 0x37, 0x33, 0x3e, 0x8d, 0xda,
+	0xf8, 0x3c, 0x87, 0xae, 0x42, 0x5a, 0x26, 0xd4, 0x72, 0x76, 0x73, 0x3d, 0xdd, 0x94, 0x9e, 0x57,
+	0xa2, 0x2b, 0x99, 0x8a, 0x78, 0xc5, 0x37, 0x58, 0x76, 0xb2, 0xee, 0x6e, 0xc7, 0x74, 0xf7, 0xe1,
+	0x46, 0x6b, 0xe3, 0xf2, 0xd1, 0x1e, 0x3f, 0x84, 0x5e, 0x82, 0x45, 0xac, 0x44, 0x6e, 0x9c, 0xda,
+	0x85, 0x5a, 0x67, 0x51, 0xa6, 0xf2, 0x5d, 0x86, 0xca, 0x54, 0xbe, 0xcb, 0x2d, 0xb1, 0xbd, 0x64,
+	0xdb, 0x9f, 0xb2, 0x64, 0x9f, 0x43, 0x6f, 0x81, 0x6a, 0x86, 0x36, 0x0b, 0x53, 0xf5, 0x7e, 0x7d,
+	0x82, 0x7e, 0xbf, 0x11, 0xf2, 0x3a, 0xf2, 0xff, 0x99, 0xa3, 0xbf, 0x36, 0xe0, 0xc9, 0x83, 0xf2,
+	0xd1, 0x2a, 0xbf, 0x43, 0xcc, 0x2f, 0xa3, 0x42, 0x1b, 0x23, 0x07, 0x7c, 0x4d, 0xd3, 0x1c, 0xd1,
+	0x79, 0x1c, 0x89, 0x74, 0x65, 0xac, 0x1d, 0xf0, 0x0d, 0x83, 0x7d, 0x0f, 0x40, 0xc4, 0x1b, 0xa1,
+	0xe7, 0x22, 0x2b, 0x17, 0xee, 0xd3, 0x0f, 0x92, 0x1f, 0x97, 0x3f, 0x47, 0x5e, 0x03, 0x87, 0x3f,
+	0x81, 0x77, 0x2e, 0x17, 0x0b, 0xa1, 0x27, 0x63, 0x16, 0xae, 0x1b, 0xa4, 0x37, 0x62, 0x1f, 0x5c,
+	0xd8, 0x98, 0x9a, 0x86, 0x16, 0x6a, 0xb6, 0x5c, 0xdc, 0x96, 0x83, 0xe5, 0xf2, 0x92, 0xa2, 0xe4,
+	0x15, 0x4e, 0xcb, 0x81, 0xa2, 0x63, 0xf8, 0x5b, 0x68, 0x71, 0x9c, 0xfe, 0x8f, 0x66, 0x1f, 0xd9,
+	0xdd, 0xe1, 0x4f, 0xe0, 0x70, 0x9c, 0xb2, 0x6f, 0xc0, 0xa5, 0xbf, 0x7f, 0x39, 0xf9, 0x3f, 0xab,
+	0xb7, 0xdf, 0xf4, 0x66, 0x95, 0x23, 0x37, 0x62, 0x76, 0x0c, 0xed, 0xd8, 0x24, 0x12, 0x34, 0x1f,
+	0x7a, 0xaa, 0x12, 0xe4, 0x25, 0x22, 0xfc, 0x7b, 0x13, 0xa0, 0x64, 0x52, 0xe7, 0x6f, 0xb5, 0x4e,
+	0xe3, 0x53, 0x5a, 0xe7, 0x3b, 0xd8, 0x4f, 0x71, 0xaa, 0xaf, 0x22, 0x85, 0x99, 0x9e, 0x8c, 0xff,
+	0x8b, 0xeb, 0x2d, 0x1c, 0x3b, 0x81, 0x03, 0x25, 0x66, 0xf3, 0x8d, 0xa2, 0xfb, 0x51, 0xc5, 0x6d,
+	0x20, 0x0b, 0xc1, 0xd5, 0x0a, 0xd1, 0xf4, 0x7e, 0x6f, 0xd4, 0xdf, 0x28, 0xdc, 0x28, 0xa4, 0x52,
+	0x28, 0x44, 0x36, 0x02, 0x98, 0x4a, 0x75, 0x87, 0xc9, 0x85, 0x92, 0x8b, 0xa0, 0xfd, 0x51, 0xd3,
+	0x35, 0x94, 0xb9, 0x00, 0x99, 0x60, 0xd0, 0x29, 0x2f, 0x40, 0x26, 0xf8, 0xd2, 0xf5, 0x1c, 0xdf,
+	0x0d, 0xff, 0xd1, 0x00, 0x97, 0x8c, 0xb3, 0xa7, 0xe0, 0x29, 0x29, 0xf5, 0x9f, 0x44, 0x26, 0xab,
+	0x75, 0x4d, 0xf4, 0x24, 0x93, 0xf4, 0x33, 0x13, 0xa4, 0x42, 0x1b, 0xf3, 0xc1, 0xcf, 0x8c, 0x54,
+	0x87, 0x13, 0x23, 0x2c, 0x07, 0xdd, 0x22, 0x07, 0x13, 0xe8, 0xd5, 0xd8, 0xf5, 0xe9, 0x71, 0xed,
+	0xf4, 0x7c, 0x5d, 0x9f, 0x9e, 0xad, 0x5c, 0x2f, 0x44, 0x8a, 0xb5, 0x69, 0x7a, 0xe9, 0x7a, 0x0d,
+	0xbf, 0xf9, 0xd2, 0xf5, 0x9a, 0xbe, 0x13, 0xfe, 0xcb, 0x01, 0x97, 0xe4, 0xec, 0x04, 0xa0, 0x7c,
+	0x09, 0x71, 0x9c, 0x96, 0xd7, 0x1a, 0x6c, 0xdb, 0x38, 0x5f, 0xcb, 0x79, 0x0d, 0xcb, 0x86, 0xe0,
+	0x4d, 0x45, 0x8a, 0xd4, 0x5c, 0xc6, 0x77, 0x7f, 0xc4, 0xb6, 0xf5, 0x48, 0xc2, 0xd7, 0x18, 0xaa,
+	0xdd, 0x82, 0x6a, 0xe7, 0x98, 0xb9, 0x34, 0xe7, 0xcd, 0x92, 0x72, 0x0d, 0xd3, 0x12, 0xc4, 0x9d,
+	0x29, 0xb9, 0xcc, 0xcd, 0xf5, 0x1d, 0x70, 0x4b, 0xb0, 0x5f, 0x41, 0x2b, 0x32, 0x6f, 0x94, 0xdd,
+	0x6b, 0xcb, 0x02, 0x49, 0x63, 0x61, 0x34, 0x76, 0xff, 0x22, 0x5a, 0x8b, 0x4a, 0x23, 0x36, 0x1a,
+	0xde, 0x6e, 0x0d, 0x03, 0xa4, 0x58, 0x17, 0xd1, 0x5b, 0xa9, 0xcc, 0x93, 0xe2, 0x80, 0x5b, 0xc2,
+	0x70, 0x45, 0x26, 0x55, 0x00, 0x25, 0x97, 0x08, 0xf6, 0x1b, 0xe8, 0x60, 0xa6, 0x95, 0xc0, 0x22,
+	0xe8, 0x99, 0x06, 0xf8, 0x72, 0xbb, 0x60, 0xc3, 0x17, 0x56, 0x6a, 0x3b, 0xa0, 0xc2, 0x0e, 0x7e,
+	0x80, 0xfd, 0xba, 0x60, 0xd7, 0x06, 0x75, 0xeb, 0x1b, 0xf4, 0x39, 0xf4, 0xb7, 0xaf, 0x90, 0x7d,
+	0xb3, 0xd1, 0xee, 0x8d, 0x3e, 0xdb, 0x04, 0xb0, 0x7e, 0xe1, 0x1b, 0x93, 0xc7, 0x2f, 0xec, 0x13,
+	0xe6, 0x52, 0xdc, 0x63, 0x46, 0xcf, 0xc6, 0xcf, 0xe0, 0xc9, 0x1f, 0xb2, 0xbb, 0x4c, 0xbe, 0xcb,
+	0x2a, 0x96, 0xbf, 0xc7, 0xba, 0xd0, 0x3a, 0x4d, 0xc5, 0x3d, 0xfa, 0x0d, 0xd6, 0x83, 0xce, 0xf5,
+	0xb2, 0xc8, 0x31, 0xd6, 0x7e, 0x93, 0x79, 0xe0, 0x8e, 0x31, 0x4a, 0x7c, 0xe7, 0xf8, 0x04, 0x60,
+	0xf3, 0xf4, 0x60, 0x4f, 0xa0, 0xf7, 0xa3, 0xd4, 0x86, 0x21, 0xb2, 0x99, 0xbf, 0xc7, 0xf6, 0xc1,
+	0x5b, 0x53, 0xc6, 0x86, 0xa1, 0x30, 0xf1, 0x9b, 0xc7, 0x09, 0xf4, 0x6a, 0xbf, 0x14, 0xc6, 0xa0,
+	0xcf, 0x91, 0x22, 0x3c, 0x97, 0xd9, 0x34, 0x15, 0xb1, 0xf6, 0xf7, 0x88, 0x47, 0x6b, 0xff, 0x8d,
+	0x12, 0x1a, 0xd5, 0x1b, 0x91, 0x15, 0x7e, 0x83, 0x5c, 0x9c, 0x2f, 0x15, 0xad, 0x01, 0xc3, 0x68,
+	0xb2, 0x3e, 0xc0, 0x65, 0xa4, 0xb1, 0xb0, 0xb4, 0x43, 0x2e, 0x5f, 0x21, 0xe6, 0x67, 0x52, 0xcf,
+	0x7d, 0xf7, 0xf8, 0x19, 0x74, 0xca, 0x05, 0xc9, 0x00, 0xda, 0x67, 0x2a, 0xca, 0xe2, 0xb9, 0xbf,
+	0xc7, 0x3a, 0xe0, 0xdc, 0x44, 0x33, 0xbf, 0x71, 0xac, 0xc1, 0xab, 0x5a, 0x99, 0xc2, 0xe3, 0x38,
+	0x5b, 0xa6, 0x91, 0xf2, 0xf7, 0xd8, 0x01, 0x74, 0xc7, 0x42, 0x61, 0xac, 0xa5, 0x5a, 0xf9, 0x0d,
+	0xe6, 0xc3, 0xfe, 0xf5, 0x6a, 0x71, 0x4b, 0xb1, 0x5e, 0x8a, 0xec, 0xce, 0xd6, 0xe0, 0x62, 0x72,
+	0xf1, 0xda, 0x77, 0xa8, 0x74, 0xe7, 0xf3, 0x48, 0x45, 0xb1, 0x46, 0x35, 0xc6, 0x7b, 0x11, 0xa3,
+	0xef, 0x52, 0x9c, 0x67, 0xa9, 0x8c, 0xef, 0x4a, 0x46, 0x8b, 0xdc, 0x5f, 0xcb, 0xf8, 0x0e, 0xb5,
+	0xdf, 0xbe, 0x6d, 0x9b, 0x7e, 0xfb, 0xf5, 0x7f, 0x06, 0x00, 0x33, 0x0c, 0xc4, 0x96, 0xd1, 0x0d,
+	0x00, 0x00,
 }
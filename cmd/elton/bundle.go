@@ -0,0 +1,349 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Volume bundle is a tar archive that contains a volume and its history.
+// The entries are stored in the following order:
+//
+//	manifest.json          bundleManifest
+//	volume.json            VolumeInfo (JSON encoded protobuf message)
+//	objects/<key>          Contents of the storage object.  Hash value is stored in PAX records of the header.
+//	commits/<number>.json  CommitInfo (JSON encoded protobuf message)
+//
+// Objects are stored before commits, and commits are sorted by commit number.  So the importer can import all entries
+// in a single pass without breaking references.
+const (
+	bundleFormat       = "elton-bundle"
+	bundleVersion      = 1
+	bundleManifestName = "manifest.json"
+	bundleVolumeName   = "volume.json"
+	bundleObjectsDir   = "objects/"
+	bundleCommitsDir   = "commits/"
+
+	paxHash          = "ELTON.hash"
+	paxHashAlgorithm = "ELTON.hashAlgorithm"
+)
+
+type bundleManifest struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	// ID of the exported volume.
+	VolumeID string `json:"volumeId"`
+	// Latest commit in this bundle.
+	Head string `json:"head"`
+	// Base commit of the incremental bundle.  Empty string if the bundle contains full history.
+	// The base commit and its ancestors are not contained in this bundle.
+	Base    string   `json:"base,omitempty"`
+	Commits []string `json:"commits"`
+	Objects []string `json:"objects"`
+}
+
+func (m *bundleManifest) validate() error {
+	if m.Format != bundleFormat {
+		return xerrors.Errorf("unknown format: %s", m.Format)
+	}
+	if m.Version != bundleVersion {
+		return xerrors.Errorf("unsupported version: %d", m.Version)
+	}
+	if m.VolumeID == "" {
+		return xerrors.Errorf("volume id is empty")
+	}
+	for _, key := range m.Objects {
+		if !isValidObjectKey(key) {
+			return xerrors.Errorf("invalid object key: %s", key)
+		}
+	}
+	if m.Head != "" {
+		head, err := elton_v2.ParseCommitID(m.Head)
+		if err != nil {
+			return err
+		}
+		if head.GetId().GetId() != m.VolumeID {
+			return xerrors.Errorf("cross-volume head: %s", m.Head)
+		}
+	}
+	for _, s := range m.Commits {
+		cid, err := elton_v2.ParseCommitID(s)
+		if err != nil {
+			return err
+		}
+		if cid.GetId().GetId() != m.VolumeID {
+			return xerrors.Errorf("cross-volume commit: %s", s)
+		}
+	}
+	return nil
+}
+func isValidObjectKey(key string) bool {
+	return key != "" && isValidFileName(key) && !strings.ContainsAny(key, "/\x00")
+}
+
+type bundleWriter struct {
+	tw *tar.Writer
+	m  jsonpb.Marshaler
+}
+
+func newBundleWriter(w io.Writer) *bundleWriter {
+	return &bundleWriter{
+		tw: tar.NewWriter(w),
+	}
+}
+func (w *bundleWriter) writeEntry(name string, body []byte, records map[string]string) error {
+	hdr := &tar.Header{
+		Name:       name,
+		Mode:       0644,
+		Size:       int64(len(body)),
+		ModTime:    time.Now(),
+		PAXRecords: records,
+	}
+	if records != nil {
+		hdr.Format = tar.FormatPAX
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return xerrors.Errorf("write header(%s): %w", name, err)
+	}
+	if _, err := w.tw.Write(body); err != nil {
+		return xerrors.Errorf("write body(%s): %w", name, err)
+	}
+	return nil
+}
+func (w *bundleWriter) writeMessage(name string, msg proto.Message) error {
+	var buf bytes.Buffer
+	if err := w.m.Marshal(&buf, msg); err != nil {
+		return xerrors.Errorf("marshal(%s): %w", name, err)
+	}
+	return w.writeEntry(name, buf.Bytes(), nil)
+}
+func (w *bundleWriter) WriteManifest(m *bundleManifest) error {
+	body, err := json.Marshal(m)
+	if err != nil {
+		return xerrors.Errorf("marshal(%s): %w", bundleManifestName, err)
+	}
+	return w.writeEntry(bundleManifestName, body, nil)
+}
+func (w *bundleWriter) WriteVolume(info *elton_v2.VolumeInfo) error {
+	return w.writeMessage(bundleVolumeName, info)
+}
+func (w *bundleWriter) WriteObject(key string, body []byte, info *elton_v2.ObjectInfo) error {
+	records := map[string]string{
+		paxHash:          hex.EncodeToString(info.GetHash()),
+		paxHashAlgorithm: info.GetHashAlgorithm(),
+	}
+	return w.writeEntry(bundleObjectsDir+key, body, records)
+}
+func (w *bundleWriter) WriteCommit(id *elton_v2.CommitID, info *elton_v2.CommitInfo) error {
+	return w.writeMessage(bundleCommitsDir+strconv.FormatUint(id.GetNumber(), 10)+".json", info)
+}
+func (w *bundleWriter) Close() error {
+	return w.tw.Close()
+}
+
+type bundleReader struct {
+	tr *tar.Reader
+	// Header and body of the last entry.
+	hdr  *tar.Header
+	body []byte
+}
+
+func newBundleReader(r io.Reader) *bundleReader {
+	return &bundleReader{
+		tr: tar.NewReader(r),
+	}
+}
+
+// next reads next entry.  It returns io.EOF if all entries are consumed.
+func (r *bundleReader) next() error {
+	hdr, err := r.tr.Next()
+	if err != nil {
+		if err == io.EOF {
+			return err
+		}
+		return xerrors.Errorf("read header: %w", err)
+	}
+	body, err := ioutil.ReadAll(r.tr)
+	if err != nil {
+		return xerrors.Errorf("read body(%s): %w", hdr.Name, err)
+	}
+	r.hdr = hdr
+	r.body = body
+	return nil
+}
+func (r *bundleReader) expect(name string) error {
+	if err := r.next(); err != nil {
+		if err == io.EOF {
+			return xerrors.Errorf("%s: unexpected EOF", name)
+		}
+		return err
+	}
+	if r.hdr.Name != name {
+		return xerrors.Errorf("unexpected entry: expected=%s actual=%s", name, r.hdr.Name)
+	}
+	return nil
+}
+func (r *bundleReader) ReadManifest() (*bundleManifest, error) {
+	if err := r.expect(bundleManifestName); err != nil {
+		return nil, err
+	}
+	m := &bundleManifest{}
+	if err := json.Unmarshal(r.body, m); err != nil {
+		return nil, xerrors.Errorf("unmarshal(%s): %w", bundleManifestName, err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, xerrors.Errorf("invalid manifest: %w", err)
+	}
+	return m, nil
+}
+func (r *bundleReader) ReadVolume() (*elton_v2.VolumeInfo, error) {
+	if err := r.expect(bundleVolumeName); err != nil {
+		return nil, err
+	}
+	info := &elton_v2.VolumeInfo{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(r.body), info); err != nil {
+		return nil, xerrors.Errorf("unmarshal(%s): %w", bundleVolumeName, err)
+	}
+	return info, nil
+}
+
+// ReadObject reads the object and verifies its contents.
+func (r *bundleReader) ReadObject(key string) ([]byte, error) {
+	if err := r.expect(bundleObjectsDir + key); err != nil {
+		return nil, err
+	}
+	if err := verifyObject(r.body, r.hdr.PAXRecords); err != nil {
+		return nil, xerrors.Errorf("object(%s): %w", key, err)
+	}
+	return r.body, nil
+}
+func (r *bundleReader) ReadCommit(id *elton_v2.CommitID) (*elton_v2.CommitInfo, error) {
+	name := bundleCommitsDir + strconv.FormatUint(id.GetNumber(), 10) + ".json"
+	if err := r.expect(name); err != nil {
+		return nil, err
+	}
+	info := &elton_v2.CommitInfo{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(r.body), info); err != nil {
+		return nil, xerrors.Errorf("unmarshal(%s): %w", name, err)
+	}
+	return info, nil
+}
+
+// verifyObject checks the contents of the object matches with the hash value.
+func verifyObject(body []byte, records map[string]string) error {
+	switch algo := records[paxHashAlgorithm]; algo {
+	case "SHA1":
+		hash := sha1.Sum(body)
+		if hex.EncodeToString(hash[:]) != records[paxHash] {
+			return xerrors.Errorf("hash mismatch")
+		}
+	case "":
+		// Hash value is not available.
+	default:
+		return xerrors.Errorf("unsupported hash algorithm: %s", algo)
+	}
+	return nil
+}
+
+// historyWalker collects commits in the DAG.
+type historyWalker struct {
+	cc      elton_v2.CommitServiceClient
+	commits map[string]*elton_v2.CommitInfo
+	ids     map[string]*elton_v2.CommitID
+}
+
+func newHistoryWalker(cc elton_v2.CommitServiceClient) *historyWalker {
+	return &historyWalker{
+		cc:      cc,
+		commits: map[string]*elton_v2.CommitInfo{},
+		ids:     map[string]*elton_v2.CommitID{},
+	}
+}
+
+// Walk collects the specified commit and its ancestors.  Commits that already collected are skipped.
+func (w *historyWalker) Walk(ctx context.Context, head *elton_v2.CommitID) error {
+	queue := []*elton_v2.CommitID{head}
+	for len(queue) > 0 {
+		cid := queue[0]
+		queue = queue[1:]
+		if cid.Empty() {
+			continue
+		}
+		key := cid.ConvertString()
+		if _, ok := w.commits[key]; ok {
+			continue
+		}
+
+		res, err := w.cc.GetCommit(ctx, &elton_v2.GetCommitRequest{
+			Id: cid,
+		})
+		if err != nil {
+			return xerrors.Errorf("get commit(%s): %w", key, err)
+		}
+		info := res.GetInfo()
+		w.commits[key] = info
+		w.ids[key] = cid
+		queue = append(queue, info.GetLeftParentID(), info.GetRightParentID())
+	}
+	return nil
+}
+
+// Commits returns collected commit IDs in ascending order of commit number.
+func (w *historyWalker) Commits() []*elton_v2.CommitID {
+	ids := make([]*elton_v2.CommitID, 0, len(w.ids))
+	for _, id := range w.ids {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].GetNumber() < ids[j].GetNumber()
+	})
+	return ids
+}
+
+// Ancestors returns the specified commit and its ancestors.  All of them must be collected before calling it.
+func (w *historyWalker) Ancestors(cid *elton_v2.CommitID) map[string]struct{} {
+	ancestors := map[string]struct{}{}
+	queue := []*elton_v2.CommitID{cid}
+	for len(queue) > 0 {
+		key := queue[0].ConvertString()
+		queue = queue[1:]
+		info, ok := w.commits[key]
+		if !ok {
+			continue
+		}
+		if _, ok := ancestors[key]; ok {
+			continue
+		}
+		ancestors[key] = struct{}{}
+		queue = append(queue, info.GetLeftParentID(), info.GetRightParentID())
+	}
+	return ancestors
+}
+
+// Objects returns object keys referenced by specified commits.
+func (w *historyWalker) Objects(commits map[string]struct{}) map[string]struct{} {
+	keys := map[string]struct{}{}
+	for key := range commits {
+		info := w.commits[key]
+		for _, file := range info.GetTree().GetInodes() {
+			okey := file.GetContentRef().GetKey().GetId()
+			if okey != "" {
+				keys[okey] = struct{}{}
+			}
+		}
+	}
+	return keys
+}
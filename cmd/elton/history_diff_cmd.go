@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"io"
+	"strings"
+)
+
+func historyDiffFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 && len(args) != 3 {
+		return errors.New("invalid args")
+	}
+	stat, err := cmd.Flags().GetBool("stat")
+	if err != nil {
+		return err
+	}
+	nameStatus, err := cmd.Flags().GetBool("name-status")
+	if err != nil {
+		return err
+	}
+	if stat && nameStatus {
+		return errors.New("--stat and --name-status are exclusive")
+	}
+
+	a, err := elton_v2.ParseCommitID(args[0])
+	if err != nil {
+		showError(err)
+		return nil
+	}
+	b, err := elton_v2.ParseCommitID(args[1])
+	if err != nil {
+		showError(err)
+		return nil
+	}
+	path := ""
+	if len(args) == 3 {
+		path = args[2]
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _historyDiffFn(ctx, a, b, path, stat); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _historyDiffFn(ctx context.Context, a, b *elton_v2.CommitID, path string, stat bool) error {
+	c, err := elton_v2.CommitService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+
+	receiver, err := c.DiffCommits(ctx, &elton_v2.DiffCommitsRequest{
+		A:    a,
+		B:    b,
+		Path: path,
+	})
+	if err != nil {
+		return xerrors.Errorf("diff commits: %w", err)
+	}
+	counts := map[elton_v2.PathChangeType]int{}
+	total := 0
+	for {
+		res, err := receiver.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return xerrors.Errorf("diff commits: %w", err)
+		}
+		change := res.GetChange()
+		counts[change.GetType()]++
+		total++
+		if stat {
+			printChangeStat(change)
+		} else {
+			printChangeNameStatus(change)
+		}
+	}
+	if stat {
+		fmt.Printf("%d paths changed, %d added, %d deleted, %d modified, %d renamed\n",
+			total, counts[elton_v2.PathChangeType_Added], counts[elton_v2.PathChangeType_Deleted],
+			counts[elton_v2.PathChangeType_Modified], counts[elton_v2.PathChangeType_Renamed])
+	}
+	return nil
+}
+
+// printChangeNameStatus prints the status letter and paths like "git diff --name-status".  Changes of the mode and
+// the owner are appended to the line.
+func printChangeNameStatus(c *elton_v2.PathChange) {
+	var buff strings.Builder
+	switch c.GetType() {
+	case elton_v2.PathChangeType_Added:
+		buff.WriteString("A\t" + c.GetPath())
+	case elton_v2.PathChangeType_Deleted:
+		buff.WriteString("D\t" + c.GetPath())
+	case elton_v2.PathChangeType_Modified:
+		buff.WriteString("M\t" + c.GetPath())
+	case elton_v2.PathChangeType_Renamed:
+		buff.WriteString("R\t" + c.GetOldPath() + "\t" + c.GetPath())
+	}
+	if c.GetModeChanged() {
+		buff.WriteString(fmt.Sprintf("\tmode=%04o->%04o", c.GetOldFile().GetMode(), c.GetNewFile().GetMode()))
+	}
+	if c.GetOwnerChanged() {
+		buff.WriteString(fmt.Sprintf("\towner=%d:%d->%d:%d",
+			c.GetOldFile().GetOwner(), c.GetOldFile().GetGroup(), c.GetNewFile().GetOwner(), c.GetNewFile().GetGroup()))
+	}
+	fmt.Println(buff.String())
+}
+
+// printChangeStat prints the path and what is changed.
+func printChangeStat(c *elton_v2.PathChange) {
+	p := c.GetPath()
+	if c.GetType() == elton_v2.PathChangeType_Renamed {
+		p = c.GetOldPath() + " => " + c.GetPath()
+	}
+	var what []string
+	switch c.GetType() {
+	case elton_v2.PathChangeType_Added:
+		what = append(what, "added")
+	case elton_v2.PathChangeType_Deleted:
+		what = append(what, "deleted")
+	case elton_v2.PathChangeType_Renamed:
+		what = append(what, "renamed")
+	}
+	if c.GetContentChanged() {
+		what = append(what, "content")
+	}
+	if c.GetModeChanged() {
+		what = append(what, "mode")
+	}
+	if c.GetOwnerChanged() {
+		what = append(what, "owner")
+	}
+	fmt.Printf(" %s | %s\n", p, strings.Join(what, ", "))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
 	"golang.org/x/xerrors"
+	"io"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -40,19 +41,17 @@ func _historyInspectFn(ctx context.Context, cid *elton_v2.CommitID, files []stri
 	}
 	defer elton_v2.Close(c)
 
-	res, err := c.GetCommit(ctx, &elton_v2.GetCommitRequest{
-		Id: cid,
-	})
-	if err != nil {
-		return xerrors.Errorf("get commit: %w", err)
-	}
-	info := res.GetInfo()
-
 	if len(files) == 0 {
-		fmt.Print(dumpCommitInfo(info))
+		res, err := c.GetCommit(ctx, &elton_v2.GetCommitRequest{
+			Id: cid,
+		})
+		if err != nil {
+			return xerrors.Errorf("get commit: %w", err)
+		}
+		fmt.Print(dumpCommitInfo(res.GetInfo()))
 	} else {
 		for _, f := range files {
-			s, err := dumpFileInfo(info, f)
+			s, err := dumpFileInfo(ctx, c, cid, f)
 			if err != nil {
 				showError(err)
 				return nil
@@ -68,32 +67,33 @@ func dumpCommitInfo(info *elton_v2.CommitInfo) string {
 	buff.WriteString(fmt.Sprintf("CreatedAt: %s\n", info.GetCreatedAt().String()))
 	buff.WriteString(fmt.Sprintf("Left: %s\n", info.GetLeftParentID().ConvertString()))
 	buff.WriteString(fmt.Sprintf("Right: %s\n", info.GetRightParentID().ConvertString()))
+	if info.GetForkedFrom() != nil {
+		buff.WriteString(fmt.Sprintf("ForkedFrom: %s\n", info.GetForkedFrom().ConvertString()))
+	}
 	buff.WriteString(fmt.Sprintf("RootIno: %d\n", info.GetTree().GetRootIno()))
 	buff.WriteString(fmt.Sprintf("Inodes: %d\n", len(info.GetTree().GetInodes())))
 	return buff.String()
 }
-func dumpFileInfo(info *elton_v2.CommitInfo, fpath string) (string, error) {
-	var ino uint64
 
+// dumpFileInfo gets the file and its directory entries from the controller.  It does not download the whole tree.
+func dumpFileInfo(ctx context.Context, c elton_v2.CommitServiceClient, cid *elton_v2.CommitID, fpath string) (string, error) {
+	req := &elton_v2.StatPathRequest{Id: cid}
 	if isFilePath(fpath) {
-		var err error
-		ino, err = searchFile(info.GetTree(), fpath)
-		if err != nil {
-			return "", xerrors.Errorf("dump file info: %w", err)
-		}
+		req.Path = fpath
 	} else {
 		// fpath is not file path.  Treat fpath as inode number.
 		var err error
-		ino, err = strconv.ParseUint(fpath, 10, 64)
+		req.Ino, err = strconv.ParseUint(fpath, 10, 64)
 		if err != nil {
 			return "", xerrors.Errorf("dump file info: %w", err)
 		}
 	}
-
-	inode, ok := info.Tree.Inodes[ino]
-	if !ok {
-		return "", xerrors.Errorf("not found inode: ino=%d", ino)
+	res, err := c.StatPath(ctx, req)
+	if err != nil {
+		return "", xerrors.Errorf("dump file info: %w", err)
 	}
+	ino := res.GetIno()
+	inode := res.GetFile()
 
 	var buff strings.Builder
 	buff.WriteString(fmt.Sprintf("Ino: %d\n", ino))
@@ -108,11 +108,46 @@ func dumpFileInfo(info *elton_v2.CommitInfo, fpath string) (string, error) {
 	buff.WriteString(fmt.Sprintf("Major: %d\n", inode.GetMajor()))
 	buff.WriteString(fmt.Sprintf("Minor: %d\n", inode.GetMinor()))
 	buff.WriteString(fmt.Sprintf("Entries:\n"))
-	for name, ino := range inode.GetEntries() {
-		buff.WriteString(fmt.Sprintf("  %q => %d\n", name, ino))
+	if inode.GetFileType() == elton_v2.FileType_Directory && res.GetEntries() > 0 {
+		err := readDir(ctx, c, res.GetId(), ino, func(entry *elton_v2.ReadDirResponse) {
+			buff.WriteString(fmt.Sprintf("  %q => %d\n", entry.GetName(), entry.GetIno()))
+		})
+		if err != nil {
+			return "", xerrors.Errorf("dump file info: %w", err)
+		}
 	}
 	return buff.String(), nil
 }
+
+// readDir calls fn for each entry in the directory.  It follows pagination until all entries are received.
+func readDir(ctx context.Context, c elton_v2.CommitServiceClient, cid *elton_v2.CommitID, dirIno uint64, fn func(entry *elton_v2.ReadDirResponse)) error {
+	next := ""
+	for {
+		receiver, err := c.ReadDir(ctx, &elton_v2.ReadDirRequest{
+			Id:   cid,
+			Ino:  dirIno,
+			Next: next,
+		})
+		if err != nil {
+			return xerrors.Errorf("read dir: %w", err)
+		}
+		next = ""
+		for {
+			res, err := receiver.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return xerrors.Errorf("read dir: %w", err)
+			}
+			fn(res)
+			next = res.GetNext()
+		}
+		if next == "" {
+			return nil
+		}
+	}
+}
 func searchFile(tree *elton_v2.Tree, fpath string) (uint64, error) {
 	fpath = filepath.Clean(fpath)
 	fpath = filepath.ToSlash(fpath)
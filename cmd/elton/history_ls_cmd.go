@@ -4,27 +4,141 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/golang/protobuf/ptypes"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/spf13/cobra"
 	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
 	"golang.org/x/xerrors"
 	"io"
+	"strings"
+	"time"
 )
 
+type historyLsOptions struct {
+	ref          string
+	order        elton_v2.ListCommitsOrder
+	since        *tspb.Timestamp
+	until        *tspb.Timestamp
+	ancestorOf   *elton_v2.CommitID
+	descendantOf *elton_v2.CommitID
+	merges       elton_v2.MergeFilter
+	showParents  bool
+}
+
 func historyLsFn(cmd *cobra.Command, args []string) error {
 	if len(args) != 1 {
 		return errors.New("invalid args")
 	}
 
 	volume := args[0]
+	opts, err := parseHistoryLsOptions(cmd)
+	if err != nil {
+		showError(err)
+		return nil
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	if err := _historyLsFn(ctx, volume); err != nil {
+	if err := _historyLsFn(ctx, volume, opts); err != nil {
 		showError(err)
 	}
 	return nil
 }
-func _historyLsFn(ctx context.Context, volumeName string) error {
+func parseHistoryLsOptions(cmd *cobra.Command) (*historyLsOptions, error) {
+	opts := &historyLsOptions{}
+	var err error
+	if opts.ref, err = cmd.Flags().GetString("ref"); err != nil {
+		return nil, err
+	}
+	if opts.showParents, err = cmd.Flags().GetBool("parents"); err != nil {
+		return nil, err
+	}
+
+	order, err := cmd.Flags().GetString("order")
+	if err != nil {
+		return nil, err
+	}
+	switch order {
+	case "first-parent":
+		opts.order = elton_v2.ListCommitsOrder_FirstParent
+	case "topo":
+		opts.order = elton_v2.ListCommitsOrder_Topological
+	case "date":
+		opts.order = elton_v2.ListCommitsOrder_Date
+	default:
+		return nil, xerrors.Errorf("invalid order: %s", order)
+	}
+
+	for _, flag := range []struct {
+		name string
+		dest **tspb.Timestamp
+	}{
+		{"since", &opts.since},
+		{"until", &opts.until},
+	} {
+		value, err := cmd.Flags().GetString(flag.name)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		if *flag.dest, err = parseTimeFlag(value); err != nil {
+			return nil, xerrors.Errorf("--%s: %w", flag.name, err)
+		}
+	}
+
+	for _, flag := range []struct {
+		name string
+		dest **elton_v2.CommitID
+	}{
+		{"ancestor-of", &opts.ancestorOf},
+		{"descendant-of", &opts.descendantOf},
+	} {
+		value, err := cmd.Flags().GetString(flag.name)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		if *flag.dest, err = elton_v2.ParseCommitID(value); err != nil {
+			return nil, xerrors.Errorf("--%s: %w", flag.name, err)
+		}
+	}
+
+	mergesOnly, err := cmd.Flags().GetBool("merges")
+	if err != nil {
+		return nil, err
+	}
+	noMerges, err := cmd.Flags().GetBool("no-merges")
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case mergesOnly && noMerges:
+		return nil, xerrors.New("--merges and --no-merges are exclusive")
+	case mergesOnly:
+		opts.merges = elton_v2.MergeFilter_MergesOnly
+	case noMerges:
+		opts.merges = elton_v2.MergeFilter_NoMerges
+	}
+	return opts, nil
+}
+
+// parseTimeFlag parses RFC3339 format time or duration.  Duration means the time before now.
+func parseTimeFlag(value string) (*tspb.Timestamp, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		d, err2 := time.ParseDuration(value)
+		if err2 != nil {
+			return nil, xerrors.Errorf("invalid time or duration: %s", value)
+		}
+		t = time.Now().Add(-d)
+	}
+	return ptypes.TimestampProto(t)
+}
+func _historyLsFn(ctx context.Context, volumeName string, opts *historyLsOptions) error {
 	// Get volume ID.
 	cv, err := elton_v2.VolumeService()
 	if err != nil {
@@ -45,24 +159,48 @@ func _historyLsFn(ctx context.Context, volumeName string) error {
 	if err != nil {
 		return xerrors.Errorf("api client: %w", err)
 	}
-	receiver, err := cc.ListCommits(ctx, &elton_v2.ListCommitsRequest{
-		Id: volID,
-	})
-	if err != nil {
-		return xerrors.Errorf("list commits: %w", err)
-	}
-
-	// Print commit ID list.
+	// Print commit ID list.  Repeat until all pages are received.
+	next := ""
 	for {
-		cRes, err := receiver.Recv()
+		receiver, err := cc.ListCommits(ctx, &elton_v2.ListCommitsRequest{
+			Id:           volID,
+			Ref:          opts.ref,
+			Next:         next,
+			Order:        opts.order,
+			Since:        opts.since,
+			Until:        opts.until,
+			AncestorOf:   opts.ancestorOf,
+			DescendantOf: opts.descendantOf,
+			Merges:       opts.merges,
+		})
 		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return xerrors.Errorf("api client: %w", err)
+			return xerrors.Errorf("list commits: %w", err)
 		}
+		next = ""
+		for {
+			cRes, err := receiver.Recv()
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				return xerrors.Errorf("api client: %w", err)
+			}
 
-		fmt.Println(cRes.GetId().ConvertString())
+			if opts.showParents {
+				// Show parents like "git log --parents".
+				line := []string{cRes.GetId().ConvertString()}
+				for _, parent := range cRes.GetParents() {
+					line = append(line, parent.ConvertString())
+				}
+				fmt.Println(strings.Join(line, " "))
+			} else {
+				fmt.Println(cRes.GetId().ConvertString())
+			}
+			next = cRes.GetNext()
+		}
+		if next == "" {
+			break
+		}
 	}
 	return nil
 }
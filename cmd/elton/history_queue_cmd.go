@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+func historyQueueFn(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return errors.New("invalid args")
+	}
+
+	volume := ""
+	if len(args) == 1 {
+		volume = args[0]
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _historyQueueFn(ctx, volume); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _historyQueueFn(ctx context.Context, volumeName string) error {
+	var vid *elton_v2.VolumeID
+	if volumeName != "" {
+		cv, err := elton_v2.VolumeService()
+		if err != nil {
+			return xerrors.Errorf("api client: %w", err)
+		}
+		defer elton_v2.Close(cv)
+		vRes, err := cv.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+			Name: volumeName,
+		})
+		if err != nil {
+			return xerrors.Errorf("inspect volume: %w", err)
+		}
+		vid = vRes.GetId()
+	}
+
+	cc, err := elton_v2.CommitService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cc)
+	res, err := cc.CommitQueueStatus(ctx, &elton_v2.CommitQueueStatusRequest{
+		Id: vid,
+	})
+	if err != nil {
+		return xerrors.Errorf("commit queue status: %w", err)
+	}
+	for _, st := range res.GetQueues() {
+		fmt.Printf("%s\tdepth=%d\tmax-depth=%d\tcommits=%d\tmerges=%d\tretries=%d\tfailures=%d\tmerge-time=%s\tmax-merge-time=%s\n",
+			st.GetId().GetId(), st.GetDepth(), st.GetMaxDepth(), st.GetCommits(), st.GetMerges(), st.GetRetries(),
+			st.GetFailures(), formatDuration(st.GetTotalMergeTime()), formatDuration(st.GetMaxMergeTime()))
+	}
+	return nil
+}
+func formatDuration(d *duration.Duration) string {
+	if d == nil {
+		return "-"
+	}
+	dd, err := ptypes.Duration(d)
+	if err != nil {
+		return "-"
+	}
+	return dd.Round(time.Microsecond).String()
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"io"
+)
+
+func historyWatchFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+	var since *elton_v2.CommitID
+	if s, err := cmd.Flags().GetString("since"); err != nil {
+		showError(err)
+		return nil
+	} else if s != "" {
+		since, err = elton_v2.ParseCommitID(s)
+		if err != nil {
+			showError(err)
+			return nil
+		}
+	}
+
+	branch, err := cmd.Flags().GetString("branch")
+	if err != nil {
+		showError(err)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _historyWatchFn(ctx, volume, branch, since); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _historyWatchFn(ctx context.Context, volumeName, branch string, since *elton_v2.CommitID) error {
+	// Get volume ID.
+	cv, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cv)
+	vRes, err := cv.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+
+	cc, err := elton_v2.CommitService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cc)
+	receiver, err := cc.WatchCommits(ctx, &elton_v2.WatchCommitsRequest{
+		Id:     vRes.GetId(),
+		Since:  since,
+		Branch: branch,
+	})
+	if err != nil {
+		return xerrors.Errorf("watch commits: %w", err)
+	}
+	// Print new commit ID until the stream is closed.
+	for {
+		res, err := receiver.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("watch commits: %w", err)
+		}
+		fmt.Println(res.GetId().ConvertString())
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/golang/protobuf/ptypes"
 	tspb "github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/spf13/cobra"
@@ -36,14 +37,19 @@ func importFn(cmd *cobra.Command, args []string) error {
 		showError(err)
 		return nil
 	}
+	noMerge, err := cmd.Flags().GetBool("no-merge")
+	if err != nil {
+		showError(err)
+		return nil
+	}
 
-	if err := _importFn(ctx, cid, base, files); err != nil {
+	if err := _importFn(ctx, cid, base, files, noMerge); err != nil {
 		showError(err)
 	}
 	return nil
 }
 
-func _importFn(ctx context.Context, cid *elton_v2.CommitID, base string, files []string) error {
+func _importFn(ctx context.Context, cid *elton_v2.CommitID, base string, files []string, noMerge bool) error {
 	c, err := elton_v2.CommitService()
 	if err != nil {
 		return xerrors.Errorf("api client: %w", err)
@@ -62,6 +68,8 @@ func _importFn(ctx context.Context, cid *elton_v2.CommitID, base string, files [
 		return xerrors.Errorf("get commit: %w", err)
 	}
 
+	// The commit may be specified by ref name.  Should use resolved CommitID as a parent.
+	parent := res.GetId()
 	tree := res.GetInfo().GetTree()
 	builder := newTreeBuilder(sc, tree)
 	filesCh := make(chan string, 10)
@@ -101,12 +109,17 @@ func _importFn(ctx context.Context, cid *elton_v2.CommitID, base string, files [
 	_, err = c.Commit(ctx, &elton_v2.CommitRequest{
 		Info: &elton_v2.CommitInfo{
 			CreatedAt:    ptypes.TimestampNow(),
-			LeftParentID: cid,
+			LeftParentID: parent,
 			Tree:         tree,
 		},
-		Id: cid.GetId(),
+		Id:      cid.GetId(),
+		Branch:  cid.GetRef(),
+		NoMerge: noMerge,
 	})
 	if err != nil {
+		if conflicts := elton_v2.CommitConflictsOf(err); conflicts != nil {
+			printCommitConflicts(conflicts)
+		}
 		return xerrors.Errorf("commit: %w", err)
 	}
 	return nil
@@ -410,3 +423,17 @@ func mustConvertTimestmap(timespec unix.Timespec) *tspb.Timestamp {
 	}
 	return ts
 }
+
+// printCommitConflicts shows paths conflicted by the commit.
+func printCommitConflicts(conflicts *elton_v2.CommitConflicts) {
+	for _, c := range conflicts.GetConflicts() {
+		fmt.Fprintf(os.Stderr, "CONFLICT(%s)\tlatest=%s\tcurrent=%s\n",
+			c.GetType(), formatConflictPaths(c.GetLatestPaths()), formatConflictPaths(c.GetCurrentPaths()))
+	}
+}
+func formatConflictPaths(paths []string) string {
+	if len(paths) == 0 {
+		return "<deleted>"
+	}
+	return strings.Join(paths, ",")
+}
@@ -23,6 +23,46 @@ var volumeCreateCmd = &cobra.Command{
 	Short: "Create a volume",
 	RunE:  volumeCreateFn,
 }
+var volumeExportCmd = &cobra.Command{
+	Use:   "export VOLUME FILE",
+	Short: "Export a volume with its history to the bundle file",
+	RunE:  volumeExportFn,
+}
+var volumeImportCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Import a volume from the bundle file",
+	RunE:  volumeImportFn,
+}
+var volumeRetentionCmd = &cobra.Command{
+	Use:   "retention VOLUME",
+	Short: "Set retention policy of commits",
+	RunE:  volumeRetentionFn,
+}
+var volumePruneCmd = &cobra.Command{
+	Use:   "prune VOLUME",
+	Short: "Delete expired commits based on the retention policy",
+	RunE:  volumePruneFn,
+}
+var volumeForkCmd = &cobra.Command{
+	Use:   "fork SRC_COMMIT NEW_NAME",
+	Short: "Create a volume from the commit without copying files",
+	RunE:  volumeForkFn,
+}
+var volumeFsckCmd = &cobra.Command{
+	Use:   "fsck VOLUME",
+	Short: "Check consistency of all commits in the volume",
+	RunE:  volumeFsckFn,
+}
+var volumeInspectCmd = &cobra.Command{
+	Use:   "inspect VOLUME",
+	Short: "Show volume metadata",
+	RunE:  volumeInspectFn,
+}
+var volumeUpdateCmd = &cobra.Command{
+	Use:   "update VOLUME",
+	Short: "Update volume metadata",
+	RunE:  volumeUpdateFn,
+}
 var debugCmd = &cobra.Command{
 	Use:   "debug",
 	Short: "Debug utilities",
@@ -45,6 +85,111 @@ var historyInspectCmd = &cobra.Command{
 	Short: "Show commit info or file info",
 	RunE:  historyInspectFn,
 }
+var historyWatchCmd = &cobra.Command{
+	Use:   "watch VOLUME",
+	Short: "Print the latest commit whenever it changes",
+	RunE:  historyWatchFn,
+}
+var historyDiffCmd = &cobra.Command{
+	Use:   "diff A B [PATH]",
+	Short: "Show changes between two commits",
+	RunE:  historyDiffFn,
+}
+var historyQueueCmd = &cobra.Command{
+	Use:   "queue [VOLUME]",
+	Short: "Show the state of commit queues",
+	RunE:  historyQueueFn,
+}
+var refCmd = &cobra.Command{
+	Use:   "ref",
+	Short: "Manage branches and tags",
+}
+var refLsCmd = &cobra.Command{
+	Use:   "ls VOLUME",
+	Short: "List refs",
+	RunE:  refLsFn,
+}
+var refCreateCmd = &cobra.Command{
+	Use:   "create VOLUME NAME COMMIT",
+	Short: "Create a branch or a tag",
+	RunE:  refCreateFn,
+}
+var refMoveCmd = &cobra.Command{
+	Use:   "move VOLUME NAME COMMIT",
+	Short: "Move a branch to the commit",
+	RunE:  refMoveFn,
+}
+var refUpdateCmd = &cobra.Command{
+	Use:   "update VOLUME EXPECTED NEW",
+	Short: "Update the latest commit or a branch only if it points to EXPECTED",
+	RunE:  refUpdateFn,
+}
+var refRmCmd = &cobra.Command{
+	Use:   "rm VOLUME NAMES...",
+	Short: "Delete refs",
+	RunE:  refRmFn,
+}
+var metaCmd = &cobra.Command{
+	Use:   "meta",
+	Short: "Manage cluster-wide properties",
+}
+var metaGetCmd = &cobra.Command{
+	Use:   "get KEY",
+	Short: "Show the property value",
+	RunE:  metaGetFn,
+}
+var metaSetCmd = &cobra.Command{
+	Use:   "set KEY VALUE",
+	Short: "Set the property value",
+	RunE:  metaSetFn,
+}
+var metaLsCmd = &cobra.Command{
+	Use:   "ls [PREFIX]",
+	Short: "List properties",
+	RunE:  metaLsFn,
+}
+var metaRmCmd = &cobra.Command{
+	Use:   "rm KEYS...",
+	Short: "Delete properties",
+	RunE:  metaRmFn,
+}
+var nodeCmd = &cobra.Command{
+	Use:   "node",
+	Short: "Manage nodes in the cluster",
+}
+var nodeLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List nodes with their status and capacity",
+	RunE:  nodeLsFn,
+}
+var nodeDrainCmd = &cobra.Command{
+	Use:   "drain NODE_ID",
+	Short: "Copy objects on the node to other storage nodes",
+	RunE:  nodeDrainFn,
+}
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Manage objects on storage nodes",
+}
+var storageRebalanceCmd = &cobra.Command{
+	Use:   "rebalance",
+	Short: "Manage the background rebalancer",
+}
+var storageRebalanceStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show progress of rebalancing and usage of storage nodes",
+	RunE:  storageRebalanceStatusFn,
+}
+var storageRebalancePauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Stop moving objects until resumed",
+	RunE:  storageRebalancePauseFn,
+}
+var storageRebalanceResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume the paused rebalancer",
+	RunE:  storageRebalanceResumeFn,
+}
 var importCmd = &cobra.Command{
 	Use:   "import CID BASE_DIR [FILES...]",
 	Short: "Import files to specified directory",
@@ -52,10 +197,48 @@ var importCmd = &cobra.Command{
 }
 
 func init() {
-	volumeCmd.AddCommand(volumeLsCmd, volumeCreateCmd)
+	volumeLsCmd.Flags().StringP("selector", "l", "", "Filter volumes by label selector (e.g. env=prod,!temp)")
+	volumeUpdateCmd.Flags().String("name", "", "Rename the volume")
+	volumeUpdateCmd.Flags().String("description", "", "Set description")
+	volumeUpdateCmd.Flags().String("owner", "", "Set owner")
+	volumeUpdateCmd.Flags().StringToString("label", nil, "Add or update labels (KEY=VALUE)")
+	volumeUpdateCmd.Flags().StringSlice("remove-label", nil, "Remove labels by key")
+	volumeUpdateCmd.Flags().String("merge-policy", "", "How to resolve conflicts of concurrent commits (reject, last-writer-wins, current-wins, latest-wins or keep-both)")
+	volumeExportCmd.Flags().String("base", "", "Export only commits after the base commit")
+	volumeRetentionCmd.Flags().Uint32("keep-last", 0, "Keep the last N commits")
+	volumeRetentionCmd.Flags().Uint32("keep-daily", 0, "Keep the newest commit of each day for the last N days")
+	volumeRetentionCmd.Flags().Duration("keep-within", 0, "Keep commits younger than the duration")
+	volumePruneCmd.Flags().Bool("dry-run", false, "Show commits to be deleted without deleting them")
+	refCreateCmd.Flags().Bool("tag", false, "Create a tag instead of a branch")
+	refUpdateCmd.Flags().String("branch", "", "Update the branch instead of the latest commit")
+	metaSetCmd.Flags().Bool("allow-replace", false, "Allow to replace or delete the property later")
+	metaSetCmd.Flags().Bool("must-create", false, "Fail if the property already exists")
+	metaSetCmd.Flags().Duration("ttl", 0, "Delete the property after the duration")
+	importCmd.Flags().Bool("no-merge", false, "Fail instead of merging if the commit is not based on the latest commit")
+	historyLsCmd.Flags().String("ref", "", "Show commits reachable from the ref")
+	historyLsCmd.Flags().String("order", "first-parent", "Traversal order (first-parent, topo or date).  topo and date also follow right parents of merge commits")
+	historyLsCmd.Flags().String("since", "", "Show commits created after the time (RFC3339 or duration like 24h)")
+	historyLsCmd.Flags().String("until", "", "Show commits created before the time (RFC3339 or duration like 24h)")
+	historyLsCmd.Flags().String("ancestor-of", "", "Show commits reachable from the commit")
+	historyLsCmd.Flags().String("descendant-of", "", "Show commits that have the commit as an ancestor")
+	historyLsCmd.Flags().Bool("merges", false, "Show only merge commits")
+	historyLsCmd.Flags().Bool("no-merges", false, "Do not show merge commits")
+	historyLsCmd.Flags().Bool("parents", false, "Show parent commits")
+	historyWatchCmd.Flags().String("since", "", "Skip commits until newer than the commit")
+	historyWatchCmd.Flags().String("branch", "", "Watch the branch instead of the latest commit")
+	historyDiffCmd.Flags().Bool("name-status", false, "Show the status and paths of changed files (default)")
+	historyDiffCmd.Flags().Bool("stat", false, "Show what is changed in each path and the summary")
+	volumeCmd.AddCommand(volumeLsCmd, volumeCreateCmd, volumeExportCmd, volumeImportCmd, volumeRetentionCmd, volumePruneCmd, volumeForkCmd, volumeInspectCmd, volumeUpdateCmd, volumeFsckCmd)
 	debugCmd.AddCommand(debugDumpObjCmd)
-	historyCmd.AddCommand(historyLsCmd, historyInspectCmd)
-	rootCmd.AddCommand(volumeCmd, debugCmd, historyCmd, importCmd)
+	historyCmd.AddCommand(historyLsCmd, historyInspectCmd, historyWatchCmd, historyDiffCmd, historyQueueCmd)
+	refCmd.AddCommand(refLsCmd, refCreateCmd, refMoveCmd, refUpdateCmd, refRmCmd)
+	metaCmd.AddCommand(metaGetCmd, metaSetCmd, metaLsCmd, metaRmCmd)
+	nodeDrainCmd.Flags().Bool("unregister", false, "Unregister the node after all objects are copied")
+	nodeDrainCmd.Flags().Bool("wait", false, "Show progress until draining is finished")
+	nodeCmd.AddCommand(nodeLsCmd, nodeDrainCmd)
+	storageRebalanceCmd.AddCommand(storageRebalanceStatusCmd, storageRebalancePauseCmd, storageRebalanceResumeCmd)
+	storageCmd.AddCommand(storageRebalanceCmd)
+	rootCmd.AddCommand(volumeCmd, debugCmd, historyCmd, refCmd, metaCmd, nodeCmd, storageCmd, importCmd)
 }
 func main() {
 	os.Exit(Main())
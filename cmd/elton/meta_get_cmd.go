@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+func metaGetFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+
+	key := args[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _metaGetFn(ctx, key); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _metaGetFn(ctx context.Context, key string) error {
+	c, err := elton_v2.MetaService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+	res, err := c.GetMeta(ctx, &elton_v2.GetMetaRequest{
+		Key: &elton_v2.PropertyID{Id: key},
+	})
+	if err != nil {
+		return xerrors.Errorf("get meta: %w", err)
+	}
+	fmt.Println(res.GetBody().GetBody())
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"io"
+	"time"
+)
+
+func metaLsFn(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return errors.New("invalid args")
+	}
+
+	prefix := ""
+	if len(args) == 1 {
+		prefix = args[0]
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _metaLsFn(ctx, prefix); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _metaLsFn(ctx context.Context, prefix string) error {
+	c, err := elton_v2.MetaService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+	receiver, err := c.ListMeta(ctx, &elton_v2.ListMetaRequest{
+		Prefix: prefix,
+	})
+	if err != nil {
+		return xerrors.Errorf("list meta: %w", err)
+	}
+
+	// Print properties.
+	for {
+		res, err := receiver.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return xerrors.Errorf("api client: %w", err)
+		}
+
+		expireAt := "-"
+		if res.GetBody().GetExpireAt() != nil {
+			if t, err := ptypes.Timestamp(res.GetBody().GetExpireAt()); err == nil {
+				expireAt = t.Local().Format(time.RFC3339)
+			}
+		}
+		fmt.Printf("%s\t%q\t%s\n", res.GetKey().GetId(), res.GetBody().GetBody(), expireAt)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+func metaRmFn(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("invalid args")
+	}
+
+	keys := args
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _metaRmFn(ctx, keys); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _metaRmFn(ctx context.Context, keys []string) error {
+	c, err := elton_v2.MetaService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+	for _, key := range keys {
+		_, err = c.DeleteMeta(ctx, &elton_v2.DeleteMetaRequest{
+			Key: &elton_v2.PropertyID{Id: key},
+		})
+		if err != nil {
+			return xerrors.Errorf("delete meta(%s): %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type metaSetOptions struct {
+	allowReplace bool
+	mustCreate   bool
+	ttl          time.Duration
+}
+
+func metaSetFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("invalid args")
+	}
+
+	key := args[0]
+	value := args[1]
+	opts := &metaSetOptions{}
+	var err error
+	if opts.allowReplace, err = cmd.Flags().GetBool("allow-replace"); err != nil {
+		showError(err)
+		return nil
+	}
+	if opts.mustCreate, err = cmd.Flags().GetBool("must-create"); err != nil {
+		showError(err)
+		return nil
+	}
+	if opts.ttl, err = cmd.Flags().GetDuration("ttl"); err != nil {
+		showError(err)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _metaSetFn(ctx, key, value, opts); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _metaSetFn(ctx context.Context, key, value string, opts *metaSetOptions) error {
+	c, err := elton_v2.MetaService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+	req := &elton_v2.SetMetaRequest{
+		Key: &elton_v2.PropertyID{Id: key},
+		Body: &elton_v2.Property{
+			Body:         value,
+			AllowReplace: opts.allowReplace,
+		},
+		MustCreate: opts.mustCreate,
+	}
+	if opts.ttl != 0 {
+		req.Ttl = ptypes.DurationProto(opts.ttl)
+	}
+	_, err = c.SetMeta(ctx, req)
+	if err != nil {
+		return xerrors.Errorf("set meta: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"io"
+	"time"
+)
+
+// drainPollInterval is the interval of showing progress when --wait is specified.
+const drainPollInterval = time.Second
+
+func nodeDrainFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+
+	id := &elton_v2.NodeID{Id: args[0]}
+	unregister, err := cmd.Flags().GetBool("unregister")
+	if err != nil {
+		return err
+	}
+	wait, err := cmd.Flags().GetBool("wait")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _nodeDrainFn(ctx, id, unregister, wait); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _nodeDrainFn(ctx context.Context, id *elton_v2.NodeID, unregister, wait bool) error {
+	c, err := elton_v2.NodeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+
+	res, err := c.DrainNode(ctx, &elton_v2.DrainNodeRequest{
+		Id:         id,
+		Unregister: unregister,
+	})
+	if err != nil {
+		return xerrors.Errorf("drain node: %w", err)
+	}
+	printDrainStatus(res.GetStatus())
+	if !wait {
+		return nil
+	}
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		st, found, err := getDrainStatus(ctx, c, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			fmt.Println("unregistered")
+			return nil
+		}
+		printDrainStatus(st)
+		if st.GetPhase() == elton_v2.DrainPhase_Drained {
+			return nil
+		}
+	}
+	return nil
+}
+func getDrainStatus(ctx context.Context, c elton_v2.NodeServiceClient, id *elton_v2.NodeID) (st *elton_v2.DrainStatus, found bool, err error) {
+	receiver, err := c.ListNodes(ctx, &elton_v2.ListNodesRequest{})
+	if err != nil {
+		return nil, false, xerrors.Errorf("list nodes: %w", err)
+	}
+	for {
+		res, err := receiver.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return st, found, nil
+			}
+			return nil, false, xerrors.Errorf("api client: %w", err)
+		}
+		if res.GetId().GetId() == id.GetId() {
+			st = res.GetNode().GetDrain()
+			found = true
+		}
+	}
+}
+func printDrainStatus(st *elton_v2.DrainStatus) {
+	fmt.Printf("%s\tobjects=%d\tbytes=%d", st.GetPhase(), st.GetCopiedObjects(), st.GetCopiedBytes())
+	if st.GetError() != "" {
+		fmt.Printf("\terror=%s", st.GetError())
+	}
+	fmt.Println()
+}
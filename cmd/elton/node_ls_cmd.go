@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"io"
+	"sort"
+	"strings"
+)
+
+func nodeLsFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 0 {
+		return errors.New("invalid args")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _nodeLsFn(ctx); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _nodeLsFn(ctx context.Context) error {
+	c, err := elton_v2.NodeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+	receiver, err := c.ListNodes(ctx, &elton_v2.ListNodesRequest{})
+	if err != nil {
+		return xerrors.Errorf("list nodes: %w", err)
+	}
+
+	var nodes []*elton_v2.ListNodesResponse
+	for {
+		res, err := receiver.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return xerrors.Errorf("api client: %w", err)
+		}
+		nodes = append(nodes, res)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].GetNode().GetName() < nodes[j].GetNode().GetName()
+	})
+
+	// Print nodes.
+	fmt.Println("ID\tNAME\tLIVENESS\tDRAIN\tROLES\tADDRESS\tFREE\tTOTAL\tOBJECTS\tVERSION\tLABELS")
+	for _, res := range nodes {
+		node := res.GetNode()
+		fmt.Printf(
+			"%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%s\t%s\n",
+			res.GetId().GetId(),
+			node.GetName(),
+			res.GetLiveness(),
+			node.GetDrain().GetPhase(),
+			strings.Join(node.GetRoles(), ","),
+			strings.Join(node.GetAddress(), ","),
+			node.GetCapacity().GetFreeBytes(),
+			node.GetCapacity().GetTotalBytes(),
+			node.GetCapacity().GetObjects(),
+			node.GetVersion(),
+			formatNodeLabels(node.GetLabels()),
+		)
+	}
+	return nil
+}
+func formatNodeLabels(labels map[string]string) string {
+	var items []string
+	for k, v := range labels {
+		items = append(items, k+"="+v)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+func refCreateFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 3 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+	name := args[1]
+	cid, err := elton_v2.ParseCommitID(args[2])
+	if err != nil {
+		showError(err)
+		return nil
+	}
+	tag, err := cmd.Flags().GetBool("tag")
+	if err != nil {
+		return err
+	}
+	refType := elton_v2.RefType_Branch
+	if tag {
+		refType = elton_v2.RefType_Tag
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _refCreateFn(ctx, volume, name, &elton_v2.Ref{Type: refType, Commit: cid}); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _refCreateFn(ctx context.Context, volumeName, name string, ref *elton_v2.Ref) error {
+	cv, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cv)
+	vRes, err := cv.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+
+	cc, err := elton_v2.CommitService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cc)
+	_, err = cc.CreateRef(ctx, &elton_v2.CreateRefRequest{
+		Id:  &elton_v2.RefID{Id: vRes.GetId(), Name: name},
+		Ref: ref,
+	})
+	if err != nil {
+		return xerrors.Errorf("create ref: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"io"
+)
+
+func refLsFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _refLsFn(ctx, volume); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _refLsFn(ctx context.Context, volumeName string) error {
+	// Get volume ID.
+	cv, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cv)
+	vRes, err := cv.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+
+	cc, err := elton_v2.CommitService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cc)
+	receiver, err := cc.ListRefs(ctx, &elton_v2.ListRefsRequest{
+		Id: vRes.GetId(),
+	})
+	if err != nil {
+		return xerrors.Errorf("list refs: %w", err)
+	}
+
+	// Print refs.
+	for {
+		res, err := receiver.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return xerrors.Errorf("api client: %w", err)
+		}
+
+		fmt.Printf("%s\t%s\t%s\n", res.GetId().GetName(), res.GetRef().GetType(), res.GetRef().GetCommit().ConvertString())
+	}
+	return nil
+}
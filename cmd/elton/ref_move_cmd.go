@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+func refMoveFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 3 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+	name := args[1]
+	cid, err := elton_v2.ParseCommitID(args[2])
+	if err != nil {
+		showError(err)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _refMoveFn(ctx, volume, name, cid); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _refMoveFn(ctx context.Context, volumeName, name string, cid *elton_v2.CommitID) error {
+	cv, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cv)
+	vRes, err := cv.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+
+	cc, err := elton_v2.CommitService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cc)
+	_, err = cc.MoveRef(ctx, &elton_v2.MoveRefRequest{
+		Id:     &elton_v2.RefID{Id: vRes.GetId(), Name: name},
+		Commit: cid,
+	})
+	if err != nil {
+		return xerrors.Errorf("move ref: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+func refRmFn(cmd *cobra.Command, args []string) error {
+	if len(args) < 2 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+	names := args[1:]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _refRmFn(ctx, volume, names); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _refRmFn(ctx context.Context, volumeName string, names []string) error {
+	cv, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cv)
+	vRes, err := cv.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+
+	cc, err := elton_v2.CommitService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cc)
+	for _, name := range names {
+		_, err = cc.DeleteRef(ctx, &elton_v2.DeleteRefRequest{
+			Id: &elton_v2.RefID{Id: vRes.GetId(), Name: name},
+		})
+		if err != nil {
+			return xerrors.Errorf("delete ref(%s): %w", name, err)
+		}
+	}
+	return nil
+}
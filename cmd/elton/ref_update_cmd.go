@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+func refUpdateFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 3 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+	expected, err := elton_v2.ParseCommitID(args[1])
+	if err != nil {
+		showError(err)
+		return nil
+	}
+	cid, err := elton_v2.ParseCommitID(args[2])
+	if err != nil {
+		showError(err)
+		return nil
+	}
+	branch, err := cmd.Flags().GetString("branch")
+	if err != nil {
+		showError(err)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _refUpdateFn(ctx, volume, branch, expected, cid); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _refUpdateFn(ctx context.Context, volumeName, branch string, expected, cid *elton_v2.CommitID) error {
+	cv, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cv)
+	vRes, err := cv.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+
+	cc, err := elton_v2.CommitService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cc)
+	_, err = cc.UpdateRef(ctx, &elton_v2.UpdateRefRequest{
+		Id:       vRes.GetId(),
+		Branch:   branch,
+		Expected: expected,
+		New:      cid,
+	})
+	if err != nil {
+		return xerrors.Errorf("update ref: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+func storageRebalanceStatusFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 0 {
+		return errors.New("invalid args")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _storageRebalanceStatusFn(ctx); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _storageRebalanceStatusFn(ctx context.Context) error {
+	c, err := elton_v2.NodeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+
+	res, err := c.RebalanceStatus(ctx, &elton_v2.RebalanceStatusRequest{})
+	if err != nil {
+		return xerrors.Errorf("rebalance status: %w", err)
+	}
+	printRebalanceStatus(res.GetStatus())
+	return nil
+}
+
+func storageRebalancePauseFn(cmd *cobra.Command, args []string) error {
+	return setRebalancePaused(args, true)
+}
+func storageRebalanceResumeFn(cmd *cobra.Command, args []string) error {
+	return setRebalancePaused(args, false)
+}
+func setRebalancePaused(args []string, paused bool) error {
+	if len(args) != 0 {
+		return errors.New("invalid args")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _setRebalancePaused(ctx, paused); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _setRebalancePaused(ctx context.Context, paused bool) error {
+	c, err := elton_v2.NodeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+
+	res, err := c.PauseRebalance(ctx, &elton_v2.PauseRebalanceRequest{
+		Paused: paused,
+	})
+	if err != nil {
+		return xerrors.Errorf("pause rebalance: %w", err)
+	}
+	printRebalanceStatus(res.GetStatus())
+	return nil
+}
+
+func printRebalanceStatus(st *elton_v2.RebalanceStatus) {
+	state := "running"
+	if st.GetPaused() {
+		state = "paused"
+	}
+	lastRun := "-"
+	if st.GetLastRunAt() != nil {
+		if t, err := ptypes.Timestamp(st.GetLastRunAt()); err == nil {
+			lastRun = t.Local().Format(time.RFC3339)
+		}
+	}
+	fmt.Printf("%s\tbalanced=%t\tobjects=%d\tbytes=%d\ttarget=%.1f%%\tlast-run=%s",
+		state, st.GetBalanced(), st.GetMovedObjects(), st.GetMovedBytes(), st.GetTargetUsage()*100, lastRun)
+	if st.GetError() != "" {
+		fmt.Printf("\terror=%s", st.GetError())
+	}
+	fmt.Println()
+	for _, n := range st.GetNodes() {
+		fmt.Printf("%s\t%.1f%%\n", n.GetId().GetId(), n.GetUsage()*100)
+	}
+}
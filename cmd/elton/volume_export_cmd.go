@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"io"
+	"os"
+	"sort"
+)
+
+func volumeExportFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+	file := args[1]
+	strBase, err := cmd.Flags().GetString("base")
+	if err != nil {
+		return err
+	}
+	var base *elton_v2.CommitID
+	if strBase != "" {
+		base, err = elton_v2.ParseCommitID(strBase)
+		if err != nil {
+			showError(err)
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _volumeExportFn(ctx, volume, file, base); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _volumeExportFn(ctx context.Context, volumeName string, file string, base *elton_v2.CommitID) error {
+	cv, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cv)
+	cc, err := elton_v2.CommitService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cc)
+	sc, err := elton_v2.StorageService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(sc)
+
+	vRes, err := cv.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+	volID := vRes.GetId()
+	if base != nil && !base.GetId().Equals(volID) {
+		return xerrors.Errorf("base commit is not in the volume: %s", base.ConvertString())
+	}
+	cRes, err := cc.GetLastCommit(ctx, &elton_v2.GetLastCommitRequest{
+		VolumeId: volID,
+	})
+	if err != nil {
+		return xerrors.Errorf("get last commit: %w", err)
+	}
+	head := cRes.GetId()
+
+	// Collect commits and objects to export.
+	walker := newHistoryWalker(cc)
+	if err := walker.Walk(ctx, head); err != nil {
+		return xerrors.Errorf("walk: %w", err)
+	}
+	all := walker.Ancestors(head)
+	var excluded map[string]struct{}
+	if base != nil {
+		if _, ok := all[base.ConvertString()]; !ok {
+			return xerrors.Errorf("base commit is not an ancestor of the latest commit: %s", base.ConvertString())
+		}
+		excluded = walker.Ancestors(base)
+	}
+	commits := map[string]struct{}{}
+	for key := range all {
+		if _, ok := excluded[key]; !ok {
+			commits[key] = struct{}{}
+		}
+	}
+	objects := walker.Objects(commits)
+	for key := range walker.Objects(excluded) {
+		delete(objects, key)
+	}
+
+	manifest := &bundleManifest{
+		Format:   bundleFormat,
+		Version:  bundleVersion,
+		VolumeID: volID.GetId(),
+		Head:     head.ConvertString(),
+		Base:     "",
+		Commits:  []string{},
+		Objects:  []string{},
+	}
+	if base != nil {
+		manifest.Base = base.ConvertString()
+	}
+	var cids []*elton_v2.CommitID
+	for _, cid := range walker.Commits() {
+		if _, ok := commits[cid.ConvertString()]; ok {
+			cids = append(cids, cid)
+			manifest.Commits = append(manifest.Commits, cid.ConvertString())
+		}
+	}
+	for key := range objects {
+		manifest.Objects = append(manifest.Objects, key)
+	}
+	sort.Strings(manifest.Objects)
+
+	// Write the bundle.
+	var out io.Writer
+	if file == "-" {
+		out = os.Stdout
+	} else {
+		f, err := os.Create(file)
+		if err != nil {
+			return xerrors.Errorf("create file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	buf := bufio.NewWriter(out)
+	w := newBundleWriter(buf)
+	if err := w.WriteManifest(manifest); err != nil {
+		return err
+	}
+	if err := w.WriteVolume(vRes.GetInfo()); err != nil {
+		return err
+	}
+	for _, key := range manifest.Objects {
+		res, err := sc.GetObject(ctx, &elton_v2.GetObjectRequest{
+			Key: &elton_v2.ObjectKey{
+				Id: key,
+			},
+		})
+		if err != nil {
+			return xerrors.Errorf("get object(%s): %w", key, err)
+		}
+		if err := w.WriteObject(key, res.GetBody().GetContents(), res.GetInfo()); err != nil {
+			return err
+		}
+	}
+	for _, cid := range cids {
+		if err := w.WriteCommit(cid, walker.commits[cid.ConvertString()]); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return xerrors.Errorf("close bundle: %w", err)
+	}
+	if err := buf.Flush(); err != nil {
+		return xerrors.Errorf("flush: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+func volumeForkFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("invalid args")
+	}
+
+	src, err := elton_v2.ParseCommitID(args[0])
+	if err != nil {
+		showError(err)
+		return nil
+	}
+	name := args[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _volumeForkFn(ctx, src, name); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _volumeForkFn(ctx context.Context, src *elton_v2.CommitID, name string) error {
+	c, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+
+	res, err := c.ForkVolume(ctx, &elton_v2.ForkVolumeRequest{
+		Src: src,
+		Info: &elton_v2.VolumeInfo{
+			Name: name,
+		},
+	})
+	if err != nil {
+		return xerrors.Errorf("fork volume: %w", err)
+	}
+	// Show volume ID.
+	fmt.Println(res.GetId())
+	return nil
+}
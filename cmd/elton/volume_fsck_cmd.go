@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+func volumeFsckFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _volumeFsckFn(ctx, args[0]); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _volumeFsckFn(ctx context.Context, volumeName string) error {
+	c, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+
+	vRes, err := c.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+	res, err := c.FsckVolume(ctx, &elton_v2.FsckVolumeRequest{
+		Id: vRes.GetId(),
+	})
+	if err != nil {
+		return xerrors.Errorf("fsck volume: %w", err)
+	}
+
+	// Show problems of damaged commits.
+	for _, r := range res.GetDamaged() {
+		for _, p := range r.GetProblems() {
+			fmt.Printf("%s\t%s\n", r.GetId().ConvertString(), p)
+		}
+	}
+	if len(res.GetDamaged()) > 0 {
+		return xerrors.Errorf("%d of %d commits are damaged", len(res.GetDamaged()), res.GetCheckedCommits())
+	}
+	fmt.Printf("%d commits are checked, no problems found\n", res.GetCheckedCommits())
+	return nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"os"
+)
+
+func volumeImportFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+
+	file := args[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _volumeImportFn(ctx, file); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _volumeImportFn(ctx context.Context, file string) error {
+	var in io.Reader
+	if file == "-" {
+		in = os.Stdin
+	} else {
+		f, err := os.Open(file)
+		if err != nil {
+			return xerrors.Errorf("open file: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	r := newBundleReader(bufio.NewReader(in))
+
+	manifest, err := r.ReadManifest()
+	if err != nil {
+		return xerrors.Errorf("read bundle: %w", err)
+	}
+	volInfo, err := r.ReadVolume()
+	if err != nil {
+		return xerrors.Errorf("read bundle: %w", err)
+	}
+	volID := &elton_v2.VolumeID{
+		Id: manifest.VolumeID,
+	}
+
+	cv, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cv)
+	cc, err := elton_v2.CommitService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cc)
+	sc, err := elton_v2.StorageService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(sc)
+
+	if manifest.Base == "" {
+		// Volume may already exist when retrying the failed import.  Remaining commits are imported in that case.
+		_, err = cv.ImportVolume(ctx, &elton_v2.ImportVolumeRequest{
+			Id:   volID,
+			Info: volInfo,
+		})
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			return xerrors.Errorf("import volume: %w", err)
+		}
+	} else {
+		// Incremental bundle requires the base commit on this cluster.
+		base, err := elton_v2.ParseCommitID(manifest.Base)
+		if err != nil {
+			return xerrors.Errorf("invalid manifest: %w", err)
+		}
+		_, err = cc.GetCommit(ctx, &elton_v2.GetCommitRequest{
+			Id: base,
+		})
+		if err != nil {
+			return xerrors.Errorf("base commit(%s): %w", manifest.Base, err)
+		}
+	}
+
+	for _, key := range manifest.Objects {
+		body, err := r.ReadObject(key)
+		if err != nil {
+			return xerrors.Errorf("read bundle: %w", err)
+		}
+		_, err = sc.CreateObject(ctx, &elton_v2.CreateObjectRequest{
+			Body: &elton_v2.ObjectBody{
+				Contents: body,
+			},
+			Key: &elton_v2.ObjectKey{
+				Id: key,
+			},
+		})
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			return xerrors.Errorf("create object(%s): %w", key, err)
+		}
+	}
+
+	imported := 0
+	for _, s := range manifest.Commits {
+		cid, err := elton_v2.ParseCommitID(s)
+		if err != nil {
+			return xerrors.Errorf("invalid manifest: %w", err)
+		}
+		info, err := r.ReadCommit(cid)
+		if err != nil {
+			return xerrors.Errorf("read bundle: %w", err)
+		}
+		_, err = cc.ImportCommit(ctx, &elton_v2.ImportCommitRequest{
+			Id:   cid,
+			Info: info,
+		})
+		if err != nil {
+			if status.Code(err) == codes.AlreadyExists {
+				continue
+			}
+			return xerrors.Errorf("import commit(%s): %w", s, err)
+		}
+		imported++
+	}
+
+	// The head of the volume should be the head of the bundle.  Otherwise, the volume has commits that are not
+	// contained in the bundle, or the bundle lacks commits.
+	if manifest.Head != "" {
+		head, err := elton_v2.ParseCommitID(manifest.Head)
+		if err != nil {
+			return xerrors.Errorf("invalid manifest: %w", err)
+		}
+		res, err := cc.GetLastCommit(ctx, &elton_v2.GetLastCommitRequest{
+			VolumeId: volID,
+		})
+		if err != nil {
+			return xerrors.Errorf("get last commit: %w", err)
+		}
+		if !res.GetId().Equals(head) {
+			return xerrors.Errorf("head mismatch: volume=%s bundle=%s", res.GetId().ConvertString(), manifest.Head)
+		}
+	}
+	fmt.Printf("%s: imported %d commits (%d objects)\n", volID.GetId(), imported, len(manifest.Objects))
+	return nil
+}
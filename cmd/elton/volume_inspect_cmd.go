@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"sort"
+	"strings"
+)
+
+func volumeInspectFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _volumeInspectFn(ctx, volume); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _volumeInspectFn(ctx context.Context, volumeName string) error {
+	c, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+
+	res, err := c.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+	fmt.Print(dumpVolumeInfo(res.GetId(), res.GetInfo()))
+	return nil
+}
+
+func dumpVolumeInfo(id *elton_v2.VolumeID, info *elton_v2.VolumeInfo) string {
+	var buff strings.Builder
+	buff.WriteString(fmt.Sprintf("ID: %s\n", id.GetId()))
+	buff.WriteString(fmt.Sprintf("Name: %s\n", info.GetName()))
+	buff.WriteString(fmt.Sprintf("Description: %s\n", info.GetDescription()))
+	buff.WriteString(fmt.Sprintf("Owner: %s\n", info.GetOwner()))
+	if createdAt, err := ptypes.Timestamp(info.GetCreatedAt()); err == nil {
+		buff.WriteString(fmt.Sprintf("CreatedAt: %s\n", createdAt.Local()))
+	} else {
+		buff.WriteString("CreatedAt: <unknown>\n")
+	}
+
+	var keys []string
+	for key := range info.GetLabels() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	buff.WriteString("Labels:\n")
+	for _, key := range keys {
+		buff.WriteString(fmt.Sprintf("  %s=%s\n", key, info.GetLabels()[key]))
+	}
+
+	buff.WriteString(fmt.Sprintf("MergePolicy: %s\n", info.GetMergePolicy()))
+
+	policy := info.GetRetention()
+	buff.WriteString("Retention:\n")
+	buff.WriteString(fmt.Sprintf("  KeepLast: %d\n", policy.GetKeepLast()))
+	buff.WriteString(fmt.Sprintf("  KeepDaily: %d\n", policy.GetKeepDaily()))
+	if keepWithin, err := ptypes.Duration(policy.GetKeepWithin()); err == nil {
+		buff.WriteString(fmt.Sprintf("  KeepWithin: %s\n", keepWithin))
+	} else {
+		buff.WriteString("  KeepWithin: <none>\n")
+	}
+	return buff.String()
+}
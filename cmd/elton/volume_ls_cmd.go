@@ -11,34 +11,50 @@ import (
 )
 
 func volumeLsFn(cmd *cobra.Command, args []string) error {
-	if err := _volumeLsFn(); err != nil {
+	selector, err := cmd.Flags().GetString("selector")
+	if err != nil {
+		return err
+	}
+	if err := _volumeLsFn(selector); err != nil {
 		showError(err)
 	}
 	return nil
 }
-func _volumeLsFn() error {
+func _volumeLsFn(selector string) error {
 	c, err := elton_v2.VolumeService()
 	if err != nil {
 		return xerrors.Errorf("api client: %w", err)
 	}
 	defer elton_v2.Close(c)
 
-	// Call api and store results to names slice.
+	// Call api and store results to names slice.  Repeat until all pages are received.
 	var names []string
-	req := &elton_v2.ListVolumesRequest{Limit: 1000}
-	receiver, err := c.ListVolumes(context.Background(), req)
-	if err != nil {
-		return xerrors.Errorf("api client: %w", err)
-	}
+	next := ""
 	for {
-		res, err := receiver.Recv()
+		req := &elton_v2.ListVolumesRequest{
+			Limit:         1000,
+			Next:          next,
+			LabelSelector: selector,
+		}
+		receiver, err := c.ListVolumes(context.Background(), req)
 		if err != nil {
-			if err == io.EOF {
-				break
-			}
 			return xerrors.Errorf("api client: %w", err)
 		}
-		names = append(names, res.GetInfo().GetName())
+		next = ""
+		for {
+			res, err := receiver.Recv()
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				return xerrors.Errorf("api client: %w", err)
+			}
+			names = append(names, res.GetInfo().GetName())
+			next = res.GetNext()
+		}
+		if next == "" {
+			break
+		}
 	}
 
 	// Print volume names to stdout.
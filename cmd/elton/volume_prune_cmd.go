@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+func volumePruneFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _volumePruneFn(ctx, volume, dryRun); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _volumePruneFn(ctx context.Context, volumeName string, dryRun bool) error {
+	c, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+
+	vRes, err := c.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+	res, err := c.PruneVolume(ctx, &elton_v2.PruneVolumeRequest{
+		Id:     vRes.GetId(),
+		DryRun: dryRun,
+	})
+	if err != nil {
+		return xerrors.Errorf("prune volume: %w", err)
+	}
+
+	// Show deleted commit ID list.
+	for _, cid := range res.GetDeleted() {
+		fmt.Println(cid.ConvertString())
+	}
+	return nil
+}
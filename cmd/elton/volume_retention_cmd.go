@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+func volumeRetentionFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+	keepLast, err := cmd.Flags().GetUint32("keep-last")
+	if err != nil {
+		return err
+	}
+	keepDaily, err := cmd.Flags().GetUint32("keep-daily")
+	if err != nil {
+		return err
+	}
+	keepWithin, err := cmd.Flags().GetDuration("keep-within")
+	if err != nil {
+		return err
+	}
+	policy := &elton_v2.RetentionPolicy{
+		KeepLast:  keepLast,
+		KeepDaily: keepDaily,
+	}
+	if keepWithin > 0 {
+		policy.KeepWithin = ptypes.DurationProto(keepWithin)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _volumeRetentionFn(ctx, volume, policy); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _volumeRetentionFn(ctx context.Context, volumeName string, policy *elton_v2.RetentionPolicy) error {
+	c, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+
+	vRes, err := c.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+	_, err = c.SetRetentionPolicy(ctx, &elton_v2.SetRetentionPolicyRequest{
+		Id:     vRes.GetId(),
+		Policy: policy,
+	})
+	if err != nil {
+		return xerrors.Errorf("set retention policy: %w", err)
+	}
+	return nil
+}
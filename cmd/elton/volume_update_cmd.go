@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+)
+
+type volumeUpdateOptions struct {
+	name         *string
+	description  *string
+	owner        *string
+	labels       map[string]string
+	removeLabels []string
+	mergePolicy  *elton_v2.MergePolicy
+}
+
+// mergePolicies maps values of --merge-policy flag to MergePolicy.
+var mergePolicies = map[string]elton_v2.MergePolicy{
+	"reject":           elton_v2.MergePolicy_RejectConflict,
+	"last-writer-wins": elton_v2.MergePolicy_LastWriterWins,
+	"current-wins":     elton_v2.MergePolicy_CurrentWins,
+	"latest-wins":      elton_v2.MergePolicy_LatestWins,
+	"keep-both":        elton_v2.MergePolicy_KeepBoth,
+}
+
+func volumeUpdateFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+
+	volume := args[0]
+	opts := volumeUpdateOptions{}
+	// Only changed flags are updated.
+	for _, flag := range []struct {
+		name string
+		dest **string
+	}{
+		{"name", &opts.name},
+		{"description", &opts.description},
+		{"owner", &opts.owner},
+	} {
+		if !cmd.Flags().Changed(flag.name) {
+			continue
+		}
+		value, err := cmd.Flags().GetString(flag.name)
+		if err != nil {
+			return err
+		}
+		*flag.dest = &value
+	}
+	labels, err := cmd.Flags().GetStringToString("label")
+	if err != nil {
+		return err
+	}
+	opts.labels = labels
+	removeLabels, err := cmd.Flags().GetStringSlice("remove-label")
+	if err != nil {
+		return err
+	}
+	opts.removeLabels = removeLabels
+	if cmd.Flags().Changed("merge-policy") {
+		value, err := cmd.Flags().GetString("merge-policy")
+		if err != nil {
+			return err
+		}
+		policy, ok := mergePolicies[value]
+		if !ok {
+			return xerrors.Errorf("invalid merge policy: %s", value)
+		}
+		opts.mergePolicy = &policy
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := _volumeUpdateFn(ctx, volume, opts); err != nil {
+		showError(err)
+	}
+	return nil
+}
+func _volumeUpdateFn(ctx context.Context, volumeName string, opts volumeUpdateOptions) error {
+	c, err := elton_v2.VolumeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+
+	vRes, err := c.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{
+		Name: volumeName,
+	})
+	if err != nil {
+		return xerrors.Errorf("inspect volume: %w", err)
+	}
+
+	info := &elton_v2.VolumeInfo{}
+	var mask []string
+	if opts.name != nil {
+		info.Name = *opts.name
+		mask = append(mask, "name")
+	}
+	if opts.description != nil {
+		info.Description = *opts.description
+		mask = append(mask, "description")
+	}
+	if opts.owner != nil {
+		info.Owner = *opts.owner
+		mask = append(mask, "owner")
+	}
+	if opts.mergePolicy != nil {
+		info.MergePolicy = *opts.mergePolicy
+		mask = append(mask, "mergePolicy")
+	}
+	if len(opts.labels) > 0 || len(opts.removeLabels) > 0 {
+		// Merge with current labels.
+		info.Labels = map[string]string{}
+		for key, value := range vRes.GetInfo().GetLabels() {
+			info.Labels[key] = value
+		}
+		for key, value := range opts.labels {
+			info.Labels[key] = value
+		}
+		for _, key := range opts.removeLabels {
+			delete(info.Labels, key)
+		}
+		mask = append(mask, "labels")
+	}
+	if len(mask) == 0 {
+		return xerrors.New("nothing to update")
+	}
+
+	res, err := c.UpdateVolume(ctx, &elton_v2.UpdateVolumeRequest{
+		Id:         vRes.GetId(),
+		Info:       info,
+		UpdateMask: mask,
+	})
+	if err != nil {
+		return xerrors.Errorf("update volume: %w", err)
+	}
+	fmt.Print(dumpVolumeInfo(vRes.GetId(), res.GetInfo()))
+	return nil
+}
@@ -3,10 +3,17 @@ package main
 import (
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
+	"time"
 )
 
 type EnvConfig struct {
 	Roles []string `required:"true"`
+	// Addresses registered to the controller.  If it is empty, addresses of all non-loopback interfaces are used.
+	AdvertiseAddrs []string `split_words:"true"`
+	// Labels of this node (e.g. "zone:a,rack:1").
+	NodeLabels        map[string]string `split_words:"true"`
+	NodeIDFile        string            `split_words:"true" default:"/var/lib/elton/node-id"`
+	HeartbeatInterval time.Duration     `split_words:"true" default:"10s"`
 	//ControllerListenAddr tcpAddr `split_words:"true"`
 	//Controllers          tcpAddrs
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"github.com/tchap/zapext/zapsyslog"
 	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/nodeagent"
+	localStorage "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/storage/local"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"log"
@@ -89,6 +91,33 @@ func Main() int {
 			sm.Serve(ctx)
 		}()
 	}
+
+	// Register this node to the controller.  The agent is restarted automatically because the controller may not be
+	// ready yet.
+	swg.Add(1)
+	go func() {
+		defer swg.Done()
+
+		sm := subsystems.ServerManager{
+			New: func() subsystems.Server {
+				a := nodeagent.NewAgent(conf.Roles)
+				a.Addresses = conf.AdvertiseAddrs
+				a.Labels = conf.NodeLabels
+				a.NodeIDFile = conf.NodeIDFile
+				a.HeartbeatInterval = conf.HeartbeatInterval
+				for _, role := range conf.Roles {
+					if role == "storage" {
+						a.StorageDir = localStorage.DefaultCacheDir
+					}
+				}
+				return a
+			},
+			Name:            "node-agent",
+			AutoRestart:     true,
+			RestartInterval: 3 * time.Second,
+		}
+		sm.Serve(ctx)
+	}()
 	swg.Wait()
 	return 0
 }
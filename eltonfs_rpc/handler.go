@@ -4,8 +4,8 @@ import (
 	"context"
 	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
 	"golang.org/x/xerrors"
-	"io"
 	"log"
+	"os"
 )
 
 type RpcHandler func(ClientNS, StructID, PacketFlag)
@@ -108,13 +108,56 @@ func handleGetObjectRequest(ns ClientNS) {
 		defer elton_v2.Close(c)
 		res, err := c.GetObject(context.Background(), req.ToGRPC())
 		if err != nil {
-			return nil, xerrors.Errorf("call api: %w", err)
+			// The object may be moved to other storage node by drainer or rebalancer.
+			var locErr error
+			res, locErr = getObjectFromLocations(req.ToGRPC())
+			if locErr != nil {
+				log.Printf("[WARN] failed to get object from other locations: %+v", locErr)
+				return nil, xerrors.Errorf("call api: %w", err)
+			}
 		}
 
 		return GetObjectResponse{}.FromGRPC(res), nil
 	})
 }
 
+// getObjectFromLocations gets the object from storage nodes recorded by the controller.
+func getObjectFromLocations(req *elton_v2.GetObjectRequest) (*elton_v2.GetObjectResponse, error) {
+	nc, err := elton_v2.NodeService()
+	if err != nil {
+		return nil, xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(nc)
+	locRes, err := nc.LocateObject(context.Background(), &elton_v2.LocateObjectRequest{
+		Key: req.GetKey(),
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("locate object: %w", err)
+	}
+
+	err = xerrors.New("no location")
+	for _, n := range locRes.GetNodes() {
+		var res *elton_v2.GetObjectResponse
+		res, err = getObjectFrom(n.GetNode(), req)
+		if err == nil {
+			return res, nil
+		}
+	}
+	return nil, err
+}
+func getObjectFrom(node *elton_v2.Node, req *elton_v2.GetObjectRequest) (*elton_v2.GetObjectResponse, error) {
+	c, err := elton_v2.StorageServiceOf(node)
+	if err != nil {
+		return nil, xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(c)
+	res, err := c.GetObject(context.Background(), req)
+	if err != nil {
+		return nil, xerrors.Errorf("call api: %w", err)
+	}
+	return res, nil
+}
+
 func handleCreateObject(ns ClientNS) {
 	rpcHandlerHelper(ns, &CreateObjectRequest{}, func(rawReq interface{}) (i interface{}, e error) {
 		req := rawReq.(*CreateObjectRequest)
@@ -143,8 +186,17 @@ func handleCreateCommitRequest(ns ClientNS) {
 			return nil, xerrors.Errorf("api client: %w", err)
 		}
 		defer elton_v2.Close(c)
-		res, err := c.Commit(context.Background(), req.ToGRPC())
+		greq := req.ToGRPC()
+		// Node name is used to rename conflicted files when merging.
+		if hostname, err := os.Hostname(); err == nil {
+			greq.GetInfo().Node = hostname
+		}
+		res, err := c.Commit(context.Background(), greq)
 		if err != nil {
+			for _, conflict := range elton_v2.CommitConflictsOf(err).GetConflicts() {
+				log.Printf("[WARN] commit conflict(%s): latest=%v current=%v",
+					conflict.GetType(), conflict.GetLatestPaths(), conflict.GetCurrentPaths())
+			}
 			return nil, xerrors.Errorf("call api: %w", err)
 		}
 
@@ -156,26 +208,13 @@ func handleNotifyLatestCommitRequest(ns ClientNS) {
 	rpcHandlerHelper(ns, &NotifyLatestCommitRequest{}, func(rawReq interface{}) (i interface{}, err error) {
 		req := rawReq.(*NotifyLatestCommitRequest)
 
-		c, err := elton_v2.CommitService()
+		ctx, cancel := context.WithTimeout(context.Background(), latestCommitTimeout)
+		defer cancel()
+		cid, err := latestCommits.Get(ctx, req.VolumeID.ToGRC())
 		if err != nil {
-			return nil, xerrors.Errorf("api client: %w", err)
-		}
-		defer elton_v2.Close(c)
-		receiver, err := c.ListCommits(context.Background(), &elton_v2.ListCommitsRequest{
-			Id:    req.VolumeID.ToGRC(),
-			Limit: 1,
-		})
-		if err != nil {
-			return nil, xerrors.Errorf("api client: %w", err)
-		}
-		res, err := receiver.Recv()
-		if err != nil {
-			return nil, xerrors.Errorf("receiver: unexpected error: %w", err)
-		}
-		out := NotifyLatestCommit{}.FromGRPC(res.GetId())
-		if _, err := receiver.Recv(); err != io.EOF {
-			return nil, xerrors.Errorf("receiver: not EOF: %w", err)
+			return nil, xerrors.Errorf("latest commit: %w", err)
 		}
+		out := NotifyLatestCommit{}.FromGRPC(cid)
 		return out, nil
 	})
 }
@@ -0,0 +1,178 @@
+package eltonfs_rpc
+
+import (
+	"context"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	watchRetryInterval = time.Second
+	// latestCommitTimeout is the maximum time to wait for the first commit from WatchCommits stream.
+	latestCommitTimeout = 10 * time.Second
+	// watchIdleTimeout is the time to stop watching the volume after the last Get() call.  The volume may be
+	// unmounted.
+	watchIdleTimeout = 10 * time.Minute
+)
+
+// latestCommits keeps the latest commit of volumes mounted on this node.  It is updated by WatchCommits stream
+// instead of polling the controller on every request.
+var latestCommits = newLatestCommitCache(watchIdleTimeout)
+
+type latestCommitCache struct {
+	lock        sync.Mutex
+	volumes     map[string]*watchedVolume
+	idleTimeout time.Duration
+}
+type watchedVolume struct {
+	// ready is closed when the first commit or an error is received.
+	ready   chan struct{}
+	isReady bool
+	latest  *elton_v2.CommitID
+	err     error
+	// lastUsed is the time of the last Get() call.
+	lastUsed time.Time
+}
+
+func newLatestCommitCache(idleTimeout time.Duration) *latestCommitCache {
+	return &latestCommitCache{
+		volumes:     map[string]*watchedVolume{},
+		idleTimeout: idleTimeout,
+	}
+}
+
+// Get returns the latest commit of the volume.  The volume is watched in background from the first call.
+func (c *latestCommitCache) Get(ctx context.Context, vid *elton_v2.VolumeID) (*elton_v2.CommitID, error) {
+	c.lock.Lock()
+	v, ok := c.volumes[vid.GetId()]
+	if !ok {
+		v = &watchedVolume{
+			ready: make(chan struct{}),
+		}
+		c.volumes[vid.GetId()] = v
+		go c.watch(vid, v)
+	}
+	v.lastUsed = time.Now()
+	c.lock.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-v.ready:
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if v.err != nil {
+		return nil, v.err
+	}
+	return v.latest, nil
+}
+func (c *latestCommitCache) watch(vid *elton_v2.VolumeID, v *watchedVolume) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.evictIdle(ctx, cancel, vid, v)
+
+	for {
+		deleted, err := c.watchOnce(ctx, vid, v)
+		if ctx.Err() != nil {
+			// Evicted.  Waiters should not wait for the stopped stream.
+			c.lock.Lock()
+			if !v.isReady {
+				v.err = xerrors.Errorf("watch commits: %w", ctx.Err())
+				v.markReady()
+			}
+			c.lock.Unlock()
+			return
+		}
+
+		c.lock.Lock()
+		if v.latest == nil || deleted {
+			// Could not get any commit, or the volume was deleted.  Stop watching and report the error to waiters.
+			// Next Get() call starts watching again.
+			v.err = err
+			v.markReady()
+			delete(c.volumes, vid.GetId())
+			c.lock.Unlock()
+			return
+		}
+		c.lock.Unlock()
+
+		log.Printf("[WARN] watch commits: %+v", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+// evictIdle stops watching the volume if Get() is not called for idleTimeout.
+func (c *latestCommitCache) evictIdle(ctx context.Context, cancel context.CancelFunc, vid *elton_v2.VolumeID, v *watchedVolume) {
+	ticker := time.NewTicker(c.idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		c.lock.Lock()
+		idle := time.Since(v.lastUsed) >= c.idleTimeout
+		if idle && c.volumes[vid.GetId()] == v {
+			delete(c.volumes, vid.GetId())
+		}
+		c.lock.Unlock()
+		if idle {
+			log.Printf("[INFO] stop watching idle volume: %s", vid.GetId())
+			cancel()
+			return
+		}
+	}
+}
+
+// watchOnce receives commits until the stream is broken.  It resumes from the last received commit.  deleted is true
+// if the volume does not exist.
+func (c *latestCommitCache) watchOnce(ctx context.Context, vid *elton_v2.VolumeID, v *watchedVolume) (deleted bool, err error) {
+	cc, err := elton_v2.CommitService()
+	if err != nil {
+		return false, xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(cc)
+
+	c.lock.Lock()
+	since := v.latest
+	c.lock.Unlock()
+	receiver, err := cc.WatchCommits(ctx, &elton_v2.WatchCommitsRequest{
+		Id:    vid,
+		Since: since,
+	})
+	if err != nil {
+		return status.Code(err) == codes.NotFound, xerrors.Errorf("watch commits: %w", err)
+	}
+	for {
+		res, err := receiver.Recv()
+		if err != nil {
+			return status.Code(err) == codes.NotFound, xerrors.Errorf("receiver: %w", err)
+		}
+
+		c.lock.Lock()
+		v.latest = res.GetId()
+		v.markReady()
+		c.lock.Unlock()
+	}
+}
+
+// markReady wakes up waiters.  Caller must hold the lock.
+func (v *watchedVolume) markReady() {
+	if !v.isReady {
+		v.isReady = true
+		close(v.ready)
+	}
+}
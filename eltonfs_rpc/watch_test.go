@@ -0,0 +1,57 @@
+package eltonfs_rpc
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"testing"
+	"time"
+)
+
+func TestLatestCommitCache_evictIdle(t *testing.T) {
+	vid := &elton_v2.VolumeID{Id: "foo"}
+	newCache := func() (*latestCommitCache, *watchedVolume) {
+		c := newLatestCommitCache(20 * time.Millisecond)
+		v := &watchedVolume{
+			ready:    make(chan struct{}),
+			lastUsed: time.Now(),
+		}
+		c.volumes[vid.GetId()] = v
+		return c, v
+	}
+	isWatched := func(c *latestCommitCache) bool {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		_, ok := c.volumes[vid.GetId()]
+		return ok
+	}
+
+	t.Run("should_evict_idle_volume", func(t *testing.T) {
+		c, v := newCache()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go c.evictIdle(ctx, cancel, vid, v)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+		assert.False(t, isWatched(c))
+	})
+	t.Run("should_keep_used_volume", func(t *testing.T) {
+		c, v := newCache()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go c.evictIdle(ctx, cancel, vid, v)
+
+		for i := 0; i < 10; i++ {
+			time.Sleep(5 * time.Millisecond)
+			c.lock.Lock()
+			v.lastUsed = time.Now()
+			c.lock.Unlock()
+		}
+		assert.NoError(t, ctx.Err())
+		assert.True(t, isWatched(c))
+	})
+}
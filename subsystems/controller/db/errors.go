@@ -34,6 +34,7 @@ func (e *InputError) Is(err error) bool {
 var (
 	ErrDupVolumeID         = &InputError{Msg: "duplicate volume id"}
 	ErrDupVolumeName       = &InputError{Msg: "duplicate volume name"}
+	ErrDupCommitID         = &InputError{Msg: "duplicate commit id"}
 	ErrNotFoundVolume      = &InputError{Msg: "not found volume"}
 	ErrNotFoundCommit      = &InputError{Msg: "not found commit"}
 	ErrNotFoundTree        = &InputError{Msg: "not found tree"}
@@ -46,6 +47,15 @@ var (
 	ErrInvalidParentCommit = &InputError{Msg: "invalid parent commit"}
 	ErrInvalidTree         = &InputError{Msg: "invalid tree"}
 	ErrLatestCommitUpdated = &InputError{Msg: "latest commit is updated by other thread"}
+	ErrDeleteLatestCommit  = &InputError{Msg: "cannot delete the latest commit"}
+	ErrDeleteRefCommit     = &InputError{Msg: "cannot delete the commit referenced by ref"}
+	ErrDeleteMergeCommit   = &InputError{Msg: "cannot re-link parents of deleted merge commits"}
+	ErrDupRef              = &InputError{Msg: "duplicate ref"}
+	ErrNotFoundRef         = &InputError{Msg: "not found ref"}
+	ErrInvalidRefName      = &InputError{Msg: "invalid ref name"}
+	ErrImmutableRef        = &InputError{Msg: "ref is immutable"}
+	ErrNotBranch           = &InputError{Msg: "ref is not a branch"}
+	ErrInvalidMergePolicy  = &InputError{Msg: "invalid merge policy"}
 )
 
 // InternalError represents an error of database internal error.
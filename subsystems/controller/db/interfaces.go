@@ -2,6 +2,7 @@ package controller_db
 
 import (
 	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"time"
 )
 
 // Stores is accessors for various databases.
@@ -10,6 +11,7 @@ type Stores interface {
 	VolumeStore() VolumeStore
 	CommitStore() CommitStore
 	NodeStore() NodeStore
+	ObjectStore() ObjectStore
 }
 
 // MetaStore is an interface for properties database.
@@ -29,6 +31,24 @@ type MetaStore interface {
 	//                         (Property.allowReplace=false).
 	// - InternalError
 	Set(id *PropertyID, prop *Property, mustCreate bool) (old *Property, err error)
+	// Delete deletes a property and returns the deleted property.
+	//
+	// Error:
+	// - ErrNotFoundProp: If property is not found.
+	// - ErrNotAllowedReplace: If specified property is not allowed replace (Property.allowReplace=false).
+	// - InternalError
+	Delete(id *PropertyID) (old *Property, err error)
+	// List calls fn for each property whose key starts with the prefix in ascending order of the key.  If fn returns an
+	// error, return immediately it.
+	//
+	// Error:
+	// - InternalError
+	List(prefix string, fn func(id *PropertyID, prop *Property) error) error
+	// DeleteExpired deletes all properties that expired at now, and returns keys of deleted properties.
+	//
+	// Error:
+	// - InternalError
+	DeleteExpired(now time.Time) (deleted []*PropertyID, err error)
 }
 
 // VolumeStore is an interface for volumes database.
@@ -61,13 +81,51 @@ type VolumeStore interface {
 	// Error:
 	// - InternalError
 	Walk(fn func(id *VolumeID, info *VolumeInfo) error) error
-	// Create creates a volume.
+	// WalkAfter walks volumes in ascending order of VolumeID and calling fn for each volume.  It starts from the next
+	// volume of after.  The after volume does not need to exist.  If after is nil, it walks all volumes.
+	//
+	// Error:
+	// - InternalError
+	WalkAfter(after *VolumeID, fn func(id *VolumeID, info *VolumeInfo) error) error
+	// Create creates a volume.  VolumeInfo.CreatedAt is overwritten by current time.
 	//
 	// Error:
 	// - ErrDupVolumeID: If volume ID is duplicated.
 	// - ErrDupVolumeName: If volume name is duplicated.
+	// - ErrInvalidMergePolicy: If merge policy is unknown.
 	// - InternalError
 	Create(info *VolumeInfo) (*VolumeID, error)
+	// Fork creates a volume from the src commit.  The first commit of new volume has the same tree as src commit, and
+	// its ForkedFrom field points to src.  The src must be resolved CommitID.  VolumeInfo.CreatedAt is overwritten like
+	// Create().
+	//
+	// Error:
+	// - ErrNotFoundCommit: If src commit is not found.
+	// - ErrInvalidParentCommit: If src is not resolved.
+	// - ErrDupVolumeID: If volume ID is duplicated.
+	// - ErrDupVolumeName: If volume name is duplicated.
+	// - ErrInvalidMergePolicy: If merge policy is unknown.
+	// - InternalError
+	Fork(src *CommitID, info *VolumeInfo) (*VolumeID, *CommitID, error)
+	// Import creates a volume with specified ID.  Unlike Create(), it does not create the first commit.  Commits should
+	// be added by CommitStore.Import().
+	//
+	// Error:
+	// - ErrDupVolumeID: If volume ID is duplicated.
+	// - ErrDupVolumeName: If volume name is duplicated.
+	// - ErrInvalidMergePolicy: If merge policy is unknown.
+	// - InternalError
+	Import(id *VolumeID, info *VolumeInfo) error
+	// Update updates a volume information inside callback().
+	// The callback() should update VolumeInfo fields during executing callback().  If callback() returns an error,
+	// changes are discarded.
+	//
+	// Error:
+	// - ErrNotFoundVolume: If volume is not found.
+	// - ErrDupVolumeName: If volume name is changed and new name is duplicated.
+	// - ErrInvalidMergePolicy: If merge policy is unknown.
+	// - InternalError
+	Update(id *VolumeID, callback func(info *VolumeInfo) error) error
 }
 
 // CommitStore is an interface for commits database.
@@ -105,6 +163,45 @@ type CommitStore interface {
 	// - ErrInvalidTree: If specified tree is invalid.
 	// - InternalError
 	Create(vid *VolumeID, info *CommitInfo, tree *Tree) (*CommitID, error)
+	// CreateOnBranch creates new commit on the branch.  If new commit is based on the branch head, it moves the branch
+	// to new commit.  The latest CommitID of the volume is not changed.
+	//
+	// Error:
+	// - ErrNotFoundRef: If specified branch is not found.
+	// - ErrNotBranch: If specified ref is not a branch.
+	// - ErrCrossVolumeCommit: If mismatch branch and info.LeftParentID and info.RightParentID.
+	// - ErrInvalidParentCommit: If parent commit ID combination is invalid.
+	// - ErrInvalidTree: If specified tree is invalid.
+	// - InternalError
+	CreateOnBranch(branch *RefID, info *CommitInfo, tree *Tree) (*CommitID, error)
+	// CreateFastForward creates new commit and moves the head to it.  The head is the branch specified by head.Name, or
+	// the latest commit of the volume if head.Name is empty.  Unlike Create() and CreateOnBranch(), it never saves the
+	// commit if the left parent is not the current head.
+	//
+	// Error:
+	// - ErrLatestCommitUpdated: If the left parent is not the current head.
+	// - ErrNotFoundVolume: If specified volume is not found.
+	// - ErrNotFoundRef: If specified branch is not found.
+	// - ErrNotBranch: If specified ref is not a branch.
+	// - ErrCrossVolumeCommit: If mismatch head and info.LeftParentID and info.RightParentID.
+	// - ErrInvalidParentCommit: If parent commit ID combination is invalid.
+	// - ErrInvalidTree: If specified tree is invalid.
+	// - InternalError
+	CreateFastForward(head *RefID, info *CommitInfo, tree *Tree) (*CommitID, error)
+	// CreateMerge saves the current commit and the merge commit in a transaction, and moves the head to the merge
+	// commit.  The merge commit must be based on the current head, and its right parent is set to the current commit.
+	// The current commit is never observed as the head.
+	//
+	// Error:
+	// - ErrLatestCommitUpdated: If merged.LeftParentID is not the current head.
+	// - ErrNotFoundVolume: If specified volume is not found.
+	// - ErrNotFoundRef: If specified branch is not found.
+	// - ErrNotBranch: If specified ref is not a branch.
+	// - ErrCrossVolumeCommit: If mismatch head and parents of commits.
+	// - ErrInvalidParentCommit: If parent commit ID combination is invalid.
+	// - ErrInvalidTree: If specified tree is invalid.
+	// - InternalError
+	CreateMerge(head *RefID, current *CommitInfo, merged *CommitInfo) (currentID *CommitID, mergedID *CommitID, err error)
 	// Tree gets a tree information from the CommitID.
 	//
 	// Error:
@@ -113,6 +210,99 @@ type CommitStore interface {
 	//                    TODO: コミットはあるのにtreeがない状況 !?
 	// - InternalError
 	Tree(id *CommitID) (*Tree, error)
+	// Import saves the commit with specified CommitID.  Parent commits must be imported before this commit.  If the
+	// volume has no commits, a commit without parents is accepted as the first commit.  The latest CommitID is updated
+	// by the same rule as Create().
+	//
+	// Error:
+	// - ErrDupCommitID: If specified commit is already exists.
+	// - ErrCrossVolumeCommit: If mismatch id and info.LeftParentID and info.RightParentID.
+	// - ErrNotFoundVolume: If specified volume is not found.
+	// - ErrInvalidParentCommit: If parent commit ID combination is invalid.
+	// - ErrInvalidTree: If specified tree is invalid.
+	// - InternalError
+	Import(id *CommitID, info *CommitInfo) error
+	// Walk calls fn for each commit in the volume.  Commits are visited in ascending order of the commit number.  If fn
+	// returns an error, return immediately it.
+	//
+	// Error:
+	// - InternalError
+	Walk(vid *VolumeID, fn func(id *CommitID, info *CommitInfo) error) error
+	// Ancestors calls fn for the start commit and all commits reachable from it through both left and right parents.
+	// Commits are visited in breadth-first order.  Trees are not loaded, so info.Tree is always nil.  If fn returns an
+	// error, return immediately it.
+	//
+	// Error:
+	// - ErrNotFoundCommit: If the start commit or any of ancestors is not found.
+	// - InternalError
+	Ancestors(start *CommitID, fn func(id *CommitID, info *CommitInfo) error) error
+	// Delete deletes commits and re-links the commit DAG.  Parents of the remaining commits are replaced with the
+	// nearest ancestors that are not deleted along both parents.  If the oldest commits are deleted, the oldest
+	// remaining commit becomes a commit without parents.  Objects that only referenced by deleted commits are released
+	// and recorded to ObjectStore.
+	//
+	// Error:
+	// - ErrNotFoundCommit: If any of commits is not found.
+	// - ErrDeleteLatestCommit: If trying to delete the latest commit.
+	// - ErrDeleteRefCommit: If trying to delete the commit referenced by ref.
+	// - ErrDeleteMergeCommit: If a remaining commit would have more than two parents.
+	// - InternalError
+	Delete(ids []*CommitID) (released []*ObjectKey, err error)
+	// Resolve returns CommitID that ref of id points to.  If id does not have ref name, it returns id as it is.
+	//
+	// Error:
+	// - ErrNotFoundRef: If ref is not found.
+	// - InternalError
+	Resolve(id *CommitID) (*CommitID, error)
+	// CreateRef creates a ref.  ref.Commit must not contain ref name.
+	//
+	// Error:
+	// - ErrInvalidRefName: If ref name is invalid.
+	// - ErrCrossVolumeCommit: If mismatch VolumeID of id and ref.Commit.
+	// - ErrNotFoundVolume: If specified volume is not found.
+	// - ErrNotFoundCommit: If specified commit is not found.
+	// - ErrDupRef: If specified ref is already exists.
+	// - InternalError
+	CreateRef(id *RefID, ref *Ref) error
+	// GetRef gets a ref.
+	//
+	// Error:
+	// - ErrNotFoundRef: If ref is not found.
+	// - InternalError
+	GetRef(id *RefID) (*Ref, error)
+	// ListRefs calls fn for each ref in the volume by ascending order of the name.  If fn returns an error, return
+	// immediately it.
+	//
+	// Error:
+	// - InternalError
+	ListRefs(vid *VolumeID, fn func(id *RefID, ref *Ref) error) error
+	// MoveRef changes the commit that branch points to.
+	//
+	// Error:
+	// - ErrNotFoundRef: If ref is not found.
+	// - ErrImmutableRef: If specified ref is a tag.
+	// - ErrCrossVolumeCommit: If mismatch VolumeID of id and cid.
+	// - ErrNotFoundCommit: If specified commit is not found.
+	// - InternalError
+	MoveRef(id *RefID, cid *CommitID) error
+	// UpdateHead changes the head to cid only if the head points to expected.  The head is the branch specified by
+	// id.Name, or the latest commit of the volume if id.Name is empty.
+	//
+	// Error:
+	// - ErrLatestCommitUpdated: If the head does not point to expected.
+	// - ErrNotFoundVolume: If specified volume is not found.
+	// - ErrNotFoundRef: If ref is not found.
+	// - ErrImmutableRef: If specified ref is a tag.
+	// - ErrCrossVolumeCommit: If mismatch VolumeID of id and expected or cid.
+	// - ErrNotFoundCommit: If specified commit is not found.
+	// - InternalError
+	UpdateHead(id *RefID, expected, cid *CommitID) error
+	// DeleteRef deletes a ref.
+	//
+	// Error:
+	// - ErrNotFoundRef: If ref is not found.
+	// - InternalError
+	DeleteRef(id *RefID) error
 }
 
 type NodeStore interface {
@@ -142,3 +332,40 @@ type NodeStore interface {
 	// - InternalError
 	List(walker func(id *NodeID, node *Node) error) error
 }
+
+// ObjectStore is an interface for objects database.
+type ObjectStore interface {
+	// WalkReleased calls fn for each released object.  Released objects are not referenced by any commits.  So they
+	// can be deleted from storage.  If fn returns an error, return immediately it.
+	//
+	// Error:
+	// - InternalError
+	WalkReleased(fn func(key *ObjectKey, releasedAt time.Time) error) error
+	// ForgetReleased removes the object from released objects.  It should be called after the object is deleted from
+	// storage.
+	//
+	// Error:
+	// - InternalError
+	ForgetReleased(key *ObjectKey) error
+	// Release records objects as released if they are not referenced by any commits.  It is used for objects that are
+	// stored but not saved in any commits.  Objects referenced by commits are ignored.
+	//
+	// Error:
+	// - InternalError
+	Release(keys []*ObjectKey) error
+	// AddLocation records that the storage node has the object.  It does nothing if already recorded.
+	//
+	// Error:
+	// - InternalError
+	AddLocation(key *ObjectKey, node *NodeID) error
+	// RemoveLocation removes the location record.  It does nothing if not recorded.
+	//
+	// Error:
+	// - InternalError
+	RemoveLocation(key *ObjectKey, node *NodeID) error
+	// Locations returns storage nodes that have the object.
+	//
+	// Error:
+	// - InternalError
+	Locations(key *ObjectKey) ([]*NodeID, error)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
 	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/idgen"
 	"go.etcd.io/bbolt"
@@ -12,8 +13,10 @@ import (
 	"log"
 	"os"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // File name of database file.
@@ -44,11 +47,32 @@ var localCommitBucket = []byte("commit")
 // - Value: CommitID
 var localLatestCommitBucket = []byte("latest-commit")
 
+// Ref bucket: It keeps refs in each volume.
+// - Key: RefID
+// - Value: Ref (JSON encoded)
+var localRefBucket = []byte("ref")
+
 // Node bucket: It keeps node information.
 // - Key: NodeID
 // - Value: Node (JSON encoded)
 var localNodeBucket = []byte("node")
 
+// Released Object bucket: It keeps objects that are not referenced by any commits.
+// - Key: ObjectKey
+// - Value: Timestamp of the release (JSON encoded)
+var localReleasedObjectBucket = []byte("released-object")
+
+// Object Reference bucket: It keeps the number of commits that reference the object.  Commits in all volumes are
+// counted.  It is updated in the same transaction as commits are created or deleted.
+// - Key: ObjectKey
+// - Value: Number of commits (JSON encoded)
+var localObjectRefBucket = []byte("object-ref")
+
+// Object Location bucket: It keeps storage nodes that have the object.
+// - Key: ObjectKey + "/" + NodeID
+// - Value: empty
+var localObjectLocationBucket = []byte("object-location")
+
 // CreateLocalDB creates database accessors.  It saves data on local file system.
 func CreateLocalDB(dir string) (stores Stores, closer func() error, err error) {
 	err = os.MkdirAll(dir, 0700)
@@ -71,6 +95,7 @@ func CreateLocalDB(dir string) (stores Stores, closer func() error, err error) {
 		localVS: localVS{DB: db},
 		localCS: localCS{DB: db},
 		localNS: localNS{DB: db},
+		localOS: localOS{DB: db},
 	}
 	return
 }
@@ -80,12 +105,14 @@ type localStores struct {
 	localVS
 	localCS
 	localNS
+	localOS
 }
 
 func (s *localStores) MetaStore() MetaStore     { return &s.localMS }
 func (s *localStores) VolumeStore() VolumeStore { return &s.localVS }
 func (s *localStores) CommitStore() CommitStore { return &s.localCS }
 func (s *localStores) NodeStore() NodeStore     { return &s.localNS }
+func (s *localStores) ObjectStore() ObjectStore { return &s.localOS }
 
 func mustMarshall(v interface{}) []byte {
 	b, err := json.Marshal(v)
@@ -101,6 +128,13 @@ func mustUnmarshal(data []byte, v interface{}) {
 	}
 }
 
+// skipJSON discards the JSON value without decoding it.
+type skipJSON struct{}
+
+func (*skipJSON) UnmarshalJSON([]byte) error {
+	return nil
+}
+
 type localEncoder struct{}
 
 func (localEncoder) VolumeID(id *VolumeID) []byte {
@@ -138,6 +172,34 @@ func (localEncoder) NodeID(id *NodeID) []byte {
 func (localEncoder) Node(node *Node) []byte {
 	return mustMarshall(node)
 }
+func (localEncoder) RefIDPrefix(id *VolumeID) []byte {
+	s := fmt.Sprintf("%s/", id.GetId())
+	return []byte(s)
+}
+func (localEncoder) RefID(id *RefID) []byte {
+	s := fmt.Sprintf("%s/%s", id.GetId().GetId(), id.GetName())
+	return []byte(s)
+}
+func (localEncoder) Ref(ref *Ref) []byte {
+	return mustMarshall(ref)
+}
+func (localEncoder) ObjectKey(key *ObjectKey) []byte {
+	return []byte(key.GetId())
+}
+func (localEncoder) ObjectLocationPrefix(key *ObjectKey) []byte {
+	s := fmt.Sprintf("%s/", key.GetId())
+	return []byte(s)
+}
+func (localEncoder) ObjectLocation(key *ObjectKey, node *NodeID) []byte {
+	s := fmt.Sprintf("%s/%s", key.GetId(), node.GetId())
+	return []byte(s)
+}
+func (localEncoder) Timestamp(ts *timestamp.Timestamp) []byte {
+	return mustMarshall(ts)
+}
+func (localEncoder) ObjectRefCount(n uint64) []byte {
+	return mustMarshall(n)
+}
 
 type localDecoder struct{}
 
@@ -181,6 +243,20 @@ func (localDecoder) CommitInfo(data []byte) *CommitInfo {
 	mustUnmarshal(data, info)
 	return info
 }
+
+// CommitInfoWithoutTree decodes the commit without the tree.  It is much faster than CommitInfo() for large trees.
+func (localDecoder) CommitInfoWithoutTree(data []byte) *CommitInfo {
+	if data == nil {
+		return nil
+	}
+	var info struct {
+		CommitInfo
+		// It shadows CommitInfo.Tree.
+		Tree skipJSON `json:"tree,omitempty"`
+	}
+	mustUnmarshal(data, &info)
+	return &info.CommitInfo
+}
 func (localDecoder) Tree(data []byte) *Tree {
 	if data == nil {
 		return nil
@@ -189,6 +265,14 @@ func (localDecoder) Tree(data []byte) *Tree {
 	mustUnmarshal(data, tree)
 	return tree
 }
+func (localDecoder) PropertyID(data []byte) *PropertyID {
+	if data == nil {
+		return nil
+	}
+	return &PropertyID{
+		Id: string(data),
+	}
+}
 func (localDecoder) Property(data []byte) *Property {
 	if data == nil {
 		return nil
@@ -213,6 +297,52 @@ func (localDecoder) Node(data []byte) *Node {
 	mustUnmarshal(data, node)
 	return node
 }
+func (localDecoder) RefID(data []byte) *RefID {
+	if data == nil {
+		return nil
+	}
+	components := strings.SplitN(string(data), "/", 2)
+	return &RefID{
+		Id:   &VolumeID{Id: components[0]},
+		Name: components[1],
+	}
+}
+func (localDecoder) Ref(data []byte) *Ref {
+	if data == nil {
+		return nil
+	}
+	ref := &Ref{}
+	mustUnmarshal(data, ref)
+	return ref
+}
+func (localDecoder) ObjectKey(data []byte) *ObjectKey {
+	if data == nil {
+		return nil
+	}
+	return &ObjectKey{
+		Id: string(data),
+	}
+}
+func (localDecoder) ObjectLocation(data []byte) (*ObjectKey, *NodeID) {
+	components := strings.SplitN(string(data), "/", 2)
+	return &ObjectKey{Id: components[0]}, &NodeID{Id: components[1]}
+}
+func (localDecoder) Timestamp(data []byte) *timestamp.Timestamp {
+	if data == nil {
+		return nil
+	}
+	ts := &timestamp.Timestamp{}
+	mustUnmarshal(data, ts)
+	return ts
+}
+func (localDecoder) ObjectRefCount(data []byte) uint64 {
+	if data == nil {
+		return 0
+	}
+	var n uint64
+	mustUnmarshal(data, &n)
+	return n
+}
 
 type localGenerator struct{}
 
@@ -286,9 +416,29 @@ func (s *localDB) createAllBuckets() error {
 			return xerrors.Errorf("latest commit bucket cannot create: %w", err)
 		}
 
+		if _, err := tx.CreateBucketIfNotExists(localRefBucket); err != nil {
+			return xerrors.Errorf("ref bucket cannot create: %w", err)
+		}
+
 		if _, err := tx.CreateBucketIfNotExists(localNodeBucket); err != nil {
 			return xerrors.Errorf("node bucket cannot create: %w", err)
 		}
+
+		if _, err := tx.CreateBucketIfNotExists(localReleasedObjectBucket); err != nil {
+			return xerrors.Errorf("released object bucket cannot create: %w", err)
+		}
+		if tx.Bucket(localObjectRefBucket) == nil {
+			if _, err := tx.CreateBucket(localObjectRefBucket); err != nil {
+				return xerrors.Errorf("object ref bucket cannot create: %w", err)
+			}
+			// The database was created by older version.  Count references from existing commits.
+			if err := (localObjectRefs{tx: tx}).Rebuild(); err != nil {
+				return xerrors.Errorf("object ref bucket cannot rebuild: %w", err)
+			}
+		}
+		if _, err := tx.CreateBucketIfNotExists(localObjectLocationBucket); err != nil {
+			return xerrors.Errorf("object location bucket cannot create: %w", err)
+		}
 		return nil
 	})
 	if err != nil {
@@ -329,6 +479,12 @@ func (s *localDB) CommitView(callback localTxFn) error {
 func (s *localDB) CommitUpdate(callback localTxFn) error {
 	return s.runTx(true, localCommitBucket, callback)
 }
+func (s *localDB) RefView(callback localTxFn) error {
+	return s.runTx(false, localRefBucket, callback)
+}
+func (s *localDB) RefUpdate(callback localTxFn) error {
+	return s.runTx(true, localRefBucket, callback)
+}
 func (s *localDB) MetaView(callback localTxFn) error {
 	return s.runTx(false, localMetaBucket, callback)
 }
@@ -341,6 +497,18 @@ func (s *localDB) NodeView(callback localTxFn) error {
 func (s *localDB) NodeUpdate(callback localTxFn) error {
 	return s.runTx(true, localNodeBucket, callback)
 }
+func (s *localDB) ReleasedObjectView(callback localTxFn) error {
+	return s.runTx(false, localReleasedObjectBucket, callback)
+}
+func (s *localDB) ReleasedObjectUpdate(callback localTxFn) error {
+	return s.runTx(true, localReleasedObjectBucket, callback)
+}
+func (s *localDB) ObjectLocationView(callback localTxFn) error {
+	return s.runTx(false, localObjectLocationBucket, callback)
+}
+func (s *localDB) ObjectLocationUpdate(callback localTxFn) error {
+	return s.runTx(true, localObjectLocationBucket, callback)
+}
 
 type localVS struct {
 	DB  *localDB
@@ -396,6 +564,7 @@ func (vs *localVS) Delete(id *VolumeID) error {
 		vnb := tx.Bucket(localVolumeNameBucket)
 		lcb := tx.Bucket(localLatestCommitBucket)
 		cb := tx.Bucket(localCommitBucket)
+		rb := tx.Bucket(localRefBucket)
 
 		// Get volume info.
 		data := vb.Get(vs.Enc.VolumeID(id))
@@ -413,6 +582,20 @@ func (vs *localVS) Delete(id *VolumeID) error {
 			return IErrDelete.Wrap(err)
 		}
 
+		// Delete refs.
+		var refs [][]byte
+		if err := bboltPrefixScan(rb, vs.Enc.RefIDPrefix(id), func(k, v []byte) error {
+			refs = append(refs, append([]byte{}, k...))
+			return nil
+		}); err != nil {
+			return IErrDelete.Wrap(err)
+		}
+		for _, k := range refs {
+			if err := rb.Delete(k); err != nil {
+				return IErrDelete.Wrap(err)
+			}
+		}
+
 		// Get latest commit.
 		data = lcb.Get(vs.Enc.VolumeID(id))
 		if len(data) == 0 {
@@ -423,14 +606,24 @@ func (vs *localVS) Delete(id *VolumeID) error {
 			return IErrDelete.Wrap(err)
 		}
 
-		// Delete commits and trees.
+		// Delete commits and trees.  Objects that only referenced by this volume are released.
+		var keys [][]byte
+		var infos []*CommitInfo
 		prefix := vs.Enc.CommitIDPrefix(id)
 		if err := bboltPrefixScan(cb, prefix, func(k, v []byte) error {
-			log.Printf("[INFO] Deleting commit %s", k)
-			return cb.Delete(k)
+			keys = append(keys, append([]byte{}, k...))
+			infos = append(infos, vs.Dec.CommitInfo(v))
+			return nil
 		}); err != nil {
 			return IErrDelete.Wrap(err)
 		}
+		objRefs := localObjectRefs{tx: tx}
+		for i, k := range keys {
+			log.Printf("[INFO] Deleting commit %s", k)
+			if _, err := objRefs.DeleteCommit(k, infos[i]); err != nil {
+				return IErrDelete.Wrap(err)
+			}
+		}
 		return nil
 	})
 }
@@ -443,14 +636,121 @@ func (vs *localVS) Walk(callback func(id *VolumeID, info *VolumeInfo) error) err
 		})
 	})
 }
+func (vs *localVS) WalkAfter(after *VolumeID, callback func(id *VolumeID, info *VolumeInfo) error) error {
+	return vs.DB.VolumeView(func(b *bbolt.Bucket) error {
+		c := b.Cursor()
+		var k, v []byte
+		if after.GetId() == "" {
+			k, v = c.First()
+		} else {
+			start := vs.Enc.VolumeID(after)
+			k, v = c.Seek(start)
+			if bytes.Equal(k, start) {
+				// Skip the last volume of previous page.
+				k, v = c.Next()
+			}
+		}
+		for ; k != nil; k, v = c.Next() {
+			if err := callback(vs.Dec.VolumeID(k), vs.Dec.VolumeInfo(v)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 func (vs *localVS) Create(info *VolumeInfo) (id *VolumeID, err error) {
 	id = vs.Gen.VolumeID()
 	err = vs.DB.Update(func(tx *bbolt.Tx) error {
+		_, err := vs.create(tx, id, info, firstCommit())
+		return err
+	})
+	return
+}
+func (vs *localVS) Fork(src *CommitID, info *VolumeInfo) (id *VolumeID, cid *CommitID, err error) {
+	if src.GetRef() != "" {
+		err = ErrInvalidParentCommit.Wrap(fmt.Errorf("unresolved ref: %s", src))
+		return
+	}
+
+	id = vs.Gen.VolumeID()
+	err = vs.DB.Update(func(tx *bbolt.Tx) (err error) {
+		data := tx.Bucket(localCommitBucket).Get(vs.Enc.CommitID(src))
+		if data == nil {
+			return ErrNotFoundCommit.Wrap(fmt.Errorf("id=%s", src))
+		}
+		srcInfo := vs.Dec.CommitInfo(data)
+
+		// The first commit shares the tree with source commit.  File contents are not copied because objects are
+		// referenced by the key.
+		cid, err = vs.create(tx, id, info, &CommitInfo{
+			CreatedAt:  ptypes.TimestampNow(),
+			Tree:       srcInfo.GetTree(),
+			ForkedFrom: src,
+		})
+		return
+	})
+	if err != nil {
+		id = nil
+		cid = nil
+	}
+	return
+}
+
+// create saves volume info and the first commit of the volume.
+func (vs *localVS) create(tx *bbolt.Tx, id *VolumeID, info *VolumeInfo, first *CommitInfo) (*CommitID, error) {
+	vb := tx.Bucket(localVolumeBucket)
+	vnb := tx.Bucket(localVolumeNameBucket)
+	lcb := tx.Bucket(localLatestCommitBucket)
+
+	if err := validateVolumeInfo(info); err != nil {
+		return nil, err
+	}
+	// Duplication check.
+	if vb.Get(vs.Enc.VolumeID(id)) != nil {
+		return nil, ErrDupVolumeID.Wrap(fmt.Errorf("id=%s", id))
+	}
+	if vnb.Get(vs.Enc.VolumeName(info)) != nil {
+		return nil, ErrDupVolumeName.Wrap(fmt.Errorf("name=%s", info.GetName()))
+	}
+	// Creation time is always set by server.
+	info.CreatedAt = ptypes.TimestampNow()
+
+	// Save volume info.
+	if err := vb.Put(
+		vs.Enc.VolumeID(id),
+		vs.Enc.VolumeInfo(info),
+	); err != nil {
+		return nil, err
+	}
+	if err := vnb.Put(
+		vs.Enc.VolumeName(info),
+		vs.Enc.VolumeID(id),
+	); err != nil {
+		return nil, err
+	}
+
+	// Create first commit.
+	newCID := vs.Gen.CommitID(id)
+	if err := (localObjectRefs{tx: tx}).PutCommit(vs.Enc.CommitID(newCID), first); err != nil {
+		return nil, err
+	}
+	if err := lcb.Put(
+		vs.Enc.VolumeID(id),
+		vs.Enc.CommitID(newCID),
+	); err != nil {
+		return nil, err
+	}
+	return newCID, nil
+}
+
+func (vs *localVS) Import(id *VolumeID, info *VolumeInfo) error {
+	return vs.DB.Update(func(tx *bbolt.Tx) error {
 		vb := tx.Bucket(localVolumeBucket)
 		vnb := tx.Bucket(localVolumeNameBucket)
-		cb := tx.Bucket(localCommitBucket)
-		lcb := tx.Bucket(localLatestCommitBucket)
 
+		if err := validateVolumeInfo(info); err != nil {
+			return err
+		}
 		// Duplication check.
 		if vb.Get(vs.Enc.VolumeID(id)) != nil {
 			return ErrDupVolumeID.Wrap(fmt.Errorf("id=%s", id))
@@ -466,27 +766,59 @@ func (vs *localVS) Create(info *VolumeInfo) (id *VolumeID, err error) {
 		); err != nil {
 			return err
 		}
-		if err := vnb.Put(
+		return vnb.Put(
 			vs.Enc.VolumeName(info),
 			vs.Enc.VolumeID(id),
-		); err != nil {
-			return err
+		)
+	})
+}
+func (vs *localVS) Update(id *VolumeID, callback func(info *VolumeInfo) error) error {
+	return vs.DB.Update(func(tx *bbolt.Tx) error {
+		vb := tx.Bucket(localVolumeBucket)
+		vnb := tx.Bucket(localVolumeNameBucket)
+
+		data := vb.Get(vs.Enc.VolumeID(id))
+		if len(data) == 0 {
+			return ErrNotFoundVolume.Wrap(fmt.Errorf("id=%s", id))
 		}
+		old := vs.Dec.VolumeInfo(data)
+		info := vs.Dec.VolumeInfo(data)
 
-		// Create empty commit.
-		newCID := vs.Gen.CommitID(id)
-		if err := cb.Put(
-			vs.Enc.CommitID(newCID),
-			vs.Enc.CommitInfo(firstCommit()),
-		); err != nil {
+		if err := callback(info); err != nil {
+			return err
+		}
+		if err := validateVolumeInfo(info); err != nil {
 			return err
 		}
-		return lcb.Put(
+
+		if old.GetName() != info.GetName() {
+			// Volume name is changed.  Should update the lookup table.
+			if vnb.Get(vs.Enc.VolumeName(info)) != nil {
+				return ErrDupVolumeName.Wrap(fmt.Errorf("name=%s", info.GetName()))
+			}
+			if err := vnb.Delete(vs.Enc.VolumeName(old)); err != nil {
+				return IErrDelete.Wrap(err)
+			}
+			if err := vnb.Put(
+				vs.Enc.VolumeName(info),
+				vs.Enc.VolumeID(id),
+			); err != nil {
+				return err
+			}
+		}
+		return vb.Put(
 			vs.Enc.VolumeID(id),
-			vs.Enc.CommitID(newCID),
+			vs.Enc.VolumeInfo(info),
 		)
 	})
-	return
+}
+
+// validateVolumeInfo rejects volume info that the controller can not handle.
+func validateVolumeInfo(info *VolumeInfo) error {
+	if _, ok := MergePolicy_name[int32(info.GetMergePolicy())]; !ok {
+		return ErrInvalidMergePolicy.Wrap(fmt.Errorf("mergePolicy=%d", info.GetMergePolicy()))
+	}
+	return nil
 }
 
 type localCS struct {
@@ -519,7 +851,7 @@ func (cs *localCS) Parents(id *CommitID) (left *CommitID, right *CommitID, err e
 	err = cs.DB.CommitView(func(b *bbolt.Bucket) error {
 		data := b.Get(cs.Enc.CommitID(id))
 		if len(data) > 0 {
-			info := cs.Dec.CommitInfo(data)
+			info := cs.Dec.CommitInfoWithoutTree(data)
 			left = info.GetLeftParentID()
 			right = info.GetRightParentID()
 			return nil
@@ -548,36 +880,12 @@ func (cs *localCS) Create(vid *VolumeID, info *CommitInfo, tree *Tree) (cid *Com
 	right := info.GetRightParentID()
 
 	// Validate arguments.
-	if left.GetId().GetId() != "" {
-		// Request to create normal commit.
-		if bytes.Compare(
-			cs.Enc.VolumeID(vid),
-			cs.Enc.VolumeID(left.GetId()),
-		) != 0 {
-			err = ErrCrossVolumeCommit.Wrap(fmt.Errorf("mismatch VolumeID and CommitInfo.LeftParentID"))
-			return
-		}
-	}
-	if right.GetId().GetId() != "" {
-		// Request to create merge commit.
-		if bytes.Compare(
-			cs.Enc.VolumeID(vid),
-			cs.Enc.VolumeID(right.GetId()),
-		) != 0 {
-			err = ErrCrossVolumeCommit.Wrap(fmt.Errorf("mismatch VolumeID and CommitInfo.RightParentID"))
-			return
-		}
-		if bytes.Compare(
-			cs.Enc.VolumeID(left.GetId()),
-			cs.Enc.VolumeID(right.GetId()),
-		) != 0 {
-			err = ErrCrossVolumeCommit.Wrap(fmt.Errorf("mismatch VolumeID of CommitInfo.LeftParentID and CommitInfo.RightParentID"))
-			return
-		}
+	if err = cs.validateParents(vid, left, right); err != nil {
+		return
 	}
 
 	// Validate tree.
-	if err2 := tree.FastValidate(); err2 != nil {
+	if err2 := tree.Validate(); err2 != nil {
 		err = ErrInvalidTree.Wrap(err2)
 		return
 	}
@@ -597,19 +905,11 @@ func (cs *localCS) Create(vid *VolumeID, info *CommitInfo, tree *Tree) (cid *Com
 				cs.Dec.CommitID(lastCID), left, right,
 			))
 		}
-		if tx.Bucket(localCommitBucket).Get(cs.Enc.CommitID(left)) == nil {
-			// Specified left parent is not found.
-			return ErrInvalidParentCommit.Wrap(fmt.Errorf("left parent commit is not found: %s", left))
-		}
-		if right != nil && tx.Bucket(localCommitBucket).Get(cs.Enc.CommitID(right)) == nil {
-			// Right parent is specified.  But it is not found.
-			return ErrInvalidParentCommit.Wrap(fmt.Errorf("right parent commit is not found: %s", right))
+		if err := cs.checkParentsExist(tx, left, right); err != nil {
+			return err
 		}
 
-		if err := tx.Bucket(localCommitBucket).Put(
-			cs.Enc.CommitID(newCID),
-			cs.Enc.CommitInfo(info),
-		); err != nil {
+		if err := (localObjectRefs{tx: tx}).PutCommit(cs.Enc.CommitID(newCID), info); err != nil {
 			return err
 		}
 
@@ -626,72 +926,858 @@ func (cs *localCS) Create(vid *VolumeID, info *CommitInfo, tree *Tree) (cid *Com
 	}
 	return
 }
-func (cs *localCS) Tree(id *CommitID) (tree *Tree, err error) {
-	var ci *CommitInfo
-	ci, err = cs.Get(id)
-	if err != nil {
+func (cs *localCS) CreateOnBranch(branch *RefID, info *CommitInfo, tree *Tree) (cid *CommitID, err error) {
+	vid := branch.GetId()
+	newCID := cs.Gen.CommitID(vid)
+	info.Tree = tree
+
+	left := info.GetLeftParentID()
+	right := info.GetRightParentID()
+
+	// Validate arguments.
+	if err = cs.validateParents(vid, left, right); err != nil {
+		return
+	}
+	if left == nil {
+		err = ErrInvalidParentCommit.Wrap(fmt.Errorf("left parent is not specified"))
 		return
 	}
-	tree = ci.GetTree()
-	return
-}
 
-type localMS struct {
-	DB  *localDB
-	Enc localEncoder
-	Dec localDecoder
-}
+	// Validate tree.
+	if err2 := tree.Validate(); err2 != nil {
+		err = ErrInvalidTree.Wrap(err2)
+		return
+	}
 
-func (ms *localMS) Get(id *PropertyID) (prop *Property, err error) {
-	err = ms.DB.MetaView(func(b *bbolt.Bucket) error {
-		data := b.Get(ms.Enc.PropertyID(id))
-		if len(data) > 0 {
-			prop = ms.Dec.Property(data)
-			return nil
+	err = cs.DB.Update(func(tx *bbolt.Tx) error {
+		rb := tx.Bucket(localRefBucket)
+
+		// Get the branch head.
+		data := rb.Get(cs.Enc.RefID(branch))
+		if data == nil {
+			return ErrNotFoundRef.Wrap(fmt.Errorf("id=%s", branch))
+		}
+		ref := cs.Dec.Ref(data)
+		if ref.GetType() != RefType_Branch {
+			return ErrNotBranch.Wrap(fmt.Errorf("id=%s", branch))
 		}
-		return ErrNotFoundProp.Wrap(fmt.Errorf("id=%s", id))
-	})
-	return
-}
-func (ms *localMS) Set(id *PropertyID, prop *Property, mustCreate bool) (old *Property, err error) {
-	err = ms.DB.MetaUpdate(func(b *bbolt.Bucket) error {
-		data := b.Get(ms.Enc.PropertyID(id))
-		if len(data) > 0 {
-			if mustCreate {
-				return ErrAlreadyExists.Wrap(fmt.Errorf("id=%s", id))
-			}
 
-			old = ms.Dec.Property(data)
-			if !old.GetAllowReplace() {
-				old = nil
-				return ErrNotAllowedReplace.Wrap(fmt.Errorf("id=%s", id))
-			}
+		if err := cs.checkParentsExist(tx, left, right); err != nil {
+			return err
+		}
+		if err := (localObjectRefs{tx: tx}).PutCommit(cs.Enc.CommitID(newCID), info); err != nil {
+			return err
 		}
 
-		return b.Put(
-			ms.Enc.PropertyID(id),
-			ms.Enc.Property(prop),
-		)
+		if ref.GetCommit().Equals(left) {
+			// New commit is based on the branch head.  Should move the branch.
+			ref.Commit = newCID
+			return rb.Put(cs.Enc.RefID(branch), cs.Enc.Ref(ref))
+		}
+		return nil
 	})
+	if err == nil {
+		cid = newCID
+	}
 	return
 }
+func (cs *localCS) CreateFastForward(head *RefID, info *CommitInfo, tree *Tree) (cid *CommitID, err error) {
+	vid := head.GetId()
+	newCID := cs.Gen.CommitID(vid)
+	info.Tree = tree
 
-type localNS struct {
-	DB  *localDB
-	Enc localEncoder
-	Dec localDecoder
-	Gen localGenerator
-}
+	left := info.GetLeftParentID()
+	right := info.GetRightParentID()
 
-func (ns *localNS) Register(id *NodeID, node *Node) error {
-	return ns.DB.NodeUpdate(func(b *bbolt.Bucket) error {
-		key := ns.Enc.NodeID(id)
-		if b.Get(key) != nil {
-			return ErrNodeAlreadyExists.Wrap(fmt.Errorf("id=%s", id))
-		}
-		return b.Put(
-			key,
-			ns.Enc.Node(node),
+	// Validate arguments.
+	if err = cs.validateParents(vid, left, right); err != nil {
+		return
+	}
+	if left == nil {
+		err = ErrInvalidParentCommit.Wrap(fmt.Errorf("left parent is not specified"))
+		return
+	}
+
+	// Validate tree.
+	if err2 := tree.Validate(); err2 != nil {
+		err = ErrInvalidTree.Wrap(err2)
+		return
+	}
+
+	err = cs.DB.Update(func(tx *bbolt.Tx) error {
+		current, ref, err := cs.getHead(tx, head)
+		if err != nil {
+			return err
+		}
+		if ref != nil && ref.GetType() != RefType_Branch {
+			return ErrNotBranch.Wrap(fmt.Errorf("id=%s", head))
+		}
+		if !current.Equals(left) {
+			return ErrLatestCommitUpdated.Wrap(fmt.Errorf("head=%s, left=%s", current, left))
+		}
+		if err := cs.checkParentsExist(tx, left, right); err != nil {
+			return err
+		}
+
+		if err := (localObjectRefs{tx: tx}).PutCommit(cs.Enc.CommitID(newCID), info); err != nil {
+			return err
+		}
+		return cs.putHead(tx, head, ref, newCID)
+	})
+	if err == nil {
+		cid = newCID
+	}
+	return
+}
+func (cs *localCS) CreateMerge(head *RefID, current *CommitInfo, merged *CommitInfo) (currentID *CommitID, mergedID *CommitID, err error) {
+	vid := head.GetId()
+	newCurrentID := cs.Gen.CommitID(vid)
+	newMergedID := cs.Gen.CommitID(vid)
+
+	// Validate arguments.
+	if err = cs.validateParents(vid, current.GetLeftParentID(), current.GetRightParentID()); err != nil {
+		return
+	}
+	if err = cs.validateParents(vid, merged.GetLeftParentID(), nil); err != nil {
+		return
+	}
+	if current.GetLeftParentID() == nil || merged.GetLeftParentID() == nil {
+		err = ErrInvalidParentCommit.Wrap(fmt.Errorf("left parent is not specified"))
+		return
+	}
+
+	// Validate trees.
+	if err2 := current.GetTree().Validate(); err2 != nil {
+		err = ErrInvalidTree.Wrap(err2)
+		return
+	}
+	if err2 := merged.GetTree().Validate(); err2 != nil {
+		err = ErrInvalidTree.Wrap(err2)
+		return
+	}
+
+	err = cs.DB.Update(func(tx *bbolt.Tx) error {
+		latest, ref, err := cs.getHead(tx, head)
+		if err != nil {
+			return err
+		}
+		if ref != nil && ref.GetType() != RefType_Branch {
+			return ErrNotBranch.Wrap(fmt.Errorf("id=%s", head))
+		}
+		if !latest.Equals(merged.GetLeftParentID()) {
+			return ErrLatestCommitUpdated.Wrap(fmt.Errorf("head=%s, left=%s", latest, merged.GetLeftParentID()))
+		}
+		if err := cs.checkParentsExist(tx, current.GetLeftParentID(), current.GetRightParentID()); err != nil {
+			return err
+		}
+
+		refs := localObjectRefs{tx: tx}
+		if err := refs.PutCommit(cs.Enc.CommitID(newCurrentID), current); err != nil {
+			return err
+		}
+		merged.RightParentID = newCurrentID
+		if err := refs.PutCommit(cs.Enc.CommitID(newMergedID), merged); err != nil {
+			return err
+		}
+		return cs.putHead(tx, head, ref, newMergedID)
+	})
+	if err == nil {
+		currentID = newCurrentID
+		mergedID = newMergedID
+	}
+	return
+}
+func (cs *localCS) Tree(id *CommitID) (tree *Tree, err error) {
+	var ci *CommitInfo
+	ci, err = cs.Get(id)
+	if err != nil {
+		return
+	}
+	tree = ci.GetTree()
+	return
+}
+func (cs *localCS) Import(id *CommitID, info *CommitInfo) error {
+	vid := id.GetId()
+	left := info.GetLeftParentID()
+	right := info.GetRightParentID()
+
+	// Validate arguments.
+	if err := cs.validateParents(vid, left, right); err != nil {
+		return err
+	}
+	if left == nil && right != nil {
+		return ErrInvalidParentCommit.Wrap(fmt.Errorf("right parent is specified without left parent: %s", right))
+	}
+
+	// Validate tree.
+	if err := info.GetTree().Validate(); err != nil {
+		return ErrInvalidTree.Wrap(err)
+	}
+
+	return cs.DB.Update(func(tx *bbolt.Tx) error {
+		cb := tx.Bucket(localCommitBucket)
+		lcb := tx.Bucket(localLatestCommitBucket)
+
+		// Check whether the volume is exist.
+		if tx.Bucket(localVolumeBucket).Get(cs.Enc.VolumeID(vid)) == nil {
+			return ErrNotFoundVolume.Wrap(fmt.Errorf("id=%s", vid))
+		}
+		if cb.Get(cs.Enc.CommitID(id)) != nil {
+			return ErrDupCommitID.Wrap(fmt.Errorf("id=%s", id))
+		}
+
+		// Check whether parent commits are valid.
+		binVid := cs.Enc.VolumeID(vid)
+		latest := cs.Dec.CommitID(lcb.Get(binVid))
+		if left == nil {
+			if latest != nil {
+				// Only the first commit can have no parents.
+				return ErrInvalidParentCommit.Wrap(fmt.Errorf("parent is not specified: last commit=%s", latest))
+			}
+		} else if cb.Get(cs.Enc.CommitID(left)) == nil {
+			return ErrInvalidParentCommit.Wrap(fmt.Errorf("left parent commit is not found: %s", left))
+		}
+		if right != nil && cb.Get(cs.Enc.CommitID(right)) == nil {
+			return ErrInvalidParentCommit.Wrap(fmt.Errorf("right parent commit is not found: %s", right))
+		}
+
+		if err := (localObjectRefs{tx: tx}).PutCommit(cs.Enc.CommitID(id), info); err != nil {
+			return err
+		}
+		if latest == nil || latest.Equals(left) {
+			// The imported commit is based on the latest commit.  Should update latest CommitID.
+			return lcb.Put(binVid, cs.Enc.CommitID(id))
+		}
+		return nil
+	})
+}
+
+func (cs *localCS) Walk(vid *VolumeID, fn func(id *CommitID, info *CommitInfo) error) error {
+	type entry struct {
+		id   *CommitID
+		info *CommitInfo
+	}
+	var entries []entry
+	err := cs.DB.CommitView(func(b *bbolt.Bucket) error {
+		return bboltPrefixScan(b, cs.Enc.CommitIDPrefix(vid), func(k, v []byte) error {
+			entries = append(entries, entry{
+				id:   cs.Dec.CommitID(k),
+				info: cs.Dec.CommitInfo(v),
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Keys are sorted by lexicographical order.  Should sort by commit number.
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].id.GetNumber() < entries[j].id.GetNumber()
+	})
+	for _, e := range entries {
+		if err := fn(e.id, e.info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (cs *localCS) Ancestors(start *CommitID, fn func(id *CommitID, info *CommitInfo) error) error {
+	type entry struct {
+		id   *CommitID
+		info *CommitInfo
+	}
+	var entries []entry
+	err := cs.DB.CommitView(func(b *bbolt.Bucket) error {
+		visited := map[string]bool{}
+		queue := []*CommitID{start}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			key := cs.Enc.CommitID(id)
+			if visited[string(key)] {
+				continue
+			}
+			visited[string(key)] = true
+
+			data := b.Get(key)
+			if data == nil {
+				return ErrNotFoundCommit.Wrap(fmt.Errorf("id=%s", id))
+			}
+			info := cs.Dec.CommitInfoWithoutTree(data)
+			entries = append(entries, entry{id: id, info: info})
+			if info.GetLeftParentID() != nil {
+				queue = append(queue, info.GetLeftParentID())
+			}
+			if info.GetRightParentID() != nil {
+				queue = append(queue, info.GetRightParentID())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if err := fn(e.id, e.info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (cs *localCS) Delete(ids []*CommitID) (released []*ObjectKey, err error) {
+	err = cs.DB.Update(func(tx *bbolt.Tx) error {
+		cb := tx.Bucket(localCommitBucket)
+		lcb := tx.Bucket(localLatestCommitBucket)
+
+		// Load commits to delete.
+		deleted := map[string]*CommitInfo{}
+		volumes := map[string]*VolumeID{}
+		for _, id := range ids {
+			key := cs.Enc.CommitID(id)
+			data := cb.Get(key)
+			if data == nil {
+				return ErrNotFoundCommit.Wrap(fmt.Errorf("id=%s", id))
+			}
+			latest := cs.Dec.CommitID(lcb.Get(cs.Enc.VolumeID(id.GetId())))
+			if latest.Equals(id) {
+				return ErrDeleteLatestCommit.Wrap(fmt.Errorf("id=%s", id))
+			}
+			deleted[string(key)] = cs.Dec.CommitInfo(data)
+			volumes[id.GetId().GetId()] = id.GetId()
+		}
+		for _, vid := range volumes {
+			if err := bboltPrefixScan(tx.Bucket(localRefBucket), cs.Enc.RefIDPrefix(vid), func(k, v []byte) error {
+				ref := cs.Dec.Ref(v)
+				if _, ok := deleted[string(cs.Enc.CommitID(ref.GetCommit()))]; ok {
+					return ErrDeleteRefCommit.Wrap(fmt.Errorf("id=%s, ref=%s", ref.GetCommit(), k))
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		// resolve returns the nearest ancestors that are not deleted.  Both parents of deleted merge commits are
+		// followed, so that commits merged from other branches are kept reachable.
+		resolved := map[string][]*CommitID{}
+		var resolve func(id *CommitID) []*CommitID
+		resolve = func(id *CommitID) []*CommitID {
+			if id == nil {
+				return nil
+			}
+			key := string(cs.Enc.CommitID(id))
+			info, ok := deleted[key]
+			if !ok {
+				return []*CommitID{id}
+			}
+			if ids, ok := resolved[key]; ok {
+				return ids
+			}
+			ids := appendUniqueCommits(nil, resolve(info.GetLeftParentID())...)
+			ids = appendUniqueCommits(ids, resolve(info.GetRightParentID())...)
+			resolved[key] = ids
+			return ids
+		}
+
+		// Re-link parents of remaining commits.
+		for _, vid := range volumes {
+			var keys [][]byte
+			var infos []*CommitInfo
+			if err := bboltPrefixScan(cb, cs.Enc.CommitIDPrefix(vid), func(k, v []byte) error {
+				if _, ok := deleted[string(k)]; ok {
+					return nil
+				}
+				keys = append(keys, append([]byte{}, k...))
+				infos = append(infos, cs.Dec.CommitInfo(v))
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			for i, info := range infos {
+				parents := appendUniqueCommits(nil, resolve(info.GetLeftParentID())...)
+				parents = appendUniqueCommits(parents, resolve(info.GetRightParentID())...)
+				var left, right *CommitID
+				switch len(parents) {
+				case 0:
+				case 1:
+					// If both parents are pointing to the same commit, it is no longer a merge commit.
+					left = parents[0]
+				case 2:
+					left, right = parents[0], parents[1]
+				default:
+					return ErrDeleteMergeCommit.Wrap(fmt.Errorf("id=%s, parents=%d", cs.Dec.CommitID(keys[i]), len(parents)))
+				}
+				if left.Equals(info.GetLeftParentID()) && right.Equals(info.GetRightParentID()) {
+					// Not changed.
+					continue
+				}
+				info.LeftParentID = left
+				info.RightParentID = right
+				if err := cb.Put(keys[i], cs.Enc.CommitInfo(info)); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Delete commits.  Objects that are not referenced by remaining commits are released.
+		refs := localObjectRefs{tx: tx}
+		for key, info := range deleted {
+			log.Printf("[INFO] Deleting commit %s", key)
+			keys, err := refs.DeleteCommit([]byte(key), info)
+			if err != nil {
+				return IErrDelete.Wrap(err)
+			}
+			released = append(released, keys...)
+		}
+		sort.Slice(released, func(i, j int) bool {
+			return released[i].GetId() < released[j].GetId()
+		})
+		return nil
+	})
+	if err != nil {
+		released = nil
+	}
+	return
+}
+
+// appendUniqueCommits appends commits that are not contained in ids.
+func appendUniqueCommits(ids []*CommitID, commits ...*CommitID) []*CommitID {
+	for _, c := range commits {
+		found := false
+		for _, id := range ids {
+			if id.Equals(c) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ids = append(ids, c)
+		}
+	}
+	return ids
+}
+
+// localObjectRefs updates reference counts of objects when commits are created or deleted.
+type localObjectRefs struct {
+	tx  *bbolt.Tx
+	Enc localEncoder
+	Dec localDecoder
+}
+
+// PutCommit saves the commit and increments reference counts of objects in its tree.
+func (r localObjectRefs) PutCommit(key []byte, info *CommitInfo) error {
+	if err := r.tx.Bucket(localCommitBucket).Put(key, r.Enc.CommitInfo(info)); err != nil {
+		return err
+	}
+	return r.add(info)
+}
+
+// DeleteCommit deletes the commit and decrements reference counts of objects in its tree.  Objects that are no longer
+// referenced by any commits are recorded as released objects.
+func (r localObjectRefs) DeleteCommit(key []byte, info *CommitInfo) (released []*ObjectKey, err error) {
+	if err = r.tx.Bucket(localCommitBucket).Delete(key); err != nil {
+		return
+	}
+	orb := r.tx.Bucket(localObjectRefBucket)
+	rob := r.tx.Bucket(localReleasedObjectBucket)
+	now := r.Enc.Timestamp(ptypes.TimestampNow())
+	for _, obj := range objectKeys(info.GetTree()) {
+		k := r.Enc.ObjectKey(obj)
+		n := r.Dec.ObjectRefCount(orb.Get(k))
+		if n > 1 {
+			if err = orb.Put(k, r.Enc.ObjectRefCount(n-1)); err != nil {
+				return
+			}
+			continue
+		}
+		if err = orb.Delete(k); err != nil {
+			return
+		}
+		if err = rob.Put(k, now); err != nil {
+			return
+		}
+		released = append(released, obj)
+	}
+	return
+}
+
+// Rebuild counts references of all commits.
+func (r localObjectRefs) Rebuild() error {
+	return r.tx.Bucket(localCommitBucket).ForEach(func(k, v []byte) error {
+		return r.add(r.Dec.CommitInfo(v))
+	})
+}
+func (r localObjectRefs) add(info *CommitInfo) error {
+	orb := r.tx.Bucket(localObjectRefBucket)
+	rob := r.tx.Bucket(localReleasedObjectBucket)
+	for _, obj := range objectKeys(info.GetTree()) {
+		k := r.Enc.ObjectKey(obj)
+		n := r.Dec.ObjectRefCount(orb.Get(k))
+		if err := orb.Put(k, r.Enc.ObjectRefCount(n+1)); err != nil {
+			return err
+		}
+		if n == 0 {
+			// The released object is referenced again.  It must not be deleted from storage.
+			if err := rob.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// objectKeys returns objects referenced by the tree without duplication.
+func objectKeys(tree *Tree) []*ObjectKey {
+	var keys []*ObjectKey
+	found := map[string]bool{}
+	for _, file := range tree.GetInodes() {
+		key := file.GetContentRef().GetKey()
+		if key.Empty() || found[key.GetId()] {
+			continue
+		}
+		found[key.GetId()] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (cs *localCS) Resolve(id *CommitID) (cid *CommitID, err error) {
+	if id.GetRef() == "" {
+		return id, nil
+	}
+	ref, err := cs.GetRef(&RefID{
+		Id:   id.GetId(),
+		Name: id.GetRef(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ref.GetCommit(), nil
+}
+func (cs *localCS) CreateRef(id *RefID, ref *Ref) error {
+	if err := ValidateRefName(id.GetName()); err != nil {
+		return ErrInvalidRefName.Wrap(err)
+	}
+	if !id.GetId().Equals(ref.GetCommit().GetId()) {
+		return ErrCrossVolumeCommit.Wrap(fmt.Errorf("mismatch RefID and Ref.Commit"))
+	}
+
+	return cs.DB.Update(func(tx *bbolt.Tx) error {
+		rb := tx.Bucket(localRefBucket)
+
+		if tx.Bucket(localVolumeBucket).Get(cs.Enc.VolumeID(id.GetId())) == nil {
+			return ErrNotFoundVolume.Wrap(fmt.Errorf("id=%s", id.GetId()))
+		}
+		if tx.Bucket(localCommitBucket).Get(cs.Enc.CommitID(ref.GetCommit())) == nil {
+			return ErrNotFoundCommit.Wrap(fmt.Errorf("id=%s", ref.GetCommit()))
+		}
+		if rb.Get(cs.Enc.RefID(id)) != nil {
+			return ErrDupRef.Wrap(fmt.Errorf("id=%s", id))
+		}
+		return rb.Put(
+			cs.Enc.RefID(id),
+			cs.Enc.Ref(ref),
+		)
+	})
+}
+func (cs *localCS) GetRef(id *RefID) (ref *Ref, err error) {
+	err = cs.DB.RefView(func(b *bbolt.Bucket) error {
+		data := b.Get(cs.Enc.RefID(id))
+		if data == nil {
+			return ErrNotFoundRef.Wrap(fmt.Errorf("id=%s", id))
+		}
+		ref = cs.Dec.Ref(data)
+		return nil
+	})
+	return
+}
+func (cs *localCS) ListRefs(vid *VolumeID, fn func(id *RefID, ref *Ref) error) error {
+	return cs.DB.RefView(func(b *bbolt.Bucket) error {
+		return bboltPrefixScan(b, cs.Enc.RefIDPrefix(vid), func(k, v []byte) error {
+			return fn(cs.Dec.RefID(k), cs.Dec.Ref(v))
+		})
+	})
+}
+func (cs *localCS) MoveRef(id *RefID, cid *CommitID) error {
+	if !id.GetId().Equals(cid.GetId()) {
+		return ErrCrossVolumeCommit.Wrap(fmt.Errorf("mismatch RefID and CommitID"))
+	}
+
+	return cs.DB.Update(func(tx *bbolt.Tx) error {
+		rb := tx.Bucket(localRefBucket)
+
+		data := rb.Get(cs.Enc.RefID(id))
+		if data == nil {
+			return ErrNotFoundRef.Wrap(fmt.Errorf("id=%s", id))
+		}
+		ref := cs.Dec.Ref(data)
+		if ref.GetType() == RefType_Tag {
+			return ErrImmutableRef.Wrap(fmt.Errorf("id=%s", id))
+		}
+		if tx.Bucket(localCommitBucket).Get(cs.Enc.CommitID(cid)) == nil {
+			return ErrNotFoundCommit.Wrap(fmt.Errorf("id=%s", cid))
+		}
+
+		ref.Commit = cid
+		return rb.Put(
+			cs.Enc.RefID(id),
+			cs.Enc.Ref(ref),
+		)
+	})
+}
+func (cs *localCS) UpdateHead(id *RefID, expected, cid *CommitID) error {
+	if !id.GetId().Equals(expected.GetId()) || !id.GetId().Equals(cid.GetId()) {
+		return ErrCrossVolumeCommit.Wrap(fmt.Errorf("mismatch RefID and CommitID"))
+	}
+
+	return cs.DB.Update(func(tx *bbolt.Tx) error {
+		current, ref, err := cs.getHead(tx, id)
+		if err != nil {
+			return err
+		}
+		if ref != nil && ref.GetType() == RefType_Tag {
+			return ErrImmutableRef.Wrap(fmt.Errorf("id=%s", id))
+		}
+		if !current.Equals(expected) {
+			return ErrLatestCommitUpdated.Wrap(fmt.Errorf("head=%s, expected=%s", current, expected))
+		}
+		if tx.Bucket(localCommitBucket).Get(cs.Enc.CommitID(cid)) == nil {
+			return ErrNotFoundCommit.Wrap(fmt.Errorf("id=%s", cid))
+		}
+		return cs.putHead(tx, id, ref, cid)
+	})
+}
+func (cs *localCS) DeleteRef(id *RefID) error {
+	return cs.DB.RefUpdate(func(b *bbolt.Bucket) error {
+		key := cs.Enc.RefID(id)
+		if b.Get(key) == nil {
+			return ErrNotFoundRef.Wrap(fmt.Errorf("id=%s", id))
+		}
+		if err := b.Delete(key); err != nil {
+			return IErrDelete.Wrap(err)
+		}
+		return nil
+	})
+}
+
+// getHead returns the commit that head points to.  If head.Name is empty, it returns the latest commit of the volume and
+// ref is nil.  Otherwise, it returns the commit and the ref.  Caller should check the type of ref.
+func (cs *localCS) getHead(tx *bbolt.Tx, head *RefID) (cid *CommitID, ref *Ref, err error) {
+	if head.GetName() == "" {
+		if tx.Bucket(localVolumeBucket).Get(cs.Enc.VolumeID(head.GetId())) == nil {
+			return nil, nil, ErrNotFoundVolume.Wrap(fmt.Errorf("id=%s", head.GetId()))
+		}
+		data := tx.Bucket(localLatestCommitBucket).Get(cs.Enc.VolumeID(head.GetId()))
+		if data == nil {
+			return nil, nil, ErrNotFoundCommit.Wrap(fmt.Errorf("volume has no commits: id=%s", head.GetId()))
+		}
+		return cs.Dec.CommitID(data), nil, nil
+	}
+
+	data := tx.Bucket(localRefBucket).Get(cs.Enc.RefID(head))
+	if data == nil {
+		return nil, nil, ErrNotFoundRef.Wrap(fmt.Errorf("id=%s", head))
+	}
+	ref = cs.Dec.Ref(data)
+	return ref.GetCommit(), ref, nil
+}
+
+// putHead changes the head to cid.  ref must be a value returned by getHead().
+func (cs *localCS) putHead(tx *bbolt.Tx, head *RefID, ref *Ref, cid *CommitID) error {
+	if ref == nil {
+		return tx.Bucket(localLatestCommitBucket).Put(
+			cs.Enc.VolumeID(head.GetId()),
+			cs.Enc.CommitID(cid),
+		)
+	}
+	ref.Commit = cid
+	return tx.Bucket(localRefBucket).Put(
+		cs.Enc.RefID(head),
+		cs.Enc.Ref(ref),
+	)
+}
+
+// checkParentsExist checks that specified parent commits are exist.
+func (cs *localCS) checkParentsExist(tx *bbolt.Tx, left, right *CommitID) error {
+	if tx.Bucket(localCommitBucket).Get(cs.Enc.CommitID(left)) == nil {
+		// Specified left parent is not found.
+		return ErrInvalidParentCommit.Wrap(fmt.Errorf("left parent commit is not found: %s", left))
+	}
+	if right != nil && tx.Bucket(localCommitBucket).Get(cs.Enc.CommitID(right)) == nil {
+		// Right parent is specified.  But it is not found.
+		return ErrInvalidParentCommit.Wrap(fmt.Errorf("right parent commit is not found: %s", right))
+	}
+	return nil
+}
+
+// validateParents checks that vid and VolumeIDs of parent commits are same.
+func (cs *localCS) validateParents(vid *VolumeID, left, right *CommitID) error {
+	if left.GetRef() != "" || right.GetRef() != "" {
+		// Parents must be resolved before saving the commit.
+		return ErrInvalidParentCommit.Wrap(fmt.Errorf("unresolved ref: left=%s, right=%s", left, right))
+	}
+	if left.GetId().GetId() != "" {
+		// Request to create normal commit.
+		if bytes.Compare(
+			cs.Enc.VolumeID(vid),
+			cs.Enc.VolumeID(left.GetId()),
+		) != 0 {
+			return ErrCrossVolumeCommit.Wrap(fmt.Errorf("mismatch VolumeID and CommitInfo.LeftParentID"))
+		}
+	}
+	if right.GetId().GetId() != "" {
+		// Request to create merge commit.
+		if bytes.Compare(
+			cs.Enc.VolumeID(vid),
+			cs.Enc.VolumeID(right.GetId()),
+		) != 0 {
+			return ErrCrossVolumeCommit.Wrap(fmt.Errorf("mismatch VolumeID and CommitInfo.RightParentID"))
+		}
+		if bytes.Compare(
+			cs.Enc.VolumeID(left.GetId()),
+			cs.Enc.VolumeID(right.GetId()),
+		) != 0 {
+			return ErrCrossVolumeCommit.Wrap(fmt.Errorf("mismatch VolumeID of CommitInfo.LeftParentID and CommitInfo.RightParentID"))
+		}
+	}
+	return nil
+}
+
+type localMS struct {
+	DB  *localDB
+	Enc localEncoder
+	Dec localDecoder
+}
+
+func (ms *localMS) Get(id *PropertyID) (prop *Property, err error) {
+	err = ms.DB.MetaView(func(b *bbolt.Bucket) error {
+		prop = ms.get(b, id, time.Now())
+		if prop == nil {
+			return ErrNotFoundProp.Wrap(fmt.Errorf("id=%s", id))
+		}
+		return nil
+	})
+	return
+}
+func (ms *localMS) Set(id *PropertyID, prop *Property, mustCreate bool) (old *Property, err error) {
+	err = ms.DB.MetaUpdate(func(b *bbolt.Bucket) error {
+		if current := ms.get(b, id, time.Now()); current != nil {
+			if mustCreate {
+				return ErrAlreadyExists.Wrap(fmt.Errorf("id=%s", id))
+			}
+
+			if !current.GetAllowReplace() {
+				return ErrNotAllowedReplace.Wrap(fmt.Errorf("id=%s", id))
+			}
+			old = current
+		}
+
+		return b.Put(
+			ms.Enc.PropertyID(id),
+			ms.Enc.Property(prop),
+		)
+	})
+	return
+}
+func (ms *localMS) Delete(id *PropertyID) (old *Property, err error) {
+	err = ms.DB.MetaUpdate(func(b *bbolt.Bucket) error {
+		current := ms.get(b, id, time.Now())
+		if current == nil {
+			return ErrNotFoundProp.Wrap(fmt.Errorf("id=%s", id))
+		}
+		if !current.GetAllowReplace() {
+			return ErrNotAllowedReplace.Wrap(fmt.Errorf("id=%s", id))
+		}
+		if err := b.Delete(ms.Enc.PropertyID(id)); err != nil {
+			return IErrDelete.Wrap(err)
+		}
+		old = current
+		return nil
+	})
+	return
+}
+func (ms *localMS) List(prefix string, fn func(id *PropertyID, prop *Property) error) error {
+	now := time.Now()
+	return ms.DB.MetaView(func(b *bbolt.Bucket) error {
+		return bboltPrefixScan(b, []byte(prefix), func(k, v []byte) error {
+			prop := ms.Dec.Property(v)
+			if isExpiredProperty(prop, now) {
+				return nil
+			}
+			return fn(ms.Dec.PropertyID(k), prop)
+		})
+	})
+}
+func (ms *localMS) DeleteExpired(now time.Time) (deleted []*PropertyID, err error) {
+	err = ms.DB.MetaUpdate(func(b *bbolt.Bucket) error {
+		var keys [][]byte
+		if err := b.ForEach(func(k, v []byte) error {
+			if isExpiredProperty(ms.Dec.Property(v), now) {
+				keys = append(keys, append([]byte{}, k...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		// Should not modify the bucket during iteration.
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return IErrDelete.Wrap(err)
+			}
+			deleted = append(deleted, ms.Dec.PropertyID(k))
+		}
+		return nil
+	})
+	if err != nil {
+		deleted = nil
+	}
+	return
+}
+
+// get returns the property.  If the property is not found or expired, it returns nil.
+func (ms *localMS) get(b *bbolt.Bucket, id *PropertyID, now time.Time) *Property {
+	data := b.Get(ms.Enc.PropertyID(id))
+	if len(data) == 0 {
+		return nil
+	}
+	prop := ms.Dec.Property(data)
+	if isExpiredProperty(prop, now) {
+		return nil
+	}
+	return prop
+}
+
+// isExpiredProperty returns true if the property has expiration time and it is passed.
+func isExpiredProperty(prop *Property, now time.Time) bool {
+	if prop.GetExpireAt() == nil {
+		return false
+	}
+	expireAt, err := ptypes.Timestamp(prop.GetExpireAt())
+	if err != nil {
+		// Invalid timestamp.  Treat it as never expires.
+		return false
+	}
+	return !now.Before(expireAt)
+}
+
+type localNS struct {
+	DB  *localDB
+	Enc localEncoder
+	Dec localDecoder
+	Gen localGenerator
+}
+
+func (ns *localNS) Register(id *NodeID, node *Node) error {
+	return ns.DB.NodeUpdate(func(b *bbolt.Bucket) error {
+		key := ns.Enc.NodeID(id)
+		if b.Get(key) != nil {
+			return ErrNodeAlreadyExists.Wrap(fmt.Errorf("id=%s", id))
+		}
+		return b.Put(
+			key,
+			ns.Enc.Node(node),
 		)
 	})
 }
@@ -730,6 +1816,73 @@ func (ns *localNS) List(walker func(id *NodeID, node *Node) error) error {
 	})
 }
 
+type localOS struct {
+	DB  *localDB
+	Enc localEncoder
+	Dec localDecoder
+}
+
+func (obs *localOS) WalkReleased(fn func(key *ObjectKey, releasedAt time.Time) error) error {
+	return obs.DB.ReleasedObjectView(func(b *bbolt.Bucket) error {
+		return b.ForEach(func(k, v []byte) error {
+			releasedAt, err := ptypes.Timestamp(obs.Dec.Timestamp(v))
+			if err != nil {
+				return IErrDatabase.Wrap(err)
+			}
+			return fn(obs.Dec.ObjectKey(k), releasedAt)
+		})
+	})
+}
+func (obs *localOS) ForgetReleased(key *ObjectKey) error {
+	return obs.DB.ReleasedObjectUpdate(func(b *bbolt.Bucket) error {
+		if err := b.Delete(obs.Enc.ObjectKey(key)); err != nil {
+			return IErrDelete.Wrap(err)
+		}
+		return nil
+	})
+}
+func (obs *localOS) Release(keys []*ObjectKey) error {
+	return obs.DB.Update(func(tx *bbolt.Tx) error {
+		orb := tx.Bucket(localObjectRefBucket)
+		rob := tx.Bucket(localReleasedObjectBucket)
+		now := obs.Enc.Timestamp(ptypes.TimestampNow())
+		for _, key := range keys {
+			k := obs.Enc.ObjectKey(key)
+			if orb.Get(k) != nil {
+				// Referenced by commits.
+				continue
+			}
+			if err := rob.Put(k, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+func (obs *localOS) AddLocation(key *ObjectKey, node *NodeID) error {
+	return obs.DB.ObjectLocationUpdate(func(b *bbolt.Bucket) error {
+		return b.Put(obs.Enc.ObjectLocation(key, node), []byte{})
+	})
+}
+func (obs *localOS) RemoveLocation(key *ObjectKey, node *NodeID) error {
+	return obs.DB.ObjectLocationUpdate(func(b *bbolt.Bucket) error {
+		if err := b.Delete(obs.Enc.ObjectLocation(key, node)); err != nil {
+			return IErrDelete.Wrap(err)
+		}
+		return nil
+	})
+}
+func (obs *localOS) Locations(key *ObjectKey) (nodes []*NodeID, err error) {
+	err = obs.DB.ObjectLocationView(func(b *bbolt.Bucket) error {
+		return bboltPrefixScan(b, obs.Enc.ObjectLocationPrefix(key), func(k, v []byte) error {
+			_, node := obs.Dec.ObjectLocation(k)
+			nodes = append(nodes, node)
+			return nil
+		})
+	})
+	return
+}
+
 func bboltPrefixScan(b *bbolt.Bucket, prefix []byte, fn func(k, v []byte) error) error {
 	c := b.Cursor()
 	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 )
 
 func withLocalDB(t *testing.T, fn func(stores Stores)) {
@@ -195,6 +196,11 @@ func TestLocalVS_Create(t *testing.T) {
 			})
 			assert.NoError(t, err)
 			assert.NotEmpty(t, vid.GetId())
+
+			// Creation time should be set.
+			info, err := vs.Get(vid)
+			assert.NoError(t, err)
+			assert.NotNil(t, info.GetCreatedAt())
 		})
 	})
 	t.Run("should_fail_when_creating_volume_with_duplicate_name", func(t *testing.T) {
@@ -212,6 +218,95 @@ func TestLocalVS_Create(t *testing.T) {
 			assert.Contains(t, err.Error(), "duplicate volume name: ")
 		})
 	})
+	t.Run("should_fail_when_merge_policy_is_unknown", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			_, err := vs.Create(&VolumeInfo{
+				Name:        "foo",
+				MergePolicy: MergePolicy(99),
+			})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid merge policy: ")
+		})
+	})
+}
+
+func TestLocalVS_Fork(t *testing.T) {
+	t.Run("should_share_tree_with_source_commit", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			cs := stores.CommitStore()
+			srcVID, err := vs.Create(&VolumeInfo{Name: "golden"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			latest, err := cs.Latest(srcVID)
+			if !assert.NoError(t, err) {
+				return
+			}
+			tree := createTree()
+			tree.Inodes[1].Entries = map[string]uint64{"file": 2}
+			tree.Inodes[2] = &File{
+				FileType:   FileType_Regular,
+				ContentRef: &FileContentRef{Key: &ObjectKey{Id: "obj"}},
+			}
+			src, err := cs.Create(srcVID, createCommit(latest, nil), tree)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			vid, cid, err := vs.Fork(src, &VolumeInfo{Name: "sandbox"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NotEqual(t, srcVID.GetId(), vid.GetId())
+			assert.Equal(t, vid.GetId(), cid.GetId().GetId())
+
+			// Forked commit should be the latest commit of new volume.
+			latest, err = cs.Latest(vid)
+			assert.NoError(t, err)
+			assert.True(t, cid.Equals(latest))
+			info, err := cs.Get(cid)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Nil(t, info.GetLeftParentID())
+			assert.True(t, src.Equals(info.GetForkedFrom()))
+			assert.Equal(t, "obj", info.GetTree().GetInodes()[2].GetContentRef().GetKey().GetId())
+		})
+	})
+	t.Run("should_fail_when_source_commit_is_not_found", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			vid, cid, err := vs.Fork(&CommitID{Id: &VolumeID{Id: "not_found"}, Number: 1}, &VolumeInfo{Name: "sandbox"})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not found commit: ")
+			assert.Nil(t, vid)
+			assert.Nil(t, cid)
+
+			// Volume should not be created.
+			_, _, err = vs.GetByName("sandbox")
+			assert.Error(t, err)
+		})
+	})
+	t.Run("should_fail_when_creating_volume_with_duplicate_name", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			cs := stores.CommitStore()
+			srcVID, err := vs.Create(&VolumeInfo{Name: "golden"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			src, err := cs.Latest(srcVID)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_, _, err = vs.Fork(src, &VolumeInfo{Name: "golden"})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "duplicate volume name: ")
+		})
+	})
 }
 
 func TestLocalVS_Walk(t *testing.T) {
@@ -255,6 +350,174 @@ func TestLocalVS_Walk(t *testing.T) {
 	})
 }
 
+func TestLocalVS_WalkAfter(t *testing.T) {
+	withLocalDB(t, func(stores Stores) {
+		vs := stores.VolumeStore()
+		for _, id := range []string{"a", "b", "c", "d"} {
+			if !assert.NoError(t, vs.Import(&VolumeID{Id: id}, &VolumeInfo{Name: "volume-" + id})) {
+				return
+			}
+		}
+		walk := func(after *VolumeID) []string {
+			var ids []string
+			err := vs.WalkAfter(after, func(id *VolumeID, info *VolumeInfo) error {
+				ids = append(ids, id.GetId())
+				return nil
+			})
+			assert.NoError(t, err)
+			return ids
+		}
+
+		assert.Equal(t, []string{"a", "b", "c", "d"}, walk(nil))
+		assert.Equal(t, []string{"c", "d"}, walk(&VolumeID{Id: "b"}))
+		// After volume was deleted.
+		assert.Equal(t, []string{"c", "d"}, walk(&VolumeID{Id: "bb"}))
+		assert.Empty(t, walk(&VolumeID{Id: "d"}))
+	})
+}
+
+func TestLocalVS_Import(t *testing.T) {
+	t.Run("should_success_when_passed_valid_args", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			cs := stores.CommitStore()
+			vid := &VolumeID{Id: "imported"}
+			err := vs.Import(vid, &VolumeInfo{Name: "foo"})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			info, err := vs.Get(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, "foo", info.GetName())
+
+			// Imported volume has no commits.
+			cid, err := cs.Latest(vid)
+			assert.Error(t, err)
+			assert.Nil(t, cid)
+		})
+	})
+	t.Run("should_fail_when_volume_id_is_duplicated", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			vid := &VolumeID{Id: "imported"}
+			err := vs.Import(vid, &VolumeInfo{Name: "foo"})
+			assert.NoError(t, err)
+
+			err = vs.Import(vid, &VolumeInfo{Name: "bar"})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "duplicate volume id: ")
+		})
+	})
+	t.Run("should_fail_when_volume_name_is_duplicated", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			_, err := vs.Create(&VolumeInfo{Name: "foo"})
+			assert.NoError(t, err)
+
+			err = vs.Import(&VolumeID{Id: "imported"}, &VolumeInfo{Name: "foo"})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "duplicate volume name: ")
+		})
+	})
+	t.Run("should_fail_when_merge_policy_is_unknown", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			err := vs.Import(&VolumeID{Id: "imported"}, &VolumeInfo{Name: "foo", MergePolicy: MergePolicy(99)})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid merge policy: ")
+		})
+	})
+}
+
+func TestLocalVS_Update(t *testing.T) {
+	t.Run("should_update_volume_info", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			vid, err := vs.Create(&VolumeInfo{Name: "foo"})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			err = vs.Update(vid, func(info *VolumeInfo) error {
+				info.Retention = &RetentionPolicy{KeepLast: 3}
+				return nil
+			})
+			assert.NoError(t, err)
+
+			info, err := vs.Get(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, uint32(3), info.GetRetention().GetKeepLast())
+		})
+	})
+	t.Run("should_update_name_index_when_renamed", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			vid, err := vs.Create(&VolumeInfo{Name: "foo"})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			err = vs.Update(vid, func(info *VolumeInfo) error {
+				info.Name = "bar"
+				return nil
+			})
+			assert.NoError(t, err)
+
+			_, _, err = vs.GetByName("foo")
+			assert.Error(t, err)
+			id, _, err := vs.GetByName("bar")
+			assert.NoError(t, err)
+			assert.Equal(t, vid.GetId(), id.GetId())
+		})
+	})
+	t.Run("should_fail_when_new_name_is_duplicated", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			vid, err := vs.Create(&VolumeInfo{Name: "foo"})
+			assert.NoError(t, err)
+			_, err = vs.Create(&VolumeInfo{Name: "bar"})
+			assert.NoError(t, err)
+
+			err = vs.Update(vid, func(info *VolumeInfo) error {
+				info.Name = "bar"
+				return nil
+			})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "duplicate volume name: ")
+		})
+	})
+	t.Run("should_discard_changes_when_callback_returns_error", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			vid, err := vs.Create(&VolumeInfo{Name: "foo"})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			err = vs.Update(vid, func(info *VolumeInfo) error {
+				info.Name = "bar"
+				return xerrors.New("dummy error")
+			})
+			assert.Error(t, err)
+
+			info, err := vs.Get(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, "foo", info.GetName())
+		})
+	})
+	t.Run("should_fail_when_volume_is_not_found", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			err := vs.Update(&VolumeID{Id: "not-found"}, func(info *VolumeInfo) error {
+				return nil
+			})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not found volume: ")
+		})
+	})
+}
+
 func TestLocalCS_Get(t *testing.T) {
 	t.Run("should_error_when_access_not_exists_commit", func(t *testing.T) {
 		withLocalDB(t, func(stores Stores) {
@@ -294,9 +557,9 @@ func TestLocalCS_Get(t *testing.T) {
 			}, &Tree{
 				RootIno: 1,
 				Inodes: map[uint64]*File{
-					1: {FileType: FileType_Directory},
+					1: {FileType: FileType_Directory, Entries: map[string]uint64{"dir": 2, "file": 3}},
 					2: {FileType: FileType_Directory},
-					3: {FileType: FileType_Regular},
+					3: {FileType: FileType_Regular, ContentRef: &FileContentRef{Key: &ObjectKey{Id: "obj"}}},
 				},
 			})
 			if !assert.Nil(t, err) || !assert.NotNil(t, cid) {
@@ -527,54 +790,1001 @@ func TestLocalCS_Create(t *testing.T) {
 			assert.Nil(t, cid)
 		})
 	})
+	t.Run("should_error_when_tree_is_invalid", func(t *testing.T) {
+		content := &FileContentRef{Key: &ObjectKey{Id: "obj"}}
+		trees := map[string]*Tree{
+			"missing_inode": {RootIno: 1, Inodes: map[uint64]*File{
+				1: {FileType: FileType_Directory, Entries: map[string]uint64{"a": 2}},
+			}},
+			"directory_cycle": {RootIno: 1, Inodes: map[uint64]*File{
+				1: {FileType: FileType_Directory},
+				2: {FileType: FileType_Directory, Entries: map[string]uint64{"b": 3}},
+				3: {FileType: FileType_Directory, Entries: map[string]uint64{"a": 2}},
+			}},
+			"multiply_linked_directory": {RootIno: 1, Inodes: map[uint64]*File{
+				1: {FileType: FileType_Directory, Entries: map[string]uint64{"a": 2, "b": 2}},
+				2: {FileType: FileType_Directory},
+			}},
+			"entries_in_file": {RootIno: 1, Inodes: map[uint64]*File{
+				1: {FileType: FileType_Directory, Entries: map[string]uint64{"a": 2}},
+				2: {FileType: FileType_Regular, ContentRef: content, Entries: map[string]uint64{"b": 1}},
+			}},
+			"no_content": {RootIno: 1, Inodes: map[uint64]*File{
+				1: {FileType: FileType_Directory, Entries: map[string]uint64{"a": 2}},
+				2: {FileType: FileType_Regular},
+			}},
+			"unreachable_inode": {RootIno: 1, Inodes: map[uint64]*File{
+				1: {FileType: FileType_Directory},
+				2: {FileType: FileType_Regular, ContentRef: content},
+			}},
+		}
+		for name, tree := range trees {
+			tree := tree
+			t.Run(name, func(t *testing.T) {
+				withLocalDB(t, func(stores Stores) {
+					vid, err := stores.VolumeStore().Create(&VolumeInfo{Name: "foo"})
+					if !assert.NoError(t, err) {
+						return
+					}
+					cs := stores.CommitStore()
+					parent, err := cs.Latest(vid)
+					if !assert.NoError(t, err) {
+						return
+					}
+					cid, err := cs.Create(vid, &CommitInfo{LeftParentID: parent}, tree)
+					if assert.Error(t, err) {
+						assert.Contains(t, err.Error(), "invalid tree: ")
+					}
+					assert.Nil(t, cid)
+				})
+			})
+		}
+	})
 }
 
-func TestLocalCS_Tree(t *testing.T) {
-	t.Run("should_error_when_access_not_exists_tree", func(t *testing.T) {
+func TestLocalCS_Import(t *testing.T) {
+	t.Run("should_success_when_importing_history", func(t *testing.T) {
 		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
 			cs := stores.CommitStore()
-			tree, err := cs.Tree(&CommitID{
-				Id:     &VolumeID{Id: "not_found"},
-				Number: 0,
-			})
+			vid := &VolumeID{Id: "imported"}
+			if !assert.NoError(t, vs.Import(vid, &VolumeInfo{Name: "foo"})) {
+				return
+			}
+
+			first := &CommitID{Id: vid, Number: 10}
+			second := &CommitID{Id: vid, Number: 20}
+			third := &CommitID{Id: vid, Number: 30}
+			merged := &CommitID{Id: vid, Number: 40}
+			assert.NoError(t, cs.Import(first, createCommit(nil, nil)))
+			assert.NoError(t, cs.Import(second, createCommit(first, nil)))
+			// The third commit does not update the latest commit because it is based on the first commit.
+			assert.NoError(t, cs.Import(third, createCommit(first, nil)))
+			latest, err := cs.Latest(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, second, latest)
+
+			assert.NoError(t, cs.Import(merged, createCommit(second, third)))
+			latest, err = cs.Latest(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, merged, latest)
+
+			left, right, err := cs.Parents(merged)
+			assert.NoError(t, err)
+			assert.Equal(t, second, left)
+			assert.Equal(t, third, right)
+		})
+	})
+	t.Run("should_fail_when_commit_is_duplicated", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			cs := stores.CommitStore()
+			vid := &VolumeID{Id: "imported"}
+			if !assert.NoError(t, vs.Import(vid, &VolumeInfo{Name: "foo"})) {
+				return
+			}
+
+			first := &CommitID{Id: vid, Number: 10}
+			assert.NoError(t, cs.Import(first, createCommit(nil, nil)))
+			err := cs.Import(first, createCommit(nil, nil))
 			assert.Error(t, err)
-			assert.Contains(t, err.Error(), "not found commit: ")
-			assert.Nil(t, tree)
+			assert.Contains(t, err.Error(), "duplicate commit id: ")
 		})
 	})
-	t.Run("should_success_when_access_exists_tree", func(t *testing.T) {
+	t.Run("should_fail_when_parent_is_not_imported", func(t *testing.T) {
 		withLocalDB(t, func(stores Stores) {
 			vs := stores.VolumeStore()
 			cs := stores.CommitStore()
-			vid, err := vs.Create(&VolumeInfo{
-				Name: "vol",
-			})
-			if !assert.NotNil(t, vid) || !assert.Nil(t, err) {
+			vid := &VolumeID{Id: "imported"}
+			if !assert.NoError(t, vs.Import(vid, &VolumeInfo{Name: "foo"})) {
 				return
 			}
 
-			parent, err := cs.Latest(vid)
-			if !assert.NoError(t, err) {
+			err := cs.Import(&CommitID{Id: vid, Number: 20}, createCommit(&CommitID{Id: vid, Number: 10}, nil))
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid parent commit: ")
+		})
+	})
+	t.Run("should_fail_when_second_root_commit_is_imported", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			cs := stores.CommitStore()
+			vid := &VolumeID{Id: "imported"}
+			if !assert.NoError(t, vs.Import(vid, &VolumeInfo{Name: "foo"})) {
 				return
 			}
 
-			cid, err := cs.Create(vid, &CommitInfo{
-				CreatedAt:    ptypes.TimestampNow(),
-				LeftParentID: parent,
-			}, &Tree{
-				RootIno: 1,
-				Inodes: map[uint64]*File{
-					1: {FileType: FileType_Directory},
-					2: {FileType: FileType_Directory},
-				},
-			})
-			if !assert.NotNil(t, cid) || !assert.Nil(t, err) {
+			assert.NoError(t, cs.Import(&CommitID{Id: vid, Number: 10}, createCommit(nil, nil)))
+			err := cs.Import(&CommitID{Id: vid, Number: 20}, createCommit(nil, nil))
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid parent commit: ")
+		})
+	})
+}
+
+func TestLocalCS_Walk(t *testing.T) {
+	withLocalDB(t, func(stores Stores) {
+		vs := stores.VolumeStore()
+		cs := stores.CommitStore()
+		vid := &VolumeID{Id: "imported"}
+		if !assert.NoError(t, vs.Import(vid, &VolumeInfo{Name: "foo"})) {
+			return
+		}
+		// Number 9 and 10 are not sorted by lexicographical order.
+		first := &CommitID{Id: vid, Number: 9}
+		second := &CommitID{Id: vid, Number: 10}
+		assert.NoError(t, cs.Import(first, createCommit(nil, nil)))
+		assert.NoError(t, cs.Import(second, createCommit(first, nil)))
+
+		var ids []*CommitID
+		err := cs.Walk(vid, func(id *CommitID, info *CommitInfo) error {
+			ids = append(ids, id)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*CommitID{first, second}, ids)
+	})
+}
+
+func TestLocalCS_Ancestors(t *testing.T) {
+	withLocalDB(t, func(stores Stores) {
+		vs := stores.VolumeStore()
+		cs := stores.CommitStore()
+		vid := &VolumeID{Id: "imported"}
+		if !assert.NoError(t, vs.Import(vid, &VolumeInfo{Name: "foo"})) {
+			return
+		}
+		// Create following commits.
+		//   1 -- 2 -- 4 -- 5
+		//    \       /
+		//     `- 3 -'
+		ids := []*CommitID{nil}
+		for i := uint64(1); i <= 5; i++ {
+			ids = append(ids, &CommitID{Id: vid, Number: i})
+		}
+		assert.NoError(t, cs.Import(ids[1], createCommit(nil, nil)))
+		assert.NoError(t, cs.Import(ids[2], createCommit(ids[1], nil)))
+		assert.NoError(t, cs.Import(ids[3], createCommit(ids[1], nil)))
+		assert.NoError(t, cs.Import(ids[4], createCommit(ids[2], ids[3])))
+		assert.NoError(t, cs.Import(ids[5], createCommit(ids[4], nil)))
+
+		var visited []*CommitID
+		err := cs.Ancestors(ids[4], func(id *CommitID, info *CommitInfo) error {
+			visited = append(visited, id)
+			assert.Nil(t, info.GetTree())
+			assert.NotNil(t, info.GetCreatedAt())
+			if id.Equals(ids[4]) {
+				assert.Equal(t, ids[2], info.GetLeftParentID())
+				assert.Equal(t, ids[3], info.GetRightParentID())
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*CommitID{ids[4], ids[2], ids[3], ids[1]}, visited)
+
+		err = cs.Ancestors(&CommitID{Id: vid, Number: 100}, func(id *CommitID, info *CommitInfo) error {
+			return nil
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found commit: ")
+	})
+}
+func TestLocalCS_Delete(t *testing.T) {
+	createCommitWithObject := func(left, right *CommitID, key string) *CommitInfo {
+		info := createCommit(left, right)
+		info.Tree.Inodes[1].Entries = map[string]uint64{"file": 2}
+		info.Tree.Inodes[2] = &File{
+			FileType:   FileType_Regular,
+			ContentRef: &FileContentRef{Key: &ObjectKey{Id: key}},
+		}
+		return info
+	}
+	// Create following commits.
+	//   1 -- 2 -- 3 -- 5
+	//         \       /
+	//          `- 4 -'
+	importCommits := func(t *testing.T, stores Stores) []*CommitID {
+		vs := stores.VolumeStore()
+		cs := stores.CommitStore()
+		vid := &VolumeID{Id: "imported"}
+		if !assert.NoError(t, vs.Import(vid, &VolumeInfo{Name: "foo"})) {
+			t.FailNow()
+		}
+		ids := []*CommitID{nil}
+		for i := uint64(1); i <= 5; i++ {
+			ids = append(ids, &CommitID{Id: vid, Number: i})
+		}
+		assert.NoError(t, cs.Import(ids[1], createCommitWithObject(nil, nil, "obj-1")))
+		assert.NoError(t, cs.Import(ids[2], createCommitWithObject(ids[1], nil, "obj-shared")))
+		assert.NoError(t, cs.Import(ids[3], createCommitWithObject(ids[2], nil, "obj-3")))
+		assert.NoError(t, cs.Import(ids[4], createCommitWithObject(ids[2], nil, "obj-shared")))
+		assert.NoError(t, cs.Import(ids[5], createCommitWithObject(ids[3], ids[4], "obj-5")))
+		return ids
+	}
+
+	t.Run("should_relink_parents", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := importCommits(t, stores)
+
+			_, err := cs.Delete([]*CommitID{ids[2], ids[3]})
+			if !assert.NoError(t, err) {
 				return
 			}
 
-			tree, err := cs.Tree(cid)
-			assert.Nil(t, err)
-			assert.NotNil(t, tree)
+			ok, err := cs.Exists(ids[2])
+			assert.NoError(t, err)
+			assert.False(t, ok)
+			left, right, err := cs.Parents(ids[4])
+			assert.NoError(t, err)
+			assert.Equal(t, ids[1], left)
+			assert.Nil(t, right)
+			left, right, err = cs.Parents(ids[5])
+			assert.NoError(t, err)
+			assert.Equal(t, ids[1], left)
+			assert.Equal(t, ids[4], right)
+		})
+	})
+	t.Run("should_make_new_root_commit", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := importCommits(t, stores)
+
+			_, err := cs.Delete([]*CommitID{ids[1], ids[2], ids[3]})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			left, right, err := cs.Parents(ids[4])
+			assert.NoError(t, err)
+			assert.Nil(t, left)
+			assert.Nil(t, right)
+			// Both parents are lost.  Right parent should be moved to left parent.
+			left, right, err = cs.Parents(ids[5])
+			assert.NoError(t, err)
+			assert.Equal(t, ids[4], left)
+			assert.Nil(t, right)
+		})
+	})
+	t.Run("should_release_unreferenced_objects", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := importCommits(t, stores)
+
+			released, err := cs.Delete([]*CommitID{ids[1], ids[2]})
+			if !assert.NoError(t, err) {
+				return
+			}
+			// obj-shared is still referenced by commit 4.
+			assert.Equal(t, []*ObjectKey{{Id: "obj-1"}}, released)
+
+			var keys []*ObjectKey
+			err = stores.ObjectStore().WalkReleased(func(key *ObjectKey, releasedAt time.Time) error {
+				keys = append(keys, key)
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, released, keys)
+
+			assert.NoError(t, stores.ObjectStore().ForgetReleased(&ObjectKey{Id: "obj-1"}))
+			keys = nil
+			err = stores.ObjectStore().WalkReleased(func(key *ObjectKey, releasedAt time.Time) error {
+				keys = append(keys, key)
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Empty(t, keys)
+		})
+	})
+	t.Run("should_keep_objects_referenced_by_other_volumes", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := importCommits(t, stores)
+			// The forked volume shares obj-shared with commit 4.
+			_, _, err := stores.VolumeStore().Fork(ids[4], &VolumeInfo{Name: "forked"})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			released, err := cs.Delete([]*CommitID{ids[2], ids[4]})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Empty(t, released)
+		})
+	})
+	t.Run("should_release_objects_of_deleted_volume", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			ids := importCommits(t, stores)
+			if !assert.NoError(t, stores.VolumeStore().Delete(ids[1].GetId())) {
+				return
+			}
+
+			var keys []string
+			err := stores.ObjectStore().WalkReleased(func(key *ObjectKey, releasedAt time.Time) error {
+				keys = append(keys, key.GetId())
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"obj-1", "obj-3", "obj-5", "obj-shared"}, keys)
+		})
+	})
+	// Create commits from the list of parent numbers.  Zero means no parent.  The last commit becomes the latest.
+	importGraph := func(t *testing.T, stores Stores, parents [][2]uint64) []*CommitID {
+		vs := stores.VolumeStore()
+		cs := stores.CommitStore()
+		vid := &VolumeID{Id: "imported"}
+		if !assert.NoError(t, vs.Import(vid, &VolumeInfo{Name: "foo"})) {
+			t.FailNow()
+		}
+		ids := []*CommitID{nil}
+		for i := range parents {
+			ids = append(ids, &CommitID{Id: vid, Number: uint64(i + 1)})
+		}
+		for i, p := range parents {
+			if !assert.NoError(t, cs.Import(ids[i+1], createCommit(ids[p[0]], ids[p[1]]))) {
+				t.FailNow()
+			}
+		}
+		return ids
+	}
+	t.Run("should_keep_right_side_of_deleted_merge_commit", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			//   1 -- 2 -- 3 -- 5 -- 6
+			//         \       /
+			//          `- 4 -'
+			ids := importGraph(t, stores, [][2]uint64{{0, 0}, {1, 0}, {2, 0}, {2, 0}, {3, 4}, {5, 0}})
+
+			_, err := cs.Delete([]*CommitID{ids[5]})
+			if !assert.NoError(t, err) {
+				return
+			}
+			left, right, err := cs.Parents(ids[6])
+			assert.NoError(t, err)
+			assert.Equal(t, ids[3], left)
+			assert.Equal(t, ids[4], right)
+		})
+	})
+	t.Run("should_fail_when_commit_has_more_than_two_parents", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			// Commit 6 merges the merge commit 5 and the commit 4.
+			ids := importGraph(t, stores, [][2]uint64{{0, 0}, {1, 0}, {1, 0}, {1, 0}, {2, 3}, {5, 4}, {6, 0}})
+
+			_, err := cs.Delete([]*CommitID{ids[5]})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "cannot re-link parents of deleted merge commits: ")
+
+			// Changes should be discarded.
+			ok, err := cs.Exists(ids[5])
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		})
+	})
+	t.Run("should_fail_when_deleting_latest_commit", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := importCommits(t, stores)
+
+			_, err := cs.Delete([]*CommitID{ids[4], ids[5]})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "cannot delete the latest commit: ")
+
+			// Changes should be discarded.
+			ok, err := cs.Exists(ids[4])
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		})
+	})
+	t.Run("should_fail_when_commit_is_not_found", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := importCommits(t, stores)
+
+			_, err := cs.Delete([]*CommitID{{Id: ids[1].GetId(), Number: 100}})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not found commit: ")
+		})
+	})
+}
+
+func TestLocalCS_Ref(t *testing.T) {
+	// Create a volume with 3 commits.  It returns a list of commit ids.
+	prepare := func(t *testing.T, stores Stores) []*CommitID {
+		vs := stores.VolumeStore()
+		cs := stores.CommitStore()
+		vid, err := vs.Create(&VolumeInfo{Name: "foo"})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		latest, err := cs.Latest(vid)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		ids := []*CommitID{latest}
+		for i := 0; i < 2; i++ {
+			cid, err := cs.Create(vid, createCommit(ids[len(ids)-1], nil), createTree())
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+			ids = append(ids, cid)
+		}
+		return ids
+	}
+
+	t.Run("should_create_and_get_ref", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			rid := &RefID{Id: ids[0].GetId(), Name: "main"}
+
+			err := cs.CreateRef(rid, &Ref{Type: RefType_Branch, Commit: ids[1]})
+			assert.NoError(t, err)
+			ref, err := cs.GetRef(rid)
+			assert.NoError(t, err)
+			assert.Equal(t, RefType_Branch, ref.GetType())
+			assert.Equal(t, ids[1], ref.GetCommit())
+
+			resolved, err := cs.Resolve(&CommitID{Id: ids[0].GetId(), Ref: "main"})
+			assert.NoError(t, err)
+			assert.Equal(t, ids[1], resolved)
+			// CommitID without ref name should be returned as it is.
+			resolved, err = cs.Resolve(ids[2])
+			assert.NoError(t, err)
+			assert.Equal(t, ids[2], resolved)
+
+			err = cs.CreateRef(rid, &Ref{Type: RefType_Branch, Commit: ids[2]})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "duplicate ref: ")
+		})
+	})
+	t.Run("should_reject_invalid_ref", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+
+			err := cs.CreateRef(&RefID{Id: ids[0].GetId(), Name: "a:b"}, &Ref{Commit: ids[1]})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid ref name: ")
+
+			err = cs.CreateRef(&RefID{Id: ids[0].GetId(), Name: "main"}, &Ref{Commit: &CommitID{Id: ids[0].GetId(), Number: 100}})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not found commit: ")
+
+			_, err = cs.GetRef(&RefID{Id: ids[0].GetId(), Name: "main"})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not found ref: ")
+		})
+	})
+	t.Run("should_move_branch_but_not_tag", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			branch := &RefID{Id: ids[0].GetId(), Name: "main"}
+			tag := &RefID{Id: ids[0].GetId(), Name: "v1"}
+			assert.NoError(t, cs.CreateRef(branch, &Ref{Type: RefType_Branch, Commit: ids[0]}))
+			assert.NoError(t, cs.CreateRef(tag, &Ref{Type: RefType_Tag, Commit: ids[0]}))
+
+			assert.NoError(t, cs.MoveRef(branch, ids[2]))
+			ref, err := cs.GetRef(branch)
+			assert.NoError(t, err)
+			assert.Equal(t, ids[2], ref.GetCommit())
+
+			err = cs.MoveRef(tag, ids[2])
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "ref is immutable: ")
+		})
+	})
+	t.Run("should_list_and_delete_refs", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			vid := ids[0].GetId()
+			assert.NoError(t, cs.CreateRef(&RefID{Id: vid, Name: "a"}, &Ref{Commit: ids[0]}))
+			assert.NoError(t, cs.CreateRef(&RefID{Id: vid, Name: "b"}, &Ref{Commit: ids[1]}))
+
+			var names []string
+			err := cs.ListRefs(vid, func(id *RefID, ref *Ref) error {
+				names = append(names, id.GetName())
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"a", "b"}, names)
+
+			assert.NoError(t, cs.DeleteRef(&RefID{Id: vid, Name: "a"}))
+			err = cs.DeleteRef(&RefID{Id: vid, Name: "a"})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not found ref: ")
+		})
+	})
+	t.Run("should_advance_branch_on_commit", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			branch := &RefID{Id: ids[0].GetId(), Name: "topic"}
+			assert.NoError(t, cs.CreateRef(branch, &Ref{Type: RefType_Branch, Commit: ids[1]}))
+
+			cid, err := cs.CreateOnBranch(branch, createCommit(ids[1], nil), createTree())
+			if !assert.NoError(t, err) {
+				return
+			}
+			ref, err := cs.GetRef(branch)
+			assert.NoError(t, err)
+			assert.Equal(t, cid, ref.GetCommit())
+
+			// The commit is not based on the branch head.  The branch should not be moved.
+			_, err = cs.CreateOnBranch(branch, createCommit(ids[2], nil), createTree())
+			assert.NoError(t, err)
+			ref, err = cs.GetRef(branch)
+			assert.NoError(t, err)
+			assert.Equal(t, cid, ref.GetCommit())
+		})
+	})
+	t.Run("should_not_delete_ref_commit", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			assert.NoError(t, cs.CreateRef(&RefID{Id: ids[0].GetId(), Name: "v1"}, &Ref{Type: RefType_Tag, Commit: ids[1]}))
+
+			_, err := cs.Delete([]*CommitID{ids[1]})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "cannot delete the commit referenced by ref: ")
+		})
+	})
+}
+func TestLocalCS_CreateFastForward(t *testing.T) {
+	t.Run("should_move_latest_when_based_on_latest", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			cs := stores.CommitStore()
+			vid, err := vs.Create(&VolumeInfo{Name: "foo"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			first, err := cs.Latest(vid)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			cid, err := cs.CreateFastForward(&RefID{Id: vid}, createCommit(first, nil), createTree())
+			assert.NoError(t, err)
+			latest, err := cs.Latest(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, cid, latest)
+		})
+	})
+	t.Run("should_fail_without_saving_when_latest_is_updated", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			cs := stores.CommitStore()
+			vid, err := vs.Create(&VolumeInfo{Name: "foo"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			first, err := cs.Latest(vid)
+			if !assert.NoError(t, err) {
+				return
+			}
+			second, err := cs.Create(vid, createCommit(first, nil), createTree())
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_, err = cs.CreateFastForward(&RefID{Id: vid}, createCommit(first, nil), createTree())
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "latest commit is updated by other thread: ")
+			}
+			latest, err := cs.Latest(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, second, latest)
+
+			var n int
+			assert.NoError(t, cs.Walk(vid, func(id *CommitID, info *CommitInfo) error {
+				n++
+				return nil
+			}))
+			assert.Equal(t, 2, n)
+		})
+	})
+	t.Run("should_move_branch", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			cs := stores.CommitStore()
+			vid, err := vs.Create(&VolumeInfo{Name: "foo"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			first, err := cs.Latest(vid)
+			if !assert.NoError(t, err) {
+				return
+			}
+			rid := &RefID{Id: vid, Name: "topic"}
+			if !assert.NoError(t, cs.CreateRef(rid, &Ref{Type: RefType_Branch, Commit: first})) {
+				return
+			}
+
+			cid, err := cs.CreateFastForward(rid, createCommit(first, nil), createTree())
+			assert.NoError(t, err)
+			ref, err := cs.GetRef(rid)
+			assert.NoError(t, err)
+			assert.Equal(t, cid, ref.GetCommit())
+			// The latest commit should not be changed.
+			latest, err := cs.Latest(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, first, latest)
+		})
+	})
+}
+func TestLocalCS_CreateMerge(t *testing.T) {
+	// Create a volume with 2 commits.  It returns a list of commit ids.
+	prepare := func(t *testing.T, stores Stores) []*CommitID {
+		vs := stores.VolumeStore()
+		cs := stores.CommitStore()
+		vid, err := vs.Create(&VolumeInfo{Name: "foo"})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		first, err := cs.Latest(vid)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		second, err := cs.Create(vid, createCommit(first, nil), createTree())
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return []*CommitID{first, second}
+	}
+
+	t.Run("should_save_both_commits_and_move_latest", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			vid := ids[0].GetId()
+
+			currentID, mergedID, err := cs.CreateMerge(&RefID{Id: vid}, createCommit(ids[0], nil), createCommit(ids[1], nil))
+			if !assert.NoError(t, err) {
+				return
+			}
+			latest, err := cs.Latest(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, mergedID, latest)
+
+			left, right, err := cs.Parents(mergedID)
+			assert.NoError(t, err)
+			assert.Equal(t, ids[1], left)
+			assert.Equal(t, currentID, right)
+			left, right, err = cs.Parents(currentID)
+			assert.NoError(t, err)
+			assert.Equal(t, ids[0], left)
+			assert.Nil(t, right)
+		})
+	})
+	t.Run("should_fail_without_saving_when_latest_is_updated", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			vid := ids[0].GetId()
+
+			// The merge commit is based on the first commit, but the latest commit is the second commit.
+			_, _, err := cs.CreateMerge(&RefID{Id: vid}, createCommit(ids[0], nil), createCommit(ids[0], nil))
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "latest commit is updated by other thread: ")
+			}
+			latest, err := cs.Latest(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, ids[1], latest)
+
+			var n int
+			assert.NoError(t, cs.Walk(vid, func(id *CommitID, info *CommitInfo) error {
+				n++
+				return nil
+			}))
+			assert.Equal(t, 2, n)
+		})
+	})
+}
+func TestLocalCS_UpdateHead(t *testing.T) {
+	// Create a volume with 2 commits.  It returns a list of commit ids.
+	prepare := func(t *testing.T, stores Stores) []*CommitID {
+		vs := stores.VolumeStore()
+		cs := stores.CommitStore()
+		vid, err := vs.Create(&VolumeInfo{Name: "foo"})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		first, err := cs.Latest(vid)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		second, err := cs.Create(vid, createCommit(first, nil), createTree())
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return []*CommitID{first, second}
+	}
+
+	t.Run("should_update_latest_when_expected_matches", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			vid := ids[0].GetId()
+
+			err := cs.UpdateHead(&RefID{Id: vid}, ids[1], ids[0])
+			assert.NoError(t, err)
+			latest, err := cs.Latest(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, ids[0], latest)
+		})
+	})
+	t.Run("should_fail_when_latest_is_updated", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			vid := ids[0].GetId()
+
+			err := cs.UpdateHead(&RefID{Id: vid}, ids[0], ids[0])
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "latest commit is updated by other thread: ")
+			}
+			latest, err := cs.Latest(vid)
+			assert.NoError(t, err)
+			assert.Equal(t, ids[1], latest)
+		})
+	})
+	t.Run("should_update_branch", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			rid := &RefID{Id: ids[0].GetId(), Name: "main"}
+			if !assert.NoError(t, cs.CreateRef(rid, &Ref{Type: RefType_Branch, Commit: ids[0]})) {
+				return
+			}
+
+			err := cs.UpdateHead(rid, ids[1], ids[1])
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "latest commit is updated by other thread: ")
+			}
+			err = cs.UpdateHead(rid, ids[0], ids[1])
+			assert.NoError(t, err)
+			ref, err := cs.GetRef(rid)
+			assert.NoError(t, err)
+			assert.Equal(t, ids[1], ref.GetCommit())
+		})
+	})
+	t.Run("should_fail_when_updating_tag", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			ids := prepare(t, stores)
+			rid := &RefID{Id: ids[0].GetId(), Name: "v1"}
+			if !assert.NoError(t, cs.CreateRef(rid, &Ref{Type: RefType_Tag, Commit: ids[0]})) {
+				return
+			}
+
+			err := cs.UpdateHead(rid, ids[0], ids[1])
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "ref is immutable: ")
+			}
+		})
+	})
+}
+
+func TestLocalCS_Tree(t *testing.T) {
+	t.Run("should_error_when_access_not_exists_tree", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			cs := stores.CommitStore()
+			tree, err := cs.Tree(&CommitID{
+				Id:     &VolumeID{Id: "not_found"},
+				Number: 0,
+			})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not found commit: ")
+			assert.Nil(t, tree)
+		})
+	})
+	t.Run("should_success_when_access_exists_tree", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			vs := stores.VolumeStore()
+			cs := stores.CommitStore()
+			vid, err := vs.Create(&VolumeInfo{
+				Name: "vol",
+			})
+			if !assert.NotNil(t, vid) || !assert.Nil(t, err) {
+				return
+			}
+
+			parent, err := cs.Latest(vid)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			cid, err := cs.Create(vid, &CommitInfo{
+				CreatedAt:    ptypes.TimestampNow(),
+				LeftParentID: parent,
+			}, &Tree{
+				RootIno: 1,
+				Inodes: map[uint64]*File{
+					1: {FileType: FileType_Directory, Entries: map[string]uint64{"dir": 2}},
+					2: {FileType: FileType_Directory},
+				},
+			})
+			if !assert.NotNil(t, cid) || !assert.Nil(t, err) {
+				return
+			}
+
+			tree, err := cs.Tree(cid)
+			assert.Nil(t, err)
+			assert.NotNil(t, tree)
+		})
+	})
+}
+
+func TestLocalMS_Delete(t *testing.T) {
+	t.Run("should_delete_property", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			ms := stores.MetaStore()
+			_, err := ms.Set(&PropertyID{Id: "foo"}, &Property{Body: "body", AllowReplace: true}, true)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			old, err := ms.Delete(&PropertyID{Id: "foo"})
+			assert.NoError(t, err)
+			assert.Equal(t, "body", old.GetBody())
+			_, err = ms.Get(&PropertyID{Id: "foo"})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "not found property: ")
+			}
+		})
+	})
+	t.Run("should_fail_when_replacement_is_not_allowed", func(t *testing.T) {
+		withLocalDB(t, func(stores Stores) {
+			ms := stores.MetaStore()
+			_, err := ms.Set(&PropertyID{Id: "foo"}, &Property{Body: "body"}, true)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_, err = ms.Delete(&PropertyID{Id: "foo"})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "replacement not allowed: ")
+			}
+		})
+	})
+}
+func TestLocalMS_List(t *testing.T) {
+	withLocalDB(t, func(stores Stores) {
+		ms := stores.MetaStore()
+		expired, _ := ptypes.TimestampProto(time.Now().Add(-time.Second))
+		props := map[string]*Property{
+			"a/2":       {Body: "2"},
+			"a/1":       {Body: "1"},
+			"b":         {Body: "b"},
+			"a/expired": {Body: "expired", ExpireAt: expired},
+		}
+		for key, prop := range props {
+			_, err := ms.Set(&PropertyID{Id: key}, prop, true)
+			if !assert.NoError(t, err) {
+				return
+			}
+		}
+
+		var keys []string
+		err := ms.List("a/", func(id *PropertyID, prop *Property) error {
+			keys = append(keys, id.GetId())
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a/1", "a/2"}, keys)
+	})
+}
+func TestLocalMS_DeleteExpired(t *testing.T) {
+	withLocalDB(t, func(stores Stores) {
+		ms := stores.MetaStore()
+		now := time.Now()
+		expireAt, _ := ptypes.TimestampProto(now.Add(time.Minute))
+		_, err := ms.Set(&PropertyID{Id: "foo"}, &Property{Body: "foo", ExpireAt: expireAt}, true)
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = ms.Set(&PropertyID{Id: "bar"}, &Property{Body: "bar"}, true)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		deleted, err := ms.DeleteExpired(now)
+		assert.NoError(t, err)
+		assert.Len(t, deleted, 0)
+
+		deleted, err = ms.DeleteExpired(now.Add(time.Hour))
+		assert.NoError(t, err)
+		if assert.Len(t, deleted, 1) {
+			assert.Equal(t, "foo", deleted[0].GetId())
+		}
+		_, err = ms.Get(&PropertyID{Id: "bar"})
+		assert.NoError(t, err)
+	})
+}
+func TestLocalOS_Location(t *testing.T) {
+	withLocalDB(t, func(stores Stores) {
+		obs := stores.ObjectStore()
+		key := &ObjectKey{Id: "obj1"}
+		otherKey := &ObjectKey{Id: "obj10"}
+		ids := func(nodes []*NodeID) []string {
+			var ids []string
+			for _, n := range nodes {
+				ids = append(ids, n.GetId())
+			}
+			return ids
+		}
+
+		assert.NoError(t, obs.AddLocation(key, &NodeID{Id: "node-a"}))
+		assert.NoError(t, obs.AddLocation(key, &NodeID{Id: "node-b"}))
+		// Should ignore duplicate records.
+		assert.NoError(t, obs.AddLocation(key, &NodeID{Id: "node-a"}))
+		assert.NoError(t, obs.AddLocation(otherKey, &NodeID{Id: "node-c"}))
+
+		nodes, err := obs.Locations(key)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"node-a", "node-b"}, ids(nodes))
+
+		assert.NoError(t, obs.RemoveLocation(key, &NodeID{Id: "node-a"}))
+		// Should ignore not recorded locations.
+		assert.NoError(t, obs.RemoveLocation(key, &NodeID{Id: "node-c"}))
+		nodes, err = obs.Locations(key)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"node-b"}, ids(nodes))
+
+		nodes, err = obs.Locations(&ObjectKey{Id: "not-found"})
+		assert.NoError(t, err)
+		assert.Len(t, nodes, 0)
+	})
+}
+func TestLocalOS_Release(t *testing.T) {
+	withLocalDB(t, func(stores Stores) {
+		vid := &VolumeID{Id: "imported"}
+		if !assert.NoError(t, stores.VolumeStore().Import(vid, &VolumeInfo{Name: "foo"})) {
+			return
+		}
+		info := createCommit(nil, nil)
+		info.Tree.Inodes[1].Entries = map[string]uint64{"file": 2}
+		info.Tree.Inodes[2] = &File{
+			FileType:   FileType_Regular,
+			ContentRef: &FileContentRef{Key: &ObjectKey{Id: "used"}},
+		}
+		if !assert.NoError(t, stores.CommitStore().Import(&CommitID{Id: vid, Number: 1}, info)) {
+			return
+		}
+
+		obs := stores.ObjectStore()
+		// Objects referenced by commits should not be released.
+		assert.NoError(t, obs.Release([]*ObjectKey{{Id: "used"}, {Id: "unused"}}))
+		var keys []string
+		err := obs.WalkReleased(func(key *ObjectKey, releasedAt time.Time) error {
+			keys = append(keys, key.GetId())
+			return nil
 		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"unused"}, keys)
 	})
 }
@@ -0,0 +1,185 @@
+package simple
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sort"
+	"sync"
+	"time"
+)
+
+func (v *localVolumeServer) CommitQueueStatus(ctx context.Context, req *CommitQueueStatusRequest) (*CommitQueueStatusResponse, error) {
+	return &CommitQueueStatusResponse{
+		Queues: v.queue.Status(req.GetId()),
+	}, nil
+}
+
+// commitQueue serializes commits for each volume.  Commits to the same volume are processed one by one in arrival
+// order, so each volume behaves like a single-writer log.  Commits to different volumes are processed concurrently.
+type commitQueue struct {
+	lock   sync.Mutex
+	queues map[string]*volumeCommitQueue
+}
+
+// volumeCommitQueue is the FIFO queue of a volume.  All fields are protected by commitQueue.lock.
+type volumeCommitQueue struct {
+	running bool
+	// waiters are closed in arrival order when the running commit finishes.
+	waiters []chan struct{}
+	stats   CommitQueueStatus
+	// Merge time in the stats is converted from them when Status() is called.
+	totalMergeTime time.Duration
+	maxMergeTime   time.Duration
+	lastMergeTime  time.Duration
+}
+
+func newCommitQueue() *commitQueue {
+	return &commitQueue{
+		queues: map[string]*volumeCommitQueue{},
+	}
+}
+
+func (q *volumeCommitQueue) depth() uint64 {
+	d := uint64(len(q.waiters))
+	if q.running {
+		d++
+	}
+	return d
+}
+
+// Do runs fn after all commits to the volume that arrived earlier are finished.  If ctx is canceled while waiting, it
+// returns the error without calling fn.
+func (c *commitQueue) Do(ctx context.Context, vid *VolumeID, fn func() error) error {
+	if err := c.enter(ctx, vid.GetId()); err != nil {
+		return err
+	}
+	defer c.leave(vid.GetId())
+	return fn()
+}
+func (c *commitQueue) enter(ctx context.Context, vid string) error {
+	c.lock.Lock()
+	q := c.queues[vid]
+	if q == nil {
+		q = &volumeCommitQueue{
+			stats: CommitQueueStatus{Id: &VolumeID{Id: vid}},
+		}
+		c.queues[vid] = q
+	}
+	var ch chan struct{}
+	if q.running {
+		ch = make(chan struct{})
+		q.waiters = append(q.waiters, ch)
+	} else {
+		q.running = true
+	}
+	if d := q.depth(); d > q.stats.MaxDepth {
+		q.stats.MaxDepth = d
+	}
+	c.lock.Unlock()
+
+	if ch == nil {
+		return nil
+	}
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	select {
+	case <-ch:
+		// The turn came while canceling.  Pass it to the next commit.
+		c.handOver(vid, q)
+	default:
+		for i := range q.waiters {
+			if q.waiters[i] == ch {
+				q.waiters = append(q.waiters[:i], q.waiters[i+1:]...)
+				break
+			}
+		}
+	}
+	return status.Error(codes.Canceled, "canceled while waiting for preceding commits")
+}
+func (c *commitQueue) leave(vid string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.handOver(vid, c.queues[vid])
+}
+
+// handOver wakes up the next commit.  If there is no waiting commit, the queue becomes idle.  Caller must hold the lock.
+func (c *commitQueue) handOver(vid string, q *volumeCommitQueue) {
+	if len(q.waiters) > 0 {
+		close(q.waiters[0])
+		q.waiters = q.waiters[1:]
+		return
+	}
+	q.running = false
+}
+
+// Observe records the result of a commit.
+func (c *commitQueue) Observe(vid *VolumeID, retries int, err error) {
+	c.update(vid, func(q *volumeCommitQueue) {
+		q.stats.Retries += uint64(retries)
+		if err == nil {
+			q.stats.Commits++
+		} else {
+			q.stats.Failures++
+		}
+	})
+}
+
+// ObserveMerge records the time spent on merging two trees.
+func (c *commitQueue) ObserveMerge(vid *VolumeID, d time.Duration) {
+	c.update(vid, func(q *volumeCommitQueue) {
+		q.stats.Merges++
+		q.totalMergeTime += d
+		q.lastMergeTime = d
+		if d > q.maxMergeTime {
+			q.maxMergeTime = d
+		}
+	})
+}
+func (c *commitQueue) update(vid *VolumeID, fn func(q *volumeCommitQueue)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if q := c.queues[vid.GetId()]; q != nil {
+		fn(q)
+	}
+}
+
+// Forget drops the stats of the deleted volume.  The queue is kept while commits are running or waiting.
+func (c *commitQueue) Forget(vid *VolumeID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if q := c.queues[vid.GetId()]; q != nil && q.depth() == 0 {
+		delete(c.queues, vid.GetId())
+	}
+}
+
+// Status returns the stats of queues ordered by volume ID.  If vid is nil, it returns all queues.
+func (c *commitQueue) Status(vid *VolumeID) []*CommitQueueStatus {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var out []*CommitQueueStatus
+	for id, q := range c.queues {
+		if vid.GetId() != "" && vid.GetId() != id {
+			continue
+		}
+		st := q.stats
+		st.Depth = q.depth()
+		st.TotalMergeTime = ptypes.DurationProto(q.totalMergeTime)
+		st.MaxMergeTime = ptypes.DurationProto(q.maxMergeTime)
+		st.LastMergeTime = ptypes.DurationProto(q.lastMergeTime)
+		out = append(out, &st)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].GetId().GetId() < out[j].GetId().GetId()
+	})
+	return out
+}
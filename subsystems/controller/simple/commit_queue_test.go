@@ -0,0 +1,121 @@
+package simple
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestCommitQueue_Do(t *testing.T) {
+	vid := &elton_v2.VolumeID{Id: "vol"}
+	// waitDepth waits until the specified number of commits are queued.
+	waitDepth := func(q *commitQueue, depth uint64) {
+		for i := 0; i < 100; i++ {
+			if st := q.Status(vid); len(st) == 1 && st[0].GetDepth() == depth {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("timeout: depth=%d", depth)
+	}
+
+	t.Run("should_process_commits_in_arrival_order", func(t *testing.T) {
+		q := newCommitQueue()
+		release := make(chan struct{})
+		done := make(chan int, 4)
+		go q.Do(context.Background(), vid, func() error {
+			<-release
+			done <- 0
+			return nil
+		})
+		waitDepth(q, 1)
+		for i := 1; i < 4; i++ {
+			go func(i int) {
+				_ = q.Do(context.Background(), vid, func() error {
+					done <- i
+					return nil
+				})
+			}(i)
+			waitDepth(q, uint64(i+1))
+		}
+
+		close(release)
+		for i := 0; i < 4; i++ {
+			assert.Equal(t, i, <-done)
+		}
+		waitDepth(q, 0)
+		assert.Equal(t, uint64(4), q.Status(vid)[0].GetMaxDepth())
+	})
+	t.Run("should_not_block_other_volumes", func(t *testing.T) {
+		q := newCommitQueue()
+		release := make(chan struct{})
+		defer close(release)
+		go q.Do(context.Background(), vid, func() error {
+			<-release
+			return nil
+		})
+		waitDepth(q, 1)
+
+		called := false
+		err := q.Do(context.Background(), &elton_v2.VolumeID{Id: "other"}, func() error {
+			called = true
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+	t.Run("should_skip_canceled_commits", func(t *testing.T) {
+		q := newCommitQueue()
+		release := make(chan struct{})
+		go q.Do(context.Background(), vid, func() error {
+			<-release
+			return nil
+		})
+		waitDepth(q, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error)
+		go func() {
+			errCh <- q.Do(ctx, vid, func() error {
+				panic("should not be called")
+			})
+		}()
+		waitDepth(q, 2)
+		cancel()
+		assert.Equal(t, codes.Canceled, status.Code(<-errCh))
+		waitDepth(q, 1)
+
+		close(release)
+		waitDepth(q, 0)
+		assert.NoError(t, q.Do(context.Background(), vid, func() error { return nil }))
+	})
+}
+
+func TestCommitQueue_Status(t *testing.T) {
+	q := newCommitQueue()
+	vid := &elton_v2.VolumeID{Id: "vol"}
+	assert.NoError(t, q.Do(context.Background(), vid, func() error {
+		q.ObserveMerge(vid, 2*time.Second)
+		q.ObserveMerge(vid, time.Second)
+		return nil
+	}))
+	q.Observe(vid, 3, nil)
+
+	st := q.Status(nil)
+	if assert.Len(t, st, 1) {
+		assert.Equal(t, "vol", st[0].GetId().GetId())
+		assert.Equal(t, uint64(1), st[0].GetCommits())
+		assert.Equal(t, uint64(2), st[0].GetMerges())
+		assert.Equal(t, uint64(3), st[0].GetRetries())
+		assert.Equal(t, int64(3), st[0].GetTotalMergeTime().GetSeconds())
+		assert.Equal(t, int64(2), st[0].GetMaxMergeTime().GetSeconds())
+		assert.Equal(t, int64(1), st[0].GetLastMergeTime().GetSeconds())
+	}
+
+	q.Forget(vid)
+	assert.Empty(t, q.Status(nil))
+}
@@ -0,0 +1,266 @@
+package simple
+
+import (
+	"context"
+	"errors"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
+	"golang.org/x/xerrors"
+	"log"
+	"time"
+)
+
+const (
+	// DefaultContentMergeMaxSize is the default size limit of files merged by contents.
+	DefaultContentMergeMaxSize = 1 << 20
+	// Time budget of merging contents of all files in a merge.  It includes fetching objects and storing merged objects.
+	contentMergeTimeout = 30 * time.Second
+)
+
+var ErrCannotMergeContents = &controller_db.InputError{Msg: "cannot merge contents"}
+
+// ContentMerger merges contents of a regular file changed in both the latest tree and the current tree.
+type ContentMerger interface {
+	// MergeContents merges changes from base to latest and changes from base to current.  It returns the key of the
+	// merged object.  If contents can not be merged, it returns ErrCannotMergeContents.
+	MergeContents(ctx context.Context, base, latest, current *ObjectKey) (*ObjectKey, error)
+	// Discard releases merged objects that are no longer needed.  Objects saved in commits are kept.
+	Discard(keys []*ObjectKey) error
+}
+
+// cachedContentMerger remembers results of the ContentMerger.  A commit may be merged several times when the head is
+// updated during the merge.  It prevents fetching the same objects and storing the same merged object again.
+type cachedContentMerger struct {
+	ContentMerger
+	results map[[3]string]contentMergeResult
+}
+type contentMergeResult struct {
+	key *ObjectKey
+	err error
+}
+
+func newCachedContentMerger(m ContentMerger) *cachedContentMerger {
+	return &cachedContentMerger{
+		ContentMerger: m,
+		results:       map[[3]string]contentMergeResult{},
+	}
+}
+func (m *cachedContentMerger) MergeContents(ctx context.Context, base, latest, current *ObjectKey) (*ObjectKey, error) {
+	k := [3]string{base.GetId(), latest.GetId(), current.GetId()}
+	if r, ok := m.results[k]; ok {
+		return r.key, r.err
+	}
+	key, err := m.ContentMerger.MergeContents(ctx, base, latest, current)
+	if err == nil || errors.Is(err, ErrCannotMergeContents) {
+		// Other errors may be temporary.  They are not cached.
+		m.results[k] = contentMergeResult{key: key, err: err}
+	}
+	return key, err
+}
+
+// DiscardMerged releases all merged objects.  It should be called after the commit is saved or failed.  Merged objects
+// are not used if the commit is failed or they are merged on the head that is updated later.
+func (m *cachedContentMerger) DiscardMerged() error {
+	var keys []*ObjectKey
+	for _, r := range m.results {
+		if r.key != nil {
+			keys = append(keys, r.key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return m.Discard(keys)
+}
+
+// StorageContentMerger fetches objects from storage nodes and merges text files by the line-based diff3 algorithm.
+// The merged object is stored to the storage node selected by the placement.  If there is no registered storage node,
+// objects are read from and written to the default storage node.
+type StorageContentMerger struct {
+	ns        controller_db.NodeStore
+	obs       controller_db.ObjectStore
+	placement *Placement
+	Dial      StorageDialer
+	// DialDefault returns a client of the default storage node.  It is closed in the same way as clients from Dial.
+	DialDefault func() (StorageServiceClient, error)
+	// Files larger than MaxSize are not merged.  If it is zero, contents are never merged.
+	MaxSize uint64
+}
+
+func (m *StorageContentMerger) MergeContents(ctx context.Context, base, latest, current *ObjectKey) (*ObjectKey, error) {
+	if m.MaxSize == 0 {
+		return nil, xerrors.Errorf("disabled: %w", ErrCannotMergeContents)
+	}
+
+	var bodies [][]byte
+	for _, key := range []*ObjectKey{base, latest, current} {
+		body, err := m.fetch(ctx, key)
+		if err != nil {
+			return nil, xerrors.Errorf("fetch %s: %w", key.GetId(), err)
+		}
+		if uint64(len(body)) > m.MaxSize {
+			return nil, xerrors.Errorf("too large object %s: %w", key.GetId(), ErrCannotMergeContents)
+		}
+		if !utils.IsText(body) {
+			return nil, xerrors.Errorf("binary object %s: %w", key.GetId(), ErrCannotMergeContents)
+		}
+		bodies = append(bodies, body)
+	}
+
+	merged, err := utils.Merge3(bodies[0], bodies[1], bodies[2])
+	if err != nil {
+		return nil, xerrors.Errorf("%s: %w", err, ErrCannotMergeContents)
+	}
+	return m.store(ctx, merged)
+}
+
+func (m *StorageContentMerger) Discard(keys []*ObjectKey) error {
+	return m.obs.Release(keys)
+}
+
+// fetch reads the object from one of the nodes that have the object.
+func (m *StorageContentMerger) fetch(ctx context.Context, key *ObjectKey) ([]byte, error) {
+	locations, err := m.obs.Locations(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		sc, err := m.DialDefault()
+		if err != nil {
+			return nil, xerrors.Errorf("api client: %w", err)
+		}
+		defer Close(sc)
+		return fetchObject(ctx, sc, key)
+	}
+
+	nodes := map[string]*Node{}
+	err = m.ns.List(func(id *NodeID, node *Node) error {
+		nodes[id.GetId()] = node
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lastErr := xerrors.New("no available node")
+	for _, id := range locations {
+		node := nodes[id.GetId()]
+		if node == nil {
+			continue
+		}
+		body, err := m.fetchFrom(ctx, id, node, key)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+func (m *StorageContentMerger) fetchFrom(ctx context.Context, id *NodeID, node *Node, key *ObjectKey) ([]byte, error) {
+	sc, err := m.Dial(id, node)
+	if err != nil {
+		return nil, xerrors.Errorf("api client: %w", err)
+	}
+	defer Close(sc)
+	return fetchObject(ctx, sc, key)
+}
+
+// store saves the merged contents as a new object and records its location.
+func (m *StorageContentMerger) store(ctx context.Context, body []byte) (*ObjectKey, error) {
+	targets, err := m.placement.Place(1, uint64(len(body)), nil)
+	if errors.Is(err, ErrNotEnoughNodes) {
+		log.Printf("[INFO] StorageContentMerger: use the default storage: %s", err)
+		sc, err := m.DialDefault()
+		if err != nil {
+			return nil, xerrors.Errorf("api client: %w", err)
+		}
+		defer Close(sc)
+		return createObject(ctx, sc, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	target := targets[0]
+	sc, err := m.Dial(target.ID, target.Node)
+	if err != nil {
+		return nil, xerrors.Errorf("api client: %w", err)
+	}
+	defer Close(sc)
+	key, err := createObject(ctx, sc, body)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.obs.AddLocation(key, target.ID); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+func createObject(ctx context.Context, sc StorageServiceClient, body []byte) (*ObjectKey, error) {
+	res, err := sc.CreateObject(ctx, &CreateObjectRequest{
+		Body: &ObjectBody{Contents: body},
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("create object: %w", err)
+	}
+	return res.GetKey(), nil
+}
+
+// mergeContents merges contents of regular files modified on both sides.  Merged files are stored to newCurrent and
+// removed from latestDiff, so they are not treated as conflicts.  Files added on both sides are not merged because
+// they do not have the base contents.
+func (m *Merger) mergeContents(latestDiff, currentDiff *Diff, newCurrent *Tree) {
+	ctx := m.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, contentMergeTimeout)
+	defer cancel()
+
+	for _, _ino := range latestDiff.Modified.Intersect(currentDiff.Modified).ToSlice() {
+		ino := _ino.(uint64)
+		bf := m.Base.Inodes[ino]
+		lf := m.Latest.Inodes[ino]
+		cf := newCurrent.Inodes[ino]
+		switch {
+		case bf.GetFileType() != FileType_Regular || lf.GetFileType() != FileType_Regular || cf.GetFileType() != FileType_Regular:
+			continue
+		case lf.GetMode() != cf.GetMode() || lf.GetOwner() != cf.GetOwner() || lf.GetGroup() != cf.GetGroup():
+			// Attributes are conflicted.
+			continue
+		}
+		baseKey := bf.GetContentRef().GetKey()
+		latestKey := lf.GetContentRef().GetKey()
+		currentKey := cf.GetContentRef().GetKey()
+		if baseKey.GetId() == latestKey.GetId() || baseKey.GetId() == currentKey.GetId() || latestKey.GetId() == currentKey.GetId() {
+			// Contents are changed on one side only.
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			log.Printf("[INFO] gave up merging contents of remaining files: %s", err)
+			return
+		}
+		key, err := m.Contents.MergeContents(ctx, baseKey, latestKey, currentKey)
+		if err != nil {
+			if errors.Is(err, ErrCannotMergeContents) {
+				log.Printf("[INFO] cannot merge contents of ino=%d: %s", ino, err)
+			} else {
+				log.Printf("[WARN] failed to merge contents of ino=%d: %+v", ino, err)
+			}
+			continue
+		}
+		log.Printf("[INFO] merged contents of ino=%d: key=%s", ino, key.GetId())
+
+		merged := cf.DeepCopy()
+		merged.ContentRef = &FileContentRef{Key: key}
+		if mtime(lf).After(mtime(cf)) {
+			merged.Atime = lf.Atime
+			merged.Mtime = lf.Mtime
+			merged.Ctime = lf.Ctime
+		}
+		newCurrent.Inodes[ino] = merged
+		latestDiff.Modified.Remove(ino)
+	}
+}
@@ -0,0 +1,225 @@
+package simple
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"testing"
+	"time"
+)
+
+type fakeContentMerger struct {
+	calls     int
+	err       error
+	discarded []*elton_v2.ObjectKey
+}
+
+func (m *fakeContentMerger) MergeContents(ctx context.Context, base, latest, current *elton_v2.ObjectKey) (*elton_v2.ObjectKey, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &elton_v2.ObjectKey{Id: base.GetId() + "+" + latest.GetId() + "+" + current.GetId()}, nil
+}
+func (m *fakeContentMerger) Discard(keys []*elton_v2.ObjectKey) error {
+	m.discarded = append(m.discarded, keys...)
+	return nil
+}
+
+func TestMerger_mergeContents(t *testing.T) {
+	base := func() *elton_v2.Tree {
+		return &newTreeBuilder().Dirs(1).File(2, 0644, "base").DirEntry(1, "a", 2).Tree
+	}
+	modMod := func(contents ContentMerger, latestMode, currentMode uint32) (*elton_v2.Tree, error) {
+		latest := &newTreeBuilder().Dirs(1).File(2, 0644, "latest").DirEntry(1, "a", 2).Tree
+		latest.Inodes[2].Mode = latestMode
+		latest.Inodes[2].Mtime = mustProtoTime(time.Unix(2, 0))
+		current := &newTreeBuilder().Dirs(1).File(2, 0644, "current").DirEntry(1, "a", 2).Tree
+		current.Inodes[2].Mode = currentMode
+		current.Inodes[2].Mtime = mustProtoTime(time.Unix(1, 0))
+		m := &Merger{
+			Info:     &elton_v2.CommitInfo{},
+			Base:     base(),
+			Latest:   latest,
+			Current:  current,
+			Contents: contents,
+		}
+		return m.Merge()
+	}
+
+	t.Run("should_merge_contents", func(t *testing.T) {
+		contents := &fakeContentMerger{}
+		tree, err := modMod(contents, 0644, 0644)
+		if assert.NoError(t, err) {
+			f := tree.Inodes[2]
+			assert.Equal(t, "id-base+id-latest+id-current", f.GetContentRef().GetKey().GetId())
+			// The newer mtime is used.
+			assert.Equal(t, int64(2), f.GetMtime().GetSeconds())
+		}
+		assert.Equal(t, 1, contents.calls)
+	})
+	t.Run("should_conflict_when_contents_can_not_be_merged", func(t *testing.T) {
+		contents := &fakeContentMerger{err: ErrCannotMergeContents}
+		_, err := modMod(contents, 0644, 0644)
+		var conflictErr *MergeConflictError
+		assert.True(t, errors.As(err, &conflictErr))
+		assert.Equal(t, 1, contents.calls)
+	})
+	t.Run("should_not_merge_after_context_is_canceled", func(t *testing.T) {
+		contents := &fakeContentMerger{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		m := &Merger{
+			Info:     &elton_v2.CommitInfo{},
+			Base:     base(),
+			Latest:   &newTreeBuilder().Dirs(1).File(2, 0644, "latest").DirEntry(1, "a", 2).Tree,
+			Current:  &newTreeBuilder().Dirs(1).File(2, 0644, "current").DirEntry(1, "a", 2).Tree,
+			Contents: contents,
+			Context:  ctx,
+		}
+		_, err := m.Merge()
+		assert.Error(t, err)
+		assert.Equal(t, 0, contents.calls)
+	})
+	t.Run("should_not_merge_when_attributes_are_conflicted", func(t *testing.T) {
+		contents := &fakeContentMerger{}
+		_, err := modMod(contents, 0644, 0600)
+		assert.Error(t, err)
+		assert.Equal(t, 0, contents.calls)
+	})
+}
+
+func TestCachedContentMerger_MergeContents(t *testing.T) {
+	ctx := context.Background()
+	base := &elton_v2.ObjectKey{Id: "base"}
+	latest := &elton_v2.ObjectKey{Id: "latest"}
+	current := &elton_v2.ObjectKey{Id: "current"}
+
+	t.Run("should_reuse_merged_object", func(t *testing.T) {
+		contents := &fakeContentMerger{}
+		m := newCachedContentMerger(contents)
+		key1, err := m.MergeContents(ctx, base, latest, current)
+		assert.NoError(t, err)
+		key2, err := m.MergeContents(ctx, base, latest, current)
+		assert.NoError(t, err)
+		assert.Equal(t, key1.GetId(), key2.GetId())
+		assert.Equal(t, 1, contents.calls)
+
+		_, err = m.MergeContents(ctx, base, current, latest)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, contents.calls)
+	})
+	t.Run("should_not_cache_temporary_errors", func(t *testing.T) {
+		contents := &fakeContentMerger{err: errors.New("connection refused")}
+		m := newCachedContentMerger(contents)
+		_, err := m.MergeContents(ctx, base, latest, current)
+		assert.Error(t, err)
+		_, err = m.MergeContents(ctx, base, latest, current)
+		assert.Error(t, err)
+		assert.Equal(t, 2, contents.calls)
+	})
+	t.Run("should_discard_merged_objects", func(t *testing.T) {
+		contents := &fakeContentMerger{}
+		m := newCachedContentMerger(contents)
+		key, err := m.MergeContents(ctx, base, latest, current)
+		assert.NoError(t, err)
+		contents.err = ErrCannotMergeContents
+		_, err = m.MergeContents(ctx, base, current, latest)
+		assert.Error(t, err)
+
+		assert.NoError(t, m.DiscardMerged())
+		assert.Equal(t, []*elton_v2.ObjectKey{key}, contents.discarded)
+	})
+}
+func TestStorageContentMerger_MergeContents(t *testing.T) {
+	ctx := context.Background()
+	withMerger := func(t *testing.T, fn func(m *StorageContentMerger, stores controller_db.Stores, sc elton_v2.StorageServiceClient)) {
+		withStorageNodes(t, []string{"a"}, func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient) {
+			_, err := n.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+				Id: &elton_v2.NodeID{Id: "a"},
+				Node: &elton_v2.Node{
+					Roles:    []string{StorageRole},
+					Capacity: &elton_v2.NodeCapacity{FreeBytes: 1 << 20},
+				},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			m := &StorageContentMerger{
+				ns:        stores.NodeStore(),
+				obs:       stores.ObjectStore(),
+				placement: n.placement,
+				Dial:      n.drainer.Dial,
+				DialDefault: func() (elton_v2.StorageServiceClient, error) {
+					panic("should not use the default storage")
+				},
+				MaxSize: DefaultContentMergeMaxSize,
+			}
+			fn(m, stores, storages["a"])
+		})
+	}
+	createObject := func(t *testing.T, stores controller_db.Stores, sc elton_v2.StorageServiceClient, body string) *elton_v2.ObjectKey {
+		res, err := sc.CreateObject(ctx, &elton_v2.CreateObjectRequest{
+			Body: &elton_v2.ObjectBody{Contents: []byte(body)},
+		})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.NoError(t, stores.ObjectStore().AddLocation(res.GetKey(), &elton_v2.NodeID{Id: "a"}))
+		return res.GetKey()
+	}
+
+	t.Run("should_merge_text_files", func(t *testing.T) {
+		withMerger(t, func(m *StorageContentMerger, stores controller_db.Stores, sc elton_v2.StorageServiceClient) {
+			base := createObject(t, stores, sc, "a\nb\nc\n")
+			latest := createObject(t, stores, sc, "A\nb\nc\n")
+			current := createObject(t, stores, sc, "a\nb\nC\n")
+
+			key, err := m.MergeContents(context.Background(), base, latest, current)
+			if !assert.NoError(t, err) {
+				return
+			}
+			res, err := sc.GetObject(ctx, &elton_v2.GetObjectRequest{Key: key})
+			if assert.NoError(t, err) {
+				assert.Equal(t, "A\nb\nC\n", string(res.GetBody().GetContents()))
+			}
+			locations, err := stores.ObjectStore().Locations(key)
+			if assert.NoError(t, err) && assert.Len(t, locations, 1) {
+				assert.Equal(t, "a", locations[0].GetId())
+			}
+		})
+	})
+	t.Run("should_fail_when_changes_overlap", func(t *testing.T) {
+		withMerger(t, func(m *StorageContentMerger, stores controller_db.Stores, sc elton_v2.StorageServiceClient) {
+			base := createObject(t, stores, sc, "a\nb\nc\n")
+			latest := createObject(t, stores, sc, "a\nB\nc\n")
+			current := createObject(t, stores, sc, "a\nX\nc\n")
+
+			_, err := m.MergeContents(context.Background(), base, latest, current)
+			assert.True(t, errors.Is(err, ErrCannotMergeContents))
+		})
+	})
+	t.Run("should_fail_on_binary_files", func(t *testing.T) {
+		withMerger(t, func(m *StorageContentMerger, stores controller_db.Stores, sc elton_v2.StorageServiceClient) {
+			base := createObject(t, stores, sc, "a\x00b\n")
+			latest := createObject(t, stores, sc, "A\x00b\n")
+			current := createObject(t, stores, sc, "a\x00B\n")
+
+			_, err := m.MergeContents(context.Background(), base, latest, current)
+			assert.True(t, errors.Is(err, ErrCannotMergeContents))
+		})
+	})
+	t.Run("should_fail_on_large_files", func(t *testing.T) {
+		withMerger(t, func(m *StorageContentMerger, stores controller_db.Stores, sc elton_v2.StorageServiceClient) {
+			m.MaxSize = 4
+			base := createObject(t, stores, sc, "a\nb\nc\n")
+			latest := createObject(t, stores, sc, "A\nb\nc\n")
+			current := createObject(t, stores, sc, "a\nb\nC\n")
+
+			_, err := m.MergeContents(context.Background(), base, latest, current)
+			assert.True(t, errors.Is(err, ErrCannotMergeContents))
+		})
+	})
+}
@@ -12,12 +12,31 @@ func NewController(databasePath string) (*Controller, func() error) {
 		panic(err)
 	}
 
+	m := newLocalMetaServer(stores.MetaStore())
+	n := newLocalNodeServer(stores.NodeStore(), stores.ObjectStore())
 	v := newLocalVolumeServer(stores.VolumeStore(), stores.CommitStore())
+	contents := &StorageContentMerger{
+		ns:          stores.NodeStore(),
+		obs:         stores.ObjectStore(),
+		placement:   n.placement,
+		Dial:        dialStorageNode,
+		DialDefault: StorageService,
+		MaxSize:     DefaultContentMergeMaxSize,
+	}
+	v.contents = contents
 	return &Controller{
-		MetaServiceServer:   newLocalMetaServer(stores.MetaStore()),
-		NodeServiceServer:   newLocalNodeServer(stores.NodeStore()),
+		MetaServiceServer:   m,
+		NodeServiceServer:   n,
 		VolumeServiceServer: v,
 		CommitServiceServer: v,
+		Pruner:              v.pruner,
+		MetaExpirer:         m.expirer,
+		NodeMonitor:         n.monitor,
+		Liveness:            n.liveness,
+		Placement:           n.placement,
+		Drainer:             n.drainer,
+		Rebalancer:          n.rebalancer,
+		ContentMerger:       contents,
 	}, closer
 }
 
@@ -26,4 +45,21 @@ type Controller struct {
 	NodeServiceServer
 	VolumeServiceServer
 	CommitServiceServer
+
+	// Pruner deletes expired commits in the background.
+	Pruner *Pruner
+	// MetaExpirer deletes expired properties in the background.
+	MetaExpirer *MetaExpirer
+	// NodeMonitor unregisters dead nodes in the background.
+	NodeMonitor *NodeMonitor
+	// Liveness is shared by NodeService and NodeMonitor.  It should be configured before serving.
+	Liveness *LivenessConfig
+	// Placement selects storage nodes for new objects.
+	Placement *Placement
+	// Drainer copies objects on draining nodes in the background.
+	Drainer *Drainer
+	// Rebalancer moves objects between storage nodes in the background.
+	Rebalancer *Rebalancer
+	// ContentMerger merges text files modified by concurrent commits.
+	ContentMerger *StorageContentMerger
 }
@@ -0,0 +1,204 @@
+package simple
+
+import (
+	mapset "github.com/deckarep/golang-set"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"path"
+	"sort"
+	"strings"
+)
+
+func (v *localVolumeServer) DiffCommits(req *DiffCommitsRequest, srv CommitService_DiffCommitsServer) error {
+	if req.GetA() == nil {
+		return status.Error(codes.InvalidArgument, "a should not nil")
+	}
+	if req.GetB() == nil {
+		return status.Error(codes.InvalidArgument, "b should not nil")
+	}
+	ctx := srv.Context()
+	resA, err := v.GetCommit(ctx, &GetCommitRequest{Id: req.GetA()})
+	if err != nil {
+		return wrapStatus(err, 0, "a")
+	}
+	resB, err := v.GetCommit(ctx, &GetCommitRequest{Id: req.GetB()})
+	if err != nil {
+		return wrapStatus(err, 0, "b")
+	}
+
+	for _, change := range diffTrees(resA.GetInfo().GetTree(), resB.GetInfo().GetTree(), req.GetPath()) {
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, "canceled")
+		default:
+		}
+		if err := srv.Send(&DiffCommitsResponse{Change: change}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffTrees returns path-level changes from the tree a to the tree b ordered by path.  Changed inodes are detected
+// by the Diff of the merger, and renames are detected by comparing paths of each inode.  Changes of timestamps and
+// directory entries are not reported.  If prefix is not empty, only changes under the prefix are returned.
+func diffTrees(a, b *Tree, prefix string) []*PathChange {
+	m := &Merger{}
+	as := m.inodeSet(a)
+	bs := m.inodeSet(b)
+	// Inode numbers may be reused for other file types.  They are treated as deleted and added.
+	retyped := mapset.NewThreadUnsafeSet()
+	for _ino := range as.Intersect(bs).Iter() {
+		ino := _ino.(uint64)
+		if a.Inodes[ino].GetFileType() != b.Inodes[ino].GetFileType() {
+			retyped.Add(ino)
+		}
+	}
+	d := &Diff{
+		Added:    bs.Difference(as).Union(retyped),
+		Deleted:  as.Difference(bs).Union(retyped),
+		Modified: m.filterNotModifiedInodes(as.Intersect(bs).Difference(retyped), a, b),
+	}
+
+	aPaths := a.AllPaths()
+	bPaths := b.AllPaths()
+	var changes []*PathChange
+	for _, _ino := range as.Union(bs).ToSlice() {
+		ino := _ino.(uint64)
+		if ino == a.GetRootIno() || ino == b.GetRootIno() {
+			continue
+		}
+		if d.Added.Contains(ino) {
+			for _, p := range bPaths[ino] {
+				changes = append(changes, &PathChange{
+					Type:    PathChangeType_Added,
+					Path:    p,
+					NewFile: withoutEntries(b.Inodes[ino]),
+				})
+			}
+		}
+		if d.Deleted.Contains(ino) {
+			for _, p := range aPaths[ino] {
+				changes = append(changes, &PathChange{
+					Type:    PathChangeType_Deleted,
+					Path:    p,
+					OldFile: withoutEntries(a.Inodes[ino]),
+				})
+			}
+		}
+		if d.Added.Contains(ino) || d.Deleted.Contains(ino) {
+			continue
+		}
+		changes = append(changes, diffInode(a.Inodes[ino], b.Inodes[ino], d.Modified.Contains(ino), aPaths[ino], bPaths[ino])...)
+	}
+
+	if prefix != "" {
+		prefix = path.Clean("/" + prefix)
+		var filtered []*PathChange
+		for _, c := range changes {
+			if isUnder(c.Path, prefix) || (c.OldPath != "" && isUnder(c.OldPath, prefix)) {
+				filtered = append(filtered, c)
+			}
+		}
+		changes = filtered
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Path != changes[j].Path {
+			return changes[i].Path < changes[j].Path
+		}
+		return changes[i].Type < changes[j].Type
+	})
+	return changes
+}
+
+// diffInode returns changes of the inode that exists in both trees.  Paths that are removed and added are paired as
+// renames in sorted order.  Remaining paths are reported as added or deleted hard links.
+func diffInode(af, bf *File, modified bool, aPaths, bPaths []string) []*PathChange {
+	newChange := func(t PathChangeType) *PathChange {
+		c := &PathChange{
+			Type:    t,
+			OldFile: withoutEntries(af),
+			NewFile: withoutEntries(bf),
+		}
+		if modified {
+			c.ContentChanged = af.GetFileType() != FileType_Directory &&
+				(af.GetContentRef().GetKey().GetId() != bf.GetContentRef().GetKey().GetId() ||
+					af.GetMajor() != bf.GetMajor() || af.GetMinor() != bf.GetMinor())
+			c.ModeChanged = af.GetMode() != bf.GetMode()
+			c.OwnerChanged = af.GetOwner() != bf.GetOwner() || af.GetGroup() != bf.GetGroup()
+		}
+		return c
+	}
+
+	var changes []*PathChange
+	if c := newChange(PathChangeType_Modified); c.ContentChanged || c.ModeChanged || c.OwnerChanged {
+		for _, p := range intersectPaths(aPaths, bPaths) {
+			c := newChange(PathChangeType_Modified)
+			c.Path = p
+			changes = append(changes, c)
+		}
+	}
+
+	removed := subtractPaths(aPaths, bPaths)
+	added := subtractPaths(bPaths, aPaths)
+	for i := 0; i < len(removed) || i < len(added); i++ {
+		switch {
+		case i < len(removed) && i < len(added):
+			c := newChange(PathChangeType_Renamed)
+			c.Path = added[i]
+			c.OldPath = removed[i]
+			changes = append(changes, c)
+		case i < len(added):
+			changes = append(changes, &PathChange{
+				Type:    PathChangeType_Added,
+				Path:    added[i],
+				NewFile: withoutEntries(bf),
+			})
+		default:
+			changes = append(changes, &PathChange{
+				Type:    PathChangeType_Deleted,
+				Path:    removed[i],
+				OldFile: withoutEntries(af),
+			})
+		}
+	}
+	return changes
+}
+
+// withoutEntries returns a copy of the file without directory entries.
+func withoutEntries(f *File) *File {
+	if f == nil {
+		return nil
+	}
+	x := f.DeepCopy()
+	x.Entries = nil
+	return x
+}
+
+// isUnder reports whether p is the dir or a path under the dir.
+func isUnder(p, dir string) bool {
+	return dir == "/" || p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+// intersectPaths returns paths contained in both sorted slices.
+func intersectPaths(a, b []string) []string {
+	var out []string
+	for _, p := range a {
+		if i := sort.SearchStrings(b, p); i < len(b) && b[i] == p {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// subtractPaths returns paths in a but not in b.  Both slices must be sorted.
+func subtractPaths(a, b []string) []string {
+	var out []string
+	for _, p := range a {
+		if i := sort.SearchStrings(b, p); i >= len(b) || b[i] != p {
+			out = append(out, p)
+		}
+	}
+	return out
+}
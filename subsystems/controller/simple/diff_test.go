@@ -0,0 +1,146 @@
+package simple
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestDiffTrees(t *testing.T) {
+	// summary converts changes to "type:path" or "type:oldPath->path" strings.
+	summary := func(changes []*elton_v2.PathChange) []string {
+		var out []string
+		for _, c := range changes {
+			s := c.GetType().String() + ":" + c.GetPath()
+			if c.GetOldPath() != "" {
+				s = c.GetType().String() + ":" + c.GetOldPath() + "->" + c.GetPath()
+			}
+			out = append(out, s)
+		}
+		return out
+	}
+	// base: /a, /dir/b
+	base := func() *treeBuilder {
+		return newTreeBuilder().Dirs(1, 2).File(3, 0644, "a").File(4, 0644, "b").
+			DirEntry(1, "a", 3).DirEntry(1, "dir", 2).DirEntry(2, "b", 4)
+	}
+
+	t.Run("should_return_nothing_for_same_trees", func(t *testing.T) {
+		assert.Empty(t, diffTrees(&base().Tree, &base().Tree, ""))
+	})
+	t.Run("should_detect_added_deleted_and_modified_files", func(t *testing.T) {
+		b := base().File(5, 0644, "c").DirEntry(1, "c", 5)
+		delete(b.Tree.Inodes[2].Entries, "b")
+		delete(b.Tree.Inodes, 4)
+		b.Tree.Inodes[3].ContentRef.Key.Id = "id-a2"
+		changes := diffTrees(&base().Tree, &b.Tree, "")
+		assert.Equal(t, []string{"Modified:/a", "Added:/c", "Deleted:/dir/b"}, summary(changes))
+		assert.True(t, changes[0].GetContentChanged())
+		assert.False(t, changes[0].GetModeChanged())
+		assert.Nil(t, changes[1].GetOldFile())
+		assert.Nil(t, changes[2].GetNewFile())
+	})
+	t.Run("should_detect_renamed_files", func(t *testing.T) {
+		b := base()
+		delete(b.Tree.Inodes[1].Entries, "a")
+		b.DirEntry(2, "a", 3)
+		b.Tree.Inodes[3].Mode = 0755
+		changes := diffTrees(&base().Tree, &b.Tree, "")
+		assert.Equal(t, []string{"Renamed:/a->/dir/a"}, summary(changes))
+		assert.False(t, changes[0].GetContentChanged())
+		assert.True(t, changes[0].GetModeChanged())
+	})
+	t.Run("should_report_files_in_renamed_directory", func(t *testing.T) {
+		b := base()
+		delete(b.Tree.Inodes[1].Entries, "dir")
+		b.DirEntry(1, "dir2", 2)
+		assert.Equal(t, []string{"Renamed:/dir->/dir2", "Renamed:/dir/b->/dir2/b"}, summary(diffTrees(&base().Tree, &b.Tree, "")))
+	})
+	t.Run("should_detect_owner_changes", func(t *testing.T) {
+		b := base()
+		b.Tree.Inodes[2].Owner = 1000
+		b.Tree.Inodes[2].Mtime = mustProtoTime(time.Unix(1, 0))
+		// Changes of timestamps are ignored.
+		b.Tree.Inodes[4].Atime = mustProtoTime(time.Unix(1, 0))
+		changes := diffTrees(&base().Tree, &b.Tree, "")
+		assert.Equal(t, []string{"Modified:/dir"}, summary(changes))
+		assert.True(t, changes[0].GetOwnerChanged())
+		assert.Nil(t, changes[0].GetNewFile().GetEntries())
+	})
+	t.Run("should_treat_reused_inode_as_deleted_and_added", func(t *testing.T) {
+		b := base()
+		b.Tree.Inodes[3] = &elton_v2.File{FileType: elton_v2.FileType_Directory}
+		assert.Equal(t, []string{"Added:/a", "Deleted:/a"}, summary(diffTrees(&base().Tree, &b.Tree, "")))
+	})
+	t.Run("should_filter_by_path", func(t *testing.T) {
+		b := base()
+		b.Tree.Inodes[3].ContentRef.Key.Id = "id-a2"
+		b.Tree.Inodes[4].ContentRef.Key.Id = "id-b2"
+		assert.Equal(t, []string{"Modified:/dir/b"}, summary(diffTrees(&base().Tree, &b.Tree, "dir")))
+		assert.Equal(t, []string{"Modified:/dir/b"}, summary(diffTrees(&base().Tree, &b.Tree, "/dir/")))
+		assert.Empty(t, diffTrees(&base().Tree, &b.Tree, "/di"))
+	})
+}
+
+func TestLocalVolumeServer_DiffCommits(t *testing.T) {
+	t.Run("should_stream_changes", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			tree := createEmptyTree()
+			tree.Inodes[1].Entries = map[string]uint64{"a": 2}
+			tree.Inodes[2] = &elton_v2.File{
+				ContentRef: &elton_v2.FileContentRef{Key: &elton_v2.ObjectKey{Id: "obj"}},
+			}
+			_, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{Info: &elton_v2.CommitInfo{Tree: createEmptyTree()}},
+				{Info: &elton_v2.CommitInfo{Tree: tree}},
+			})
+			client := elton_v2.NewCommitServiceClient(dial())
+			stream, err := client.DiffCommits(ctx, &elton_v2.DiffCommitsRequest{
+				A: commits[0],
+				B: commits[1],
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			var changes []*elton_v2.PathChange
+			for {
+				res, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+				changes = append(changes, res.GetChange())
+			}
+			if assert.Len(t, changes, 1) {
+				assert.Equal(t, elton_v2.PathChangeType_Added, changes[0].GetType())
+				assert.Equal(t, "/a", changes[0].GetPath())
+			}
+		})
+	})
+	t.Run("should_return_NotFound_when_commit_is_not_found", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{Info: &elton_v2.CommitInfo{Tree: createEmptyTree()}},
+			})
+			client := elton_v2.NewCommitServiceClient(dial())
+			stream, err := client.DiffCommits(ctx, &elton_v2.DiffCommitsRequest{
+				A: commits[0],
+				B: &elton_v2.CommitID{Id: volume, Number: 100},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = stream.Recv()
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+}
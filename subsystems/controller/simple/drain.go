@@ -0,0 +1,224 @@
+package simple
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/protobuf/ptypes"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log"
+	"time"
+)
+
+func (n *localNodeServer) DrainNode(ctx context.Context, req *DrainNodeRequest) (*DrainNodeResponse, error) {
+	now, err := ptypes.TimestampProto(n.now())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var st *DrainStatus
+	err = n.ns.Update(req.GetId(), func(node *Node) error {
+		if node.GetDrain().GetPhase() != DrainPhase_Draining {
+			// Start draining from the first object.  If the node is already draining, keep the progress.
+			node.Drain = &DrainStatus{
+				Phase:     DrainPhase_Draining,
+				StartedAt: now,
+			}
+		}
+		node.Drain.Unregister = req.GetUnregister()
+		node.Drain.UpdatedAt = now
+		st = node.Drain
+		return nil
+	})
+	if errors.Is(err, controller_db.ErrNotFoundNode) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		log.Printf("[CRITICAL] Missing error handling: %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &DrainNodeResponse{Status: st}, nil
+}
+
+// StorageDialer returns a client of the storage node.  The returned client must implement io.Closer.
+type StorageDialer func(id *NodeID, node *Node) (StorageServiceClient, error)
+
+// dialStorageNode connects to the first address of the storage node.
+func dialStorageNode(id *NodeID, node *Node) (StorageServiceClient, error) {
+	return StorageServiceOf(node)
+}
+
+// Drainer copies objects on draining nodes to other storage nodes.
+type Drainer struct {
+	ns        controller_db.NodeStore
+	obs       controller_db.ObjectStore
+	placement *Placement
+	now       func() time.Time
+	Dial      StorageDialer
+}
+
+// DrainAll copies objects on all draining nodes.  If it fails to drain a node, the error is recorded to the
+// DrainStatus and the node is drained again by the next call.
+func (d *Drainer) DrainAll(ctx context.Context) error {
+	draining := map[string]*Node{}
+	var ids []*NodeID
+	err := d.ns.List(func(id *NodeID, node *Node) error {
+		if node.GetDrain().GetPhase() == DrainPhase_Draining {
+			draining[id.GetId()] = node
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		drainErr := d.drain(ctx, id, draining[id.GetId()], draining)
+		if drainErr == nil {
+			continue
+		}
+		log.Printf("[WARN] Drainer: node %s: %+v", id.GetId(), drainErr)
+		err := d.update(id, func(st *DrainStatus) {
+			st.Error = drainErr.Error()
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drain copies objects from the node.  It resumes from the next of DrainStatus.lastKey.
+func (d *Drainer) drain(ctx context.Context, id *NodeID, node *Node, draining map[string]*Node) error {
+	if !hasRole(node, StorageRole) {
+		// Nothing to copy.
+		return d.finish(id)
+	}
+
+	src, err := d.Dial(id, node)
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer Close(src)
+
+	receiver, err := src.ListObjects(ctx, &ListObjectsRequest{
+		After: node.GetDrain().GetLastKey(),
+	})
+	if err != nil {
+		return xerrors.Errorf("list objects: %w", err)
+	}
+	for {
+		res, err := receiver.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return xerrors.Errorf("list objects: %w", err)
+		}
+
+		key := res.GetKey()
+		size, err := d.copyObject(ctx, src, id, key, draining)
+		if err != nil {
+			return xerrors.Errorf("copy object %s: %w", key.GetId(), err)
+		}
+		err = d.update(id, func(st *DrainStatus) {
+			st.LastKey = key.GetId()
+			st.CopiedObjects++
+			st.CopiedBytes += size
+			st.Error = ""
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return d.finish(id)
+}
+
+// copyObject copies the object to other storage node and updates the location records.  If the object already exists
+// on other nodes that are not draining, it only removes the location record of the draining node.
+func (d *Drainer) copyObject(ctx context.Context, src StorageServiceClient, srcID *NodeID, key *ObjectKey, draining map[string]*Node) (uint64, error) {
+	locations, err := d.obs.Locations(key)
+	if err != nil {
+		return 0, err
+	}
+	for _, loc := range locations {
+		if _, ok := draining[loc.GetId()]; !ok && loc.GetId() != srcID.GetId() {
+			return 0, d.obs.RemoveLocation(key, srcID)
+		}
+	}
+
+	body, err := fetchObject(ctx, src, key)
+	if err != nil {
+		return 0, err
+	}
+
+	targets, err := d.placement.Place(1, uint64(len(body)), append(locations, srcID))
+	if err != nil {
+		return 0, err
+	}
+	target := targets[0]
+	dst, err := d.Dial(target.ID, target.Node)
+	if err != nil {
+		return 0, xerrors.Errorf("api client: %w", err)
+	}
+	defer Close(dst)
+	if err := storeObject(ctx, dst, key, body); err != nil {
+		return 0, err
+	}
+
+	if err := d.obs.AddLocation(key, target.ID); err != nil {
+		return 0, err
+	}
+	if err := d.obs.RemoveLocation(key, srcID); err != nil {
+		return 0, err
+	}
+	return uint64(len(body)), nil
+}
+
+// finish marks the node as drained.  The node is unregistered if requested.
+func (d *Drainer) finish(id *NodeID) error {
+	unregister := false
+	err := d.update(id, func(st *DrainStatus) {
+		st.Phase = DrainPhase_Drained
+		st.Error = ""
+		unregister = st.GetUnregister()
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Drainer: node %s is drained", id.GetId())
+
+	if unregister {
+		err = d.ns.Unregister(id)
+		if err != nil && !errors.Is(err, controller_db.ErrNotFoundNode) {
+			return err
+		}
+		log.Printf("[INFO] Drainer: node %s is unregistered", id.GetId())
+	}
+	return nil
+}
+
+// update updates the DrainStatus of the node.  It is ignored if the node is already unregistered.
+func (d *Drainer) update(id *NodeID, fn func(st *DrainStatus)) error {
+	now, err := ptypes.TimestampProto(d.now())
+	if err != nil {
+		return err
+	}
+	err = d.ns.Update(id, func(node *Node) error {
+		if node.Drain == nil {
+			node.Drain = &DrainStatus{}
+		}
+		fn(node.Drain)
+		node.Drain.UpdatedAt = now
+		return nil
+	})
+	if errors.Is(err, controller_db.ErrNotFoundNode) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,209 @@
+package simple
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	localStorage "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/storage/local"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
+	"google.golang.org/grpc"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+type closableStorageServiceClient struct {
+	io.Closer
+	elton_v2.StorageServiceClient
+}
+
+// withStorageNodes starts storage servers and a node server that knows them.
+func withStorageNodes(t *testing.T, names []string, fn func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient)) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+	stores, closer, err := controller_db.CreateLocalDB(dir)
+	if err != nil {
+		panic(err)
+	}
+	defer closer()
+
+	storages := map[string]elton_v2.StorageServiceClient{}
+	dials := map[string]func() *grpc.ClientConn{}
+	var start func(i int)
+	start = func(i int) {
+		if i == len(names) {
+			n := newLocalNodeServer(stores.NodeStore(), stores.ObjectStore())
+			dial := func(id *elton_v2.NodeID, node *elton_v2.Node) (elton_v2.StorageServiceClient, error) {
+				conn := dials[id.GetId()]()
+				return &closableStorageServiceClient{
+					Closer:               conn,
+					StorageServiceClient: elton_v2.NewStorageServiceClient(conn),
+				}, nil
+			}
+			n.drainer.Dial = dial
+			n.rebalancer.Dial = dial
+			fn(n, stores, storages)
+			return
+		}
+
+		cacheDir, err := ioutil.TempDir(dir, "")
+		if err != nil {
+			panic(err)
+		}
+		utils.WithTestServer(&localStorage.LocalStorage{CacheDir: cacheDir}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			dials[names[i]] = dial
+			storages[names[i]] = elton_v2.NewStorageServiceClient(dial())
+			start(i + 1)
+		})
+	}
+	start(0)
+}
+
+func TestDrainer_DrainAll(t *testing.T) {
+	ctx := context.Background()
+	register := func(t *testing.T, n *localNodeServer, id string) {
+		_, err := n.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+			Id: &elton_v2.NodeID{Id: id},
+			Node: &elton_v2.Node{
+				Roles:    []string{StorageRole},
+				Capacity: &elton_v2.NodeCapacity{FreeBytes: 1 << 20},
+			},
+		})
+		assert.NoError(t, err)
+	}
+	createObject := func(t *testing.T, stores controller_db.Stores, sc elton_v2.StorageServiceClient, node string, body string) *elton_v2.ObjectKey {
+		res, err := sc.CreateObject(ctx, &elton_v2.CreateObjectRequest{
+			Body: &elton_v2.ObjectBody{Contents: []byte(body)},
+		})
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		assert.NoError(t, stores.ObjectStore().AddLocation(res.GetKey(), &elton_v2.NodeID{Id: node}))
+		return res.GetKey()
+	}
+	getNode := func(t *testing.T, stores controller_db.Stores, id string) (found *elton_v2.Node) {
+		assert.NoError(t, stores.NodeStore().List(func(nid *elton_v2.NodeID, node *elton_v2.Node) error {
+			if nid.GetId() == id {
+				found = node
+			}
+			return nil
+		}))
+		return
+	}
+
+	t.Run("should_copy_objects_and_unregister", func(t *testing.T) {
+		withStorageNodes(t, []string{"old", "new"}, func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient) {
+			register(t, n, "old")
+			register(t, n, "new")
+			keys := []*elton_v2.ObjectKey{
+				createObject(t, stores, storages["old"], "old", "foo"),
+				createObject(t, stores, storages["old"], "old", "bar"),
+			}
+
+			res, err := n.DrainNode(ctx, &elton_v2.DrainNodeRequest{
+				Id:         &elton_v2.NodeID{Id: "old"},
+				Unregister: true,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, elton_v2.DrainPhase_Draining, res.GetStatus().GetPhase())
+			assert.NoError(t, n.drainer.DrainAll(ctx))
+
+			// Objects should be copied to the new node.
+			for _, key := range keys {
+				gres, err := storages["new"].GetObject(ctx, &elton_v2.GetObjectRequest{Key: key})
+				assert.NoError(t, err)
+				assert.NotEmpty(t, gres.GetBody().GetContents())
+
+				locations, err := stores.ObjectStore().Locations(key)
+				assert.NoError(t, err)
+				if assert.Len(t, locations, 1) {
+					assert.Equal(t, "new", locations[0].GetId())
+				}
+			}
+			assert.Nil(t, getNode(t, stores, "old"))
+		})
+	})
+	t.Run("should_resume_from_last_key", func(t *testing.T) {
+		withStorageNodes(t, []string{"old", "new"}, func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient) {
+			register(t, n, "old")
+			register(t, n, "new")
+			createObject(t, stores, storages["old"], "old", "foo")
+			createObject(t, stores, storages["old"], "old", "bar")
+
+			_, err := n.DrainNode(ctx, &elton_v2.DrainNodeRequest{Id: &elton_v2.NodeID{Id: "old"}})
+			if !assert.NoError(t, err) {
+				return
+			}
+			// Pretend that all objects were copied before interruption.
+			var last string
+			rres, err := storages["old"].ListObjects(ctx, &elton_v2.ListObjectsRequest{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			for {
+				res, err := rres.Recv()
+				if err == io.EOF {
+					break
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+				last = res.GetKey().GetId()
+			}
+			assert.NoError(t, stores.NodeStore().Update(&elton_v2.NodeID{Id: "old"}, func(node *elton_v2.Node) error {
+				node.Drain.LastKey = last
+				return nil
+			}))
+
+			// Calling DrainNode again should keep the progress.
+			_, err = n.DrainNode(ctx, &elton_v2.DrainNodeRequest{Id: &elton_v2.NodeID{Id: "old"}})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NoError(t, n.drainer.DrainAll(ctx))
+
+			node := getNode(t, stores, "old")
+			assert.Equal(t, elton_v2.DrainPhase_Drained, node.GetDrain().GetPhase())
+			assert.Equal(t, uint64(0), node.GetDrain().GetCopiedObjects())
+		})
+	})
+	t.Run("should_record_error_when_no_destination", func(t *testing.T) {
+		withStorageNodes(t, []string{"old"}, func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient) {
+			register(t, n, "old")
+			createObject(t, stores, storages["old"], "old", "foo")
+
+			_, err := n.DrainNode(ctx, &elton_v2.DrainNodeRequest{Id: &elton_v2.NodeID{Id: "old"}})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NoError(t, n.drainer.DrainAll(ctx))
+
+			node := getNode(t, stores, "old")
+			assert.Equal(t, elton_v2.DrainPhase_Draining, node.GetDrain().GetPhase())
+			assert.Contains(t, node.GetDrain().GetError(), "not enough storage nodes")
+		})
+	})
+	t.Run("should_not_place_objects_on_draining_nodes", func(t *testing.T) {
+		withStorageNodes(t, []string{"a", "b"}, func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient) {
+			register(t, n, "a")
+			register(t, n, "b")
+			_, err := n.DrainNode(ctx, &elton_v2.DrainNodeRequest{Id: &elton_v2.NodeID{Id: "a"}})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			res, err := n.PlaceObject(ctx, &elton_v2.PlaceObjectRequest{Replicas: 1})
+			if !assert.NoError(t, err) || !assert.Len(t, res.GetNodes(), 1) {
+				return
+			}
+			assert.Equal(t, "b", res.GetNodes()[0].GetId().GetId())
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package simple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+func (v *localVolumeServer) FsckVolume(ctx context.Context, req *FsckVolumeRequest) (*FsckVolumeResponse, error) {
+	if req.GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "id is null")
+	}
+
+	ok, err := v.vs.Exists(req.GetId())
+	if err != nil {
+		log.Println("ERROR:", err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, controller_db.ErrNotFoundVolume.Wrap(fmt.Errorf("id=%s", req.GetId().GetId())).Error())
+	}
+
+	res, err := fsckVolume(v.cs, req.GetId())
+	if err != nil {
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return res, nil
+}
+
+// fsckVolume checks trees and parents of all commits in the volume.  It only reports problems and never repairs them.
+func fsckVolume(cs controller_db.CommitStore, vid *VolumeID) (*FsckVolumeResponse, error) {
+	type commit struct {
+		id   *CommitID
+		info *CommitInfo
+	}
+	var commits []commit
+	exists := map[uint64]bool{}
+	err := cs.Walk(vid, func(id *CommitID, info *CommitInfo) error {
+		commits = append(commits, commit{id, info})
+		exists[id.GetNumber()] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	checkParent := func(side string, parent *CommitID) string {
+		switch {
+		case parent == nil:
+			return ""
+		case !parent.GetId().Equals(vid):
+			return fmt.Sprintf("%s parent is in other volume: %s", side, parent.ConvertString())
+		case !exists[parent.GetNumber()]:
+			return fmt.Sprintf("%s parent is not found: %s", side, parent.ConvertString())
+		}
+		return ""
+	}
+
+	res := &FsckVolumeResponse{}
+	for _, c := range commits {
+		problems := c.info.GetTree().Check()
+		left := c.info.GetLeftParentID()
+		right := c.info.GetRightParentID()
+		if left == nil && right != nil {
+			problems = append(problems, "right parent is specified without left parent")
+		}
+		for _, p := range []string{checkParent("left", left), checkParent("right", right)} {
+			if p != "" {
+				problems = append(problems, p)
+			}
+		}
+
+		res.CheckedCommits++
+		if len(problems) > 0 {
+			log.Printf("[WARN] fsck: damaged commit: id=%s problems=%q", c.id.ConvertString(), problems)
+			res.Damaged = append(res.Damaged, &FsckResult{
+				Id:       c.id,
+				Problems: problems,
+			})
+		}
+	}
+	return res, nil
+}
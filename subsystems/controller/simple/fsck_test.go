@@ -0,0 +1,81 @@
+package simple
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// walkOnlyCS returns commits that can not be saved by CommitStore.Create().
+type walkOnlyCS struct {
+	controller_db.CommitStore
+	commits map[uint64]*elton_v2.CommitInfo
+}
+
+func (cs *walkOnlyCS) Walk(vid *elton_v2.VolumeID, fn func(id *elton_v2.CommitID, info *elton_v2.CommitInfo) error) error {
+	for num := uint64(1); num <= uint64(len(cs.commits)); num++ {
+		if err := fn(&elton_v2.CommitID{Id: vid, Number: num}, cs.commits[num]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestLocalVolumeServer_FsckVolume(t *testing.T) {
+	t.Run("should_not_report_healthy_volume", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume, _ := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{Info: &elton_v2.CommitInfo{Tree: createEmptyTree()}},
+			})
+			client := elton_v2.NewVolumeServiceClient(dial())
+			res, err := client.FsckVolume(ctx, &elton_v2.FsckVolumeRequest{Id: volume})
+			if assert.NoError(t, err) {
+				assert.Equal(t, uint64(2), res.GetCheckedCommits())
+				assert.Empty(t, res.GetDamaged())
+			}
+		})
+	})
+	t.Run("should_return_NotFound_when_volume_is_not_found", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			_, err := client.FsckVolume(ctx, &elton_v2.FsckVolumeRequest{
+				Id: &elton_v2.VolumeID{Id: "not-found"},
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("should_report_damaged_commits", func(t *testing.T) {
+		vid := &elton_v2.VolumeID{Id: "vol"}
+		cs := &walkOnlyCS{commits: map[uint64]*elton_v2.CommitInfo{
+			1: {Tree: createEmptyTree()},
+			// The entry points to the missing inode.
+			2: {
+				LeftParentID: &elton_v2.CommitID{Id: vid, Number: 1},
+				Tree:         &newTreeBuilder().Dirs(1).DirEntry(1, "a", 2).Tree,
+			},
+			// The parent commit is not found.
+			3: {
+				LeftParentID: &elton_v2.CommitID{Id: vid, Number: 10},
+				Tree:         createEmptyTree(),
+			},
+		}}
+
+		res, err := fsckVolume(cs, vid)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, uint64(3), res.GetCheckedCommits())
+		if assert.Len(t, res.GetDamaged(), 2) {
+			assert.Equal(t, uint64(2), res.GetDamaged()[0].GetId().GetNumber())
+			assert.Equal(t, []string{`entry points to missing inode: ino=1 name="a" child=2`}, res.GetDamaged()[0].GetProblems())
+			assert.Equal(t, uint64(3), res.GetDamaged()[1].GetId().GetNumber())
+			assert.Equal(t, []string{"left parent is not found: " + (&elton_v2.CommitID{Id: vid, Number: 10}).ConvertString()}, res.GetDamaged()[1].GetProblems())
+		}
+	})
+}
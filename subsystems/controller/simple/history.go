@@ -0,0 +1,228 @@
+package simple
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"golang.org/x/xerrors"
+	"sort"
+	"time"
+)
+
+type commitEntry struct {
+	id        *CommitID
+	info      *CommitInfo
+	createdAt time.Time
+}
+
+func newCommitEntry(id *CommitID, info *CommitInfo) *commitEntry {
+	createdAt, err := ptypes.Timestamp(info.GetCreatedAt())
+	if err != nil {
+		// Invalid timestamp.  Treat it as the oldest commit.
+		createdAt = time.Time{}
+	}
+	return &commitEntry{
+		id:        id,
+		info:      info,
+		createdAt: createdAt,
+	}
+}
+
+// parents returns the parent commits in order of left and right.
+func (e *commitEntry) parents() []*CommitID {
+	var parents []*CommitID
+	if e.info.GetLeftParentID() != nil {
+		parents = append(parents, e.info.GetLeftParentID())
+	}
+	if e.info.GetRightParentID() != nil {
+		parents = append(parents, e.info.GetRightParentID())
+	}
+	return parents
+}
+
+// commitIterator returns the next commit.  It returns nil if no more commits.
+type commitIterator func() (*commitEntry, error)
+
+// firstParentIterator follows left parents from start.
+func firstParentIterator(cs controller_db.CommitStore, start *CommitID) commitIterator {
+	cid := start
+	return func() (*commitEntry, error) {
+		if cid.GetId().GetId() == "" {
+			return nil, nil
+		}
+		info, err := cs.Get(cid)
+		if err != nil {
+			return nil, err
+		}
+		e := newCommitEntry(cid, info)
+		cid = info.GetLeftParentID()
+		return e, nil
+	}
+}
+
+// sliceIterator returns commits in the slice in order.
+func sliceIterator(entries []*commitEntry) commitIterator {
+	return func() (*commitEntry, error) {
+		if len(entries) == 0 {
+			return nil, nil
+		}
+		e := entries[0]
+		entries = entries[1:]
+		return e, nil
+	}
+}
+
+// filterIterator skips commits that do not match the filter.
+func filterIterator(it commitIterator, match func(e *commitEntry) bool) commitIterator {
+	return func() (*commitEntry, error) {
+		for {
+			e, err := it()
+			if e == nil || err != nil {
+				return e, err
+			}
+			if match(e) {
+				return e, nil
+			}
+		}
+	}
+}
+
+// loadAncestors returns all commits that are reachable from start through both left and right parents.  The start
+// commit is included.  Trees of commits are not loaded.
+func loadAncestors(cs controller_db.CommitStore, start *CommitID) (map[string]*commitEntry, error) {
+	commits := map[string]*commitEntry{}
+	err := cs.Ancestors(start, func(id *CommitID, info *CommitInfo) error {
+		commits[id.ConvertString()] = newCommitEntry(id, info)
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("load ancestors(%s): %w", start.ConvertString(), err)
+	}
+	return commits, nil
+}
+
+// loadDescendants returns keys of commits that have the base commit as an ancestor.  The base commit is included.
+func loadDescendants(cs controller_db.CommitStore, base *CommitID) (map[string]bool, error) {
+	children := map[string][]string{}
+	err := cs.Walk(base.GetId(), func(id *CommitID, info *CommitInfo) error {
+		for _, parent := range newCommitEntry(id, info).parents() {
+			children[parent.ConvertString()] = append(children[parent.ConvertString()], id.ConvertString())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("walk commits: %w", err)
+	}
+
+	descendants := map[string]bool{}
+	queue := []string{base.ConvertString()}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		if descendants[key] {
+			continue
+		}
+		descendants[key] = true
+		queue = append(queue, children[key]...)
+	}
+	return descendants, nil
+}
+
+// newerThan returns true if a should be listed before b in date order.
+func newerThan(a, b *commitEntry) bool {
+	if a.createdAt.Equal(b.createdAt) {
+		return a.id.GetNumber() > b.id.GetNumber()
+	}
+	return a.createdAt.After(b.createdAt)
+}
+
+// sortByDate sorts commits by created time in descending order.
+func sortByDate(commits map[string]*commitEntry) []*commitEntry {
+	var sorted []*commitEntry
+	for _, e := range commits {
+		sorted = append(sorted, e)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return newerThan(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// sortTopologically sorts commits so that children are always listed before their parents.  If some commits are
+// ready at the same time, the newer commit is listed first.
+func sortTopologically(commits map[string]*commitEntry) []*commitEntry {
+	// Count children of each commit.
+	numChildren := map[string]int{}
+	for _, e := range commits {
+		for _, parent := range e.parents() {
+			numChildren[parent.ConvertString()]++
+		}
+	}
+
+	var ready []*commitEntry
+	for key, e := range commits {
+		if numChildren[key] == 0 {
+			ready = append(ready, e)
+		}
+	}
+	var sorted []*commitEntry
+	for len(ready) > 0 {
+		// Pick the newest commit.
+		newest := 0
+		for i := range ready {
+			if newerThan(ready[i], ready[newest]) {
+				newest = i
+			}
+		}
+		e := ready[newest]
+		ready = append(ready[:newest], ready[newest+1:]...)
+		sorted = append(sorted, e)
+
+		for _, parent := range e.parents() {
+			key := parent.ConvertString()
+			numChildren[key]--
+			if pe, ok := commits[key]; ok && numChildren[key] == 0 {
+				ready = append(ready, pe)
+			}
+		}
+	}
+	return sorted
+}
+
+// commitFilter decides whether the commit should be listed.
+type commitFilter struct {
+	since       time.Time
+	until       time.Time
+	merges      MergeFilter
+	ancestors   map[string]*commitEntry
+	descendants map[string]bool
+}
+
+func (f *commitFilter) Match(e *commitEntry) bool {
+	if !f.since.IsZero() && e.createdAt.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && e.createdAt.After(f.until) {
+		return false
+	}
+	isMerge := e.info.GetRightParentID() != nil
+	switch f.merges {
+	case MergeFilter_MergesOnly:
+		if !isMerge {
+			return false
+		}
+	case MergeFilter_NoMerges:
+		if isMerge {
+			return false
+		}
+	}
+	if f.ancestors != nil {
+		if _, ok := f.ancestors[e.id.ConvertString()]; !ok {
+			return false
+		}
+	}
+	if f.descendants != nil && !f.descendants[e.id.ConvertString()] {
+		return false
+	}
+	return true
+}
@@ -1,10 +1,17 @@
 package simple
 
 import (
+	"context"
+	"fmt"
 	mapset "github.com/deckarep/golang-set"
 	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
 	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"log"
+	"path"
+	"sort"
+	"strings"
 )
 
 type ModificationType uint8
@@ -103,6 +110,20 @@ type Merger struct {
 	Base    *Tree
 	Latest  *Tree
 	Current *Tree
+	// Policy decides how to resolve conflicts.  If it is RejectConflict, Merge() returns an error on any conflict.
+	Policy MergePolicy
+	// Contents merges regular files modified on both sides.  If it is nil, such files are treated as conflicts.
+	Contents ContentMerger
+	// Context is used to merge contents.  If it is nil, context.Background() is used.
+	Context context.Context
+
+	// Reverse index of the Base tree.  It is used to find parent directories of deleted or moved inodes.
+	baseParents map[uint64]InoSlice
+	// Inodes whose attributes are already merged.  They are excluded from attribute conflict checks.
+	attrMerged mapset.Set
+	// Renames and moves in each tree.
+	latestMoves  map[uint64]*inodeMove
+	currentMoves map[uint64]*inodeMove
 }
 
 func (m *Merger) Merge() (*Tree, error) {
@@ -116,6 +137,21 @@ func (m *Merger) Merge() (*Tree, error) {
 	// Fix inode number to prevent conflict.  Result is stored to newCurrent.  m.Current tree is kept original status.
 	newCurrent := m.shiftIno(latestDiff, currentDiff)
 
+	// Detect renames and moves.  A rename on one side should not conflict with changes on the other side.
+	m.baseParents = m.reverseIndex(m.Base)
+	m.attrMerged = mapset.NewThreadUnsafeSet()
+	m.latestMoves = m.findMoves(m.Latest)
+	m.currentMoves = m.findMoves(newCurrent)
+	m.applyCommonMoves()
+	m.mergeMoveAttributes(latestDiff, currentDiff, newCurrent)
+	if m.Contents != nil {
+		m.mergeContents(latestDiff, currentDiff, newCurrent)
+	}
+
+	if m.Policy != MergePolicy_RejectConflict {
+		return m.mergeWithPolicy(latestDiff, currentDiff, newCurrent)
+	}
+
 	// Check conflicts.
 	if err := m.checkFileConflict(latestDiff, currentDiff, newCurrent); err != nil {
 		return nil, err
@@ -123,19 +159,17 @@ func (m *Merger) Merge() (*Tree, error) {
 	if err := m.checkDirConflict(latestDiff, currentDiff, newCurrent); err != nil {
 		return nil, err
 	}
-
-	rb := m.reverseIndex(m.Base)
-	rl := m.reverseIndex(m.Latest)
-	rc := m.reverseIndex(m.Current)
-
-	_ = rb
-	_ = rl
-	_ = rc
-
-	// TODO: Apply merge policies.
-	log.Println("[WARN] merge policies check is not implemented")
-
-	return m.mergeTree(latestDiff, currentDiff, newCurrent)
+	if err := m.checkMoveConflict(newCurrent); err != nil {
+		return nil, err
+	}
+	tree, err := m.mergeTree(latestDiff, currentDiff, newCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.checkReachable(tree, newCurrent); err != nil {
+		return nil, err
+	}
+	return tree, nil
 }
 
 // mergeTree creates merged tree by apply currentDiff to latest tree.
@@ -148,8 +182,15 @@ func (m *Merger) mergeTree(latestDiff *Diff, currentDiff *Diff, newCurrent *Tree
 	for _ino := range currentDiff.Modified.Iter() {
 		ino := _ino.(uint64)
 		if newCurrent.Inodes[ino].FileType == FileType_Directory {
-			// Copy directory attributes from current tree.
+			// Copy directory attributes from current tree.  Directory entries are based on the latest tree to keep
+			// entries changed in the latest tree.
 			i := newCurrent.Inodes[ino].DeepCopy()
+			if latest := m.Latest.Inodes[ino]; latest.GetFileType() == FileType_Directory {
+				i.Entries = map[string]uint64{}
+				for name, to := range latest.Entries {
+					i.Entries[name] = to
+				}
+			}
 			tree.Inodes[ino] = i
 
 			// Apply changes of directory entries.
@@ -178,6 +219,7 @@ func (m *Merger) mergeTree(latestDiff *Diff, currentDiff *Diff, newCurrent *Tree
 }
 
 // shiftIno shifts inode number (ino) of added inodes to prevent conflict.  m.Current tree is kept original status.
+// Shifted inodes are replaced in currentDiff.
 func (m *Merger) shiftIno(latestDiff, currentDiff *Diff) *Tree {
 	newCurrent := m.Current.DeepCopy()
 	for _oldIno := range latestDiff.Added.Intersect(currentDiff.Added).Iter() {
@@ -191,6 +233,8 @@ func (m *Merger) shiftIno(latestDiff, currentDiff *Diff) *Tree {
 		// Fix inodes table.
 		newCurrent.Inodes[newIno] = newCurrent.Inodes[oldIno]
 		delete(newCurrent.Inodes, oldIno)
+		currentDiff.Added.Remove(oldIno)
+		currentDiff.Added.Add(newIno)
 
 		// Fix directory entries.
 		for _, inode := range newCurrent.Inodes {
@@ -269,10 +313,10 @@ func (m *Merger) filterNotModifiedInodes(inodes mapset.Set, base, other *Tree) m
 func (m *Merger) checkFileConflict(latestDiffAll, currentDiffAll *Diff, newCurrent *Tree) error {
 	// Filter by file type.
 	latestDiff := latestDiffAll.Filter(func(ino uint64) bool {
-		return m.Latest.Inodes[ino].FileType != FileType_Directory
+		return m.Latest.Inodes[ino].FileType != FileType_Directory && !m.attrMerged.Contains(ino)
 	})
 	currentDiff := currentDiffAll.Filter(func(ino uint64) bool {
-		return newCurrent.Inodes[ino].FileType != FileType_Directory
+		return newCurrent.Inodes[ino].FileType != FileType_Directory && !m.attrMerged.Contains(ino)
 	})
 
 	return conflictRule{}.CheckConflictRulesFile(latestDiff, currentDiff, m.Latest, newCurrent)
@@ -287,7 +331,11 @@ func (m *Merger) checkDirConflict(latestDiffAll, currentDiffAll *Diff, newCurren
 		return newCurrent.Inodes[ino].FileType == FileType_Directory
 	})
 
-	if err := (conflictRule{}).CheckConflictRulesFile(latestDiff, currentDiff, m.Latest, newCurrent); err != nil {
+	// Attributes of moved directories may be already merged.  Their directory entries are still checked.
+	notMerged := func(ino uint64) bool {
+		return !m.attrMerged.Contains(ino)
+	}
+	if err := (conflictRule{}).CheckConflictRulesFile(latestDiff.Filter(notMerged), currentDiff.Filter(notMerged), m.Latest, newCurrent); err != nil {
 		return err
 	}
 	return conflictRule{}.CheckConflictRulesDir(latestDiff, currentDiff, m.Base, m.Latest, newCurrent)
@@ -298,40 +346,39 @@ type conflictRule struct{}
 // CheckConflictRulesFile checks conflict of files and directories (attributes only).
 func (conflictRule) CheckConflictRulesFile(a, b *Diff, aTree, bTree *Tree) error {
 	if inoset := a.Deleted.Intersect(b.Added); inoset.Cardinality() > 0 {
-		err := xerrors.Errorf("conflict(del-add): %s", inoset)
+		err := newInodeConflictError("del-add", inoset, aTree, bTree)
 		log.Printf("[WARN] %s", err)
 		return err
 	}
 	if inoset := a.Deleted.Intersect(b.Modified); inoset.Cardinality() > 0 {
-		return xerrors.Errorf("conflict(del-mod): %s", inoset)
+		return newInodeConflictError("del-mod", inoset, aTree, bTree)
 	}
 	if inoset := a.Added.Intersect(b.Deleted); inoset.Cardinality() > 0 {
-		err := xerrors.Errorf("conflict(add-del): %s", inoset)
+		err := newInodeConflictError("add-del", inoset, aTree, bTree)
 		log.Printf("[WARN] %s", err)
 		return err
 	}
 	if inoset := a.Added.Intersect(b.Added); inoset.Cardinality() > 0 {
-		err := xerrors.Errorf("conflict(add-add): %s", inoset)
+		err := newInodeConflictError("add-add", inoset, aTree, bTree)
 		log.Printf("[WARN] %s", err)
 		return err
 	}
 	if inoset := a.Added.Intersect(b.Modified); inoset.Cardinality() > 0 {
-		err := xerrors.Errorf("conflict(add-mod): %s", inoset)
+		err := newInodeConflictError("add-mod", inoset, aTree, bTree)
 		log.Printf("[WARN] %s", err)
 		return err
 	}
 	if inoset := a.Modified.Intersect(b.Deleted); inoset.Cardinality() > 0 {
-		return xerrors.Errorf("conflict(mod-del): %s", inoset)
+		return newInodeConflictError("mod-del", inoset, aTree, bTree)
 	}
 	if inoset := a.Modified.Intersect(b.Added); inoset.Cardinality() > 0 {
-		err := xerrors.Errorf("conflict(mod-add): %s", inoset)
+		err := newInodeConflictError("mod-add", inoset, aTree, bTree)
 		log.Printf("[WARN] %s", err)
 		return err
 	}
 	if inoset := a.Modified.Intersect(b.Modified); inoset.Cardinality() > 0 {
-		for _ino := range inoset.Iter() {
-			// TODO: goroutine leak.  ループ中にbreakまたはreturnすると、Iter()内部で作成した1つのgoroutineがリークする。
-
+		mismatched := mapset.NewThreadUnsafeSet()
+		for _, _ino := range inoset.ToSlice() {
 			ino := _ino.(uint64)
 			aino := aTree.Inodes[ino]
 			bino := bTree.Inodes[ino]
@@ -339,24 +386,27 @@ func (conflictRule) CheckConflictRulesFile(a, b *Diff, aTree, bTree *Tree) error
 			if aino.FileType == FileType_Directory {
 				if !aino.EqualsDirWithoutContents(bino) {
 					// THe result is not same.
-					return xerrors.Errorf("conflict(mod-mod): a=%s, b=%s", aino, bino)
+					mismatched.Add(ino)
 				}
 			} else {
 				if !aino.EqualsFile(bino) {
 					// The result is not same.
-					return xerrors.Errorf("conflict(mod-mod): a=%s, b=%s", aino, bino)
+					mismatched.Add(ino)
 				}
 			}
 			// Changed same file by two ways (base->latest and base->current), but the result is same.
 			// This changes are should allow.
 		}
+		if mismatched.Cardinality() > 0 {
+			return newInodeConflictError("mod-mod", mismatched, aTree, bTree)
+		}
 	}
 	return nil
 }
 
 // CheckConflictRulesDir checks conflict of directory entries.
 func (conflictRule) CheckConflictRulesDir(a, b *Diff, baseTree, aTree, bTree *Tree) error {
-	for _ino := range a.Modified.Intersect(b.Modified).Iter() {
+	for _, _ino := range a.Modified.Intersect(b.Modified).ToSlice() {
 		ino := _ino.(uint64)
 		baseFile := baseTree.Inodes[ino]
 		aFile := aTree.Inodes[ino]
@@ -366,52 +416,159 @@ func (conflictRule) CheckConflictRulesDir(a, b *Diff, baseTree, aTree, bTree *Tr
 		bDiff := newEntryDiff(baseFile, bFile)
 
 		if nameSet := aDiff.Deleted.Intersect(bDiff.Added); nameSet.Cardinality() > 0 {
-			err := xerrors.Errorf("conflict(del-add): base=%s a=%s b=%s conflicted_entries=%s", baseFile, aFile, bFile, nameSet)
+			err := newEntryConflictError("del-add", ino, nameSet, aTree, bTree)
 			log.Printf("[WARN] %s", err)
 			return err
 		}
 		if nameSet := aDiff.Deleted.Intersect(bDiff.Modified); nameSet.Cardinality() > 0 {
-			return xerrors.Errorf("conflict(del-mod): base=%s a=%s b=%s conflicted_entries=%s", baseFile, aFile, bFile, nameSet)
+			return newEntryConflictError("del-mod", ino, nameSet, aTree, bTree)
 		}
 		if nameSet := aDiff.Added.Intersect(bDiff.Deleted); nameSet.Cardinality() > 0 {
-			err := xerrors.Errorf("conflict(add-del): base=%s a=%s b=%s conflicted_entries=%s", baseFile, aFile, bFile, nameSet)
+			err := newEntryConflictError("add-del", ino, nameSet, aTree, bTree)
 			log.Printf("[WARN] %s", err)
 			return err
 		}
 		if nameSet := aDiff.Added.Intersect(bDiff.Added); nameSet.Cardinality() > 0 {
 			// todo: 挙動未定
-			err := xerrors.Errorf("conflict(add-add): base=%s a=%s b=%s conflicted_entries=%s", baseFile, aFile, bFile, nameSet)
+			err := newEntryConflictError("add-add", ino, nameSet, aTree, bTree)
 			log.Printf("[WARN] %s", err)
 			return err
 		}
 		if nameSet := aDiff.Added.Intersect(bDiff.Modified); nameSet.Cardinality() > 0 {
-			err := xerrors.Errorf("conflict(add-mod): base=%s a=%s b=%s conflicted_entries=%s", baseFile, aFile, bFile, nameSet)
+			err := newEntryConflictError("add-mod", ino, nameSet, aTree, bTree)
 			log.Printf("[WARN] %s", err)
 			return err
 		}
 		if nameSet := aDiff.Modified.Intersect(bDiff.Deleted); nameSet.Cardinality() > 0 {
-			return xerrors.Errorf("conflict(mod-del): base=%s a=%s b=%s conflicted_entries=%s", baseFile, aFile, bFile, nameSet)
+			return newEntryConflictError("mod-del", ino, nameSet, aTree, bTree)
 		}
 		if nameSet := aDiff.Modified.Intersect(bDiff.Added); nameSet.Cardinality() > 0 {
-			err := xerrors.Errorf("conflict(mod-add): base=%s a=%s b=%s conflicted_entries=%s", baseFile, aFile, bFile, nameSet)
+			err := newEntryConflictError("mod-add", ino, nameSet, aTree, bTree)
 			log.Printf("[WARN] %s", err)
 			return err
 		}
 		if nameSet := aDiff.Modified.Intersect(bDiff.Modified); nameSet.Cardinality() > 0 {
-			for _name := range nameSet.Iter() {
+			mismatched := mapset.NewThreadUnsafeSet()
+			for _, _name := range nameSet.ToSlice() {
 				name := _name.(string)
 				if aFile.Entries[name] != bFile.Entries[name] {
 					// The referenced inode number of directory entries associated "name" is changed.  And it is not match.
-					return xerrors.Errorf("conflict(mod-mod): base=%s a=%s b=%s conflicted_entries=%s", baseFile, aFile, bFile, nameSet)
+					mismatched.Add(name)
 				}
 				// The referenced inode number of directory entries associated "name" is changed.  But it is referenced
 				// same inode number.
 			}
+			if mismatched.Cardinality() > 0 {
+				return newEntryConflictError("mod-mod", ino, mismatched, aTree, bTree)
+			}
 		}
 	}
 	return nil
 }
 
+// MergeConflictError is returned by Merger if the latest tree and the current tree have conflicted changes.
+type MergeConflictError struct {
+	Conflicts []*CommitConflict
+}
+
+// newInodeConflictError creates an error for the inodes changed in both trees.
+func newInodeConflictError(conflictType string, inoset mapset.Set, latest, current *Tree) *MergeConflictError {
+	var inos InoSlice
+	for _, ino := range inoset.ToSlice() {
+		inos = append(inos, ino.(uint64))
+	}
+	sort.Sort(inos)
+
+	latestPaths := latest.AllPaths()
+	currentPaths := current.AllPaths()
+	e := &MergeConflictError{}
+	for _, ino := range inos {
+		c := &CommitConflict{
+			Type:         conflictType,
+			Ino:          ino,
+			LatestPaths:  latestPaths[ino],
+			CurrentPaths: currentPaths[ino],
+			LatestFile:   latest.GetInodes()[ino],
+			CurrentFile:  current.GetInodes()[ino],
+		}
+		if c.LatestFile != nil {
+			c.LatestIno = ino
+		}
+		if c.CurrentFile != nil {
+			c.CurrentIno = ino
+		}
+		e.Conflicts = append(e.Conflicts, c)
+	}
+	return e
+}
+
+// newEntryConflictError creates an error for the directory entries changed in both trees.
+func newEntryConflictError(conflictType string, dirIno uint64, nameSet mapset.Set, latest, current *Tree) *MergeConflictError {
+	var names []string
+	for _, name := range nameSet.ToSlice() {
+		names = append(names, name.(string))
+	}
+	sort.Strings(names)
+
+	// Paths of the directory are looked up only once for each tree.
+	latestDirs := latest.AllPaths()[dirIno]
+	currentDirs := current.AllPaths()[dirIno]
+	entryPaths := func(tree *Tree, dirs []string, name string) []string {
+		if _, ok := tree.GetInodes()[dirIno].GetEntries()[name]; !ok {
+			return nil
+		}
+		var paths []string
+		for _, dir := range dirs {
+			paths = append(paths, path.Join(dir, name))
+		}
+		return paths
+	}
+
+	e := &MergeConflictError{}
+	for _, name := range names {
+		latestIno := latest.GetInodes()[dirIno].GetEntries()[name]
+		currentIno := current.GetInodes()[dirIno].GetEntries()[name]
+		e.Conflicts = append(e.Conflicts, &CommitConflict{
+			Type:         conflictType,
+			Ino:          dirIno,
+			Name:         name,
+			LatestIno:    latestIno,
+			CurrentIno:   currentIno,
+			LatestPaths:  entryPaths(latest, latestDirs, name),
+			CurrentPaths: entryPaths(current, currentDirs, name),
+			LatestFile:   latest.GetInodes()[latestIno],
+			CurrentFile:  current.GetInodes()[currentIno],
+		})
+	}
+	return e
+}
+
+func (e *MergeConflictError) Error() string {
+	var buff strings.Builder
+	for i, c := range e.Conflicts {
+		if i > 0 {
+			buff.WriteString(", ")
+		}
+		buff.WriteString(fmt.Sprintf("conflict(%s): ino=%d", c.GetType(), c.GetIno()))
+		if c.GetName() != "" {
+			buff.WriteString(fmt.Sprintf(" name=%s", c.GetName()))
+		}
+		buff.WriteString(fmt.Sprintf(" latest=%v current=%v", c.GetLatestPaths(), c.GetCurrentPaths()))
+	}
+	return buff.String()
+}
+
+// Status converts the error to the gRPC status.  Details of the status contain CommitConflicts.
+func (e *MergeConflictError) Status(prefix string) error {
+	st := status.New(codes.Aborted, prefix+": "+e.Error())
+	withDetails, err := st.WithDetails(&CommitConflicts{Conflicts: e.Conflicts})
+	if err != nil {
+		log.Printf("[ERROR] failed to add conflict details: %+v", err)
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
 type entryDiff struct {
 	Added    mapset.Set
 	Deleted  mapset.Set
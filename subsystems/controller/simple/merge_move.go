@@ -0,0 +1,355 @@
+package simple
+
+import (
+	mapset "github.com/deckarep/golang-set"
+	"github.com/golang/protobuf/ptypes"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"log"
+	"sort"
+	"time"
+)
+
+// inodeLink is a directory entry that refers an inode.
+type inodeLink struct {
+	Dir  uint64
+	Name string
+}
+
+// inodeMove is a rename or a move of the inode.  The inode exists in both trees, but directory entries that refer
+// the inode are changed.
+type inodeMove struct {
+	Ino  uint64
+	From []inodeLink
+	To   []inodeLink
+	// Ctime of the moved inode.  It is used as the time of the move.
+	Ctime time.Time
+}
+
+func (mv *inodeMove) SameDestination(other *inodeMove) bool {
+	return sameLinks(mv.To, other.To)
+}
+func sameLinks(a, b []inodeLink) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// links returns directory entries that refer the inode in deterministic order.  parents is the reverse index of the
+// tree.
+func links(tree *Tree, parents map[uint64]InoSlice, ino uint64) []inodeLink {
+	var out []inodeLink
+	visited := map[uint64]bool{}
+	for _, dirIno := range parents[ino] {
+		if visited[dirIno] {
+			continue
+		}
+		visited[dirIno] = true
+		for name, to := range tree.Inodes[dirIno].GetEntries() {
+			if to == ino {
+				out = append(out, inodeLink{Dir: dirIno, Name: name})
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Dir != out[j].Dir {
+			return out[i].Dir < out[j].Dir
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// findMoves detects renames and moves from the base tree to the tree.  Unlinked inodes are not moves.
+func (m *Merger) findMoves(tree *Tree) map[uint64]*inodeMove {
+	parents := m.reverseIndex(tree)
+	moves := map[uint64]*inodeMove{}
+	for ino, f := range tree.Inodes {
+		if ino == tree.RootIno || m.Base.Inodes[ino] == nil {
+			continue
+		}
+		from := links(m.Base, m.baseParents, ino)
+		to := links(tree, parents, ino)
+		if len(to) == 0 || sameLinks(from, to) {
+			continue
+		}
+		moves[ino] = &inodeMove{
+			Ino:   ino,
+			From:  from,
+			To:    to,
+			Ctime: ctime(f),
+		}
+	}
+	return moves
+}
+
+// applyCommonMoves applies moves made on both sides in the same way to the base tree.  They are not treated as
+// changes of directory entries, so they never conflict.  m.Base is replaced with the modified copy.
+func (m *Merger) applyCommonMoves() {
+	var base *Tree
+	for ino, lm := range m.latestMoves {
+		cm := m.currentMoves[ino]
+		if cm == nil || !lm.SameDestination(cm) {
+			continue
+		}
+		if base == nil {
+			base = m.Base.DeepCopy()
+		}
+		for _, l := range lm.From {
+			delete(base.Inodes[l.Dir].Entries, l.Name)
+		}
+		for _, l := range lm.To {
+			if dir := base.Inodes[l.Dir]; dir.GetFileType() == FileType_Directory {
+				dir.Entries[l.Name] = ino
+			}
+		}
+	}
+	if base != nil {
+		m.Base = base
+	}
+}
+
+// mergeMoveAttributes merges attributes of inodes that are moved on one side and modified on the other side.  A
+// rename only updates the ctime of the inode, so other attributes and contents are taken from the modified side.
+// Merged inodes are stored to newCurrent and added to m.attrMerged.
+func (m *Merger) mergeMoveAttributes(latestDiff, currentDiff *Diff, newCurrent *Tree) {
+	for _, _ino := range latestDiff.Modified.Intersect(currentDiff.Modified).ToSlice() {
+		ino := _ino.(uint64)
+		if m.latestMoves[ino] == nil && m.currentMoves[ino] == nil {
+			continue
+		}
+		bf := m.Base.Inodes[ino]
+		lf := m.Latest.Inodes[ino]
+		cf := newCurrent.Inodes[ino]
+		lOnly := m.latestMoves[ino] != nil && changedOnlyCtime(bf, lf)
+		cOnly := m.currentMoves[ino] != nil && changedOnlyCtime(bf, cf)
+		if !lOnly && !cOnly {
+			continue
+		}
+
+		merged := cf.DeepCopy()
+		if cOnly {
+			// Take attributes and contents from the latest tree.
+			copyAttributes(merged, lf)
+			merged.ContentRef = lf.ContentRef
+		}
+		if ctime(cf).Before(ctime(lf)) {
+			merged.Ctime = lf.Ctime
+		} else {
+			merged.Ctime = cf.Ctime
+		}
+		newCurrent.Inodes[ino] = merged
+		m.attrMerged.Add(ino)
+		log.Printf("[INFO] merged attributes of the moved inode: ino=%d", ino)
+	}
+}
+
+// changedOnlyCtime reports whether f is same as the base except ctime.  Directory entries and mtime of directories are
+// ignored.
+func changedOnlyCtime(base, f *File) bool {
+	f2 := f.DeepCopy()
+	f2.Ctime = base.Ctime
+	if base.FileType == FileType_Directory {
+		return base.EqualsDirWithoutContents(f2)
+	}
+	return base.EqualsFile(f2)
+}
+func ctime(f *File) time.Time {
+	if f.GetCtime() == nil {
+		return time.Time{}
+	}
+	t, err := ptypes.Timestamp(f.GetCtime())
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// movedToDifferentPlaces returns inodes that are moved on both sides to different places.
+func (m *Merger) movedToDifferentPlaces() mapset.Set {
+	out := mapset.NewThreadUnsafeSet()
+	for ino, lm := range m.latestMoves {
+		if cm := m.currentMoves[ino]; cm != nil && !lm.SameDestination(cm) {
+			out.Add(ino)
+		}
+	}
+	return out
+}
+
+// checkMoveConflict checks inodes moved on both sides to different places.
+func (m *Merger) checkMoveConflict(newCurrent *Tree) error {
+	if inoset := m.movedToDifferentPlaces(); inoset.Cardinality() > 0 {
+		err := newInodeConflictError("move-move", inoset, m.Latest, newCurrent)
+		log.Printf("[WARN] %s", err)
+		return err
+	}
+	return nil
+}
+
+// checkReachable checks moved inodes are reachable from the root directory of the merged tree.  Concurrent moves may
+// create a directory cycle (e.g. moving /a into /b and moving /b into /a) or orphan a subtree.
+func (m *Merger) checkReachable(tree, newCurrent *Tree) error {
+	cycles := mapset.NewThreadUnsafeSet()
+	orphans := mapset.NewThreadUnsafeSet()
+	parents := m.reverseIndex(tree)
+	for _, ino := range m.unreachableMoves(tree) {
+		if inCycle(parents, ino) {
+			cycles.Add(ino)
+		} else {
+			orphans.Add(ino)
+		}
+	}
+	if cycles.Cardinality() > 0 {
+		err := newInodeConflictError("move-cycle", cycles, m.Latest, newCurrent)
+		log.Printf("[WARN] %s", err)
+		return err
+	}
+	if orphans.Cardinality() > 0 {
+		err := newInodeConflictError("move-orphan", orphans, m.Latest, newCurrent)
+		log.Printf("[WARN] %s", err)
+		return err
+	}
+	return nil
+}
+
+// unreachableMoves returns moved inodes that exist in the tree but can not be reached from the root directory.
+func (m *Merger) unreachableMoves(tree *Tree) InoSlice {
+	reachable := map[uint64]bool{}
+	var walk func(ino uint64)
+	walk = func(ino uint64) {
+		if reachable[ino] || tree.Inodes[ino] == nil {
+			return
+		}
+		reachable[ino] = true
+		for _, to := range tree.Inodes[ino].Entries {
+			walk(to)
+		}
+	}
+	walk(tree.RootIno)
+
+	var inos InoSlice
+	for ino := range tree.Inodes {
+		if reachable[ino] {
+			continue
+		}
+		if m.latestMoves[ino] != nil || m.currentMoves[ino] != nil {
+			inos = append(inos, ino)
+		}
+	}
+	sort.Sort(inos)
+	return inos
+}
+
+// inCycle reports whether the inode is an ancestor of itself.
+func inCycle(parents map[uint64]InoSlice, ino uint64) bool {
+	visited := map[uint64]bool{}
+	queue := append(InoSlice{}, parents[ino]...)
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if p == ino {
+			return true
+		}
+		if visited[p] {
+			continue
+		}
+		visited[p] = true
+		queue = append(queue, parents[p]...)
+	}
+	return false
+}
+
+// resolveMove keeps the destination of the winner and removes directory entries added by the loser.  The tree contains
+// changes in the current tree.
+func (m *Merger) resolveMove(tree *Tree, ino uint64, winner mergeSide) {
+	keep, loser := m.latestMoves[ino], m.currentMoves[ino]
+	if winner == currentSide {
+		keep, loser = loser, keep
+	}
+	kept := map[inodeLink]bool{}
+	for _, l := range keep.To {
+		kept[l] = true
+		if dir := tree.Inodes[l.Dir]; dir.GetFileType() == FileType_Directory {
+			if _, ok := dir.Entries[l.Name]; !ok {
+				dir.Entries[l.Name] = ino
+			}
+		}
+	}
+	for _, l := range loser.To {
+		if kept[l] {
+			continue
+		}
+		if dir := tree.Inodes[l.Dir]; dir != nil && dir.Entries[l.Name] == ino {
+			delete(dir.Entries, l.Name)
+		}
+	}
+}
+
+// revertUnreachableMoves reverts moves that make inodes unreachable from the root directory.  Moves are reverted one
+// by one until all moved inodes become reachable.  Moves of the losing side are preferred, then older moves.
+func (m *Merger) revertUnreachableMoves(tree, newCurrent *Tree) error {
+	type candidate struct {
+		side  mergeSide
+		moves map[uint64]*inodeMove
+		mv    *inodeMove
+		loser bool
+	}
+	for {
+		var candidates []*candidate
+		for _, ino := range m.unreachableMoves(tree) {
+			winner, err := m.winner(&mergeConflict{Ino: ino, Latest: InodeModified, Current: InodeModified, Move: true}, newCurrent)
+			if err != nil {
+				return err
+			}
+			if mv := m.latestMoves[ino]; mv != nil {
+				candidates = append(candidates, &candidate{latestSide, m.latestMoves, mv, winner != latestSide})
+			}
+			if mv := m.currentMoves[ino]; mv != nil {
+				candidates = append(candidates, &candidate{currentSide, m.currentMoves, mv, winner != currentSide})
+			}
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if candidates[i].loser != candidates[j].loser {
+				return candidates[i].loser
+			}
+			return candidates[i].mv.Ctime.Before(candidates[j].mv.Ctime)
+		})
+
+		c := candidates[0]
+		log.Printf("[INFO] revert the move in %s tree to resolve unreachable inode: ino=%d", c.side, c.mv.Ino)
+		m.revertMove(tree, c.mv)
+		delete(c.moves, c.mv.Ino)
+	}
+}
+
+// revertMove removes directory entries added by the move and restores the original entries.  Entries are renamed if
+// the name is already used.
+func (m *Merger) revertMove(tree *Tree, mv *inodeMove) {
+	for _, l := range mv.To {
+		if dir := tree.Inodes[l.Dir]; dir != nil && dir.Entries[l.Name] == mv.Ino {
+			delete(dir.Entries, l.Name)
+		}
+	}
+	for _, l := range mv.From {
+		dir := tree.Inodes[l.Dir]
+		if dir.GetFileType() != FileType_Directory {
+			continue
+		}
+		name := l.Name
+		if existing, ok := dir.Entries[name]; ok {
+			if existing == mv.Ino {
+				continue
+			}
+			name = m.conflictName(dir, name)
+		}
+		dir.Entries[name] = mv.Ino
+	}
+}
@@ -0,0 +1,137 @@
+package simple
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"testing"
+	"time"
+)
+
+func TestMerger_Merge_moves(t *testing.T) {
+	withCtime := func(tree *Tree, ino uint64, sec int64) *Tree {
+		tree.Inodes[ino].Ctime = mustProtoTime(time.Unix(sec, 0))
+		return tree
+	}
+	merge := func(policy MergePolicy, base, latest, current *Tree) (*Tree, error) {
+		m := &Merger{
+			Info:    &CommitInfo{Node: "node1"},
+			Base:    base,
+			Latest:  latest,
+			Current: current,
+			Policy:  policy,
+		}
+		return m.Merge()
+	}
+	conflictType := func(err error) string {
+		var conflictErr *MergeConflictError
+		if errors.As(err, &conflictErr) && len(conflictErr.Conflicts) > 0 {
+			return conflictErr.Conflicts[0].GetType()
+		}
+		return ""
+	}
+
+	// base: /a
+	fileBase := func() *Tree {
+		return &newTreeBuilder().Dirs(1).File(2, 0644, "a").DirEntry(1, "a", 2).Tree
+	}
+	// Rename /a to /name.
+	renamed := func(name string, sec int64) *Tree {
+		return withCtime(&newTreeBuilder().Dirs(1).File(2, 0644, "a").DirEntry(1, name, 2).Tree, 2, sec)
+	}
+	edited := func(sec int64) *Tree {
+		tree := &newTreeBuilder().Dirs(1).File(2, 0644, "edited").DirEntry(1, "a", 2).Tree
+		tree.Inodes[2].Mtime = mustProtoTime(time.Unix(sec, 0))
+		return withCtime(tree, 2, sec)
+	}
+
+	t.Run("should_merge_rename_and_edit", func(t *testing.T) {
+		tree, err := merge(MergePolicy_RejectConflict, fileBase(), renamed("b", 2), edited(3))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/b"}, tree.Paths(2))
+			assert.Equal(t, "id-edited", tree.Inodes[2].GetContentRef().GetKey().GetId())
+			assert.Equal(t, int64(3), tree.Inodes[2].GetCtime().GetSeconds())
+		}
+	})
+	t.Run("should_merge_edit_and_rename", func(t *testing.T) {
+		tree, err := merge(MergePolicy_RejectConflict, fileBase(), edited(2), renamed("b", 3))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/b"}, tree.Paths(2))
+			assert.Equal(t, "id-edited", tree.Inodes[2].GetContentRef().GetKey().GetId())
+			assert.Equal(t, int64(3), tree.Inodes[2].GetCtime().GetSeconds())
+		}
+	})
+	t.Run("should_merge_same_renames", func(t *testing.T) {
+		tree, err := merge(MergePolicy_RejectConflict, fileBase(), renamed("b", 2), renamed("b", 2))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/b"}, tree.Paths(2))
+		}
+	})
+	t.Run("reject/move-move", func(t *testing.T) {
+		_, err := merge(MergePolicy_RejectConflict, fileBase(), renamed("b", 2), renamed("c", 3))
+		assert.Equal(t, "move-move", conflictType(err))
+	})
+	t.Run("latest_wins/move-move", func(t *testing.T) {
+		tree, err := merge(MergePolicy_LatestWins, fileBase(), renamed("b", 2), renamed("c", 3))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/b"}, tree.Paths(2))
+		}
+	})
+	t.Run("current_wins/move-move", func(t *testing.T) {
+		tree, err := merge(MergePolicy_CurrentWins, fileBase(), renamed("b", 2), renamed("c", 3))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/c"}, tree.Paths(2))
+		}
+	})
+	t.Run("last_writer_wins/move-move", func(t *testing.T) {
+		tree, err := merge(MergePolicy_LastWriterWins, fileBase(), renamed("b", 3), renamed("c", 2))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/b"}, tree.Paths(2))
+		}
+	})
+
+	// base: /a/, /b/
+	dirBase := func() *Tree {
+		return &newTreeBuilder().Dirs(1, 2, 3).DirEntry(1, "a", 2).DirEntry(1, "b", 3).Tree
+	}
+	// latest: /b/a/
+	aIntoB := func() *Tree {
+		return &newTreeBuilder().Dirs(1, 2, 3).DirEntry(1, "b", 3).DirEntry(3, "a", 2).Tree
+	}
+	// current: /a/b/
+	bIntoA := func() *Tree {
+		return &newTreeBuilder().Dirs(1, 2, 3).DirEntry(1, "a", 2).DirEntry(2, "b", 3).Tree
+	}
+
+	t.Run("reject/move-cycle", func(t *testing.T) {
+		_, err := merge(MergePolicy_RejectConflict, dirBase(), aIntoB(), bIntoA())
+		assert.Equal(t, "move-cycle", conflictType(err))
+	})
+	t.Run("latest_wins/move-cycle", func(t *testing.T) {
+		tree, err := merge(MergePolicy_LatestWins, dirBase(), aIntoB(), bIntoA())
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/b/a"}, tree.Paths(2))
+			assert.Equal(t, []string{"/b"}, tree.Paths(3))
+		}
+	})
+	t.Run("current_wins/move-cycle", func(t *testing.T) {
+		tree, err := merge(MergePolicy_CurrentWins, dirBase(), aIntoB(), bIntoA())
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/a"}, tree.Paths(2))
+			assert.Equal(t, []string{"/a/b"}, tree.Paths(3))
+		}
+	})
+	t.Run("should_merge_independent_moves", func(t *testing.T) {
+		// base: /a/, /b/, /c/
+		base := &newTreeBuilder().Dirs(1, 2, 3, 4).DirEntry(1, "a", 2).DirEntry(1, "b", 3).DirEntry(1, "c", 4).Tree
+		// latest: /b/a/, /c/
+		latest := &newTreeBuilder().Dirs(1, 2, 3, 4).DirEntry(1, "b", 3).DirEntry(3, "a", 2).DirEntry(1, "c", 4).Tree
+		// current: /a/, /b/c/
+		current := &newTreeBuilder().Dirs(1, 2, 3, 4).DirEntry(1, "a", 2).DirEntry(1, "b", 3).DirEntry(3, "c", 4).Tree
+		tree, err := merge(MergePolicy_RejectConflict, base, latest, current)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/b/a"}, tree.Paths(2))
+			assert.Equal(t, []string{"/b/c"}, tree.Paths(4))
+		}
+	})
+}
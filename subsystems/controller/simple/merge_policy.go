@@ -0,0 +1,422 @@
+package simple
+
+import (
+	"fmt"
+	"github.com/golang/protobuf/ptypes"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"log"
+	"sort"
+	"time"
+)
+
+type mergeSide uint8
+
+const (
+	latestSide mergeSide = iota
+	currentSide
+)
+
+func (s mergeSide) String() string {
+	if s == latestSide {
+		return "latest"
+	}
+	return "current"
+}
+
+// mergeConflict is a change that conflicts between the latest tree and the current tree.  If Name is empty, the inode
+// is changed on both sides.  Otherwise, the directory entry named Name in the directory Ino is changed on both sides.
+// If Move is true, the inode is moved on both sides to different places.
+type mergeConflict struct {
+	Ino     uint64
+	Name    string
+	Latest  ModificationType
+	Current ModificationType
+	Move    bool
+}
+
+func (c *mergeConflict) String() string {
+	if c.Move {
+		return fmt.Sprintf("move ino=%d", c.Ino)
+	}
+	if c.Name == "" {
+		return fmt.Sprintf("ino=%d", c.Ino)
+	}
+	return fmt.Sprintf("dir=%d name=%s", c.Ino, c.Name)
+}
+
+// mergeWithPolicy merges trees and resolves conflicts by m.Policy.
+func (m *Merger) mergeWithPolicy(latestDiff, currentDiff *Diff, newCurrent *Tree) (*Tree, error) {
+	conflicts := m.findConflicts(latestDiff, currentDiff, newCurrent)
+
+	// Apply all changes in the current tree at first.  Then, changes in the latest tree are restored if the latest
+	// tree wins.
+	tree, err := m.mergeTree(latestDiff, currentDiff, newCurrent)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conflicts {
+		winner, err := m.winner(c, newCurrent)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] resolve conflict(%s): policy=%s winner=%s", c, m.Policy, winner)
+		switch {
+		case c.Move:
+			m.resolveMove(tree, c.Ino, winner)
+		case c.Name == "":
+			m.resolveInode(tree, newCurrent, c, winner)
+		default:
+			m.resolveEntry(tree, newCurrent, c, winner)
+		}
+	}
+	// Concurrent moves may make directories unreachable.  They should be reverted before removing unreachable inodes.
+	if err := m.revertUnreachableMoves(tree, newCurrent); err != nil {
+		return nil, err
+	}
+	m.removeUnreachable(tree)
+	return tree, nil
+}
+
+// findConflicts returns changes conflicted between latestDiff and currentDiff in deterministic order.
+func (m *Merger) findConflicts(latestDiff, currentDiff *Diff, newCurrent *Tree) []*mergeConflict {
+	var conflicts []*mergeConflict
+	for _, ino := range m.movedToDifferentPlaces().ToSlice() {
+		conflicts = append(conflicts, &mergeConflict{
+			Ino:     ino.(uint64),
+			Latest:  InodeModified,
+			Current: InodeModified,
+			Move:    true,
+		})
+	}
+	for _, _ino := range latestDiff.Changed().Intersect(currentDiff.Changed()).ToSlice() {
+		ino := _ino.(uint64)
+		l := latestDiff.HowChanges(ino)
+		c := currentDiff.HowChanges(ino)
+		if l == InodeDeleted && c == InodeDeleted {
+			continue
+		}
+		if l != InodeModified || c != InodeModified {
+			// Deleted on one side and modified on the other side.
+			conflicts = append(conflicts, &mergeConflict{Ino: ino, Latest: l, Current: c})
+			continue
+		}
+
+		lf := m.Latest.Inodes[ino]
+		cf := newCurrent.Inodes[ino]
+		merged := m.attrMerged.Contains(ino)
+		if lf.FileType != FileType_Directory {
+			if !merged && !lf.EqualsFile(cf) {
+				conflicts = append(conflicts, &mergeConflict{Ino: ino, Latest: l, Current: c})
+			}
+			continue
+		}
+		if !merged && !lf.EqualsDirWithoutContents(cf) {
+			conflicts = append(conflicts, &mergeConflict{Ino: ino, Latest: l, Current: c})
+		}
+
+		lDiff := newEntryDiff(m.Base.Inodes[ino], lf)
+		cDiff := newEntryDiff(m.Base.Inodes[ino], cf)
+		lChanged := lDiff.Added.Union(lDiff.Deleted).Union(lDiff.Modified)
+		cChanged := cDiff.Added.Union(cDiff.Deleted).Union(cDiff.Modified)
+		for _, _name := range lChanged.Intersect(cChanged).ToSlice() {
+			name := _name.(string)
+			lTo, lok := lf.Entries[name]
+			cTo, cok := cf.Entries[name]
+			if lok == cok && lTo == cTo {
+				// Changed to the same inode on both sides.
+				continue
+			}
+			conflicts = append(conflicts, &mergeConflict{
+				Ino:     ino,
+				Name:    name,
+				Latest:  lDiff.howChanges(name),
+				Current: cDiff.howChanges(name),
+			})
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Ino != conflicts[j].Ino {
+			return conflicts[i].Ino < conflicts[j].Ino
+		}
+		if conflicts[i].Move != conflicts[j].Move {
+			return conflicts[i].Move
+		}
+		return conflicts[i].Name < conflicts[j].Name
+	})
+	return conflicts
+}
+
+// winner decides the side that wins the conflict.  It returns an error if the policy is unknown.
+func (m *Merger) winner(c *mergeConflict, newCurrent *Tree) (mergeSide, error) {
+	switch m.Policy {
+	case MergePolicy_CurrentWins:
+		return currentSide, nil
+	case MergePolicy_LatestWins:
+		return latestSide, nil
+	case MergePolicy_KeepBoth:
+		// Modified file is kept rather than deleted.  Otherwise, the latest tree keeps the original name and the
+		// file in the current tree is renamed.
+		if c.Latest == InodeDeleted {
+			return currentSide, nil
+		}
+		return latestSide, nil
+	case MergePolicy_LastWriterWins:
+		if m.modTime(m.Latest, c).After(m.modTime(newCurrent, c)) {
+			return latestSide, nil
+		}
+		return currentSide, nil
+	default:
+		return latestSide, xerrors.Errorf("unexpected merge policy: %s", m.Policy)
+	}
+}
+
+// modTime returns the time when the conflicted change was made in the tree.  If the change is a deletion, the mtime
+// of the parent directory is used.  If the change is a move, the ctime of the inode is used.
+func (m *Merger) modTime(tree *Tree, c *mergeConflict) time.Time {
+	if c.Move {
+		moves := m.currentMoves
+		if tree == m.Latest {
+			moves = m.latestMoves
+		}
+		if mv := moves[c.Ino]; mv != nil {
+			return mv.Ctime
+		}
+		// Not moved in the tree.
+		return time.Time{}
+	}
+	if c.Name != "" {
+		if to, ok := tree.Inodes[c.Ino].GetEntries()[c.Name]; ok && tree.Inodes[to] != nil {
+			return mtime(tree.Inodes[to])
+		}
+		return mtime(tree.Inodes[c.Ino])
+	}
+	if f := tree.Inodes[c.Ino]; f != nil {
+		return mtime(f)
+	}
+	var latest time.Time
+	for _, parent := range m.baseParents[c.Ino] {
+		if t := mtime(tree.Inodes[parent]); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+func mtime(f *File) time.Time {
+	if f.GetMtime() == nil {
+		return time.Time{}
+	}
+	t, err := ptypes.Timestamp(f.GetMtime())
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// resolveInode resolves the conflict of the inode.  The tree contains changes in the current tree.
+func (m *Merger) resolveInode(tree, newCurrent *Tree, c *mergeConflict, winner mergeSide) {
+	ino := c.Ino
+	if winner == currentSide {
+		if c.Latest == InodeDeleted {
+			// Directory entries deleted in the latest tree should be restored.
+			m.restore(tree, newCurrent, ino)
+			m.relink(tree, newCurrent, ino)
+		}
+		return
+	}
+
+	switch {
+	case c.Latest == InodeDeleted:
+		delete(tree.Inodes, ino)
+		m.unlink(tree, ino)
+	case c.Current == InodeDeleted:
+		m.restore(tree, m.Latest, ino)
+		m.relink(tree, m.Latest, ino)
+	case m.Latest.Inodes[ino].FileType == FileType_Directory:
+		// Keep directory entries merged by mergeTree().
+		copyAttributes(tree.Inodes[ino], m.Latest.Inodes[ino])
+	default:
+		tree.Inodes[ino] = m.Latest.Inodes[ino].DeepCopy()
+		if m.Policy == MergePolicy_KeepBoth {
+			newIno := tree.NextIno(m.Base, m.Latest, newCurrent)
+			tree.Inodes[newIno] = newCurrent.Inodes[ino].DeepCopy()
+			m.linkConflicted(tree, ino, newIno)
+		}
+	}
+}
+
+// resolveEntry resolves the conflict of the directory entry.  The tree contains changes in the current tree.
+func (m *Merger) resolveEntry(tree, newCurrent *Tree, c *mergeConflict, winner mergeSide) {
+	if winner == currentSide {
+		return
+	}
+	dir := tree.Inodes[c.Ino]
+	if dir == nil {
+		// The directory is deleted by the other conflict.
+		return
+	}
+
+	lTo, lok := m.Latest.Inodes[c.Ino].Entries[c.Name]
+	cTo, cok := newCurrent.Inodes[c.Ino].Entries[c.Name]
+	if lok {
+		dir.Entries[c.Name] = lTo
+		m.restore(tree, m.Latest, lTo)
+	} else {
+		delete(dir.Entries, c.Name)
+	}
+	if m.Policy == MergePolicy_KeepBoth && lok && cok {
+		dir.Entries[m.conflictName(dir, c.Name)] = cTo
+		m.restore(tree, newCurrent, cTo)
+	}
+}
+
+// restore copies the inode from src if the tree does not have it.  Descendants of the directory are restored
+// recursively.
+func (m *Merger) restore(tree, src *Tree, ino uint64) {
+	f := tree.Inodes[ino]
+	if f == nil {
+		if src.Inodes[ino] == nil {
+			return
+		}
+		f = src.Inodes[ino].DeepCopy()
+		tree.Inodes[ino] = f
+	}
+	if f.FileType != FileType_Directory {
+		return
+	}
+	for name, to := range f.Entries {
+		if tree.Inodes[to] != nil {
+			continue
+		}
+		if src.Inodes[to] == nil {
+			// Not found in both trees.
+			delete(f.Entries, name)
+			continue
+		}
+		m.restore(tree, src, to)
+	}
+}
+
+// relink adds directory entries that refer the inode in src to the tree.  Entries are renamed if the name is already
+// used.
+func (m *Merger) relink(tree, src *Tree, ino uint64) {
+	for parentIno, parent := range src.Inodes {
+		if parent.FileType != FileType_Directory {
+			continue
+		}
+		dir := tree.Inodes[parentIno]
+		if dir.GetFileType() != FileType_Directory {
+			continue
+		}
+		for name, to := range parent.Entries {
+			if to != ino {
+				continue
+			}
+			if existing, ok := dir.Entries[name]; ok {
+				if existing == ino {
+					continue
+				}
+				name = m.conflictName(dir, name)
+			}
+			dir.Entries[name] = ino
+		}
+	}
+}
+
+// unlink removes all directory entries that refer the inode.
+func (m *Merger) unlink(tree *Tree, ino uint64) {
+	for _, f := range tree.Inodes {
+		for name, to := range f.Entries {
+			if to == ino {
+				delete(f.Entries, name)
+			}
+		}
+	}
+}
+
+// linkConflicted adds the conflictedIno to all directories that have entries of ino with conflicted names.
+func (m *Merger) linkConflicted(tree *Tree, ino, conflictedIno uint64) {
+	for _, f := range tree.Inodes {
+		var names []string
+		for name, to := range f.Entries {
+			if to == ino {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			f.Entries[m.conflictName(f, name)] = conflictedIno
+		}
+	}
+}
+
+// conflictName returns a name to keep the file in the current tree.  It never conflicts with other entries in dir.
+func (m *Merger) conflictName(dir *File, name string) string {
+	node := m.Info.GetNode()
+	if node == "" {
+		node = "unknown"
+	}
+	base := fmt.Sprintf("%s.conflict-%s", name, node)
+	candidate := base
+	for i := 2; ; i++ {
+		if _, ok := dir.Entries[candidate]; !ok {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// removeUnreachable removes inodes that can not be reached from the root directory.  They are left by resolving
+// conflicts.
+func (m *Merger) removeUnreachable(tree *Tree) {
+	reachable := map[uint64]bool{}
+	var walk func(ino uint64)
+	walk = func(ino uint64) {
+		if reachable[ino] {
+			return
+		}
+		f := tree.Inodes[ino]
+		if f == nil {
+			return
+		}
+		reachable[ino] = true
+		for name, to := range f.Entries {
+			if tree.Inodes[to] == nil {
+				delete(f.Entries, name)
+				continue
+			}
+			walk(to)
+		}
+	}
+	walk(tree.RootIno)
+
+	for ino := range tree.Inodes {
+		if !reachable[ino] {
+			delete(tree.Inodes, ino)
+		}
+	}
+}
+
+// copyAttributes copies attributes except directory entries.
+func copyAttributes(dst, src *File) {
+	dst.Mode = src.Mode
+	dst.Owner = src.Owner
+	dst.Group = src.Group
+	dst.Atime = src.Atime
+	dst.Mtime = src.Mtime
+	dst.Ctime = src.Ctime
+}
+
+func (d *entryDiff) howChanges(name string) ModificationType {
+	if d.Added.Contains(name) {
+		return InodeAdded
+	}
+	if d.Deleted.Contains(name) {
+		return InodeDeleted
+	}
+	if d.Modified.Contains(name) {
+		return InodeModified
+	}
+	return InodeNotModified
+}
@@ -625,10 +625,22 @@ func Test_conflictRule_CheckConflictRulesFile(t *testing.T) {
 	t.Run("mod-mod/mismatch", func(t *testing.T) {
 		a := &newDiffBuilder().Modify(2).Diff
 		b := &newDiffBuilder().Modify(2).Diff
-		at := &newTreeBuilder().Dirs(1).File(2, 0644, "foo").Tree
-		bt := &newTreeBuilder().Dirs(1).File(2, 0644, "bar").Tree
+		at := &newTreeBuilder().Dirs(1).File(2, 0644, "foo").DirEntry(1, "foo", 2).Tree
+		bt := &newTreeBuilder().Dirs(1).File(2, 0644, "bar").DirEntry(1, "bar", 2).Tree
 		err := conflictRule{}.CheckConflictRulesFile(a, b, at, bt)
-		assert.Error(t, err)
+		if assert.IsType(t, &MergeConflictError{}, err) {
+			conflicts := err.(*MergeConflictError).Conflicts
+			assert.Equal(t, []*CommitConflict{{
+				Type:         "mod-mod",
+				Ino:          2,
+				LatestIno:    2,
+				CurrentIno:   2,
+				LatestPaths:  []string{"/foo"},
+				CurrentPaths: []string{"/bar"},
+				LatestFile:   at.Inodes[2],
+				CurrentFile:  bt.Inodes[2],
+			}}, conflicts)
+		}
 	})
 }
 
@@ -736,3 +748,140 @@ func Test_conflictRule_CheckConflictRulesDir(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestMerger_Merge(t *testing.T) {
+	// base: /a
+	base := func() *Tree {
+		return &newTreeBuilder().Dirs(1).File(2, 0644, "a").DirEntry(1, "a", 2).Tree
+	}
+	withMtime := func(tree *Tree, ino uint64, sec int64) *Tree {
+		tree.Inodes[ino].Mtime = mustProtoTime(time.Unix(sec, 0))
+		return tree
+	}
+	// rootContents returns contents of files in the root directory.
+	rootContents := func(tree *Tree) map[string]string {
+		out := map[string]string{}
+		for name, ino := range tree.Inodes[tree.RootIno].Entries {
+			out[name] = tree.Inodes[ino].GetContentRef().GetKey().GetId()
+		}
+		return out
+	}
+	merge := func(policy MergePolicy, latest, current *Tree) (*Tree, error) {
+		m := &Merger{
+			Info:    &CommitInfo{Node: "node1"},
+			Base:    base(),
+			Latest:  latest,
+			Current: current,
+			Policy:  policy,
+		}
+		return m.Merge()
+	}
+	// Both sides modify /a.
+	modMod := func(policy MergePolicy, latestMtime, currentMtime int64) (*Tree, error) {
+		latest := &newTreeBuilder().Dirs(1).File(2, 0644, "latest").DirEntry(1, "a", 2).Tree
+		current := &newTreeBuilder().Dirs(1).File(2, 0644, "current").DirEntry(1, "a", 2).Tree
+		return merge(policy, withMtime(latest, 2, latestMtime), withMtime(current, 2, currentMtime))
+	}
+	// Latest deletes /a and current modifies /a.
+	delMod := func(policy MergePolicy) (*Tree, error) {
+		latest := &newTreeBuilder().Dirs(1).Tree
+		current := &newTreeBuilder().Dirs(1).File(2, 0644, "current").DirEntry(1, "a", 2).Tree
+		return merge(policy, latest, current)
+	}
+
+	t.Run("should_merge_files_added_on_both_sides", func(t *testing.T) {
+		latest := &newTreeBuilder().Dirs(1).File(2, 0644, "a").File(3, 0644, "b").
+			DirEntry(1, "a", 2).DirEntry(1, "b", 3).Tree
+		current := &newTreeBuilder().Dirs(1).File(2, 0644, "a").File(3, 0644, "c").
+			DirEntry(1, "a", 2).DirEntry(1, "c", 3).Tree
+		tree, err := merge(MergePolicy_RejectConflict, latest, current)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"a": "id-a", "b": "id-b", "c": "id-c"}, rootContents(tree))
+		}
+	})
+	t.Run("should_fail_on_unknown_policy", func(t *testing.T) {
+		_, err := modMod(MergePolicy(99), 1, 2)
+		assert.Error(t, err)
+	})
+	t.Run("reject/should_fail_on_conflict", func(t *testing.T) {
+		_, err := modMod(MergePolicy_RejectConflict, 1, 2)
+		assert.Error(t, err)
+	})
+	t.Run("latest_wins/mod-mod", func(t *testing.T) {
+		tree, err := modMod(MergePolicy_LatestWins, 1, 2)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"a": "id-latest"}, rootContents(tree))
+		}
+	})
+	t.Run("current_wins/mod-mod", func(t *testing.T) {
+		tree, err := modMod(MergePolicy_CurrentWins, 2, 1)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"a": "id-current"}, rootContents(tree))
+		}
+	})
+	t.Run("last_writer_wins/mod-mod", func(t *testing.T) {
+		tree, err := modMod(MergePolicy_LastWriterWins, 2, 1)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"a": "id-latest"}, rootContents(tree))
+		}
+		tree, err = modMod(MergePolicy_LastWriterWins, 1, 2)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"a": "id-current"}, rootContents(tree))
+		}
+	})
+	t.Run("keep_both/mod-mod", func(t *testing.T) {
+		tree, err := modMod(MergePolicy_KeepBoth, 1, 2)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{
+				"a":                "id-latest",
+				"a.conflict-node1": "id-current",
+			}, rootContents(tree))
+		}
+	})
+	t.Run("latest_wins/del-mod", func(t *testing.T) {
+		tree, err := delMod(MergePolicy_LatestWins)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{}, rootContents(tree))
+			assert.Len(t, tree.Inodes, 1)
+		}
+	})
+	t.Run("current_wins/del-mod", func(t *testing.T) {
+		tree, err := delMod(MergePolicy_CurrentWins)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"a": "id-current"}, rootContents(tree))
+		}
+	})
+	t.Run("keep_both/del-mod", func(t *testing.T) {
+		tree, err := delMod(MergePolicy_KeepBoth)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"a": "id-current"}, rootContents(tree))
+		}
+	})
+	t.Run("keep_both/add-add_same_name", func(t *testing.T) {
+		latest := &newTreeBuilder().Dirs(1).File(2, 0644, "a").File(3, 0644, "latest").
+			DirEntry(1, "a", 2).DirEntry(1, "b", 3).Tree
+		current := &newTreeBuilder().Dirs(1).File(2, 0644, "a").File(3, 0644, "current").
+			DirEntry(1, "a", 2).DirEntry(1, "b", 3).Tree
+		tree, err := merge(MergePolicy_KeepBoth, latest, current)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{
+				"a":                "id-a",
+				"b":                "id-latest",
+				"b.conflict-node1": "id-current",
+			}, rootContents(tree))
+			assert.Len(t, tree.Inodes, 4)
+		}
+	})
+	t.Run("current_wins/add-add_same_name", func(t *testing.T) {
+		latest := &newTreeBuilder().Dirs(1).File(2, 0644, "a").File(3, 0644, "latest").
+			DirEntry(1, "a", 2).DirEntry(1, "b", 3).Tree
+		current := &newTreeBuilder().Dirs(1).File(2, 0644, "a").File(3, 0644, "current").
+			DirEntry(1, "a", 2).DirEntry(1, "b", 3).Tree
+		tree, err := merge(MergePolicy_CurrentWins, latest, current)
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"a": "id-a", "b": "id-current"}, rootContents(tree))
+			// The inode of latest file should be removed.
+			assert.Len(t, tree.Inodes, 3)
+		}
+	})
+}
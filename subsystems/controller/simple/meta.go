@@ -3,21 +3,35 @@ package simple
 import (
 	"context"
 	"errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
 	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log"
+	"time"
 )
 
 func newLocalMetaServer(ms controller_db.MetaStore) *localMetaServer {
+	watcher := newMetaWatcher()
 	return &localMetaServer{
-		ms: ms,
+		ms:      ms,
+		watcher: watcher,
+		expirer: &MetaExpirer{
+			ms:      ms,
+			watcher: watcher,
+			now:     time.Now,
+		},
+		now: time.Now,
 	}
 }
 
 type localMetaServer struct {
-	ms controller_db.MetaStore
+	ms      controller_db.MetaStore
+	watcher *metaWatcher
+	expirer *MetaExpirer
+	now     func() time.Time
 }
 
 func (m *localMetaServer) GetMeta(ctx context.Context, req *GetMetaRequest) (*GetMetaResponse, error) {
@@ -39,7 +53,28 @@ func (m *localMetaServer) GetMeta(ctx context.Context, req *GetMetaRequest) (*Ge
 	}, nil
 }
 func (m *localMetaServer) SetMeta(ctx context.Context, req *SetMetaRequest) (*SetMetaResponse, error) {
-	old, err := m.ms.Set(req.GetKey(), req.GetBody(), req.GetMustCreate())
+	body := req.GetBody()
+	if req.GetTtl() != nil {
+		ttl, err := ptypes.Duration(req.GetTtl())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ttl: %s", err)
+		}
+		if ttl <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "ttl should be positive")
+		}
+		expireAt, err := ptypes.TimestampProto(m.now().Add(ttl))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ttl: %s", err)
+		}
+		// Should not modify the request.
+		body = proto.Clone(body).(*Property)
+		if body == nil {
+			body = &Property{}
+		}
+		body.ExpireAt = expireAt
+	}
+
+	old, err := m.ms.Set(req.GetKey(), body, req.GetMustCreate())
 	if err != nil {
 		if errors.Is(err, controller_db.ErrAlreadyExists) {
 			return nil, status.Error(codes.AlreadyExists, err.Error())
@@ -55,9 +90,108 @@ func (m *localMetaServer) SetMeta(ctx context.Context, req *SetMetaRequest) (*Se
 		return nil, status.Error(codes.Internal, "database error")
 	}
 
+	m.watcher.Notify(&WatchMetaResponse{
+		Key:  req.GetKey(),
+		Body: body,
+	})
 	return &SetMetaResponse{
 		Key:     req.GetKey(),
 		OldBody: old,
 		Created: old != nil,
 	}, nil
 }
+func (m *localMetaServer) DeleteMeta(ctx context.Context, req *DeleteMetaRequest) (*DeleteMetaResponse, error) {
+	old, err := m.ms.Delete(req.GetKey())
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundProp) {
+			return nil, status.Errorf(codes.NotFound, "property not found")
+		}
+		if errors.Is(err, controller_db.ErrNotAllowedReplace) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+
+	m.watcher.Notify(&WatchMetaResponse{
+		Key:     req.GetKey(),
+		Body:    old,
+		Deleted: true,
+	})
+	return &DeleteMetaResponse{
+		Key:     req.GetKey(),
+		OldBody: old,
+	}, nil
+}
+func (m *localMetaServer) ListMeta(req *ListMetaRequest, srv MetaService_ListMetaServer) error {
+	breakLoop := errors.New("break loop")
+	err := m.ms.List(req.GetPrefix(), func(id *PropertyID, prop *Property) error {
+		select {
+		case <-srv.Context().Done():
+			// Context canceled.
+			return breakLoop
+		default:
+			return srv.Send(&ListMetaResponse{
+				Key:  id,
+				Body: prop,
+			})
+		}
+	})
+	if err == breakLoop {
+		return status.Error(codes.Canceled, "canceled")
+	}
+	if err != nil {
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return status.Error(codes.Internal, "database error")
+	}
+	return nil
+}
+func (m *localMetaServer) WatchMeta(req *WatchMetaRequest, srv MetaService_WatchMetaServer) error {
+	sub := m.watcher.Subscribe(req.GetPrefix())
+	defer m.watcher.Unsubscribe(sub)
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return status.Error(codes.Canceled, "canceled")
+		case event, ok := <-sub.ch:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "too many events are not received")
+			}
+			if err := srv.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// MetaExpirer deletes expired properties and notifies watchers.  Expired properties are already invisible before
+// deleted by MetaExpirer.
+type MetaExpirer struct {
+	ms      controller_db.MetaStore
+	watcher *metaWatcher
+	now     func() time.Time
+}
+
+// ExpireAll deletes all expired properties.
+func (e *MetaExpirer) ExpireAll() error {
+	deleted, err := e.ms.DeleteExpired(e.now())
+	if err != nil {
+		return err
+	}
+	for _, id := range deleted {
+		e.watcher.Notify(&WatchMetaResponse{
+			Key:     id,
+			Deleted: true,
+		})
+	}
+	return nil
+}
@@ -2,13 +2,19 @@ package simple
 
 import (
 	"context"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
 	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"io"
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
 )
 
 func TestLocalMetaServer_GetMeta(t *testing.T) {
@@ -112,3 +118,225 @@ func TestLocalMetaServer_SetMeta(t *testing.T) {
 		})
 	})
 }
+
+func TestLocalMetaServer_SetMeta_TTL(t *testing.T) {
+	t.Run("should_hide_expired_property", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewMetaServiceClient(dial())
+			_, err := client.SetMeta(ctx, &elton_v2.SetMetaRequest{
+				Key:  &elton_v2.PropertyID{Id: "foo"},
+				Body: &elton_v2.Property{Body: "body"},
+				Ttl:  ptypes.DurationProto(50 * time.Millisecond),
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			gres, err := client.GetMeta(ctx, &elton_v2.GetMetaRequest{Key: &elton_v2.PropertyID{Id: "foo"}})
+			assert.NoError(t, err)
+			assert.NotNil(t, gres.GetBody().GetExpireAt())
+
+			time.Sleep(100 * time.Millisecond)
+			_, err = client.GetMeta(ctx, &elton_v2.GetMetaRequest{Key: &elton_v2.PropertyID{Id: "foo"}})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			// Expired property can be created again even if it is not allowed replacement.
+			_, err = client.SetMeta(ctx, &elton_v2.SetMetaRequest{
+				Key:        &elton_v2.PropertyID{Id: "foo"},
+				Body:       &elton_v2.Property{Body: "body"},
+				MustCreate: true,
+			})
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("should_fail_when_ttl_is_negative", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewMetaServiceClient(dial())
+			_, err := client.SetMeta(ctx, &elton_v2.SetMetaRequest{
+				Key:  &elton_v2.PropertyID{Id: "foo"},
+				Body: &elton_v2.Property{Body: "body"},
+				Ttl:  ptypes.DurationProto(-time.Second),
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+}
+
+func TestLocalMetaServer_DeleteMeta(t *testing.T) {
+	t.Run("should_delete_property", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewMetaServiceClient(dial())
+			_, err := client.SetMeta(ctx, &elton_v2.SetMetaRequest{
+				Key:  &elton_v2.PropertyID{Id: "foo"},
+				Body: &elton_v2.Property{Body: "body", AllowReplace: true},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			dres, err := client.DeleteMeta(ctx, &elton_v2.DeleteMetaRequest{Key: &elton_v2.PropertyID{Id: "foo"}})
+			assert.NoError(t, err)
+			assert.Equal(t, "body", dres.GetOldBody().GetBody())
+			_, err = client.GetMeta(ctx, &elton_v2.GetMetaRequest{Key: &elton_v2.PropertyID{Id: "foo"}})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("should_fail_when_property_is_not_found", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewMetaServiceClient(dial())
+			_, err := client.DeleteMeta(ctx, &elton_v2.DeleteMetaRequest{Key: &elton_v2.PropertyID{Id: "foo"}})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("should_fail_when_replacement_is_not_allowed", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewMetaServiceClient(dial())
+			_, err := client.SetMeta(ctx, &elton_v2.SetMetaRequest{
+				Key:  &elton_v2.PropertyID{Id: "foo"},
+				Body: &elton_v2.Property{Body: "body"},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_, err = client.DeleteMeta(ctx, &elton_v2.DeleteMetaRequest{Key: &elton_v2.PropertyID{Id: "foo"}})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	})
+}
+
+func TestLocalMetaServer_ListMeta(t *testing.T) {
+	t.Run("should_list_properties_with_prefix", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewMetaServiceClient(dial())
+			for _, key := range []string{"config/b", "config/a", "leader", "config/expired"} {
+				req := &elton_v2.SetMetaRequest{
+					Key:  &elton_v2.PropertyID{Id: key},
+					Body: &elton_v2.Property{Body: key},
+				}
+				if key == "config/expired" {
+					req.Ttl = ptypes.DurationProto(time.Nanosecond)
+				}
+				_, err := client.SetMeta(ctx, req)
+				if !assert.NoError(t, err) {
+					return
+				}
+			}
+			time.Sleep(time.Millisecond)
+
+			stream, err := client.ListMeta(ctx, &elton_v2.ListMetaRequest{Prefix: "config/"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			var keys []string
+			for {
+				res, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+				keys = append(keys, res.GetKey().GetId())
+			}
+			assert.Equal(t, []string{"config/a", "config/b"}, keys)
+		})
+	})
+}
+
+func TestLocalMetaServer_WatchMeta(t *testing.T) {
+	t.Run("should_receive_changes", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewMetaServiceClient(dial())
+			stream, err := client.WatchMeta(ctx, &elton_v2.WatchMetaRequest{Prefix: "config/"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			// Wait for the subscription.  Properties set before subscribing are not sent.
+			time.Sleep(50 * time.Millisecond)
+
+			for _, key := range []string{"other", "config/a"} {
+				_, err := client.SetMeta(ctx, &elton_v2.SetMetaRequest{
+					Key:  &elton_v2.PropertyID{Id: key},
+					Body: &elton_v2.Property{Body: key, AllowReplace: true},
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+			}
+			_, err = client.DeleteMeta(ctx, &elton_v2.DeleteMetaRequest{Key: &elton_v2.PropertyID{Id: "config/a"}})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			res, err := stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "config/a", res.GetKey().GetId())
+			assert.Equal(t, "config/a", res.GetBody().GetBody())
+			assert.False(t, res.GetDeleted())
+			res, err = stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "config/a", res.GetKey().GetId())
+			assert.True(t, res.GetDeleted())
+		})
+	})
+}
+
+func TestMetaWatcher(t *testing.T) {
+	t.Run("should_close_subscription_when_subscriber_is_slow", func(t *testing.T) {
+		w := newMetaWatcher()
+		sub := w.Subscribe("")
+		defer w.Unsubscribe(sub)
+
+		for i := 0; i < metaWatchBufferSize+1; i++ {
+			w.Notify(&elton_v2.WatchMetaResponse{Key: &elton_v2.PropertyID{Id: "foo"}})
+		}
+		n := 0
+		for range sub.ch {
+			n++
+		}
+		assert.Equal(t, metaWatchBufferSize, n)
+	})
+}
+
+func TestMetaExpirer(t *testing.T) {
+	t.Run("should_notify_expired_properties", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer os.RemoveAll(dir)
+		stores, closer, err := controller_db.CreateLocalDB(dir)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer closer()
+
+		m := newLocalMetaServer(stores.MetaStore())
+		sub := m.watcher.Subscribe("")
+		defer m.watcher.Unsubscribe(sub)
+		_, err = m.SetMeta(context.Background(), &elton_v2.SetMetaRequest{
+			Key:  &elton_v2.PropertyID{Id: "foo"},
+			Body: &elton_v2.Property{Body: "body"},
+			Ttl:  ptypes.DurationProto(time.Minute),
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		<-sub.ch
+
+		// Not expired yet.
+		assert.NoError(t, m.expirer.ExpireAll())
+		assert.Len(t, sub.ch, 0)
+
+		m.expirer.now = func() time.Time { return time.Now().Add(time.Hour) }
+		assert.NoError(t, m.expirer.ExpireAll())
+		if assert.Len(t, sub.ch, 1) {
+			event := <-sub.ch
+			assert.Equal(t, "foo", event.GetKey().GetId())
+			assert.True(t, event.GetDeleted())
+		}
+	})
+}
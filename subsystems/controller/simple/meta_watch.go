@@ -0,0 +1,72 @@
+package simple
+
+import (
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"strings"
+	"sync"
+)
+
+// metaWatchBufferSize is the number of events that can be queued for each subscriber.
+const metaWatchBufferSize = 64
+
+// metaWatcher delivers changes of properties to subscribers.
+type metaWatcher struct {
+	lock sync.Mutex
+	subs map[*metaSubscription]struct{}
+}
+
+// metaSubscription receives changes of properties whose key starts with the prefix.  If the subscriber is too slow and
+// the buffer is full, the channel is closed.
+type metaSubscription struct {
+	prefix string
+	ch     chan *WatchMetaResponse
+}
+
+func newMetaWatcher() *metaWatcher {
+	return &metaWatcher{
+		subs: map[*metaSubscription]struct{}{},
+	}
+}
+
+// Subscribe starts watching properties.  Caller must call Unsubscribe after use.
+func (w *metaWatcher) Subscribe(prefix string) *metaSubscription {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	sub := &metaSubscription{
+		prefix: prefix,
+		ch:     make(chan *WatchMetaResponse, metaWatchBufferSize),
+	}
+	w.subs[sub] = struct{}{}
+	return sub
+}
+func (w *metaWatcher) Unsubscribe(sub *metaSubscription) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if _, ok := w.subs[sub]; !ok {
+		// Already closed by Notify().
+		return
+	}
+	delete(w.subs, sub)
+	close(sub.ch)
+}
+
+// Notify sends the event to subscribers that watching the key.  It never blocks.
+func (w *metaWatcher) Notify(event *WatchMetaResponse) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for sub := range w.subs {
+		if !strings.HasPrefix(event.GetKey().GetId(), sub.prefix) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			// The subscriber is too slow.  Close the subscription instead of dropping events silently.
+			delete(w.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
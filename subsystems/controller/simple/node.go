@@ -3,25 +3,87 @@ package simple
 import (
 	"context"
 	"errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
 	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log"
+	"time"
 )
 
-func newLocalNodeServer(ns controller_db.NodeStore) *localNodeServer {
+const (
+	DefaultNodeSuspectTimeout = 30 * time.Second
+	DefaultNodeDeadTimeout    = 2 * time.Minute
+)
+
+func newLocalNodeServer(ns controller_db.NodeStore, obs controller_db.ObjectStore) *localNodeServer {
+	liveness := &LivenessConfig{
+		SuspectTimeout: DefaultNodeSuspectTimeout,
+		DeadTimeout:    DefaultNodeDeadTimeout,
+	}
+	placement := &Placement{
+		ns:       ns,
+		liveness: liveness,
+		now:      time.Now,
+		Policy: &TopologyPolicy{
+			DomainLabels: DefaultDomainLabels,
+		},
+	}
 	return &localNodeServer{
-		ns: ns,
+		ns:        ns,
+		obs:       obs,
+		liveness:  liveness,
+		placement: placement,
+		drainer: &Drainer{
+			ns:        ns,
+			obs:       obs,
+			placement: placement,
+			now:       time.Now,
+			Dial:      dialStorageNode,
+		},
+		rebalancer: &Rebalancer{
+			ns:        ns,
+			obs:       obs,
+			liveness:  liveness,
+			now:       time.Now,
+			Dial:      dialStorageNode,
+			BatchSize: DefaultRebalanceBatchSize,
+			Threshold: DefaultRebalanceThreshold,
+		},
+		monitor: &NodeMonitor{
+			ns:       ns,
+			liveness: liveness,
+			now:      time.Now,
+		},
+		now: time.Now,
 	}
 }
 
 type localNodeServer struct {
-	ns controller_db.NodeStore
+	ns         controller_db.NodeStore
+	obs        controller_db.ObjectStore
+	liveness   *LivenessConfig
+	monitor    *NodeMonitor
+	placement  *Placement
+	drainer    *Drainer
+	rebalancer *Rebalancer
+	now        func() time.Time
 }
 
 func (n *localNodeServer) RegisterNode(ctx context.Context, req *RegisterNodeRequest) (*RegisterNodeResponse, error) {
-	err := n.ns.Register(req.GetId(), req.GetNode())
+	node := proto.Clone(req.GetNode()).(*Node)
+	if node == nil {
+		node = &Node{}
+	}
+	lastSeen, err := ptypes.TimestampProto(n.now())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	node.LastSeen = lastSeen
+
+	err = n.ns.Register(req.GetId(), node)
 	if errors.Is(err, controller_db.ErrNodeAlreadyExists) {
 		return nil, status.Error(codes.AlreadyExists, err.Error())
 	}
@@ -32,6 +94,24 @@ func (n *localNodeServer) RegisterNode(ctx context.Context, req *RegisterNodeReq
 	return &RegisterNodeResponse{}, nil
 }
 func (n *localNodeServer) UnregisterNode(ctx context.Context, req *UnregisterNodeRequest) (*UnregisterNodeResponse, error) {
+	if !req.GetForce() {
+		var node *Node
+		err := n.ns.List(func(id *NodeID, x *Node) error {
+			if id.GetId() == req.GetId().GetId() {
+				node = x
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if node != nil && hasRole(node, StorageRole) && node.GetDrain().GetPhase() != DrainPhase_Drained {
+			// Objects on the node will be lost.
+			return nil, status.Errorf(codes.FailedPrecondition, "storage node is not drained: id=%s phase=%s", req.GetId().GetId(), node.GetDrain().GetPhase())
+		}
+	}
+
 	err := n.ns.Unregister(req.GetId())
 	if errors.Is(err, controller_db.ErrNotFoundNode) {
 		return nil, status.Error(codes.NotFound, err.Error())
@@ -43,8 +123,16 @@ func (n *localNodeServer) UnregisterNode(ctx context.Context, req *UnregisterNod
 	return &UnregisterNodeResponse{}, nil
 }
 func (n *localNodeServer) Ping(ctx context.Context, req *PingNodeRequest) (*PingNodeResponse, error) {
-	err := n.ns.Update(req.GetId(), func(node *Node) error {
-		// TODO: update uptime.
+	lastSeen, err := ptypes.TimestampProto(n.now())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	err = n.ns.Update(req.GetId(), func(node *Node) error {
+		if info := req.GetNode(); info != nil {
+			updateNodeInfo(node, info)
+		}
+		node.Uptime = req.GetUptime()
+		node.LastSeen = lastSeen
 		return nil
 	})
 	if errors.Is(err, controller_db.ErrNotFoundNode) {
@@ -58,14 +146,16 @@ func (n *localNodeServer) Ping(ctx context.Context, req *PingNodeRequest) (*Ping
 }
 func (n *localNodeServer) ListNodes(req *ListNodesRequest, stream NodeService_ListNodesServer) error {
 	var breakLoop = errors.New("break loop")
+	now := n.now()
 	err := n.ns.List(func(id *NodeID, node *Node) error {
 		select {
 		case <-stream.Context().Done():
 			return breakLoop
 		default:
 			return stream.Send(&ListNodesResponse{
-				Id:   id,
-				Node: node,
+				Id:       id,
+				Node:     node,
+				Liveness: n.liveness.Liveness(node, now),
 			})
 		}
 	})
@@ -78,3 +168,156 @@ func (n *localNodeServer) ListNodes(req *ListNodesRequest, stream NodeService_Li
 	}
 	return nil
 }
+func (n *localNodeServer) PlaceObject(ctx context.Context, req *PlaceObjectRequest) (*PlaceObjectResponse, error) {
+	replicas := int(req.GetReplicas())
+	if replicas == 0 {
+		replicas = 1
+	}
+	candidates, err := n.placement.Place(replicas, req.GetSize(), req.GetExclude())
+	if errors.Is(err, ErrNotEnoughNodes) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		log.Printf("[CRITICAL] Missing error handling: %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &PlaceObjectResponse{}
+	for _, c := range candidates {
+		res.Nodes = append(res.Nodes, &PlacedNode{
+			Id:   c.ID,
+			Node: c.Node,
+		})
+	}
+	return res, nil
+}
+func (n *localNodeServer) LocateObject(ctx context.Context, req *LocateObjectRequest) (*LocateObjectResponse, error) {
+	locations, err := n.obs.Locations(req.GetKey())
+	if err != nil {
+		log.Printf("[CRITICAL] Missing error handling: %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	located := map[string]bool{}
+	for _, loc := range locations {
+		located[loc.GetId()] = true
+	}
+
+	res := &LocateObjectResponse{}
+	err = n.ns.List(func(id *NodeID, node *Node) error {
+		// Location records of unregistered nodes are ignored.
+		if located[id.GetId()] {
+			res.Nodes = append(res.Nodes, &PlacedNode{
+				Id:   id,
+				Node: node,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITICAL] Missing error handling: %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(res.Nodes) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no location: key=%s", req.GetKey().GetId())
+	}
+	return res, nil
+}
+
+// updateNodeInfo overwrites the node information reported by the node itself.  Fields managed by the controller are
+// kept.
+func updateNodeInfo(node *Node, info *Node) {
+	node.Address = info.GetAddress()
+	node.Name = info.GetName()
+	node.Roles = info.GetRoles()
+	node.Capacity = info.GetCapacity()
+	node.Labels = info.GetLabels()
+	node.Version = info.GetVersion()
+}
+
+// LivenessConfig decides liveness of nodes from the elapsed time since the last ping.
+type LivenessConfig struct {
+	// The node becomes Suspect if no ping is received for SuspectTimeout.
+	SuspectTimeout time.Duration
+	// The node becomes Dead if no ping is received for DeadTimeout.
+	DeadTimeout time.Duration
+	// Dead node is unregistered if no ping is received for EvictTimeout.  If it is zero, dead nodes are never
+	// unregistered automatically.  Storage nodes are not unregistered until they are drained.
+	EvictTimeout time.Duration
+}
+
+func (c *LivenessConfig) Liveness(node *Node, now time.Time) NodeLiveness {
+	if node.GetLastSeen() == nil {
+		return NodeLiveness_UnknownLiveness
+	}
+	lastSeen, err := ptypes.Timestamp(node.GetLastSeen())
+	if err != nil {
+		return NodeLiveness_UnknownLiveness
+	}
+	elapsed := now.Sub(lastSeen)
+	switch {
+	case elapsed >= c.DeadTimeout:
+		return NodeLiveness_Dead
+	case elapsed >= c.SuspectTimeout:
+		return NodeLiveness_Suspect
+	default:
+		return NodeLiveness_Alive
+	}
+}
+
+// shouldEvict returns true if the node is dead for a long time.
+func (c *LivenessConfig) shouldEvict(node *Node, now time.Time) bool {
+	if c.EvictTimeout <= 0 || node.GetLastSeen() == nil {
+		return false
+	}
+	lastSeen, err := ptypes.Timestamp(node.GetLastSeen())
+	if err != nil {
+		return false
+	}
+	return now.Sub(lastSeen) >= c.EvictTimeout
+}
+
+// NodeMonitor watches liveness of nodes and unregisters nodes that stay dead.
+type NodeMonitor struct {
+	ns       controller_db.NodeStore
+	liveness *LivenessConfig
+	now      func() time.Time
+	// Last liveness of each node.  It is used to log state transitions.
+	last map[string]NodeLiveness
+}
+
+// CheckAll checks liveness of all nodes and unregisters nodes that should be evicted.
+func (m *NodeMonitor) CheckAll() error {
+	now := m.now()
+	current := map[string]NodeLiveness{}
+	var evict []*NodeID
+	err := m.ns.List(func(id *NodeID, node *Node) error {
+		liveness := m.liveness.Liveness(node, now)
+		current[id.GetId()] = liveness
+		if last, ok := m.last[id.GetId()]; ok && last != liveness {
+			log.Printf("[INFO] NodeMonitor: node %s is changed from %s to %s", id.GetId(), last, liveness)
+		}
+		if m.liveness.shouldEvict(node, now) {
+			if hasRole(node, StorageRole) && node.GetDrain().GetPhase() != DrainPhase_Drained {
+				// Same as UnregisterNode().  Location records of objects on the node must not be lost.
+				log.Printf("[WARN] NodeMonitor: dead storage node %s is kept because it is not drained", id.GetId())
+				return nil
+			}
+			evict = append(evict, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The node may send ping after List().  It will be registered again by the node itself.
+	for _, id := range evict {
+		log.Printf("[WARN] NodeMonitor: unregister dead node %s", id.GetId())
+		if err := m.ns.Unregister(id); err != nil {
+			return err
+		}
+		delete(current, id.GetId())
+	}
+	m.last = current
+	return nil
+}
@@ -2,14 +2,19 @@ package simple
 
 import (
 	"context"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
 	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"io"
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
 )
 
 func TestLocalNodeServer_RegisterNode(t *testing.T) {
@@ -56,6 +61,39 @@ func TestLocalNodeServer_UnregisterNode(t *testing.T) {
 			assert.IsType(t, &elton_v2.UnregisterNodeResponse{}, res)
 		})
 	})
+	t.Run("storage_node", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewNodeServiceClient(dial())
+			register := func(id string, phase elton_v2.DrainPhase) {
+				_, err := client.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+					Id: &elton_v2.NodeID{Id: id},
+					Node: &elton_v2.Node{
+						Roles: []string{StorageRole},
+						Drain: &elton_v2.DrainStatus{Phase: phase},
+					},
+				})
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+			}
+			register("not-drained", elton_v2.DrainPhase_Draining)
+			register("drained", elton_v2.DrainPhase_Drained)
+
+			_, err := client.UnregisterNode(ctx, &elton_v2.UnregisterNodeRequest{
+				Id: &elton_v2.NodeID{Id: "not-drained"},
+			})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			_, err = client.UnregisterNode(ctx, &elton_v2.UnregisterNodeRequest{
+				Id:    &elton_v2.NodeID{Id: "not-drained"},
+				Force: true,
+			})
+			assert.NoError(t, err)
+			_, err = client.UnregisterNode(ctx, &elton_v2.UnregisterNodeRequest{
+				Id: &elton_v2.NodeID{Id: "drained"},
+			})
+			assert.NoError(t, err)
+		})
+	})
 }
 
 func TestLocalNodeServer_Ping(t *testing.T) {
@@ -78,6 +116,125 @@ func TestLocalNodeServer_Ping(t *testing.T) {
 			assert.IsType(t, &elton_v2.PingNodeResponse{}, pres)
 		})
 	})
+	t.Run("should_record_uptime_and_last_seen", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewNodeServiceClient(dial())
+			_, err := client.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+				Id:   &elton_v2.NodeID{Id: "node-1"},
+				Node: &elton_v2.Node{},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = client.Ping(ctx, &elton_v2.PingNodeRequest{
+				Id:     &elton_v2.NodeID{Id: "node-1"},
+				Uptime: 100,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			stream, err := client.ListNodes(ctx, &elton_v2.ListNodesRequest{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			res, err := stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, uint64(100), res.GetNode().GetUptime())
+			assert.NotNil(t, res.GetNode().GetLastSeen())
+			assert.Equal(t, elton_v2.NodeLiveness_Alive, res.GetLiveness())
+		})
+	})
+	t.Run("should_update_node_info", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewNodeServiceClient(dial())
+			_, err := client.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+				Id: &elton_v2.NodeID{Id: "node-1"},
+				Node: &elton_v2.Node{
+					Name:  "old-name",
+					Roles: []string{"storage"},
+				},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = client.Ping(ctx, &elton_v2.PingNodeRequest{
+				Id: &elton_v2.NodeID{Id: "node-1"},
+				Node: &elton_v2.Node{
+					Address: []string{"192.0.2.1"},
+					Name:    "new-name",
+					Roles:   []string{"controller", "storage"},
+					Capacity: &elton_v2.NodeCapacity{
+						TotalBytes: 1000,
+						FreeBytes:  400,
+						Objects:    3,
+					},
+					Labels:  map[string]string{"zone": "a"},
+					Version: "v1.0",
+					// Should be ignored.
+					Uptime: 999,
+				},
+				Uptime: 100,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			stream, err := client.ListNodes(ctx, &elton_v2.ListNodesRequest{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			res, err := stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+			node := res.GetNode()
+			assert.Equal(t, []string{"192.0.2.1"}, node.GetAddress())
+			assert.Equal(t, "new-name", node.GetName())
+			assert.Equal(t, []string{"controller", "storage"}, node.GetRoles())
+			assert.Equal(t, uint64(1000), node.GetCapacity().GetTotalBytes())
+			assert.Equal(t, uint64(400), node.GetCapacity().GetFreeBytes())
+			assert.Equal(t, uint64(3), node.GetCapacity().GetObjects())
+			assert.Equal(t, map[string]string{"zone": "a"}, node.GetLabels())
+			assert.Equal(t, "v1.0", node.GetVersion())
+			assert.Equal(t, uint64(100), node.GetUptime())
+			assert.NotNil(t, node.GetLastSeen())
+		})
+	})
+	t.Run("should_keep_node_info_when_not_specified", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewNodeServiceClient(dial())
+			_, err := client.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+				Id: &elton_v2.NodeID{Id: "node-1"},
+				Node: &elton_v2.Node{
+					Name:    "node",
+					Version: "v1.0",
+				},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = client.Ping(ctx, &elton_v2.PingNodeRequest{
+				Id: &elton_v2.NodeID{Id: "node-1"},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			stream, err := client.ListNodes(ctx, &elton_v2.ListNodesRequest{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			res, err := stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "node", res.GetNode().GetName())
+			assert.Equal(t, "v1.0", res.GetNode().GetVersion())
+		})
+	})
 	t.Run("ping_from_not_registered_node", func(t *testing.T) {
 		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
 			client := elton_v2.NewNodeServiceClient(dial())
@@ -174,3 +331,102 @@ func TestLocalNodeServer_ListNodes(t *testing.T) {
 		})
 	})
 }
+
+func TestLivenessConfig_Liveness(t *testing.T) {
+	c := &LivenessConfig{
+		SuspectTimeout: time.Minute,
+		DeadTimeout:    time.Hour,
+	}
+	now := time.Now()
+	nodeSeenAt := func(d time.Duration) *elton_v2.Node {
+		lastSeen, _ := ptypes.TimestampProto(now.Add(-d))
+		return &elton_v2.Node{LastSeen: lastSeen}
+	}
+
+	assert.Equal(t, elton_v2.NodeLiveness_UnknownLiveness, c.Liveness(&elton_v2.Node{}, now))
+	assert.Equal(t, elton_v2.NodeLiveness_Alive, c.Liveness(nodeSeenAt(time.Second), now))
+	assert.Equal(t, elton_v2.NodeLiveness_Suspect, c.Liveness(nodeSeenAt(time.Minute), now))
+	assert.Equal(t, elton_v2.NodeLiveness_Dead, c.Liveness(nodeSeenAt(time.Hour), now))
+}
+
+func TestNodeMonitor_CheckAll(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	stores, closer, err := controller_db.CreateLocalDB(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer closer()
+
+	n := newLocalNodeServer(stores.NodeStore(), stores.ObjectStore())
+	for _, id := range []string{"alive", "dead"} {
+		_, err := n.RegisterNode(context.Background(), &elton_v2.RegisterNodeRequest{
+			Id:   &elton_v2.NodeID{Id: id},
+			Node: &elton_v2.Node{Name: id},
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+	// Only "alive" node sends ping after a day.
+	later := time.Now().Add(24 * time.Hour)
+	n.now = func() time.Time { return later }
+	_, err = n.Ping(context.Background(), &elton_v2.PingNodeRequest{Id: &elton_v2.NodeID{Id: "alive"}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	n.monitor.now = func() time.Time { return later }
+
+	// Dead nodes should be kept if eviction is disabled.
+	assert.NoError(t, n.monitor.CheckAll())
+	var nodes []string
+	assert.NoError(t, stores.NodeStore().List(func(id *elton_v2.NodeID, node *elton_v2.Node) error {
+		nodes = append(nodes, id.GetId())
+		return nil
+	}))
+	assert.Equal(t, []string{"alive", "dead"}, nodes)
+
+	n.liveness.EvictTimeout = time.Hour
+	assert.NoError(t, n.monitor.CheckAll())
+	nodes = nil
+	assert.NoError(t, stores.NodeStore().List(func(id *elton_v2.NodeID, node *elton_v2.Node) error {
+		nodes = append(nodes, id.GetId())
+		return nil
+	}))
+	assert.Equal(t, []string{"alive"}, nodes)
+
+	// Dead storage nodes should be kept until they are drained.
+	_, err = n.RegisterNode(context.Background(), &elton_v2.RegisterNodeRequest{
+		Id:   &elton_v2.NodeID{Id: "storage"},
+		Node: &elton_v2.Node{Name: "storage", Roles: []string{StorageRole}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	later = later.Add(24 * time.Hour)
+	assert.NoError(t, n.monitor.CheckAll())
+	nodes = nil
+	assert.NoError(t, stores.NodeStore().List(func(id *elton_v2.NodeID, node *elton_v2.Node) error {
+		nodes = append(nodes, id.GetId())
+		return nil
+	}))
+	assert.Equal(t, []string{"storage"}, nodes)
+
+	err = stores.NodeStore().Update(&elton_v2.NodeID{Id: "storage"}, func(node *elton_v2.Node) error {
+		node.Drain = &elton_v2.DrainStatus{Phase: elton_v2.DrainPhase_Drained}
+		return nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, n.monitor.CheckAll())
+	nodes = nil
+	assert.NoError(t, stores.NodeStore().List(func(id *elton_v2.NodeID, node *elton_v2.Node) error {
+		nodes = append(nodes, id.GetId())
+		return nil
+	}))
+	assert.Empty(t, nodes)
+}
@@ -0,0 +1,57 @@
+package simple
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"golang.org/x/xerrors"
+)
+
+// Number of items returned by list APIs when the limit is not specified.
+const defaultListLimit = 1000
+
+// Kinds of the page token.  It prevents to use the token for other APIs.
+const (
+	volumePageToken = "volume"
+	commitPageToken = "commit"
+	// Token for ListCommits in DAG mode.
+	commitDAGPageToken = "commit-dag"
+	// Token for ReadDir.  The key is the last entry name.
+	dirEntryPageToken = "dirent"
+)
+
+// pageToken is a continuation token of list APIs.  Clients should treat it as an opaque string.
+// It keeps the position of the cursor by key instead of the offset.  So the token is still valid after other items
+// are added or deleted.
+type pageToken struct {
+	Kind string `json:"k"`
+	Key  string `json:"p"`
+}
+
+func encodePageToken(kind, key string) string {
+	data, err := json.Marshal(&pageToken{
+		Kind: kind,
+		Key:  key,
+	})
+	if err != nil {
+		// Marshaling string fields never fails.
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+func decodePageToken(kind, token string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", xerrors.Errorf("invalid page token: %w", err)
+	}
+	var t pageToken
+	if err := json.Unmarshal(data, &t); err != nil {
+		return "", xerrors.Errorf("invalid page token: %w", err)
+	}
+	if t.Kind != kind {
+		return "", xerrors.Errorf("invalid page token: kind mismatch: expected=%s actual=%s", kind, t.Kind)
+	}
+	if t.Key == "" {
+		return "", xerrors.New("invalid page token: empty key")
+	}
+	return t.Key, nil
+}
@@ -0,0 +1,147 @@
+package simple
+
+import (
+	"context"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"path"
+	"sort"
+	"strings"
+)
+
+func (v *localVolumeServer) LookupPath(ctx context.Context, req *LookupPathRequest) (*LookupPathResponse, error) {
+	cid, tree, err := v.commitTree(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	ino, err := lookupPath(tree, req.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	return &LookupPathResponse{
+		Id:  cid,
+		Ino: ino,
+	}, nil
+}
+func (v *localVolumeServer) ReadDir(req *ReadDirRequest, srv CommitService_ReadDirServer) error {
+	var after string
+	if req.GetNext() != "" {
+		key, err := decodePageToken(dirEntryPageToken, req.GetNext())
+		if err != nil {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		after = key
+	}
+	limit := req.GetLimit()
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+
+	_, tree, err := v.commitTree(srv.Context(), req.GetId())
+	if err != nil {
+		return err
+	}
+	ino, err := lookupIno(tree, req.GetIno(), req.GetPath())
+	if err != nil {
+		return err
+	}
+	dir := tree.Inodes[ino]
+	if dir.GetFileType() != FileType_Directory {
+		return status.Errorf(codes.FailedPrecondition, "not a directory: ino=%d", ino)
+	}
+
+	names := make([]string, 0, len(dir.GetEntries()))
+	for name := range dir.GetEntries() {
+		if name > after {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		select {
+		case <-srv.Context().Done():
+			return status.Error(codes.Canceled, "canceled")
+		default:
+		}
+		child := dir.Entries[name]
+		res := &ReadDirResponse{
+			Name: name,
+			Ino:  child,
+			File: withoutEntries(tree.Inodes[child]),
+		}
+		last := uint64(i+1) >= limit
+		if last && i+1 < len(names) {
+			// Limit reached.  Remaining entries will be returned in the next page.
+			res.Next = encodePageToken(dirEntryPageToken, name)
+		}
+		if err := srv.Send(res); err != nil {
+			return err
+		}
+		if last {
+			break
+		}
+	}
+	return nil
+}
+func (v *localVolumeServer) StatPath(ctx context.Context, req *StatPathRequest) (*StatPathResponse, error) {
+	cid, tree, err := v.commitTree(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	ino, err := lookupIno(tree, req.GetIno(), req.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	f := tree.Inodes[ino]
+	return &StatPathResponse{
+		Id:      cid,
+		Ino:     ino,
+		File:    withoutEntries(f),
+		Entries: uint64(len(f.GetEntries())),
+	}, nil
+}
+
+// commitTree returns the resolved CommitID and the tree of the commit.
+func (v *localVolumeServer) commitTree(ctx context.Context, id *CommitID) (*CommitID, *Tree, error) {
+	res, err := v.GetCommit(ctx, &GetCommitRequest{Id: id})
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.GetId(), res.GetInfo().GetTree(), nil
+}
+
+// lookupIno returns the inode number if it is not zero.  Otherwise, it resolves the path.  It returns gRPC errors.
+func lookupIno(tree *Tree, ino uint64, p string) (uint64, error) {
+	if ino == 0 {
+		return lookupPath(tree, p)
+	}
+	if tree.GetInodes()[ino] == nil {
+		return 0, status.Errorf(codes.NotFound, "not found inode: ino=%d", ino)
+	}
+	return ino, nil
+}
+
+// lookupPath resolves the absolute path in the tree and returns the inode number.  It returns gRPC errors.
+func lookupPath(tree *Tree, p string) (uint64, error) {
+	if !strings.HasPrefix(p, "/") {
+		return 0, status.Errorf(codes.InvalidArgument, "path must start with slash (\"/\"): %q", p)
+	}
+	p = path.Clean(p)
+	ino := tree.GetRootIno()
+	if p == "/" {
+		return ino, nil
+	}
+	for _, name := range strings.Split(p[1:], "/") {
+		dir := tree.GetInodes()[ino]
+		if dir.GetFileType() != FileType_Directory {
+			return 0, status.Errorf(codes.FailedPrecondition, "not a directory: ino=%d", ino)
+		}
+		child, ok := dir.GetEntries()[name]
+		if !ok || tree.GetInodes()[child] == nil {
+			return 0, status.Errorf(codes.NotFound, "not found: %s", p)
+		}
+		ino = child
+	}
+	return ino, nil
+}
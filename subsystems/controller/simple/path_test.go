@@ -0,0 +1,114 @@
+package simple
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"testing"
+)
+
+func TestLocalVolumeServer_paths(t *testing.T) {
+	// withTree creates a commit that has /a, /dir/b, /dir/c and /dir/d.
+	withTree := func(t *testing.T, fn func(ctx context.Context, client elton_v2.CommitServiceClient, cid *elton_v2.CommitID)) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			tree := &newTreeBuilder().Dirs(1, 2).File(3, 0644, "a").File(4, 0600, "b").File(5, 0644, "c").File(6, 0644, "d").
+				DirEntry(1, "a", 3).DirEntry(1, "dir", 2).DirEntry(2, "b", 4).DirEntry(2, "c", 5).DirEntry(2, "d", 6).Tree
+			_, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{Info: &elton_v2.CommitInfo{Tree: tree}},
+			})
+			fn(ctx, elton_v2.NewCommitServiceClient(dial()), commits[0])
+		})
+	}
+	readDir := func(ctx context.Context, client elton_v2.CommitServiceClient, req *elton_v2.ReadDirRequest) ([]*elton_v2.ReadDirResponse, error) {
+		stream, err := client.ReadDir(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		var out []*elton_v2.ReadDirResponse
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return out, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, res)
+		}
+	}
+
+	t.Run("LookupPath", func(t *testing.T) {
+		withTree(t, func(ctx context.Context, client elton_v2.CommitServiceClient, cid *elton_v2.CommitID) {
+			res, err := client.LookupPath(ctx, &elton_v2.LookupPathRequest{Id: cid, Path: "/dir/b"})
+			if assert.NoError(t, err) {
+				assert.Equal(t, uint64(4), res.GetIno())
+				assert.True(t, cid.Equals(res.GetId()))
+			}
+			res, err = client.LookupPath(ctx, &elton_v2.LookupPathRequest{Id: cid, Path: "/dir/../a"})
+			if assert.NoError(t, err) {
+				assert.Equal(t, uint64(3), res.GetIno())
+			}
+
+			_, err = client.LookupPath(ctx, &elton_v2.LookupPathRequest{Id: cid, Path: "/dir/x"})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			_, err = client.LookupPath(ctx, &elton_v2.LookupPathRequest{Id: cid, Path: "/a/b"})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			_, err = client.LookupPath(ctx, &elton_v2.LookupPathRequest{Id: cid, Path: "a"})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+	t.Run("StatPath", func(t *testing.T) {
+		withTree(t, func(ctx context.Context, client elton_v2.CommitServiceClient, cid *elton_v2.CommitID) {
+			res, err := client.StatPath(ctx, &elton_v2.StatPathRequest{Id: cid, Path: "/dir"})
+			if assert.NoError(t, err) {
+				assert.Equal(t, uint64(2), res.GetIno())
+				assert.Equal(t, elton_v2.FileType_Directory, res.GetFile().GetFileType())
+				assert.Empty(t, res.GetFile().GetEntries())
+				assert.Equal(t, uint64(3), res.GetEntries())
+			}
+			res, err = client.StatPath(ctx, &elton_v2.StatPathRequest{Id: cid, Ino: 4})
+			if assert.NoError(t, err) {
+				assert.Equal(t, uint32(0600), res.GetFile().GetMode())
+			}
+			_, err = client.StatPath(ctx, &elton_v2.StatPathRequest{Id: cid, Ino: 100})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("ReadDir", func(t *testing.T) {
+		withTree(t, func(ctx context.Context, client elton_v2.CommitServiceClient, cid *elton_v2.CommitID) {
+			page1, err := readDir(ctx, client, &elton_v2.ReadDirRequest{Id: cid, Path: "/dir", Limit: 2})
+			if !assert.NoError(t, err) || !assert.Len(t, page1, 2) {
+				return
+			}
+			assert.Equal(t, "b", page1[0].GetName())
+			assert.Equal(t, uint64(4), page1[0].GetIno())
+			assert.Equal(t, "id-b", page1[0].GetFile().GetContentRef().GetKey().GetId())
+			assert.Equal(t, "c", page1[1].GetName())
+			assert.Empty(t, page1[0].GetNext())
+			assert.NotEmpty(t, page1[1].GetNext())
+
+			page2, err := readDir(ctx, client, &elton_v2.ReadDirRequest{Id: cid, Path: "/dir", Limit: 2, Next: page1[1].GetNext()})
+			if assert.NoError(t, err) && assert.Len(t, page2, 1) {
+				assert.Equal(t, "d", page2[0].GetName())
+				assert.Empty(t, page2[0].GetNext())
+			}
+
+			byIno, err := readDir(ctx, client, &elton_v2.ReadDirRequest{Id: cid, Ino: 1})
+			if assert.NoError(t, err) && assert.Len(t, byIno, 2) {
+				assert.Equal(t, "a", byIno[0].GetName())
+				assert.Equal(t, "dir", byIno[1].GetName())
+				assert.Empty(t, byIno[1].GetFile().GetEntries())
+			}
+
+			_, err = readDir(ctx, client, &elton_v2.ReadDirRequest{Id: cid, Path: "/a"})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			_, err = readDir(ctx, client, &elton_v2.ReadDirRequest{Id: cid, Path: "/dir", Next: "invalid"})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		})
+	})
+}
@@ -0,0 +1,142 @@
+package simple
+
+import (
+	"fmt"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StorageRole is the role name of nodes that store objects.
+const StorageRole = "storage"
+
+var ErrNotEnoughNodes = &controller_db.InputError{Msg: "not enough storage nodes"}
+
+// DefaultDomainLabels are label keys of failure domains from the widest to the narrowest.
+var DefaultDomainLabels = []string{"zone", "rack"}
+
+// PlacementCandidate is a storage node that can store the object.
+type PlacementCandidate struct {
+	ID   *NodeID
+	Node *Node
+}
+
+// PlacementPolicy decides storage nodes to store a new object.
+type PlacementPolicy interface {
+	// Select returns n nodes from candidates in order of preference.  All candidates are alive, not draining and have
+	// enough free space.  It returns ErrNotEnoughNodes if it can not select n nodes.
+	Select(candidates []*PlacementCandidate, n int) ([]*PlacementCandidate, error)
+}
+
+// Placement collects candidates from NodeStore and selects nodes with the Policy.
+type Placement struct {
+	ns       controller_db.NodeStore
+	liveness *LivenessConfig
+	now      func() time.Time
+	// Policy can be replaced before serving.
+	Policy PlacementPolicy
+}
+
+// Place returns nodes to store an object of the size.  Nodes in exclude are never selected.
+func (p *Placement) Place(replicas int, size uint64, exclude []*NodeID) ([]*PlacementCandidate, error) {
+	excluded := map[string]bool{}
+	for _, id := range exclude {
+		excluded[id.GetId()] = true
+	}
+
+	now := p.now()
+	var candidates []*PlacementCandidate
+	err := p.ns.List(func(id *NodeID, node *Node) error {
+		switch {
+		case excluded[id.GetId()]:
+		case !hasRole(node, StorageRole):
+		case p.liveness.Liveness(node, now) != NodeLiveness_Alive:
+		case node.GetDrain().GetPhase() != DrainPhase_NotDraining:
+		case node.GetCapacity().GetFreeBytes() < size:
+		default:
+			candidates = append(candidates, &PlacementCandidate{
+				ID:   id,
+				Node: node,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.Policy.Select(candidates, replicas)
+}
+
+func hasRole(node *Node, role string) bool {
+	for _, r := range node.GetRoles() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// TopologyPolicy spreads replicas across failure domains.  Failure domains are identified by values of DomainLabels.
+// Nodes that have more free space are preferred in the same failure domain level.  If there are not enough failure
+// domains, some replicas are placed in the same domain.
+type TopologyPolicy struct {
+	// Label keys from the widest domain to the narrowest domain (e.g. zone, rack).
+	DomainLabels []string
+}
+
+func (p *TopologyPolicy) Select(candidates []*PlacementCandidate, n int) ([]*PlacementCandidate, error) {
+	sorted := append([]*PlacementCandidate{}, candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		fi := sorted[i].Node.GetCapacity().GetFreeBytes()
+		fj := sorted[j].Node.GetCapacity().GetFreeBytes()
+		if fi != fj {
+			return fi > fj
+		}
+		return sorted[i].ID.GetId() < sorted[j].ID.GetId()
+	})
+
+	levels := len(p.DomainLabels)
+	// used[level] contains failure domains that already have a replica.
+	used := make([]map[string]bool, levels+1)
+	for i := range used {
+		used[i] = map[string]bool{}
+	}
+	isSelected := map[*PlacementCandidate]bool{}
+	var selected []*PlacementCandidate
+
+	// Select nodes in different zones at first, then nodes in different racks.  Finally, any nodes are selected.
+	for level := 1; level <= levels+1; level++ {
+		for _, c := range sorted {
+			if len(selected) >= n {
+				return selected, nil
+			}
+			if isSelected[c] {
+				continue
+			}
+			if level <= levels && used[level][p.domain(c.Node, level)] {
+				continue
+			}
+
+			isSelected[c] = true
+			selected = append(selected, c)
+			for l := 1; l <= levels; l++ {
+				used[l][p.domain(c.Node, l)] = true
+			}
+		}
+	}
+	if len(selected) < n {
+		return nil, ErrNotEnoughNodes.Wrap(fmt.Errorf("required=%d available=%d", n, len(selected)))
+	}
+	return selected, nil
+}
+
+// domain returns an identifier of the failure domain at the level.  Level 1 is the widest domain.
+func (p *TopologyPolicy) domain(node *Node, level int) string {
+	values := make([]string, level)
+	for i := 0; i < level; i++ {
+		values[i] = node.GetLabels()[p.DomainLabels[i]]
+	}
+	return strings.Join(values, "\x00")
+}
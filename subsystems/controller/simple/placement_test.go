@@ -0,0 +1,150 @@
+package simple
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func newCandidate(id, zone, rack string, free uint64) *PlacementCandidate {
+	return &PlacementCandidate{
+		ID: &elton_v2.NodeID{Id: id},
+		Node: &elton_v2.Node{
+			Roles:    []string{StorageRole},
+			Capacity: &elton_v2.NodeCapacity{FreeBytes: free},
+			Labels: map[string]string{
+				"zone": zone,
+				"rack": rack,
+			},
+		},
+	}
+}
+func candidateIDs(candidates []*PlacementCandidate) []string {
+	var ids []string
+	for _, c := range candidates {
+		ids = append(ids, c.ID.GetId())
+	}
+	return ids
+}
+
+func TestTopologyPolicy_Select(t *testing.T) {
+	policy := &TopologyPolicy{DomainLabels: DefaultDomainLabels}
+
+	t.Run("should_prefer_free_space", func(t *testing.T) {
+		selected, err := policy.Select([]*PlacementCandidate{
+			newCandidate("a", "", "", 10),
+			newCandidate("b", "", "", 30),
+			newCandidate("c", "", "", 20),
+		}, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b"}, candidateIDs(selected))
+	})
+	t.Run("should_spread_across_zones", func(t *testing.T) {
+		selected, err := policy.Select([]*PlacementCandidate{
+			newCandidate("a1", "a", "1", 30),
+			newCandidate("a2", "a", "2", 20),
+			newCandidate("b1", "b", "1", 10),
+		}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a1", "b1"}, candidateIDs(selected))
+	})
+	t.Run("should_spread_across_racks_after_zones", func(t *testing.T) {
+		selected, err := policy.Select([]*PlacementCandidate{
+			newCandidate("a1-1", "a", "1", 50),
+			newCandidate("a1-2", "a", "1", 40),
+			newCandidate("a2", "a", "2", 10),
+			newCandidate("b1", "b", "1", 20),
+		}, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a1-1", "b1", "a2"}, candidateIDs(selected))
+	})
+	t.Run("should_share_domain_when_not_enough_domains", func(t *testing.T) {
+		selected, err := policy.Select([]*PlacementCandidate{
+			newCandidate("a", "a", "1", 20),
+			newCandidate("b", "a", "1", 10),
+		}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, candidateIDs(selected))
+	})
+	t.Run("should_fail_when_not_enough_nodes", func(t *testing.T) {
+		_, err := policy.Select([]*PlacementCandidate{
+			newCandidate("a", "a", "1", 20),
+		}, 2)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "not enough storage nodes: ")
+		}
+	})
+}
+
+func TestLocalNodeServer_PlaceObject(t *testing.T) {
+	register := func(t *testing.T, ctx context.Context, client elton_v2.NodeServiceClient, id string, node *elton_v2.Node) {
+		node.LastSeen = ptypes.TimestampNow()
+		_, err := client.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+			Id:   &elton_v2.NodeID{Id: id},
+			Node: node,
+		})
+		assert.NoError(t, err)
+	}
+	storageNode := func(zone string, free uint64) *elton_v2.Node {
+		return &elton_v2.Node{
+			Roles:    []string{StorageRole},
+			Capacity: &elton_v2.NodeCapacity{FreeBytes: free},
+			Labels:   map[string]string{"zone": zone},
+		}
+	}
+
+	t.Run("should_select_storage_nodes", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewNodeServiceClient(dial())
+			register(t, ctx, client, "controller", &elton_v2.Node{Roles: []string{"controller"}})
+			register(t, ctx, client, "small", storageNode("a", 10))
+			register(t, ctx, client, "node-a", storageNode("a", 1000))
+			register(t, ctx, client, "node-b", storageNode("b", 100))
+
+			res, err := client.PlaceObject(ctx, &elton_v2.PlaceObjectRequest{
+				Replicas: 2,
+				Size:     50,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			var ids []string
+			for _, n := range res.GetNodes() {
+				ids = append(ids, n.GetId().GetId())
+			}
+			assert.Equal(t, []string{"node-a", "node-b"}, ids)
+		})
+	})
+	t.Run("should_not_select_excluded_nodes", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewNodeServiceClient(dial())
+			register(t, ctx, client, "node-a", storageNode("a", 1000))
+			register(t, ctx, client, "node-b", storageNode("b", 100))
+
+			res, err := client.PlaceObject(ctx, &elton_v2.PlaceObjectRequest{
+				Exclude: []*elton_v2.NodeID{{Id: "node-a"}},
+			})
+			if !assert.NoError(t, err) || !assert.Len(t, res.GetNodes(), 1) {
+				return
+			}
+			assert.Equal(t, "node-b", res.GetNodes()[0].GetId().GetId())
+		})
+	})
+	t.Run("should_fail_when_not_enough_nodes", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewNodeServiceClient(dial())
+			register(t, ctx, client, "node-a", storageNode("a", 1000))
+
+			_, err := client.PlaceObject(ctx, &elton_v2.PlaceObjectRequest{
+				Replicas: 2,
+			})
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		})
+	})
+}
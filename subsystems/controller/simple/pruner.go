@@ -0,0 +1,202 @@
+package simple
+
+import (
+	"errors"
+	"github.com/golang/protobuf/ptypes"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"golang.org/x/xerrors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Commits younger than minPruneAge are always kept.  They may be used as a parent commit by in-flight commit requests.
+const minPruneAge = time.Minute
+
+// Pruner deletes expired commits based on the retention policy of each volume.
+type Pruner struct {
+	vs controller_db.VolumeStore
+	cs controller_db.CommitStore
+	// Returns current time.  It is replaced in tests.
+	now func() time.Time
+}
+
+func newPruner(vs controller_db.VolumeStore, cs controller_db.CommitStore) *Pruner {
+	return &Pruner{
+		vs:  vs,
+		cs:  cs,
+		now: time.Now,
+	}
+}
+
+// PruneAll prunes all volumes that have the retention policy.
+func (p *Pruner) PruneAll() error {
+	type volume struct {
+		id     *VolumeID
+		policy *RetentionPolicy
+	}
+	var volumes []volume
+	err := p.vs.Walk(func(id *VolumeID, info *VolumeInfo) error {
+		if !isEmptyPolicy(info.GetRetention()) {
+			volumes = append(volumes, volume{id: id, policy: info.GetRetention()})
+		}
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("walk volumes: %w", err)
+	}
+
+	// A broken volume should not stop pruning of other volumes.
+	var failed []string
+	for _, v := range volumes {
+		deleted, released, err := p.Prune(v.id, v.policy, false)
+		if err != nil {
+			log.Printf("[WARN] Pruner: volume=%s: %+v", v.id.GetId(), err)
+			failed = append(failed, v.id.GetId())
+			continue
+		}
+		if len(deleted) > 0 {
+			log.Printf("[INFO] Pruner: volume=%s deleted=%d released=%d", v.id.GetId(), len(deleted), len(released))
+		}
+	}
+	if len(failed) > 0 {
+		return xerrors.Errorf("failed to prune %d volumes: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Prune deletes expired commits in the volume.  If dryRun is true, it only returns commits to be deleted.
+func (p *Pruner) Prune(vid *VolumeID, policy *RetentionPolicy, dryRun bool) (deleted []*CommitID, released []*ObjectKey, err error) {
+	if isEmptyPolicy(policy) {
+		// Keep all commits.
+		return nil, nil, nil
+	}
+
+	latest, err := p.cs.Latest(vid)
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundCommit) {
+			// The volume has no commit.
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	// Commits pointed by refs can not be deleted.
+	pinned := []*CommitID{latest}
+	err = p.cs.ListRefs(vid, func(id *RefID, ref *Ref) error {
+		pinned = append(pinned, ref.GetCommit())
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var commits []prunerCommit
+	err = p.cs.Walk(vid, func(id *CommitID, info *CommitInfo) error {
+		if info.GetRightParentID() != nil {
+			// Merge commits are kept.  If they are deleted, a remaining commit may need more than two parents to keep
+			// all merged commits reachable.
+			pinned = append(pinned, id)
+		}
+		createdAt, err := ptypes.Timestamp(info.GetCreatedAt())
+		if err != nil {
+			// Invalid timestamp.  Treat it as the oldest commit.
+			createdAt = time.Time{}
+		}
+		commits = append(commits, prunerCommit{
+			id:        id,
+			createdAt: createdAt,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deleted = expiredCommits(policy, commits, pinned, p.now())
+	if dryRun || len(deleted) == 0 {
+		return deleted, nil, nil
+	}
+	released, err = p.cs.Delete(deleted)
+	if err != nil {
+		return nil, nil, err
+	}
+	return deleted, released, nil
+}
+
+type prunerCommit struct {
+	id        *CommitID
+	createdAt time.Time
+}
+
+func isEmptyPolicy(policy *RetentionPolicy) bool {
+	return policy.GetKeepLast() == 0 &&
+		policy.GetKeepDaily() == 0 &&
+		policy.GetKeepWithin() == nil
+}
+
+// expiredCommits returns commits that do not match any rules of the policy.  Pinned commits are always kept.
+func expiredCommits(policy *RetentionPolicy, commits []prunerCommit, pinned []*CommitID, now time.Time) []*CommitID {
+	if isEmptyPolicy(policy) {
+		return nil
+	}
+
+	// Sort by created time in descending order.
+	sorted := make([]prunerCommit, len(commits))
+	copy(sorted, commits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].createdAt.Equal(sorted[j].createdAt) {
+			return sorted[i].id.GetNumber() > sorted[j].id.GetNumber()
+		}
+		return sorted[i].createdAt.After(sorted[j].createdAt)
+	})
+
+	keepWithin := time.Duration(0)
+	if policy.GetKeepWithin() != nil {
+		d, err := ptypes.Duration(policy.GetKeepWithin())
+		if err == nil {
+			keepWithin = d
+		}
+	}
+	if keepWithin < minPruneAge {
+		keepWithin = minPruneAge
+	}
+
+	// Start time of the oldest day that keepDaily rule covers.
+	today := now.UTC().Truncate(24 * time.Hour)
+	dailyLimit := today.AddDate(0, 0, -int(policy.GetKeepDaily())+1)
+	keptDays := map[time.Time]bool{}
+
+	var expired []*CommitID
+	for i, c := range sorted {
+		keep := false
+		for _, id := range pinned {
+			if c.id.Equals(id) {
+				keep = true
+			}
+		}
+		if uint32(i) < policy.GetKeepLast() {
+			keep = true
+		}
+		if now.Sub(c.createdAt) < keepWithin {
+			keep = true
+		}
+		if policy.GetKeepDaily() > 0 {
+			day := c.createdAt.UTC().Truncate(24 * time.Hour)
+			if !day.Before(dailyLimit) && !keptDays[day] {
+				// It is the newest commit of the day.
+				keptDays[day] = true
+				keep = true
+			}
+		}
+		if !keep {
+			expired = append(expired, c.id)
+		}
+	}
+
+	// Return in ascending order of the commit number.
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].GetNumber() < expired[j].GetNumber()
+	})
+	return expired
+}
@@ -0,0 +1,166 @@
+package simple
+
+import (
+	"errors"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+// brokenVolumeCS fails to get the latest commit of the volume.
+type brokenVolumeCS struct {
+	controller_db.CommitStore
+	broken *VolumeID
+}
+
+func (cs *brokenVolumeCS) Latest(vid *VolumeID) (*CommitID, error) {
+	if vid.Equals(cs.broken) {
+		return nil, errors.New("broken")
+	}
+	return cs.CommitStore.Latest(vid)
+}
+
+func TestPruner_PruneAll(t *testing.T) {
+	t.Run("should_continue_after_broken_volume", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer os.RemoveAll(dir)
+		stores, closer, err := controller_db.CreateLocalDB(dir)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer closer()
+
+		vs := stores.VolumeStore()
+		policy := &RetentionPolicy{KeepLast: 1}
+		var vids []*VolumeID
+		var first []*CommitID
+		for _, name := range []string{"a", "b"} {
+			vid, err := vs.Create(&VolumeInfo{Name: name, Retention: policy})
+			if !assert.NoError(t, err) {
+				return
+			}
+			cid, err := stores.CommitStore().Latest(vid)
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = stores.CommitStore().CreateFastForward(&RefID{Id: vid}, &CommitInfo{
+				CreatedAt:    ptypes.TimestampNow(),
+				LeftParentID: cid,
+			}, &Tree{
+				RootIno: 1,
+				Inodes:  map[uint64]*File{1: {FileType: FileType_Directory}},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			vids = append(vids, vid)
+			first = append(first, cid)
+		}
+
+		// The broken volume is pruned first.
+		broken, other := 0, 1
+		if vids[0].GetId() > vids[1].GetId() {
+			broken, other = 1, 0
+		}
+		p := newPruner(vs, &brokenVolumeCS{CommitStore: stores.CommitStore(), broken: vids[broken]})
+		p.now = func() time.Time { return time.Now().Add(time.Hour) }
+		err = p.PruneAll()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), vids[broken].GetId())
+
+		ok, err := stores.CommitStore().Exists(first[broken])
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = stores.CommitStore().Exists(first[other])
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func Test_expiredCommits(t *testing.T) {
+	vid := &VolumeID{Id: "foo"}
+	now := time.Date(2020, 1, 10, 12, 0, 0, 0, time.UTC)
+	// Create a commit per 6 hours.  Number 1 is the oldest commit, and number 20 is the latest commit.
+	var commits []prunerCommit
+	for i := 1; i <= 20; i++ {
+		commits = append(commits, prunerCommit{
+			id:        &CommitID{Id: vid, Number: uint64(i)},
+			createdAt: now.Add(-time.Duration(20-i) * 6 * time.Hour),
+		})
+	}
+	pinned := []*CommitID{commits[len(commits)-1].id}
+	numbers := func(ids []*CommitID) []uint64 {
+		var nums []uint64
+		for _, id := range ids {
+			nums = append(nums, id.GetNumber())
+		}
+		return nums
+	}
+	rangeOf := func(from, to uint64) []uint64 {
+		var nums []uint64
+		for i := from; i <= to; i++ {
+			nums = append(nums, i)
+		}
+		return nums
+	}
+
+	tests := []struct {
+		name   string
+		policy *RetentionPolicy
+		want   []uint64
+	}{
+		{
+			name:   "empty_policy_should_keep_all",
+			policy: &RetentionPolicy{},
+			want:   nil,
+		}, {
+			name:   "nil_policy_should_keep_all",
+			policy: nil,
+			want:   nil,
+		}, {
+			name:   "keep_last",
+			policy: &RetentionPolicy{KeepLast: 5},
+			want:   rangeOf(1, 15),
+		}, {
+			name:   "keep_within",
+			policy: &RetentionPolicy{KeepWithin: ptypes.DurationProto(25 * time.Hour)},
+			want:   rangeOf(1, 15),
+		}, {
+			// Commits of 2020-01-10: 18, 19, 20
+			// Commits of 2020-01-09: 14, 15, 16, 17
+			// Commits of 2020-01-08: 10, 11, 12, 13
+			name:   "keep_daily",
+			policy: &RetentionPolicy{KeepDaily: 3},
+			want:   append(append(rangeOf(1, 12), 14, 15, 16), 18, 19),
+		}, {
+			name: "multiple_rules",
+			policy: &RetentionPolicy{
+				KeepLast:  3,
+				KeepDaily: 2,
+			},
+			want: rangeOf(1, 16),
+		}, {
+			name:   "latest_commit_should_be_kept",
+			policy: &RetentionPolicy{KeepWithin: ptypes.DurationProto(0)},
+			want:   rangeOf(1, 19),
+		},
+	}
+	t.Run("ref_commit_should_be_kept", func(t *testing.T) {
+		got := expiredCommits(&RetentionPolicy{KeepLast: 5}, commits, append(pinned, commits[2].id), now)
+		assert.Equal(t, append(rangeOf(1, 2), rangeOf(4, 15)...), numbers(got))
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expiredCommits(tt.policy, commits, pinned, now)
+			assert.Equal(t, tt.want, numbers(got))
+		})
+	}
+}
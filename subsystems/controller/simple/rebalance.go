@@ -0,0 +1,255 @@
+package simple
+
+import (
+	"context"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"golang.org/x/xerrors"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRebalanceBatchSize = 100
+	DefaultRebalanceThreshold = 0.1
+)
+
+func (n *localNodeServer) RebalanceStatus(ctx context.Context, req *RebalanceStatusRequest) (*RebalanceStatusResponse, error) {
+	return &RebalanceStatusResponse{
+		Status: n.rebalancer.Status(),
+	}, nil
+}
+func (n *localNodeServer) PauseRebalance(ctx context.Context, req *PauseRebalanceRequest) (*PauseRebalanceResponse, error) {
+	return &PauseRebalanceResponse{
+		Status: n.rebalancer.SetPaused(req.GetPaused()),
+	}, nil
+}
+
+// Rebalancer moves objects from the most used storage node to the least used storage node.  Usage of nodes is
+// reported by heartbeats, so the interval should be longer than the heartbeat interval.
+type Rebalancer struct {
+	ns       controller_db.NodeStore
+	obs      controller_db.ObjectStore
+	liveness *LivenessConfig
+	now      func() time.Time
+	Dial     StorageDialer
+	// Maximum number of objects moved by a RebalanceOnce() call.
+	BatchSize int
+	// Nodes are rebalanced if the difference between its usage and the target usage exceeds Threshold.
+	Threshold float64
+
+	lock   sync.Mutex
+	status RebalanceStatus
+	// Listing objects of the source node is resumed from the cursor.
+	cursors map[string]string
+}
+type rebalanceNode struct {
+	id    *NodeID
+	node  *Node
+	usage float64
+}
+
+func (r *Rebalancer) Status() *RebalanceStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return proto.Clone(&r.status).(*RebalanceStatus)
+}
+func (r *Rebalancer) SetPaused(paused bool) *RebalanceStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.status.Paused = paused
+	return proto.Clone(&r.status).(*RebalanceStatus)
+}
+
+// RebalanceOnce moves a batch of objects.  It does nothing while paused.
+func (r *Rebalancer) RebalanceOnce(ctx context.Context) error {
+	r.lock.Lock()
+	paused := r.status.Paused
+	r.lock.Unlock()
+	if paused {
+		return nil
+	}
+
+	err := r.rebalance(ctx)
+
+	lastRunAt, tsErr := ptypes.TimestampProto(r.now())
+	if tsErr != nil {
+		return tsErr
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.status.LastRunAt = lastRunAt
+	r.status.Error = ""
+	if err != nil {
+		r.status.Error = err.Error()
+	}
+	return err
+}
+func (r *Rebalancer) rebalance(ctx context.Context) error {
+	now := r.now()
+	var nodes []*rebalanceNode
+	var used, total uint64
+	err := r.ns.List(func(id *NodeID, node *Node) error {
+		capacity := node.GetCapacity()
+		switch {
+		case !hasRole(node, StorageRole):
+		case r.liveness.Liveness(node, now) != NodeLiveness_Alive:
+		case node.GetDrain().GetPhase() != DrainPhase_NotDraining:
+		case capacity.GetTotalBytes() == 0 || capacity.GetFreeBytes() > capacity.GetTotalBytes():
+		default:
+			u := capacity.GetTotalBytes() - capacity.GetFreeBytes()
+			used += u
+			total += capacity.GetTotalBytes()
+			nodes = append(nodes, &rebalanceNode{
+				id:    id,
+				node:  node,
+				usage: float64(u) / float64(capacity.GetTotalBytes()),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].usage > nodes[j].usage
+	})
+	var target float64
+	if total > 0 {
+		target = float64(used) / float64(total)
+	}
+	balanced := true
+	if len(nodes) >= 2 {
+		src := nodes[0]
+		dst := nodes[len(nodes)-1]
+		balanced = src.usage-target <= r.Threshold && target-dst.usage <= r.Threshold
+	}
+
+	r.lock.Lock()
+	r.status.Balanced = balanced
+	r.status.TargetUsage = target
+	r.status.Nodes = nil
+	for _, n := range nodes {
+		r.status.Nodes = append(r.status.Nodes, &NodeUsage{
+			Id:    n.id,
+			Usage: n.usage,
+		})
+	}
+	r.lock.Unlock()
+	if balanced {
+		return nil
+	}
+
+	// Move objects until the source node or the destination node reaches the target usage.
+	src := nodes[0]
+	dst := nodes[len(nodes)-1]
+	limit := (src.usage - target) * float64(src.node.GetCapacity().GetTotalBytes())
+	if l := (target - dst.usage) * float64(dst.node.GetCapacity().GetTotalBytes()); l < limit {
+		limit = l
+	}
+	return r.move(ctx, src, dst, uint64(limit))
+}
+
+// move moves objects from src to dst until the total size exceeds limit or the number of objects reaches BatchSize.
+func (r *Rebalancer) move(ctx context.Context, src, dst *rebalanceNode, limit uint64) error {
+	sc, err := r.Dial(src.id, src.node)
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer Close(sc)
+	dc, err := r.Dial(dst.id, dst.node)
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer Close(dc)
+
+	// Cancel listing when the batch is finished.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r.lock.Lock()
+	if r.cursors == nil {
+		r.cursors = map[string]string{}
+	}
+	cursor := r.cursors[src.id.GetId()]
+	r.lock.Unlock()
+	defer func() {
+		r.lock.Lock()
+		r.cursors[src.id.GetId()] = cursor
+		r.lock.Unlock()
+	}()
+
+	receiver, err := sc.ListObjects(ctx, &ListObjectsRequest{After: cursor})
+	if err != nil {
+		return xerrors.Errorf("list objects: %w", err)
+	}
+	var count int
+	var moved uint64
+	for count < r.BatchSize && moved < limit {
+		res, err := receiver.Recv()
+		if err == io.EOF {
+			// Start from the first object in the next run.
+			cursor = ""
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("list objects: %w", err)
+		}
+
+		key := res.GetKey()
+		size, err := r.moveObject(ctx, sc, dc, src.id, dst.id, key)
+		if err != nil {
+			return xerrors.Errorf("move object %s: %w", key.GetId(), err)
+		}
+		cursor = key.GetId()
+		count++
+		moved += size
+
+		r.lock.Lock()
+		if size > 0 {
+			r.status.MovedObjects++
+			r.status.MovedBytes += size
+		}
+		r.lock.Unlock()
+	}
+	return nil
+}
+
+// moveObject copies the object to dst, updates the location records and deletes the object from src.  It returns zero
+// if dst already has the object.
+func (r *Rebalancer) moveObject(ctx context.Context, sc, dc StorageServiceClient, srcID, dstID *NodeID, key *ObjectKey) (uint64, error) {
+	locations, err := r.obs.Locations(key)
+	if err != nil {
+		return 0, err
+	}
+	for _, loc := range locations {
+		if loc.GetId() == dstID.GetId() {
+			// It is a replica.  Keep both.
+			return 0, nil
+		}
+	}
+
+	body, err := fetchObject(ctx, sc, key)
+	if err != nil {
+		return 0, err
+	}
+	if err := storeObject(ctx, dc, key, body); err != nil {
+		return 0, err
+	}
+	if err := r.obs.AddLocation(key, dstID); err != nil {
+		return 0, err
+	}
+	if err := r.obs.RemoveLocation(key, srcID); err != nil {
+		return 0, err
+	}
+	// Readers can find the object from the location record, so the source object can be deleted.
+	if _, err := sc.DeleteObject(ctx, &DeleteObjectRequest{Key: key}); err != nil {
+		return 0, xerrors.Errorf("delete object: %w", err)
+	}
+	return uint64(len(body)), nil
+}
@@ -0,0 +1,148 @@
+package simple
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestRebalancer_RebalanceOnce(t *testing.T) {
+	ctx := context.Background()
+	register := func(t *testing.T, n *localNodeServer, id string, total, free uint64) {
+		_, err := n.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+			Id: &elton_v2.NodeID{Id: id},
+			Node: &elton_v2.Node{
+				Roles:    []string{StorageRole},
+				Capacity: &elton_v2.NodeCapacity{TotalBytes: total, FreeBytes: free},
+			},
+		})
+		assert.NoError(t, err)
+	}
+	createObject := func(t *testing.T, sc elton_v2.StorageServiceClient, body string) *elton_v2.ObjectKey {
+		res, err := sc.CreateObject(ctx, &elton_v2.CreateObjectRequest{
+			Body: &elton_v2.ObjectBody{Contents: []byte(body)},
+		})
+		assert.NoError(t, err)
+		return res.GetKey()
+	}
+	locate := func(t *testing.T, n *localNodeServer, key *elton_v2.ObjectKey) []string {
+		res, err := n.LocateObject(ctx, &elton_v2.LocateObjectRequest{Key: key})
+		assert.NoError(t, err)
+		var ids []string
+		for _, node := range res.GetNodes() {
+			ids = append(ids, node.GetId().GetId())
+		}
+		return ids
+	}
+
+	t.Run("should_move_objects_to_empty_node", func(t *testing.T) {
+		withStorageNodes(t, []string{"old", "new"}, func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient) {
+			register(t, n, "old", 1000, 100)
+			register(t, n, "new", 1000, 1000)
+			keys := []*elton_v2.ObjectKey{
+				createObject(t, storages["old"], "foo"),
+				createObject(t, storages["old"], "bar"),
+			}
+
+			assert.NoError(t, n.rebalancer.RebalanceOnce(ctx))
+			for _, key := range keys {
+				_, err := storages["new"].GetObject(ctx, &elton_v2.GetObjectRequest{Key: key})
+				assert.NoError(t, err)
+				_, err = storages["old"].GetObject(ctx, &elton_v2.GetObjectRequest{Key: key})
+				assert.Error(t, err)
+				assert.Equal(t, []string{"new"}, locate(t, n, key))
+			}
+
+			res, err := n.RebalanceStatus(ctx, &elton_v2.RebalanceStatusRequest{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			st := res.GetStatus()
+			assert.False(t, st.GetBalanced())
+			assert.Equal(t, uint64(2), st.GetMovedObjects())
+			assert.Equal(t, uint64(6), st.GetMovedBytes())
+			assert.InDelta(t, 0.45, st.GetTargetUsage(), 0.001)
+			assert.Len(t, st.GetNodes(), 2)
+			assert.NotNil(t, st.GetLastRunAt())
+			assert.Empty(t, st.GetError())
+		})
+	})
+	t.Run("should_move_objects_in_batches", func(t *testing.T) {
+		withStorageNodes(t, []string{"old", "new"}, func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient) {
+			register(t, n, "old", 1000, 100)
+			register(t, n, "new", 1000, 1000)
+			createObject(t, storages["old"], "foo")
+			createObject(t, storages["old"], "bar")
+			n.rebalancer.BatchSize = 1
+
+			assert.NoError(t, n.rebalancer.RebalanceOnce(ctx))
+			assert.Equal(t, uint64(1), n.rebalancer.Status().GetMovedObjects())
+			assert.NoError(t, n.rebalancer.RebalanceOnce(ctx))
+			assert.Equal(t, uint64(2), n.rebalancer.Status().GetMovedObjects())
+		})
+	})
+	t.Run("should_not_move_objects_when_balanced", func(t *testing.T) {
+		withStorageNodes(t, []string{"a", "b"}, func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient) {
+			register(t, n, "a", 1000, 500)
+			register(t, n, "b", 1000, 550)
+			createObject(t, storages["a"], "foo")
+
+			assert.NoError(t, n.rebalancer.RebalanceOnce(ctx))
+			st := n.rebalancer.Status()
+			assert.True(t, st.GetBalanced())
+			assert.Equal(t, uint64(0), st.GetMovedObjects())
+		})
+	})
+	t.Run("should_not_move_objects_while_paused", func(t *testing.T) {
+		withStorageNodes(t, []string{"old", "new"}, func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient) {
+			register(t, n, "old", 1000, 100)
+			register(t, n, "new", 1000, 1000)
+			key := createObject(t, storages["old"], "foo")
+
+			res, err := n.PauseRebalance(ctx, &elton_v2.PauseRebalanceRequest{Paused: true})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.True(t, res.GetStatus().GetPaused())
+			assert.NoError(t, n.rebalancer.RebalanceOnce(ctx))
+			_, err = storages["old"].GetObject(ctx, &elton_v2.GetObjectRequest{Key: key})
+			assert.NoError(t, err)
+
+			_, err = n.PauseRebalance(ctx, &elton_v2.PauseRebalanceRequest{Paused: false})
+			assert.NoError(t, err)
+			assert.NoError(t, n.rebalancer.RebalanceOnce(ctx))
+			assert.Equal(t, []string{"new"}, locate(t, n, key))
+		})
+	})
+}
+
+func TestLocalNodeServer_LocateObject(t *testing.T) {
+	ctx := context.Background()
+	withStorageNodes(t, []string{"a"}, func(n *localNodeServer, stores controller_db.Stores, storages map[string]elton_v2.StorageServiceClient) {
+		key := &elton_v2.ObjectKey{Id: "obj"}
+
+		t.Run("should_fail_when_no_location", func(t *testing.T) {
+			_, err := n.LocateObject(ctx, &elton_v2.LocateObjectRequest{Key: key})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+		t.Run("should_ignore_unregistered_nodes", func(t *testing.T) {
+			assert.NoError(t, stores.ObjectStore().AddLocation(key, &elton_v2.NodeID{Id: "a"}))
+			_, err := n.LocateObject(ctx, &elton_v2.LocateObjectRequest{Key: key})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+
+			_, err = n.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+				Id:   &elton_v2.NodeID{Id: "a"},
+				Node: &elton_v2.Node{Roles: []string{StorageRole}},
+			})
+			assert.NoError(t, err)
+			res, err := n.LocateObject(ctx, &elton_v2.LocateObjectRequest{Key: key})
+			if assert.NoError(t, err) && assert.Len(t, res.GetNodes(), 1) {
+				assert.Equal(t, "a", res.GetNodes()[0].GetId().GetId())
+			}
+		})
+	})
+}
@@ -0,0 +1,216 @@
+package simple
+
+import (
+	"context"
+	"errors"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+func (v *localVolumeServer) CreateRef(ctx context.Context, req *CreateRefRequest) (*CreateRefResponse, error) {
+	if req.GetId().GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "id should not nil")
+	}
+	if req.GetRef().GetCommit() == nil {
+		return nil, status.Error(codes.InvalidArgument, "commit should not nil")
+	}
+	// The commit may be specified by other ref.
+	cid, err := v.resolve(req.GetRef().GetCommit())
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.cs.CreateRef(req.GetId(), &Ref{
+		Type:   req.GetRef().GetType(),
+		Commit: cid,
+	})
+	if err != nil {
+		if errors.Is(err, controller_db.ErrDupRef) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		if errors.Is(err, controller_db.ErrNotFoundVolume) || errors.Is(err, controller_db.ErrNotFoundCommit) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, controller_db.ErrInvalidRefName) || errors.Is(err, controller_db.ErrCrossVolumeCommit) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &CreateRefResponse{}, nil
+}
+func (v *localVolumeServer) GetRef(ctx context.Context, req *GetRefRequest) (*GetRefResponse, error) {
+	if req.GetId().GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "id should not nil")
+	}
+
+	ref, err := v.cs.GetRef(req.GetId())
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundRef) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &GetRefResponse{
+		Id:  req.GetId(),
+		Ref: ref,
+	}, nil
+}
+func (v *localVolumeServer) ListRefs(req *ListRefsRequest, srv CommitService_ListRefsServer) error {
+	if req.GetId().Empty() {
+		return status.Error(codes.InvalidArgument, "id should not nil")
+	}
+
+	breakLoop := errors.New("break loop")
+	err := v.cs.ListRefs(req.GetId(), func(id *RefID, ref *Ref) error {
+		select {
+		case <-srv.Context().Done():
+			// Context canceled.
+			return breakLoop
+		default:
+			return srv.Send(&ListRefsResponse{
+				Id:  id,
+				Ref: ref,
+			})
+		}
+	})
+	if err == breakLoop {
+		return status.Error(codes.Canceled, "canceled")
+	}
+	if err != nil {
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+func (v *localVolumeServer) MoveRef(ctx context.Context, req *MoveRefRequest) (*MoveRefResponse, error) {
+	if req.GetId().GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "id should not nil")
+	}
+	if req.GetCommit() == nil {
+		return nil, status.Error(codes.InvalidArgument, "commit should not nil")
+	}
+	cid, err := v.resolve(req.GetCommit())
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.cs.MoveRef(req.GetId(), cid)
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundRef) || errors.Is(err, controller_db.ErrNotFoundCommit) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, controller_db.ErrImmutableRef) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, controller_db.ErrCrossVolumeCommit) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	v.watcher.NotifyRef(req.GetId(), cid)
+	return &MoveRefResponse{}, nil
+}
+func (v *localVolumeServer) UpdateRef(ctx context.Context, req *UpdateRefRequest) (*UpdateRefResponse, error) {
+	if req.GetId().GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id should not nil")
+	}
+	if req.GetExpected() == nil {
+		return nil, status.Error(codes.InvalidArgument, "expected should not nil")
+	}
+	if req.GetNew() == nil {
+		return nil, status.Error(codes.InvalidArgument, "new should not nil")
+	}
+	expected, err := v.resolve(req.GetExpected())
+	if err != nil {
+		return nil, wrapStatus(err, 0, "expected")
+	}
+	cid, err := v.resolve(req.GetNew())
+	if err != nil {
+		return nil, wrapStatus(err, 0, "new")
+	}
+
+	err = v.cs.UpdateHead(&RefID{Id: req.GetId(), Name: req.GetBranch()}, expected, cid)
+	if err != nil {
+		if errors.Is(err, controller_db.ErrLatestCommitUpdated) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		if errors.Is(err, controller_db.ErrNotFoundVolume) ||
+			errors.Is(err, controller_db.ErrNotFoundRef) ||
+			errors.Is(err, controller_db.ErrNotFoundCommit) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, controller_db.ErrImmutableRef) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, controller_db.ErrCrossVolumeCommit) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	v.watcher.NotifyRef(&RefID{Id: req.GetId(), Name: req.GetBranch()}, cid)
+	return &UpdateRefResponse{}, nil
+}
+func (v *localVolumeServer) DeleteRef(ctx context.Context, req *DeleteRefRequest) (*DeleteRefResponse, error) {
+	if req.GetId().GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "id should not nil")
+	}
+
+	err := v.cs.DeleteRef(req.GetId())
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundRef) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	v.watcher.CloseRef(req.GetId())
+	return &DeleteRefResponse{}, nil
+}
+
+// resolve returns CommitID that ref points to.  If cid does not have ref name, it returns cid as it is.
+func (v *localVolumeServer) resolve(cid *CommitID) (*CommitID, error) {
+	resolved, err := v.cs.Resolve(cid)
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundRef) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resolved, nil
+}
@@ -8,10 +8,13 @@ import (
 	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
 	"io/ioutil"
+	"log"
 	"math"
 	"net"
 	"os"
 	"strconv"
+	"sync"
+	"time"
 )
 
 type Server struct {
@@ -19,6 +22,29 @@ type Server struct {
 	Listener   net.Listener
 	// Path to database directory.
 	DatabaseAddr string
+	// Interval of pruning expired commits.  If it is zero, expired commits are pruned only by PruneVolume RPC.
+	PruneInterval time.Duration
+	// Interval of deleting expired properties.  If it is zero, expired properties are kept in the database, but they
+	// are invisible from clients.
+	MetaExpireInterval time.Duration
+	// Timeouts to decide liveness of nodes.  If they are zero, default values are used.
+	NodeSuspectTimeout time.Duration
+	NodeDeadTimeout    time.Duration
+	// Dead nodes are unregistered if no ping is received for NodeEvictTimeout.  If it is zero, dead nodes are kept.
+	// Storage nodes are kept until they are drained.
+	NodeEvictTimeout time.Duration
+	// Interval of checking liveness of nodes.  If it is zero, dead nodes are never unregistered.
+	NodeMonitorInterval time.Duration
+	// Interval of copying objects on draining nodes.  If it is zero, nodes are never drained.
+	DrainInterval time.Duration
+	// Interval of moving a batch of objects to balance usage of storage nodes.  If it is zero, objects are never
+	// rebalanced.
+	RebalanceInterval time.Duration
+	// Policy to select storage nodes for new objects.  If it is nil, TopologyPolicy with DefaultDomainLabels is used.
+	PlacementPolicy PlacementPolicy
+	// Text files modified by concurrent commits are merged if they are smaller than ContentMergeMaxSize.  If it is
+	// zero, contents are never merged.
+	ContentMergeMaxSize uint64
 }
 
 func (s *Server) Name() string {
@@ -53,6 +79,43 @@ func (s *Server) Serve(ctx context.Context) error {
 	handler, dbClose := NewController(s.DatabaseAddr)
 	defer dbClose()
 
+	if s.NodeSuspectTimeout > 0 {
+		handler.Liveness.SuspectTimeout = s.NodeSuspectTimeout
+	}
+	if s.NodeDeadTimeout > 0 {
+		handler.Liveness.DeadTimeout = s.NodeDeadTimeout
+	}
+	handler.Liveness.EvictTimeout = s.NodeEvictTimeout
+	if s.PlacementPolicy != nil {
+		handler.Placement.Policy = s.PlacementPolicy
+	}
+	handler.ContentMerger.MaxSize = s.ContentMergeMaxSize
+
+	// Background tasks are stopped before closing the database.  Deferred calls are executed in reverse order.
+	var tasks sync.WaitGroup
+	defer tasks.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	start := func(interval time.Duration, name string, fn func() error) {
+		if interval <= 0 {
+			return
+		}
+		tasks.Add(1)
+		go func() {
+			defer tasks.Done()
+			runPeriodically(ctx, interval, name, fn)
+		}()
+	}
+	start(s.PruneInterval, "Pruner", handler.Pruner.PruneAll)
+	start(s.MetaExpireInterval, "MetaExpirer", handler.MetaExpirer.ExpireAll)
+	start(s.NodeMonitorInterval, "NodeMonitor", handler.NodeMonitor.CheckAll)
+	start(s.DrainInterval, "Drainer", func() error {
+		return handler.Drainer.DrainAll(ctx)
+	})
+	start(s.RebalanceInterval, "Rebalancer", func() error {
+		return handler.Rebalancer.RebalanceOnce(ctx)
+	})
+
 	srv := grpc.NewServer(
 		// Increase receivable packet size.
 		grpc.MaxRecvMsgSize(math.MaxInt32),
@@ -67,6 +130,28 @@ func (s *Server) Serve(ctx context.Context) error {
 
 func NewServer() *Server {
 	return &Server{
-		ListenAddr: "0.0.0.0:" + strconv.Itoa(subsystems.ControllerPort),
+		ListenAddr:          "0.0.0.0:" + strconv.Itoa(subsystems.ControllerPort),
+		PruneInterval:       time.Hour,
+		MetaExpireInterval:  10 * time.Second,
+		NodeMonitorInterval: 10 * time.Second,
+		DrainInterval:       10 * time.Second,
+		RebalanceInterval:   time.Minute,
+		ContentMergeMaxSize: DefaultContentMergeMaxSize,
+	}
+}
+
+// runPeriodically calls fn every interval until ctx is canceled.  Errors are logged with the name of the task.
+func runPeriodically(ctx context.Context, interval time.Duration, name string, fn func() error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(); err != nil {
+				log.Printf("[ERROR] %s: %+v", name, err)
+			}
+		}
 	}
 }
@@ -0,0 +1,52 @@
+package simple
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fetchObject gets the whole object from the storage node and verifies it.
+func fetchObject(ctx context.Context, src StorageServiceClient, key *ObjectKey) ([]byte, error) {
+	res, err := src.GetObject(ctx, &GetObjectRequest{Key: key})
+	if err != nil {
+		return nil, xerrors.Errorf("get object: %w", err)
+	}
+	body := res.GetBody().GetContents()
+	if err := verifyObject(body, res.GetInfo()); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// storeObject saves the object to the storage node with the same key.  It is not an error if the object already
+// exists, because it may be copied by the previous attempt.
+func storeObject(ctx context.Context, dst StorageServiceClient, key *ObjectKey, body []byte) error {
+	_, err := dst.CreateObject(ctx, &CreateObjectRequest{
+		Key:  key,
+		Body: &ObjectBody{Contents: body},
+	})
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return xerrors.Errorf("create object: %w", err)
+	}
+	return nil
+}
+
+// verifyObject checks the hash of the object body.  Unknown hash algorithms are not checked.
+func verifyObject(body []byte, info *ObjectInfo) error {
+	if info.GetHashAlgorithm() != "SHA1" {
+		return nil
+	}
+	hash := sha1.Sum(body)
+	if !bytes.Equal(hash[:], info.GetHash()) {
+		return xerrors.New("hash mismatch")
+	}
+	if info.GetSize() != 0 && info.GetSize() != uint64(len(body)) {
+		return xerrors.Errorf("size mismatch: expected=%d actual=%d", info.GetSize(), len(body))
+	}
+	return nil
+}
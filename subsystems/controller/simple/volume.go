@@ -10,24 +10,40 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log"
+	"strings"
+	"time"
 )
 
+// Maximum number of retries when the head is moved by other commits during Commit().
+const maxCommitRetries = 10
+
 func newLocalVolumeServer(vs controller_db.VolumeStore, cs controller_db.CommitStore) *localVolumeServer {
 	return &localVolumeServer{
-		vs: vs,
-		cs: cs,
+		vs:      vs,
+		cs:      cs,
+		pruner:  newPruner(vs, cs),
+		watcher: newCommitWatcher(),
+		queue:   newCommitQueue(),
 	}
 }
 
 type localVolumeServer struct {
-	vs controller_db.VolumeStore
-	cs controller_db.CommitStore
+	vs      controller_db.VolumeStore
+	cs      controller_db.CommitStore
+	pruner  *Pruner
+	watcher *commitWatcher
+	queue   *commitQueue
+	// contents merges regular files modified by concurrent commits.  It is optional.
+	contents ContentMerger
 }
 
 func (v *localVolumeServer) CreateVolume(ctx context.Context, req *CreateVolumeRequest) (*CreateVolumeResponse, error) {
 	if req.GetInfo() == nil {
 		return nil, status.Error(codes.InvalidArgument, "info is null")
 	}
+	if err := ValidateLabels(req.GetInfo().GetLabels()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	vid, err := v.vs.Create(req.GetInfo())
 	if err != nil {
@@ -61,34 +77,62 @@ func (v *localVolumeServer) DeleteVolume(ctx context.Context, req *DeleteVolumeR
 		log.Println("ERROR:", err)
 		return nil, status.Error(codes.Internal, "database error")
 	}
+	v.watcher.CloseVolume(req.GetId())
+	v.queue.Forget(req.GetId())
 	return &DeleteVolumeResponse{}, nil
 }
 func (v *localVolumeServer) ListVolumes(req *ListVolumesRequest, stream VolumeService_ListVolumesServer) error {
+	var after *VolumeID
 	if req.GetNext() != "" {
-		return status.Error(codes.FailedPrecondition, "next parameter is not supported") // TODO
+		key, err := decodePageToken(volumePageToken, req.GetNext())
+		if err != nil {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		after = &VolumeID{Id: key}
 	}
 	limit := req.GetLimit()
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+	selector, err := ParseLabelSelector(req.GetLabelSelector())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
 
+	// The response is sent after the next volume is found.  Because the last response of the page should have the
+	// continuation token only if remaining volumes exist.
+	var pending *ListVolumesResponse
 	count := uint64(0)
 	breakLoop := errors.New("break loop")
-	err := v.vs.Walk(func(id *VolumeID, info *VolumeInfo) error {
+	err = v.vs.WalkAfter(after, func(id *VolumeID, info *VolumeInfo) error {
 		select {
 		case <-stream.Context().Done():
 			// Context canceled.
+			pending = nil
 			return breakLoop
 		default:
-			res := &ListVolumesResponse{
-				Id:   id,
-				Info: info,
+			if !selector.Matches(info.GetLabels()) {
+				return nil
 			}
-			if err := stream.Send(res); err != nil {
-				return err
+			if pending != nil {
+				count++
+				if count >= limit {
+					// Limit reached.  Remaining volumes will be returned in the next page.
+					pending.Next = encodePageToken(volumePageToken, pending.GetId().GetId())
+					err := stream.Send(pending)
+					pending = nil
+					if err != nil {
+						return err
+					}
+					return breakLoop
+				}
+				if err := stream.Send(pending); err != nil {
+					return err
+				}
 			}
-
-			count++
-			if limit > 0 && count >= limit {
-				// Limit reached.
-				return breakLoop
+			pending = &ListVolumesResponse{
+				Id:   id,
+				Info: info,
 			}
 			return nil
 		}
@@ -96,6 +140,10 @@ func (v *localVolumeServer) ListVolumes(req *ListVolumesRequest, stream VolumeSe
 	if err == breakLoop {
 		err = nil
 	}
+	if err == nil && pending != nil {
+		// It is the last volume.
+		err = stream.Send(pending)
+	}
 	if err != nil {
 		if errors.Is(err, &controller_db.InputError{}) {
 			return status.Error(codes.InvalidArgument, err.Error())
@@ -150,6 +198,190 @@ func (v *localVolumeServer) InspectVolume(ctx context.Context, req *InspectVolum
 		panic("unreachable")
 	}
 }
+func (v *localVolumeServer) UpdateVolume(ctx context.Context, req *UpdateVolumeRequest) (*UpdateVolumeResponse, error) {
+	if req.GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "id is null")
+	}
+	if req.GetInfo() == nil {
+		return nil, status.Error(codes.InvalidArgument, "info is null")
+	}
+	if len(req.GetUpdateMask()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "updateMask is empty")
+	}
+	for _, field := range req.GetUpdateMask() {
+		switch field {
+		case "name":
+			if req.GetInfo().GetName() == "" {
+				return nil, status.Error(codes.InvalidArgument, "name is empty")
+			}
+		case "labels":
+			if err := ValidateLabels(req.GetInfo().GetLabels()); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		case "description", "owner":
+		case "mergePolicy":
+			if _, ok := MergePolicy_name[int32(req.GetInfo().GetMergePolicy())]; !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "unknown mergePolicy: %d", req.GetInfo().GetMergePolicy())
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported field in updateMask: %s", field)
+		}
+	}
+
+	var updated *VolumeInfo
+	err := v.vs.Update(req.GetId(), func(info *VolumeInfo) error {
+		for _, field := range req.GetUpdateMask() {
+			switch field {
+			case "name":
+				info.Name = req.GetInfo().GetName()
+			case "labels":
+				info.Labels = req.GetInfo().GetLabels()
+			case "description":
+				info.Description = req.GetInfo().GetDescription()
+			case "owner":
+				info.Owner = req.GetInfo().GetOwner()
+			case "mergePolicy":
+				info.MergePolicy = req.GetInfo().GetMergePolicy()
+			}
+		}
+		updated = info
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundVolume) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, controller_db.ErrDupVolumeName) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Println("ERROR:", err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+	return &UpdateVolumeResponse{
+		Info: updated,
+	}, nil
+}
+func (v *localVolumeServer) ImportVolume(ctx context.Context, req *ImportVolumeRequest) (*ImportVolumeResponse, error) {
+	if req.GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "id is null")
+	}
+	if req.GetInfo() == nil {
+		return nil, status.Error(codes.InvalidArgument, "info is null")
+	}
+
+	err := v.vs.Import(req.GetId(), req.GetInfo())
+	if err != nil {
+		if errors.Is(err, controller_db.ErrDupVolumeID) || errors.Is(err, controller_db.ErrDupVolumeName) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Println("ERROR:", err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+	return &ImportVolumeResponse{}, nil
+}
+func (v *localVolumeServer) SetRetentionPolicy(ctx context.Context, req *SetRetentionPolicyRequest) (*SetRetentionPolicyResponse, error) {
+	if req.GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "id is null")
+	}
+	if req.GetPolicy().GetKeepWithin() != nil {
+		d, err := ptypes.Duration(req.GetPolicy().GetKeepWithin())
+		if err != nil || d < 0 {
+			return nil, status.Error(codes.InvalidArgument, "keepWithin is invalid")
+		}
+	}
+
+	err := v.vs.Update(req.GetId(), func(info *VolumeInfo) error {
+		info.Retention = req.GetPolicy()
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundVolume) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Println("ERROR:", err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+	return &SetRetentionPolicyResponse{}, nil
+}
+func (v *localVolumeServer) PruneVolume(ctx context.Context, req *PruneVolumeRequest) (*PruneVolumeResponse, error) {
+	if req.GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "id is null")
+	}
+
+	info, err := v.vs.Get(req.GetId())
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundVolume) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Println("ERROR:", err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+
+	deleted, released, err := v.pruner.Prune(req.GetId(), info.GetRetention(), req.GetDryRun())
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundCommit) || errors.Is(err, controller_db.ErrDeleteLatestCommit) {
+			// Commits are updated by other requests during pruning.
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &PruneVolumeResponse{
+		Deleted:  deleted,
+		Released: released,
+	}, nil
+}
+func (v *localVolumeServer) ForkVolume(ctx context.Context, req *ForkVolumeRequest) (*ForkVolumeResponse, error) {
+	if req.GetSrc().GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "src is null")
+	}
+	if req.GetInfo() == nil {
+		return nil, status.Error(codes.InvalidArgument, "info is null")
+	}
+	if err := ValidateLabels(req.GetInfo().GetLabels()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Source commit may be specified by ref name.
+	src, err := v.resolve(req.GetSrc())
+	if err != nil {
+		return nil, err
+	}
+
+	vid, cid, err := v.vs.Fork(src, req.GetInfo())
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundCommit) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, controller_db.ErrDupVolumeID) || errors.Is(err, controller_db.ErrDupVolumeName) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.Println("ERROR:", err)
+		return nil, status.Error(codes.Internal, "database error")
+	}
+	return &ForkVolumeResponse{
+		Id:     vid,
+		Commit: cid,
+	}, nil
+}
 
 func (v *localVolumeServer) GetLastCommit(ctx context.Context, req *GetLastCommitRequest) (*GetLastCommitResponse, error) {
 	vid := req.GetVolumeId()
@@ -190,16 +422,126 @@ func (v *localVolumeServer) GetLastCommit(ctx context.Context, req *GetLastCommi
 	}, nil
 }
 func (v *localVolumeServer) ListCommits(req *ListCommitsRequest, srv CommitService_ListCommitsServer) error {
-	if req.GetNext() != "" {
-		return status.Error(codes.InvalidArgument, "next parameter is not supported")
-	}
 	limit := req.GetLimit()
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+	order := req.GetOrder()
+	if _, ok := ListCommitsOrder_name[int32(order)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown order: %d", order)
+	}
+	if _, ok := MergeFilter_name[int32(req.GetMerges())]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown merge filter: %d", req.GetMerges())
+	}
+	filter, err := v.newCommitFilter(req)
+	if err != nil {
+		return err
+	}
 
 	vid := req.GetId()
-	cid, err := v.cs.Latest(vid)
+	// head is the commit that starts traversal.  resumeAfter is the last commit of previous page (DAG mode only).
+	var head, resumeAfter *CommitID
+	if req.GetNext() != "" {
+		head, resumeAfter, err = v.decodeCommitPageToken(vid, order, req.GetNext())
+		if err != nil {
+			return err
+		}
+	} else if req.GetRef() != "" {
+		// List commits from the commit that ref points to.
+		head, err = v.resolve(&CommitID{Id: vid, Ref: req.GetRef()})
+		if err != nil {
+			return err
+		}
+	} else {
+		head, err = v.cs.Latest(vid)
+		if err != nil {
+			if errors.Is(err, controller_db.ErrNotFoundCommit) {
+				// The volume has no commit.
+				return nil
+			}
+			if errors.Is(err, &controller_db.InputError{}) {
+				log.Printf("[CRITICAL] Missing error handling: %+v", err)
+				return status.Error(codes.Internal, err.Error())
+			}
+			log.Printf("[ERROR] %+v", err)
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	var it commitIterator
+	if order == ListCommitsOrder_FirstParent {
+		it = firstParentIterator(v.cs, head)
+	} else {
+		commits, err := loadAncestors(v.cs, head)
+		if err != nil {
+			if errors.Is(err, controller_db.ErrNotFoundCommit) {
+				if req.GetNext() != "" {
+					return status.Errorf(codes.FailedPrecondition, "page token is expired: %s", err)
+				}
+				return status.Errorf(codes.Aborted, "commits are deleted during processing: %s", err)
+			}
+			log.Printf("[ERROR] %+v", err)
+			return status.Error(codes.Internal, err.Error())
+		}
+		var sorted []*commitEntry
+		if order == ListCommitsOrder_Date {
+			sorted = sortByDate(commits)
+		} else {
+			sorted = sortTopologically(commits)
+		}
+		if resumeAfter != nil {
+			found := false
+			for i, e := range sorted {
+				if e.id.Equals(resumeAfter) {
+					sorted = sorted[i+1:]
+					found = true
+					break
+				}
+			}
+			if !found {
+				return status.Errorf(codes.FailedPrecondition, "page token is expired: commit is not found: %s", resumeAfter.ConvertString())
+			}
+		}
+		it = sliceIterator(sorted)
+	}
+	it = filterIterator(it, filter.Match)
+
+	count := uint64(0)
+	cur, err := it()
+	for err == nil && cur != nil {
+		select {
+		case <-srv.Context().Done():
+			return status.Error(codes.Canceled, "canceled")
+		default:
+		}
+
+		res := &ListCommitsResponse{
+			Id:        cur.id,
+			Parents:   cur.parents(),
+			CreatedAt: cur.info.GetCreatedAt(),
+		}
+		count++
+		var next *commitEntry
+		next, err = it()
+		if err == nil && count >= limit && next != nil {
+			// Limit reached.  Remaining commits will be returned in the next page.
+			if order == ListCommitsOrder_FirstParent {
+				res.Next = encodePageToken(commitPageToken, next.id.ConvertString())
+			} else {
+				res.Next = encodePageToken(commitDAGPageToken, head.ConvertString()+","+cur.id.ConvertString())
+			}
+		}
+		if err := srv.Send(res); err != nil {
+			return fmt.Errorf("failed to send response: %w", err)
+		}
+		if count >= limit {
+			break
+		}
+		cur = next
+	}
 	if err != nil {
 		if errors.Is(err, controller_db.ErrNotFoundCommit) {
-			// The volume has no commit.
+			// The commit deleted during processing.
 			return nil
 		}
 		if errors.Is(err, &controller_db.InputError{}) {
@@ -209,53 +551,137 @@ func (v *localVolumeServer) ListCommits(req *ListCommitsRequest, srv CommitServi
 		log.Printf("[ERROR] %+v", err)
 		return status.Error(codes.Internal, err.Error())
 	}
+	return nil
+}
 
-	count := uint64(0)
-	for cid.GetId().GetId() != "" {
-		select {
-		case <-srv.Context().Done():
-			return status.Error(codes.Canceled, "canceled")
-		default:
-			err = srv.Send(&ListCommitsResponse{
-				Next: "",
-				Id:   cid,
-			})
-			if err != nil {
-				return fmt.Errorf("failed to send response: %w", err)
-			}
-			count++
-			if limit > 0 && count >= limit {
-				// Limit reached.
-				return nil
-			}
+// decodeCommitPageToken returns the commit to start traversal and the last commit of previous page.
+func (v *localVolumeServer) decodeCommitPageToken(vid *VolumeID, order ListCommitsOrder, token string) (head, last *CommitID, err error) {
+	parse := func(s string) (*CommitID, error) {
+		cid, err := ParseCommitID(s)
+		if err != nil || cid.GetRef() != "" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %s", s)
 		}
+		if !cid.GetId().Equals(vid) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token: volume mismatch")
+		}
+		return cid, nil
+	}
 
-		info, err := v.cs.Get(cid)
+	if order == ListCommitsOrder_FirstParent {
+		// The token points to the next commit.
+		key, err := decodePageToken(commitPageToken, token)
 		if err != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		head, err = parse(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		ok, err := v.cs.Exists(head)
+		if err != nil {
+			log.Printf("[ERROR] %+v", err)
+			return nil, nil, status.Error(codes.Internal, err.Error())
+		}
+		if !ok {
+			// The commit is deleted by pruner.
+			return nil, nil, status.Errorf(codes.FailedPrecondition, "page token is expired: commit is not found: %s", key)
+		}
+		return head, nil, nil
+	}
+
+	// The token keeps the head commit of the first page and the last commit of previous page.  The order is stable
+	// even if new commits are added, because the traversal always starts from the same head.
+	key, err := decodePageToken(commitDAGPageToken, token)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	parts := strings.SplitN(key, ",", 2)
+	if len(parts) != 2 {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid page token: %s", key)
+	}
+	if head, err = parse(parts[0]); err != nil {
+		return nil, nil, err
+	}
+	if last, err = parse(parts[1]); err != nil {
+		return nil, nil, err
+	}
+	return head, last, nil
+}
+
+// newCommitFilter creates a commitFilter from ListCommitsRequest.
+func (v *localVolumeServer) newCommitFilter(req *ListCommitsRequest) (*commitFilter, error) {
+	filter := &commitFilter{
+		merges: req.GetMerges(),
+	}
+	var err error
+	if req.GetSince() != nil {
+		if filter.since, err = ptypes.Timestamp(req.GetSince()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "since is invalid: %s", err)
+		}
+	}
+	if req.GetUntil() != nil {
+		if filter.until, err = ptypes.Timestamp(req.GetUntil()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "until is invalid: %s", err)
+		}
+	}
+
+	// resolve resolves the commit and checks that the commit belongs to the volume.
+	resolve := func(cid *CommitID, name string) (*CommitID, error) {
+		cid, err := v.resolve(cid)
+		if err != nil {
+			return nil, wrapStatus(err, 0, name)
+		}
+		if !cid.GetId().Equals(req.GetId()) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: volume mismatch", name)
+		}
+		ok, err := v.cs.Exists(cid)
+		if err != nil {
+			log.Printf("[ERROR] %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "%s: not found commit: %s", name, cid.ConvertString())
+		}
+		return cid, nil
+	}
+	if req.GetAncestorOf() != nil {
+		cid, err := resolve(req.GetAncestorOf(), "ancestorOf")
+		if err != nil {
+			return nil, err
+		}
+		if filter.ancestors, err = loadAncestors(v.cs, cid); err != nil {
 			if errors.Is(err, controller_db.ErrNotFoundCommit) {
-				// The commit deleted during processing.
-				return nil
-			}
-			if errors.Is(err, &controller_db.InputError{}) {
-				log.Printf("[CRITICAL] Missing error handling: %+v", err)
-				return status.Error(codes.Internal, err.Error())
+				return nil, status.Errorf(codes.Aborted, "commits are deleted during processing: %s", err)
 			}
 			log.Printf("[ERROR] %+v", err)
-			return status.Error(codes.Internal, err.Error())
+			return nil, status.Error(codes.Internal, err.Error())
 		}
-		cid = info.GetLeftParentID()
 	}
-	return nil
+	if req.GetDescendantOf() != nil {
+		cid, err := resolve(req.GetDescendantOf(), "descendantOf")
+		if err != nil {
+			return nil, err
+		}
+		if filter.descendants, err = loadDescendants(v.cs, cid); err != nil {
+			log.Printf("[ERROR] %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return filter, nil
 }
 func (v *localVolumeServer) GetCommit(ctx context.Context, req *GetCommitRequest) (*GetCommitResponse, error) {
 	if req.GetId() == nil {
 		return nil, status.Error(codes.InvalidArgument, "id should not nil")
 	}
+	cid, err := v.resolve(req.GetId())
+	if err != nil {
+		return nil, err
+	}
 
-	info, err := v.cs.Get(req.GetId())
+	info, err := v.cs.Get(cid)
 	if err != nil {
 		if errors.Is(err, controller_db.ErrNotFoundCommit) {
-			return nil, status.Errorf(codes.NotFound, "not found commit: %s", req.GetId())
+			return nil, status.Errorf(codes.NotFound, "not found commit: %s", cid)
 		}
 		if errors.Is(err, &controller_db.InputError{}) {
 			log.Printf("[CRITICAL] Missing error handling: %+v", err)
@@ -266,7 +692,7 @@ func (v *localVolumeServer) GetCommit(ctx context.Context, req *GetCommitRequest
 	}
 
 	return &GetCommitResponse{
-		Id:   req.GetId(),
+		Id:   cid,
 		Info: info,
 	}, nil
 }
@@ -282,65 +708,172 @@ func (v *localVolumeServer) Commit(ctx context.Context, req *CommitRequest) (*Co
 	}
 
 	// Base info
-	baseID := req.GetInfo().GetLeftParentID()
 	resCmt, err := v.GetCommit(ctx, &GetCommitRequest{
-		Id: baseID,
+		Id: req.GetInfo().GetLeftParentID(),
 	})
 	if err != nil {
 		return nil, wrapStatus(err, codes.InvalidArgument, "left parent")
 	}
+	// Left parent may be specified by ref name.  Should replace it with resolved CommitID.
+	baseID := resCmt.GetId()
 	baseTree := resCmt.GetInfo().GetTree()
+	req.GetInfo().LeftParentID = baseID
+	if req.GetInfo().GetRightParentID().GetRef() != "" {
+		rightID, err := v.resolve(req.GetInfo().GetRightParentID())
+		if err != nil {
+			return nil, wrapStatus(err, codes.InvalidArgument, "right parent")
+		}
+		req.GetInfo().RightParentID = rightID
+	}
 
-	// Last info
-	resLast, err := v.GetLastCommit(ctx, &GetLastCommitRequest{
-		VolumeId: req.Id,
+	// Commits to the same volume are processed in arrival order.  The head may still be moved by other APIs (e.g.
+	// UpdateRef), so the commit is retried a bounded number of times.
+	var cid *CommitID
+	var retries int
+	var contents ContentMerger
+	if v.contents != nil {
+		// Merged contents are reused on retries.
+		cached := newCachedContentMerger(v.contents)
+		defer func() {
+			// Merged objects are created before the commit is saved.  Unused objects should be garbage collected.
+			if err := cached.DiscardMerged(); err != nil {
+				log.Printf("[WARN] failed to release merged objects: %+v", err)
+			}
+		}()
+		contents = cached
+	}
+	err = v.queue.Do(ctx, req.GetId(), func() error {
+		if req.GetNoMerge() {
+			var err error
+			cid, err = v.commitFastForward(req.GetId(), req.GetBranch(), req.GetInfo())
+			if err != nil {
+				return wrapStatus(err, 0, "saving new commit")
+			}
+			return nil
+		}
+		for ; ; retries++ {
+			var err error
+			cid, err = v.commitOrMerge(ctx, req, baseID, baseTree, contents)
+			if err == nil {
+				return nil
+			}
+			if errors.Is(err, controller_db.ErrLatestCommitUpdated) && retries < maxCommitRetries {
+				log.Printf("[INFO] head is updated during commit, retrying: volume=%s branch=%s retry=%d", req.GetId(), req.GetBranch(), retries+1)
+				continue
+			}
+			return err
+		}
 	})
+	v.queue.Observe(req.GetId(), retries, err)
 	if err != nil {
-		return nil, wrapStatus(err, codes.InvalidArgument, "last commit")
+		if st, ok := status.FromError(err); ok {
+			return nil, st.Err()
+		}
+		return nil, wrapStatus(commitStatus(err), 0, "saving new commit")
 	}
-	lastID := resLast.GetId()
-	lastTree := resLast.GetInfo().GetTree()
+	return &CommitResponse{Id: cid}, nil
+}
 
-	if baseID.Equals(lastID) {
-		cid, err := v.commit(req.GetId(), req.GetInfo())
+// commitOrMerge saves the commit if it is based on the head.  Otherwise, it merges the commit and the head, and saves
+// both the commit and the merge commit.  The head is moved by compare-and-swap, so it returns ErrLatestCommitUpdated if
+// other commits are saved during the merge.
+func (v *localVolumeServer) commitOrMerge(ctx context.Context, req *CommitRequest, baseID *CommitID, baseTree *Tree, contents ContentMerger) (*CommitID, error) {
+	// Last info
+	var lastID *CommitID
+	var lastTree *Tree
+	if req.GetBranch() == "" {
+		resLast, err := v.GetLastCommit(ctx, &GetLastCommitRequest{
+			VolumeId: req.Id,
+		})
 		if err != nil {
-			return nil, wrapStatus(err, 0, "saving new commit")
+			return nil, wrapStatus(err, codes.InvalidArgument, "last commit")
 		}
-		return &CommitResponse{Id: cid}, nil
+		lastID = resLast.GetId()
+		lastTree = resLast.GetInfo().GetTree()
 	} else {
-		// Some transactions are committed during this transaction processing.  Should try to merge two commits.
-		m := &Merger{
-			Info:    req.GetInfo(),
-			Base:    baseTree,
-			Latest:  lastTree,
-			Current: req.GetInfo().GetTree(),
-		}
-		mergedTree, err := m.Merge()
+		resRef, err := v.GetRef(ctx, &GetRefRequest{
+			Id: &RefID{Id: req.GetId(), Name: req.GetBranch()},
+		})
 		if err != nil {
-			return nil, wrapStatus(err, 0, "merge two commits")
+			return nil, wrapStatus(err, codes.InvalidArgument, "branch")
 		}
-
-		// We succeed merge latest tree and current tree.  Commit latest current tree and merged tree.
-		// todo: latestを進めずにコミットする
-		currentCid, err := v.commit(req.GetId(), req.GetInfo())
-		if err != nil {
-			return nil, wrapStatus(err, 0, "saving current commit")
+		if resRef.GetRef().GetType() != RefType_Branch {
+			return nil, status.Errorf(codes.InvalidArgument, "branch: ref is not a branch: %s", req.GetBranch())
 		}
-		mergedCid, err := v.commit(req.GetId(), &CommitInfo{
-			CreatedAt:     ptypes.TimestampNow(),
-			LeftParentID:  lastID,
-			RightParentID: currentCid,
-			Tree:          mergedTree,
+		resHead, err := v.GetCommit(ctx, &GetCommitRequest{
+			Id: resRef.GetRef().GetCommit(),
 		})
 		if err != nil {
-			return nil, wrapStatus(err, 0, "saving merged commit")
+			return nil, wrapStatus(err, codes.InvalidArgument, "branch head")
+		}
+		lastID = resHead.GetId()
+		lastTree = resHead.GetInfo().GetTree()
+	}
+
+	head := &RefID{Id: req.GetId(), Name: req.GetBranch()}
+	if baseID.Equals(lastID) {
+		cid, err := v.cs.CreateFastForward(head, req.GetInfo(), req.GetInfo().GetTree())
+		if err != nil {
+			return nil, err
+		}
+		v.watcher.NotifyRef(head, cid)
+		return cid, nil
+	}
+
+	// Some transactions are committed during this transaction processing.  Should try to merge two commits.
+	vi, err := v.vs.Get(req.GetId())
+	if err != nil {
+		return nil, wrapStatus(err, codes.InvalidArgument, "volume")
+	}
+	m := &Merger{
+		Info:     req.GetInfo(),
+		Base:     baseTree,
+		Latest:   lastTree,
+		Current:  req.GetInfo().GetTree(),
+		Policy:   vi.GetMergePolicy(),
+		Contents: contents,
+		Context:  ctx,
+	}
+	start := time.Now()
+	mergedTree, err := m.Merge()
+	v.queue.ObserveMerge(req.GetId(), time.Since(start))
+	if err != nil {
+		var conflictErr *MergeConflictError
+		if errors.As(err, &conflictErr) {
+			return nil, conflictErr.Status("merge two commits")
 		}
-		return &CommitResponse{Id: mergedCid}, nil
+		return nil, wrapStatus(err, 0, "merge two commits")
 	}
+
+	// We succeed merge latest tree and current tree.  Save the current commit and the merged commit atomically.  The
+	// right parent of the merged commit is set to the current commit.
+	_, mergedCid, err := v.cs.CreateMerge(head, req.GetInfo(), &CommitInfo{
+		CreatedAt:    ptypes.TimestampNow(),
+		LeftParentID: lastID,
+		Tree:         mergedTree,
+	})
+	if err != nil {
+		return nil, err
+	}
+	v.watcher.NotifyRef(head, mergedCid)
+	return mergedCid, nil
 }
-func (v *localVolumeServer) commit(vid *VolumeID, info *CommitInfo) (*CommitID, error) {
-	cid, err := v.cs.Create(vid, info, info.GetTree())
+func (v *localVolumeServer) ImportCommit(ctx context.Context, req *ImportCommitRequest) (*ImportCommitResponse, error) {
+	if req.GetId().GetId().Empty() {
+		return nil, status.Error(codes.InvalidArgument, "id should not nil")
+	}
+	if req.GetInfo() == nil {
+		return nil, status.Error(codes.InvalidArgument, "info should not nil")
+	}
+	if req.GetInfo().GetTree() == nil {
+		return nil, status.Error(codes.InvalidArgument, "tree should not nil")
+	}
+
+	err := v.cs.Import(req.GetId(), req.GetInfo())
 	if err != nil {
+		if errors.Is(err, controller_db.ErrDupCommitID) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
 		if errors.Is(err, controller_db.ErrCrossVolumeCommit) ||
 			errors.Is(err, controller_db.ErrNotFoundVolume) ||
 			errors.Is(err, controller_db.ErrInvalidParentCommit) ||
@@ -354,9 +887,47 @@ func (v *localVolumeServer) commit(vid *VolumeID, info *CommitInfo) (*CommitID,
 		log.Printf("[ERROR] %+v", err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+
+	// The imported commit may not be the latest commit.
+	if latest, err := v.cs.Latest(req.GetId().GetId()); err == nil {
+		v.watcher.Notify(latest)
+	} else {
+		log.Printf("[ERROR] %+v", err)
+	}
+	return &ImportCommitResponse{}, nil
+}
+
+// commitFastForward creates new commit only if it is based on the head.  It never merges commits.
+func (v *localVolumeServer) commitFastForward(vid *VolumeID, branch string, info *CommitInfo) (*CommitID, error) {
+	cid, err := v.cs.CreateFastForward(&RefID{Id: vid, Name: branch}, info, info.GetTree())
+	if err != nil {
+		return nil, commitStatus(err)
+	}
+	v.watcher.NotifyRef(&RefID{Id: vid, Name: branch}, cid)
 	return cid, nil
 }
 
+// commitStatus converts the error returned by CommitStore to gRPC error.
+func commitStatus(err error) error {
+	if errors.Is(err, controller_db.ErrLatestCommitUpdated) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	if errors.Is(err, controller_db.ErrNotFoundRef) ||
+		errors.Is(err, controller_db.ErrNotBranch) ||
+		errors.Is(err, controller_db.ErrCrossVolumeCommit) ||
+		errors.Is(err, controller_db.ErrNotFoundVolume) ||
+		errors.Is(err, controller_db.ErrInvalidParentCommit) ||
+		errors.Is(err, controller_db.ErrInvalidTree) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, &controller_db.InputError{}) {
+		log.Printf("[CRITICAL] Missing error handling: %+v", err)
+		return status.Error(codes.Internal, err.Error())
+	}
+	log.Printf("[ERROR] %+v", err)
+	return status.Error(codes.Internal, err.Error())
+}
+
 // wrapStatus returns new gRPC error object with specified code and prefix.
 // If base error code is codes.Internal or code==0, the code argument is ignored and keeps original gRPC error code.
 func wrapStatus(err error, code codes.Code, prefix string) error {
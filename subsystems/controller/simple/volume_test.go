@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/golang/protobuf/ptypes"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/stretchr/testify/assert"
 	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
 	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
@@ -14,7 +15,9 @@ import (
 	"io"
 	"math/rand"
 	"sort"
+	"sync"
 	"testing"
+	"time"
 )
 
 func createVolume(t *testing.T, dial func() *grpc.ClientConn, ctx context.Context) *elton_v2.VolumeID {
@@ -269,8 +272,66 @@ func TestLocalVolumeServer_ListVolumes(t *testing.T) {
 			assert.Equal(t, 3, count)
 		})
 	})
-	t.Run("should_fail_when_next_parameter_is_specified", func(t *testing.T) {
-		// NOTE: local volume server is not supported of pagination feature.
+	t.Run("should_filter_volumes_by_label_selector", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			volumes := map[string]map[string]string{
+				"golden":  {"env": "prod", "tier": "base"},
+				"sandbox": {"env": "dev"},
+				"scratch": nil,
+			}
+			for name, labels := range volumes {
+				_, err := client.CreateVolume(ctx, &elton_v2.CreateVolumeRequest{
+					Info: &elton_v2.VolumeInfo{Name: name, Labels: labels},
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+			}
+
+			list := func(selector string) []string {
+				stream, err := client.ListVolumes(ctx, &elton_v2.ListVolumesRequest{
+					LabelSelector: selector,
+				})
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+				var names []string
+				for {
+					res, err := stream.Recv()
+					if err == io.EOF {
+						break
+					}
+					if !assert.NoError(t, err) {
+						t.FailNow()
+					}
+					names = append(names, res.GetInfo().GetName())
+				}
+				sort.Strings(names)
+				return names
+			}
+			assert.Equal(t, []string{"golden"}, list("env=prod"))
+			assert.Equal(t, []string{"golden", "sandbox"}, list("env"))
+			assert.Equal(t, []string{"sandbox", "scratch"}, list("env!=prod"))
+			assert.Equal(t, []string{"scratch"}, list("!env"))
+			assert.Equal(t, []string{"golden"}, list("env, tier==base"))
+			assert.Empty(t, list("env=prod,!tier"))
+		})
+	})
+	t.Run("should_fail_when_label_selector_is_invalid", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			stream, err := client.ListVolumes(ctx, &elton_v2.ListVolumesRequest{
+				LabelSelector: "env=prod,,",
+			})
+			assert.NoError(t, err)
+
+			res, err := stream.Recv()
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, res)
+		})
+	})
+	t.Run("should_fail_when_next_parameter_is_invalid", func(t *testing.T) {
 		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
 			client := elton_v2.NewVolumeServiceClient(dial())
 
@@ -281,11 +342,70 @@ func TestLocalVolumeServer_ListVolumes(t *testing.T) {
 			assert.NotNil(t, stream)
 
 			res, err := stream.Recv()
-			assert.Equal(t, status.Convert(err).Message(), "next parameter is not supported")
+			assert.Contains(t, status.Convert(err).Message(), "invalid page token")
 			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
 			assert.Nil(t, res)
 		})
 	})
+	t.Run("should_page_through_all_volumes", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			var expected []string
+			for i := 0; i < 7; i++ {
+				expected = append(expected, fmt.Sprintf("volume-%d", i))
+			}
+			if _, err := createVolumesByName(t, client, ctx, expected); err != nil {
+				return
+			}
+
+			var names []string
+			var pages int
+			next := ""
+			for {
+				stream, err := client.ListVolumes(ctx, &elton_v2.ListVolumesRequest{
+					Limit: 3,
+					Next:  next,
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+				pages++
+				next = ""
+				for {
+					res, err := stream.Recv()
+					if err == io.EOF {
+						break
+					}
+					if !assert.NoError(t, err) {
+						return
+					}
+					// Only the last response may have the token.
+					assert.Empty(t, next)
+					next = res.GetNext()
+					names = append(names, res.GetInfo().GetName())
+				}
+				if next == "" {
+					break
+				}
+				// Volumes created after the first page should not break the token.
+				if pages == 1 {
+					if _, err := createVolumesByName(t, client, ctx, []string{"volume-new"}); err != nil {
+						return
+					}
+				}
+			}
+			// The new volume may or may not be listed.  It depends on the generated VolumeID.
+			var existing []string
+			for _, name := range names {
+				if name != "volume-new" {
+					existing = append(existing, name)
+				}
+			}
+			sort.Strings(existing)
+			assert.Equal(t, expected, existing)
+			assert.GreaterOrEqual(t, pages, 3)
+		})
+	})
 }
 
 func TestLocalVolumeServer_InspectVolume(t *testing.T) {
@@ -373,6 +493,56 @@ func TestLocalVolumeServer_InspectVolume(t *testing.T) {
 	})
 }
 
+func TestLocalVolumeServer_ForkVolume(t *testing.T) {
+	t.Run("should_success_when_source_is_specified_by_ref", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume, commits := createCommits(t, dial, ctx, "golden", []*elton_v2.CommitRequest{
+				{
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				},
+			})
+			cc := elton_v2.NewCommitServiceClient(dial())
+			_, err := cc.CreateRef(ctx, &elton_v2.CreateRefRequest{
+				Id:  &elton_v2.RefID{Id: volume, Name: "v1"},
+				Ref: &elton_v2.Ref{Type: elton_v2.RefType_Tag, Commit: commits[0]},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			vc := elton_v2.NewVolumeServiceClient(dial())
+			res, err := vc.ForkVolume(ctx, &elton_v2.ForkVolumeRequest{
+				Src:  &elton_v2.CommitID{Id: volume, Ref: "v1"},
+				Info: &elton_v2.VolumeInfo{Name: "sandbox"},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			lres, err := cc.GetLastCommit(ctx, &elton_v2.GetLastCommitRequest{VolumeId: res.GetId()})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, res.GetCommit().GetNumber(), lres.GetId().GetNumber())
+			assert.Equal(t, commits[0].GetNumber(), lres.GetInfo().GetForkedFrom().GetNumber())
+		})
+	})
+	t.Run("should_fail_when_source_is_not_found", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume := createVolume(t, dial, ctx)
+			client := elton_v2.NewVolumeServiceClient(dial())
+			res, err := client.ForkVolume(ctx, &elton_v2.ForkVolumeRequest{
+				Src:  &elton_v2.CommitID{Id: volume, Number: 1},
+				Info: &elton_v2.VolumeInfo{Name: "sandbox"},
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			assert.Nil(t, res)
+		})
+	})
+}
+
 func TestLocalVolumeServer_GetLastCommit(t *testing.T) {
 	t.Run("should_success_when_valid_volume_id", func(t *testing.T) {
 		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
@@ -426,7 +596,7 @@ func TestLocalVolumeServer_GetLastCommit(t *testing.T) {
 }
 
 func TestLocalVolumeServer_ListCommits(t *testing.T) {
-	t.Run("should_fail_when_requesting_with_pagination", func(t *testing.T) {
+	t.Run("should_fail_when_next_parameter_is_invalid", func(t *testing.T) {
 		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
 			client := elton_v2.NewCommitServiceClient(dial())
 			stream, err := client.ListCommits(ctx, &elton_v2.ListCommitsRequest{
@@ -519,6 +689,248 @@ func TestLocalVolumeServer_ListCommits(t *testing.T) {
 			assert.Nil(t, res)
 		})
 	})
+	t.Run("should_page_through_all_commits", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			var reqs []*elton_v2.CommitRequest
+			for i := 0; i < 4; i++ {
+				reqs = append(reqs, &elton_v2.CommitRequest{
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				})
+			}
+			volume, commits := createCommits(t, dial, ctx, "test-volume", reqs)
+
+			client := elton_v2.NewCommitServiceClient(dial())
+			var numbers []uint64
+			var tokens []string
+			next := ""
+			for {
+				stream, err := client.ListCommits(ctx, &elton_v2.ListCommitsRequest{
+					Id:    volume,
+					Limit: 2,
+					Next:  next,
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+				next = ""
+				for {
+					res, err := stream.Recv()
+					if err == io.EOF {
+						break
+					}
+					if !assert.NoError(t, err) {
+						return
+					}
+					next = res.GetNext()
+					numbers = append(numbers, res.GetId().GetNumber())
+				}
+				if next == "" {
+					break
+				}
+				tokens = append(tokens, next)
+			}
+			// 4 commits and the first empty commit.
+			assert.Len(t, numbers, 5)
+			assert.Len(t, tokens, 2)
+			for i := range commits {
+				assert.Equal(t, commits[len(commits)-1-i].GetNumber(), numbers[i])
+			}
+
+			// The token should be rejected if it is used for other volume.
+			other := createVolume(t, dial, ctx)
+			stream, err := client.ListCommits(ctx, &elton_v2.ListCommitsRequest{
+				Id:   other,
+				Next: tokens[0],
+			})
+			assert.NoError(t, err)
+			_, err = stream.Recv()
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+}
+
+func TestLocalVolumeServer_ListCommits_DAG(t *testing.T) {
+	// Import following commits.  Numbers in parentheses are created time.
+	//   1(t1) -- 2(t3) -- 3(t4) -- 5(t5)
+	//              \               /
+	//               `-- 4(t2) ---'
+	// Commit 4 is older than commit 2 although it is a child of commit 2.
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return base.Add(time.Duration(minutes) * time.Minute)
+	}
+	importDAG := func(t *testing.T, ctx context.Context, dial func() *grpc.ClientConn) *elton_v2.VolumeID {
+		vc := elton_v2.NewVolumeServiceClient(dial())
+		cc := elton_v2.NewCommitServiceClient(dial())
+		vid := &elton_v2.VolumeID{Id: "dag"}
+		_, err := vc.ImportVolume(ctx, &elton_v2.ImportVolumeRequest{
+			Id:   vid,
+			Info: &elton_v2.VolumeInfo{Name: "dag"},
+		})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		cid := func(n uint64) *elton_v2.CommitID {
+			if n == 0 {
+				return nil
+			}
+			return &elton_v2.CommitID{Id: vid, Number: n}
+		}
+		for _, c := range []struct {
+			number, left, right uint64
+			minutes             int
+		}{
+			{1, 0, 0, 1},
+			{2, 1, 0, 3},
+			{3, 2, 0, 4},
+			{4, 2, 0, 2},
+			{5, 3, 4, 5},
+		} {
+			createdAt, _ := ptypes.TimestampProto(at(c.minutes))
+			_, err := cc.ImportCommit(ctx, &elton_v2.ImportCommitRequest{
+				Id: cid(c.number),
+				Info: &elton_v2.CommitInfo{
+					CreatedAt:     createdAt,
+					LeftParentID:  cid(c.left),
+					RightParentID: cid(c.right),
+					Tree:          createEmptyTree(),
+				},
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+		}
+		return vid
+	}
+	list := func(t *testing.T, client elton_v2.CommitServiceClient, ctx context.Context, req *elton_v2.ListCommitsRequest) ([]uint64, string) {
+		stream, err := client.ListCommits(ctx, req)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		var numbers []uint64
+		next := ""
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+			numbers = append(numbers, res.GetId().GetNumber())
+			next = res.GetNext()
+		}
+		return numbers, next
+	}
+	timestamp := func(minutes int) *tspb.Timestamp {
+		ts, _ := ptypes.TimestampProto(at(minutes))
+		return ts
+	}
+
+	utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+		vid := importDAG(t, ctx, dial)
+		client := elton_v2.NewCommitServiceClient(dial())
+		tests := []struct {
+			name string
+			req  *elton_v2.ListCommitsRequest
+			want []uint64
+		}{
+			{
+				name: "first_parent",
+				req:  &elton_v2.ListCommitsRequest{},
+				want: []uint64{5, 3, 2, 1},
+			}, {
+				name: "topological",
+				req:  &elton_v2.ListCommitsRequest{Order: elton_v2.ListCommitsOrder_Topological},
+				want: []uint64{5, 3, 4, 2, 1},
+			}, {
+				name: "date",
+				req:  &elton_v2.ListCommitsRequest{Order: elton_v2.ListCommitsOrder_Date},
+				want: []uint64{5, 3, 2, 4, 1},
+			}, {
+				name: "merges_only",
+				req: &elton_v2.ListCommitsRequest{
+					Order:  elton_v2.ListCommitsOrder_Topological,
+					Merges: elton_v2.MergeFilter_MergesOnly,
+				},
+				want: []uint64{5},
+			}, {
+				name: "no_merges",
+				req: &elton_v2.ListCommitsRequest{
+					Order:  elton_v2.ListCommitsOrder_Topological,
+					Merges: elton_v2.MergeFilter_NoMerges,
+				},
+				want: []uint64{3, 4, 2, 1},
+			}, {
+				name: "ancestor_of",
+				req: &elton_v2.ListCommitsRequest{
+					Order:      elton_v2.ListCommitsOrder_Topological,
+					AncestorOf: &elton_v2.CommitID{Id: vid, Number: 4},
+				},
+				want: []uint64{4, 2, 1},
+			}, {
+				name: "descendant_of",
+				req: &elton_v2.ListCommitsRequest{
+					Order:        elton_v2.ListCommitsOrder_Date,
+					DescendantOf: &elton_v2.CommitID{Id: vid, Number: 2},
+				},
+				want: []uint64{5, 3, 2, 4},
+			}, {
+				name: "since",
+				req: &elton_v2.ListCommitsRequest{
+					Order: elton_v2.ListCommitsOrder_Date,
+					Since: timestamp(3),
+				},
+				want: []uint64{5, 3, 2},
+			}, {
+				name: "until",
+				req: &elton_v2.ListCommitsRequest{
+					Order: elton_v2.ListCommitsOrder_Date,
+					Until: timestamp(2),
+				},
+				want: []uint64{4, 1},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.req.Id = vid
+				got, next := list(t, client, ctx, tt.req)
+				assert.Equal(t, tt.want, got)
+				assert.Empty(t, next)
+			})
+		}
+
+		t.Run("should_page_through_dag", func(t *testing.T) {
+			var got []uint64
+			next := ""
+			for {
+				numbers, token := list(t, client, ctx, &elton_v2.ListCommitsRequest{
+					Id:    vid,
+					Limit: 2,
+					Next:  next,
+					Order: elton_v2.ListCommitsOrder_Topological,
+				})
+				got = append(got, numbers...)
+				if token == "" {
+					break
+				}
+				next = token
+			}
+			assert.Equal(t, []uint64{5, 3, 4, 2, 1}, got)
+		})
+		t.Run("should_fail_when_filter_commit_is_not_found", func(t *testing.T) {
+			stream, err := client.ListCommits(ctx, &elton_v2.ListCommitsRequest{
+				Id:         vid,
+				AncestorOf: &elton_v2.CommitID{Id: vid, Number: 100},
+			})
+			assert.NoError(t, err)
+			_, err = stream.Recv()
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
 }
 
 func TestLocalVolumeServer_Commit(t *testing.T) {
@@ -573,4 +985,714 @@ func TestLocalVolumeServer_Commit(t *testing.T) {
 			assert.Nil(t, res)
 		})
 	})
+	t.Run("should_fail_without_merge_when_no_merge_is_specified", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				},
+			})
+			client := elton_v2.NewCommitServiceClient(dial())
+			first, err := client.GetCommit(ctx, &elton_v2.GetCommitRequest{Id: commits[0]})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// The parent of commits[0] is no longer the latest commit.
+			res, err := client.Commit(ctx, &elton_v2.CommitRequest{
+				Id: volume,
+				Info: &elton_v2.CommitInfo{
+					CreatedAt:    ptypes.TimestampNow(),
+					LeftParentID: first.GetInfo().GetLeftParentID(),
+					Tree:         createEmptyTree(),
+				},
+				NoMerge: true,
+			})
+			assert.Equal(t, codes.Aborted, status.Code(err))
+			assert.Nil(t, res)
+			lres, err := client.GetLastCommit(ctx, &elton_v2.GetLastCommitRequest{VolumeId: volume})
+			assert.NoError(t, err)
+			assert.Equal(t, commits[0].GetNumber(), lres.GetId().GetNumber())
+
+			// Commit based on the latest commit should succeed.
+			res, err = client.Commit(ctx, &elton_v2.CommitRequest{
+				Id: volume,
+				Info: &elton_v2.CommitInfo{
+					CreatedAt:    ptypes.TimestampNow(),
+					LeftParentID: commits[0],
+					Tree:         createEmptyTree(),
+				},
+				NoMerge: true,
+			})
+			assert.NoError(t, err)
+			lres, err = client.GetLastCommit(ctx, &elton_v2.GetLastCommitRequest{VolumeId: volume})
+			assert.NoError(t, err)
+			assert.Equal(t, res.GetId().GetNumber(), lres.GetId().GetNumber())
+		})
+	})
+	t.Run("should_return_conflict_details", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			treeWithFile := func(contents string) *elton_v2.Tree {
+				tree := createEmptyTree()
+				tree.Inodes[1].Entries = map[string]uint64{"a": 2}
+				tree.Inodes[2] = &elton_v2.File{
+					ContentRef: &elton_v2.FileContentRef{Key: &elton_v2.ObjectKey{Id: contents}},
+				}
+				return tree
+			}
+			volume, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      treeWithFile("latest"),
+					},
+				},
+			})
+			client := elton_v2.NewCommitServiceClient(dial())
+			first, err := client.GetCommit(ctx, &elton_v2.GetCommitRequest{Id: commits[0]})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// Both commits create "/a".
+			_, err = client.Commit(ctx, &elton_v2.CommitRequest{
+				Id: volume,
+				Info: &elton_v2.CommitInfo{
+					CreatedAt:    ptypes.TimestampNow(),
+					LeftParentID: first.GetInfo().GetLeftParentID(),
+					Tree:         treeWithFile("current"),
+				},
+			})
+			assert.Equal(t, codes.Aborted, status.Code(err))
+			conflicts := elton_v2.CommitConflictsOf(err)
+			if !assert.NotNil(t, conflicts) || !assert.Len(t, conflicts.GetConflicts(), 1) {
+				return
+			}
+			c := conflicts.GetConflicts()[0]
+			assert.Equal(t, "add-add", c.GetType())
+			assert.Equal(t, uint64(1), c.GetIno())
+			assert.Equal(t, "a", c.GetName())
+			assert.Equal(t, []string{"/a"}, c.GetLatestPaths())
+			assert.Equal(t, []string{"/a"}, c.GetCurrentPaths())
+			assert.Equal(t, "latest", c.GetLatestFile().GetContentRef().GetKey().GetId())
+			assert.Equal(t, "current", c.GetCurrentFile().GetContentRef().GetKey().GetId())
+		})
+	})
+	t.Run("should_merge_concurrent_commits_without_losing_changes", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				},
+			})
+			client := elton_v2.NewCommitServiceClient(dial())
+
+			// All commits are based on the same commit and create different files.
+			const n = 8
+			var wg sync.WaitGroup
+			errs := make([]error, n)
+			for i := 0; i < n; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					tree := createEmptyTree()
+					tree.Inodes[1].Entries = map[string]uint64{fmt.Sprintf("file%d", i): 2}
+					tree.Inodes[2] = &elton_v2.File{
+						ContentRef: &elton_v2.FileContentRef{Key: &elton_v2.ObjectKey{Id: fmt.Sprintf("obj%d", i)}},
+					}
+					_, errs[i] = client.Commit(ctx, &elton_v2.CommitRequest{
+						Id: volume,
+						Info: &elton_v2.CommitInfo{
+							CreatedAt:    ptypes.TimestampNow(),
+							LeftParentID: commits[0],
+							Tree:         tree,
+						},
+					})
+				}(i)
+			}
+			wg.Wait()
+			for _, err := range errs {
+				assert.NoError(t, err)
+			}
+
+			res, err := client.GetLastCommit(ctx, &elton_v2.GetLastCommitRequest{VolumeId: volume})
+			if !assert.NoError(t, err) {
+				return
+			}
+			tree := res.GetInfo().GetTree()
+			assert.Len(t, tree.GetInodes()[tree.GetRootIno()].GetEntries(), n)
+
+			// Commits are serialized, so the head is never moved during the merge.
+			qres, err := client.CommitQueueStatus(ctx, &elton_v2.CommitQueueStatusRequest{Id: volume})
+			if assert.NoError(t, err) && assert.Len(t, qres.GetQueues(), 1) {
+				st := qres.GetQueues()[0]
+				assert.Equal(t, volume.GetId(), st.GetId().GetId())
+				assert.Equal(t, uint64(0), st.GetDepth())
+				assert.True(t, st.GetMaxDepth() >= 1)
+				assert.Equal(t, uint64(n+1), st.GetCommits())
+				assert.Equal(t, uint64(n-1), st.GetMerges())
+				assert.Equal(t, uint64(0), st.GetRetries())
+				assert.Equal(t, uint64(0), st.GetFailures())
+			}
+		})
+	})
+}
+
+func TestLocalVolumeServer_UpdateVolume(t *testing.T) {
+	t.Run("should_update_only_specified_fields", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			cres, err := client.CreateVolume(ctx, &elton_v2.CreateVolumeRequest{
+				Info: &elton_v2.VolumeInfo{
+					Name:        "foo",
+					Description: "old description",
+					Owner:       "alice",
+				},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			res, err := client.UpdateVolume(ctx, &elton_v2.UpdateVolumeRequest{
+				Id: cres.GetId(),
+				Info: &elton_v2.VolumeInfo{
+					Name:        "bar",
+					Labels:      map[string]string{"env": "dev"},
+					Description: "ignored",
+				},
+				UpdateMask: []string{"name", "labels"},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "bar", res.GetInfo().GetName())
+			assert.Equal(t, map[string]string{"env": "dev"}, res.GetInfo().GetLabels())
+			assert.Equal(t, "old description", res.GetInfo().GetDescription())
+			assert.Equal(t, "alice", res.GetInfo().GetOwner())
+			assert.NotNil(t, res.GetInfo().GetCreatedAt())
+
+			// Name index should be updated.
+			ires, err := client.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{Name: "bar"})
+			assert.NoError(t, err)
+			assert.Equal(t, cres.GetId().GetId(), ires.GetId().GetId())
+			_, err = client.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{Name: "foo"})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("should_fail_when_new_name_is_duplicated", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			ids, err := createVolumesByName(t, client, ctx, []string{"foo", "bar"})
+			if err != nil {
+				return
+			}
+
+			res, err := client.UpdateVolume(ctx, &elton_v2.UpdateVolumeRequest{
+				Id:         ids[0],
+				Info:       &elton_v2.VolumeInfo{Name: "bar"},
+				UpdateMask: []string{"name"},
+			})
+			assert.Equal(t, codes.AlreadyExists, status.Code(err))
+			assert.Nil(t, res)
+		})
+	})
+	t.Run("should_fail_when_update_mask_is_invalid", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			ids, err := createVolumesByName(t, client, ctx, []string{"foo"})
+			if err != nil {
+				return
+			}
+
+			_, err = client.UpdateVolume(ctx, &elton_v2.UpdateVolumeRequest{
+				Id:   ids[0],
+				Info: &elton_v2.VolumeInfo{Name: "bar"},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			_, err = client.UpdateVolume(ctx, &elton_v2.UpdateVolumeRequest{
+				Id:         ids[0],
+				Info:       &elton_v2.VolumeInfo{},
+				UpdateMask: []string{"createdAt"},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			_, err = client.UpdateVolume(ctx, &elton_v2.UpdateVolumeRequest{
+				Id:         ids[0],
+				Info:       &elton_v2.VolumeInfo{Labels: map[string]string{"bad key": "x"}},
+				UpdateMask: []string{"labels"},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			_, err = client.UpdateVolume(ctx, &elton_v2.UpdateVolumeRequest{
+				Id:         ids[0],
+				Info:       &elton_v2.VolumeInfo{MergePolicy: 100},
+				UpdateMask: []string{"mergePolicy"},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+	t.Run("should_update_merge_policy", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			ids, err := createVolumesByName(t, client, ctx, []string{"foo"})
+			if err != nil {
+				return
+			}
+
+			res, err := client.UpdateVolume(ctx, &elton_v2.UpdateVolumeRequest{
+				Id:         ids[0],
+				Info:       &elton_v2.VolumeInfo{MergePolicy: elton_v2.MergePolicy_KeepBoth},
+				UpdateMask: []string{"mergePolicy"},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, elton_v2.MergePolicy_KeepBoth, res.GetInfo().GetMergePolicy())
+			assert.Equal(t, "foo", res.GetInfo().GetName())
+		})
+	})
+	t.Run("should_fail_when_volume_is_not_found", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			res, err := client.UpdateVolume(ctx, &elton_v2.UpdateVolumeRequest{
+				Id:         &elton_v2.VolumeID{Id: "not-found"},
+				Info:       &elton_v2.VolumeInfo{Owner: "bob"},
+				UpdateMask: []string{"owner"},
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			assert.Nil(t, res)
+		})
+	})
+}
+
+func TestLocalVolumeServer_ImportVolume(t *testing.T) {
+	t.Run("should_success_when_importing_new_volume", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			vid := &elton_v2.VolumeID{Id: "imported"}
+			_, err := client.ImportVolume(ctx, &elton_v2.ImportVolumeRequest{
+				Id:   vid,
+				Info: &elton_v2.VolumeInfo{Name: "foo"},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			res, err := client.InspectVolume(ctx, &elton_v2.InspectVolumeRequest{Name: "foo"})
+			assert.NoError(t, err)
+			assert.Equal(t, vid.GetId(), res.GetId().GetId())
+
+			// Imported volume has no commits.
+			cc := elton_v2.NewCommitServiceClient(dial())
+			_, err = cc.GetLastCommit(ctx, &elton_v2.GetLastCommitRequest{VolumeId: vid})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("should_fail_when_volume_id_is_duplicated", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			vid := &elton_v2.VolumeID{Id: "imported"}
+			_, err := client.ImportVolume(ctx, &elton_v2.ImportVolumeRequest{
+				Id:   vid,
+				Info: &elton_v2.VolumeInfo{Name: "foo"},
+			})
+			assert.NoError(t, err)
+
+			res, err := client.ImportVolume(ctx, &elton_v2.ImportVolumeRequest{
+				Id:   vid,
+				Info: &elton_v2.VolumeInfo{Name: "bar"},
+			})
+			assert.Equal(t, codes.AlreadyExists, status.Code(err))
+			assert.Nil(t, res)
+		})
+	})
+	t.Run("should_fail_when_id_is_not_specified", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			res, err := client.ImportVolume(ctx, &elton_v2.ImportVolumeRequest{
+				Info: &elton_v2.VolumeInfo{Name: "foo"},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, res)
+		})
+	})
+}
+
+func TestLocalVolumeServer_ImportCommit(t *testing.T) {
+	t.Run("should_keep_commit_ids", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			vc := elton_v2.NewVolumeServiceClient(dial())
+			cc := elton_v2.NewCommitServiceClient(dial())
+			vid := &elton_v2.VolumeID{Id: "imported"}
+			_, err := vc.ImportVolume(ctx, &elton_v2.ImportVolumeRequest{
+				Id:   vid,
+				Info: &elton_v2.VolumeInfo{Name: "foo"},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			first := &elton_v2.CommitID{Id: vid, Number: 100}
+			second := &elton_v2.CommitID{Id: vid, Number: 200}
+			_, err = cc.ImportCommit(ctx, &elton_v2.ImportCommitRequest{
+				Id: first,
+				Info: &elton_v2.CommitInfo{
+					CreatedAt: ptypes.TimestampNow(),
+					Tree:      createEmptyTree(),
+				},
+			})
+			assert.NoError(t, err)
+			_, err = cc.ImportCommit(ctx, &elton_v2.ImportCommitRequest{
+				Id: second,
+				Info: &elton_v2.CommitInfo{
+					CreatedAt:    ptypes.TimestampNow(),
+					LeftParentID: first,
+					Tree:         createEmptyTree(),
+				},
+			})
+			assert.NoError(t, err)
+
+			res, err := cc.GetLastCommit(ctx, &elton_v2.GetLastCommitRequest{VolumeId: vid})
+			assert.NoError(t, err)
+			assert.Equal(t, second.GetNumber(), res.GetId().GetNumber())
+		})
+	})
+	t.Run("should_fail_when_commit_is_duplicated", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			vc := elton_v2.NewVolumeServiceClient(dial())
+			cc := elton_v2.NewCommitServiceClient(dial())
+			vid := &elton_v2.VolumeID{Id: "imported"}
+			_, err := vc.ImportVolume(ctx, &elton_v2.ImportVolumeRequest{
+				Id:   vid,
+				Info: &elton_v2.VolumeInfo{Name: "foo"},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			req := &elton_v2.ImportCommitRequest{
+				Id: &elton_v2.CommitID{Id: vid, Number: 100},
+				Info: &elton_v2.CommitInfo{
+					CreatedAt: ptypes.TimestampNow(),
+					Tree:      createEmptyTree(),
+				},
+			}
+			_, err = cc.ImportCommit(ctx, req)
+			assert.NoError(t, err)
+			res, err := cc.ImportCommit(ctx, req)
+			assert.Equal(t, codes.AlreadyExists, status.Code(err))
+			assert.Nil(t, res)
+		})
+	})
+	t.Run("should_fail_when_volume_is_not_found", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			cc := elton_v2.NewCommitServiceClient(dial())
+			res, err := cc.ImportCommit(ctx, &elton_v2.ImportCommitRequest{
+				Id: &elton_v2.CommitID{Id: &elton_v2.VolumeID{Id: "not-found"}, Number: 100},
+				Info: &elton_v2.CommitInfo{
+					CreatedAt: ptypes.TimestampNow(),
+					Tree:      createEmptyTree(),
+				},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, res)
+		})
+	})
+}
+
+func TestLocalVolumeServer_PruneVolume(t *testing.T) {
+	// importOldCommits imports commits that created 10 days ago.
+	importOldCommits := func(t *testing.T, ctx context.Context, dial func() *grpc.ClientConn, n int) (*elton_v2.VolumeID, []*elton_v2.CommitID) {
+		vc := elton_v2.NewVolumeServiceClient(dial())
+		cc := elton_v2.NewCommitServiceClient(dial())
+		vid := &elton_v2.VolumeID{Id: "imported"}
+		_, err := vc.ImportVolume(ctx, &elton_v2.ImportVolumeRequest{
+			Id:   vid,
+			Info: &elton_v2.VolumeInfo{Name: "foo"},
+		})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		var ids []*elton_v2.CommitID
+		var parent *elton_v2.CommitID
+		for i := 1; i <= n; i++ {
+			cid := &elton_v2.CommitID{Id: vid, Number: uint64(i)}
+			createdAt, _ := ptypes.TimestampProto(time.Now().Add(-10*24*time.Hour + time.Duration(i)*time.Second))
+			_, err := cc.ImportCommit(ctx, &elton_v2.ImportCommitRequest{
+				Id: cid,
+				Info: &elton_v2.CommitInfo{
+					CreatedAt:    createdAt,
+					LeftParentID: parent,
+					Tree:         createEmptyTree(),
+				},
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+			ids = append(ids, cid)
+			parent = cid
+		}
+		return vid, ids
+	}
+
+	t.Run("should_keep_all_commits_without_policy", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			vid, _ := importOldCommits(t, ctx, dial, 5)
+			client := elton_v2.NewVolumeServiceClient(dial())
+			res, err := client.PruneVolume(ctx, &elton_v2.PruneVolumeRequest{Id: vid})
+			assert.NoError(t, err)
+			assert.Empty(t, res.GetDeleted())
+		})
+	})
+	t.Run("should_delete_expired_commits", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			vid, ids := importOldCommits(t, ctx, dial, 5)
+			client := elton_v2.NewVolumeServiceClient(dial())
+			_, err := client.SetRetentionPolicy(ctx, &elton_v2.SetRetentionPolicyRequest{
+				Id:     vid,
+				Policy: &elton_v2.RetentionPolicy{KeepLast: 2},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// Dry run should not delete commits.
+			res, err := client.PruneVolume(ctx, &elton_v2.PruneVolumeRequest{Id: vid, DryRun: true})
+			assert.NoError(t, err)
+			assert.Len(t, res.GetDeleted(), 3)
+			res, err = client.PruneVolume(ctx, &elton_v2.PruneVolumeRequest{Id: vid})
+			assert.NoError(t, err)
+			assert.Len(t, res.GetDeleted(), 3)
+
+			// Remaining commits should be listed.
+			cc := elton_v2.NewCommitServiceClient(dial())
+			stream, err := cc.ListCommits(ctx, &elton_v2.ListCommitsRequest{Id: vid})
+			if !assert.NoError(t, err) {
+				return
+			}
+			var numbers []uint64
+			for {
+				res, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+				numbers = append(numbers, res.GetId().GetNumber())
+			}
+			assert.Equal(t, []uint64{ids[4].GetNumber(), ids[3].GetNumber()}, numbers)
+		})
+	})
+	t.Run("should_fail_when_volume_is_not_found", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewVolumeServiceClient(dial())
+			res, err := client.PruneVolume(ctx, &elton_v2.PruneVolumeRequest{
+				Id: &elton_v2.VolumeID{Id: "not-found"},
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			assert.Nil(t, res)
+		})
+	})
+}
+
+func TestLocalVolumeServer_Refs(t *testing.T) {
+	t.Run("should_commit_on_branch", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				},
+			})
+			client := elton_v2.NewCommitServiceClient(dial())
+			_, err := client.CreateRef(ctx, &elton_v2.CreateRefRequest{
+				Id:  &elton_v2.RefID{Id: volume, Name: "topic"},
+				Ref: &elton_v2.Ref{Type: elton_v2.RefType_Branch, Commit: commits[0]},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// Create a commit based on the branch.
+			res, err := client.Commit(ctx, &elton_v2.CommitRequest{
+				Id: volume,
+				Info: &elton_v2.CommitInfo{
+					CreatedAt:    ptypes.TimestampNow(),
+					LeftParentID: &elton_v2.CommitID{Id: volume, Ref: "topic"},
+					Tree:         createEmptyTree(),
+				},
+				Branch: "topic",
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// The branch should point to the new commit.
+			gres, err := client.GetCommit(ctx, &elton_v2.GetCommitRequest{
+				Id: &elton_v2.CommitID{Id: volume, Ref: "topic"},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, res.GetId().GetNumber(), gres.GetId().GetNumber())
+			assert.Equal(t, commits[0].GetNumber(), gres.GetInfo().GetLeftParentID().GetNumber())
+		})
+	})
+	t.Run("should_not_move_tag", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				}, {
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				},
+			})
+			client := elton_v2.NewCommitServiceClient(dial())
+			tag := &elton_v2.RefID{Id: volume, Name: "v1"}
+			_, err := client.CreateRef(ctx, &elton_v2.CreateRefRequest{
+				Id:  tag,
+				Ref: &elton_v2.Ref{Type: elton_v2.RefType_Tag, Commit: commits[0]},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_, err = client.MoveRef(ctx, &elton_v2.MoveRefRequest{Id: tag, Commit: commits[1]})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			_, err = client.Commit(ctx, &elton_v2.CommitRequest{
+				Id: volume,
+				Info: &elton_v2.CommitInfo{
+					CreatedAt:    ptypes.TimestampNow(),
+					LeftParentID: commits[1],
+					Tree:         createEmptyTree(),
+				},
+				Branch: "v1",
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+	t.Run("should_fail_when_ref_is_not_found", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume := createVolume(t, dial, ctx)
+			client := elton_v2.NewCommitServiceClient(dial())
+			res, err := client.GetCommit(ctx, &elton_v2.GetCommitRequest{
+				Id: &elton_v2.CommitID{Id: volume, Ref: "not-found"},
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			assert.Nil(t, res)
+			_, err = client.DeleteRef(ctx, &elton_v2.DeleteRefRequest{
+				Id: &elton_v2.RefID{Id: volume, Name: "not-found"},
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+}
+func TestLocalVolumeServer_UpdateRef(t *testing.T) {
+	t.Run("should_update_latest_when_expected_matches", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				}, {
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				},
+			})
+			client := elton_v2.NewCommitServiceClient(dial())
+
+			// Expected commit is outdated.
+			_, err := client.UpdateRef(ctx, &elton_v2.UpdateRefRequest{
+				Id:       volume,
+				Expected: commits[0],
+				New:      commits[0],
+			})
+			assert.Equal(t, codes.Aborted, status.Code(err))
+
+			_, err = client.UpdateRef(ctx, &elton_v2.UpdateRefRequest{
+				Id:       volume,
+				Expected: commits[1],
+				New:      commits[0],
+			})
+			assert.NoError(t, err)
+			lres, err := client.GetLastCommit(ctx, &elton_v2.GetLastCommitRequest{VolumeId: volume})
+			assert.NoError(t, err)
+			assert.Equal(t, commits[0].GetNumber(), lres.GetId().GetNumber())
+		})
+	})
+	t.Run("should_update_branch_when_expected_matches", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				}, {
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				},
+			})
+			client := elton_v2.NewCommitServiceClient(dial())
+			_, err := client.CreateRef(ctx, &elton_v2.CreateRefRequest{
+				Id:  &elton_v2.RefID{Id: volume, Name: "topic"},
+				Ref: &elton_v2.Ref{Type: elton_v2.RefType_Branch, Commit: commits[0]},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// Expected commit can be specified by ref name.
+			_, err = client.UpdateRef(ctx, &elton_v2.UpdateRefRequest{
+				Id:       volume,
+				Branch:   "topic",
+				Expected: &elton_v2.CommitID{Id: volume, Ref: "topic"},
+				New:      commits[1],
+			})
+			assert.NoError(t, err)
+			rres, err := client.GetRef(ctx, &elton_v2.GetRefRequest{
+				Id: &elton_v2.RefID{Id: volume, Name: "topic"},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, commits[1].GetNumber(), rres.GetRef().GetCommit().GetNumber())
+		})
+	})
+	t.Run("should_fail_when_branch_not_found", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			volume, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				{
+					Info: &elton_v2.CommitInfo{
+						CreatedAt: ptypes.TimestampNow(),
+						Tree:      createEmptyTree(),
+					},
+				},
+			})
+			client := elton_v2.NewCommitServiceClient(dial())
+			_, err := client.UpdateRef(ctx, &elton_v2.UpdateRefRequest{
+				Id:       volume,
+				Branch:   "not-found",
+				Expected: commits[0],
+				New:      commits[0],
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
 }
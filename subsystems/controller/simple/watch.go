@@ -0,0 +1,249 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	. "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	controller_db "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"sync"
+)
+
+// Maximum number of commits replayed by WatchCommits().  If more commits are created after since, only the latest
+// commit is sent.
+const watchReplayLimit = 1000
+
+func (v *localVolumeServer) WatchCommits(req *WatchCommitsRequest, srv CommitService_WatchCommitsServer) error {
+	vid := req.GetId()
+	if vid.GetId() == "" {
+		return status.Error(codes.InvalidArgument, "id should not nil")
+	}
+	last := req.GetSince()
+	if last != nil && last.GetId().GetId() != vid.GetId() {
+		return status.Error(codes.InvalidArgument, "since is not a commit of the volume")
+	}
+	head := &RefID{Id: vid, Name: req.GetBranch()}
+
+	// Subscribe before getting the head to avoid missing commits created between them.
+	sub := v.watcher.SubscribeRef(head)
+	defer v.watcher.Unsubscribe(sub)
+
+	ok, err := v.vs.Exists(vid)
+	if err != nil {
+		log.Println("ERROR:", err)
+		return status.Error(codes.Internal, "database error")
+	}
+	if !ok {
+		return status.Error(codes.NotFound, controller_db.ErrNotFoundVolume.Wrap(fmt.Errorf("id=%s", vid.GetId())).Error())
+	}
+
+	for {
+		// Notifications may arrive out of order.  The head is always read from the database.
+		cid, err := v.watchedHead(head)
+		if err != nil {
+			return err
+		}
+		commits, err := v.replayCommits(last, cid)
+		if err != nil {
+			return err
+		}
+		for _, cid := range commits {
+			if err := srv.Send(&WatchCommitsResponse{Id: cid}); err != nil {
+				return err
+			}
+			last = cid
+		}
+
+		select {
+		case <-srv.Context().Done():
+			return status.Error(codes.Canceled, "canceled")
+		case _, ok := <-sub.ch:
+			if !ok {
+				if head.GetName() != "" {
+					return status.Error(codes.NotFound, "branch deleted")
+				}
+				return status.Error(codes.NotFound, "volume deleted")
+			}
+		}
+	}
+}
+
+// watchedHead returns the commit pointed by the head.  If the volume has no commits, it returns nil.
+func (v *localVolumeServer) watchedHead(head *RefID) (*CommitID, error) {
+	if head.GetName() != "" {
+		ref, err := v.cs.GetRef(head)
+		if err != nil {
+			if errors.Is(err, controller_db.ErrNotFoundRef) {
+				return nil, status.Error(codes.NotFound, err.Error())
+			}
+			log.Printf("[ERROR] %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return ref.GetCommit(), nil
+	}
+
+	latest, err := v.cs.Latest(head.GetId())
+	if err != nil {
+		if errors.Is(err, controller_db.ErrNotFoundCommit) {
+			return nil, nil
+		}
+		if errors.Is(err, &controller_db.InputError{}) {
+			log.Printf("[CRITICAL] Missing error handling: %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.Printf("[ERROR] %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return latest, nil
+}
+
+// replayCommits returns commits after since until the head in ascending order.  New commits are always based on the
+// previous head, so they are found along left parents.  The head may move to older commit by UpdateRef(), so we
+// should not compare commit numbers.  If since is not found within watchReplayLimit commits, it returns only the head.
+func (v *localVolumeServer) replayCommits(since, head *CommitID) ([]*CommitID, error) {
+	if head == nil || head.Equals(since) {
+		// Already sent.
+		return nil, nil
+	}
+	if since == nil {
+		return []*CommitID{head}, nil
+	}
+
+	commits := []*CommitID{head}
+	for len(commits) < watchReplayLimit {
+		left, _, err := v.cs.Parents(commits[len(commits)-1])
+		if err != nil {
+			if errors.Is(err, controller_db.ErrNotFoundCommit) {
+				break
+			}
+			log.Printf("[ERROR] %+v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if left == nil {
+			break
+		}
+		if left.Equals(since) {
+			for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+				commits[i], commits[j] = commits[j], commits[i]
+			}
+			return commits, nil
+		}
+		commits = append(commits, left)
+	}
+	return []*CommitID{head}, nil
+}
+
+// commitWatcher delivers the latest commit of volumes to subscribers.
+type commitWatcher struct {
+	lock sync.Mutex
+	subs map[string]map[*commitSubscription]struct{}
+}
+
+// commitSubscription receives the latest commit of a volume or the head of a branch.  If the subscriber is slow,
+// intermediate commits are coalesced and only the latest commit is kept.  The channel is closed when the volume or
+// the branch is deleted.
+type commitSubscription struct {
+	vid    string
+	branch string
+	ch     chan *CommitID
+}
+
+func newCommitWatcher() *commitWatcher {
+	return &commitWatcher{
+		subs: map[string]map[*commitSubscription]struct{}{},
+	}
+}
+
+// Subscribe starts watching the latest commit of the volume.  Caller must call Unsubscribe after use.
+func (w *commitWatcher) Subscribe(vid *VolumeID) *commitSubscription {
+	return w.SubscribeRef(&RefID{Id: vid})
+}
+
+// SubscribeRef starts watching the head.  If head.Name is empty, it watches the latest commit of the volume.  Caller
+// must call Unsubscribe after use.
+func (w *commitWatcher) SubscribeRef(head *RefID) *commitSubscription {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	sub := &commitSubscription{
+		vid:    head.GetId().GetId(),
+		branch: head.GetName(),
+		ch:     make(chan *CommitID, 1),
+	}
+	if w.subs[sub.vid] == nil {
+		w.subs[sub.vid] = map[*commitSubscription]struct{}{}
+	}
+	w.subs[sub.vid][sub] = struct{}{}
+	return sub
+}
+func (w *commitWatcher) Unsubscribe(sub *commitSubscription) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	subs := w.subs[sub.vid]
+	if _, ok := subs[sub]; !ok {
+		// Already removed by CloseVolume().
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(w.subs, sub.vid)
+	}
+}
+
+// Notify sends the new latest commit to all subscribers of the volume.  It never blocks.
+func (w *commitWatcher) Notify(cid *CommitID) {
+	w.NotifyRef(&RefID{Id: cid.GetId()}, cid)
+}
+
+// NotifyRef sends the new commit pointed by the head to all subscribers of the head.  It never blocks.
+func (w *commitWatcher) NotifyRef(head *RefID, cid *CommitID) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for sub := range w.subs[head.GetId().GetId()] {
+		if sub.branch != head.GetName() {
+			continue
+		}
+		select {
+		case sub.ch <- cid:
+		default:
+			// The subscriber has not received previous commit yet.  Replace it with new one.
+			select {
+			case <-sub.ch:
+			default:
+			}
+			sub.ch <- cid
+		}
+	}
+}
+
+// CloseRef closes all subscriptions of the branch.
+func (w *commitWatcher) CloseRef(head *RefID) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	subs := w.subs[head.GetId().GetId()]
+	for sub := range subs {
+		if sub.branch == head.GetName() {
+			close(sub.ch)
+			delete(subs, sub)
+		}
+	}
+	if len(subs) == 0 {
+		delete(w.subs, head.GetId().GetId())
+	}
+}
+
+// CloseVolume closes all subscriptions of the volume.
+func (w *commitWatcher) CloseVolume(vid *VolumeID) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for sub := range w.subs[vid.GetId()] {
+		close(sub.ch)
+	}
+	delete(w.subs, vid.GetId())
+}
@@ -0,0 +1,265 @@
+package simple
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestCommitWatcher(t *testing.T) {
+	vid := &elton_v2.VolumeID{Id: "foo"}
+	cid := func(n uint64) *elton_v2.CommitID {
+		return &elton_v2.CommitID{Id: vid, Number: n}
+	}
+
+	t.Run("should_coalesce_commits_when_subscriber_is_slow", func(t *testing.T) {
+		w := newCommitWatcher()
+		sub := w.Subscribe(vid)
+		defer w.Unsubscribe(sub)
+
+		w.Notify(cid(1))
+		w.Notify(cid(2))
+		w.Notify(cid(3))
+		assert.Equal(t, uint64(3), (<-sub.ch).GetNumber())
+		assert.Len(t, sub.ch, 0)
+	})
+	t.Run("should_not_notify_other_volumes", func(t *testing.T) {
+		w := newCommitWatcher()
+		sub := w.Subscribe(vid)
+		defer w.Unsubscribe(sub)
+
+		w.Notify(&elton_v2.CommitID{Id: &elton_v2.VolumeID{Id: "bar"}, Number: 1})
+		assert.Len(t, sub.ch, 0)
+	})
+	t.Run("should_close_subscriptions_when_volume_closed", func(t *testing.T) {
+		w := newCommitWatcher()
+		sub := w.Subscribe(vid)
+
+		w.CloseVolume(vid)
+		_, ok := <-sub.ch
+		assert.False(t, ok)
+		// Unsubscribe after CloseVolume should not panic.
+		w.Unsubscribe(sub)
+		assert.Len(t, w.subs, 0)
+	})
+	t.Run("should_notify_only_subscribers_of_the_branch", func(t *testing.T) {
+		w := newCommitWatcher()
+		latest := w.Subscribe(vid)
+		defer w.Unsubscribe(latest)
+		branch := w.SubscribeRef(&elton_v2.RefID{Id: vid, Name: "topic"})
+		defer w.Unsubscribe(branch)
+
+		w.NotifyRef(&elton_v2.RefID{Id: vid, Name: "topic"}, cid(1))
+		assert.Len(t, latest.ch, 0)
+		assert.Equal(t, uint64(1), (<-branch.ch).GetNumber())
+
+		w.CloseRef(&elton_v2.RefID{Id: vid, Name: "topic"})
+		_, ok := <-branch.ch
+		assert.False(t, ok)
+		w.Notify(cid(2))
+		assert.Equal(t, uint64(2), (<-latest.ch).GetNumber())
+	})
+}
+
+func TestLocalVolumeServer_WatchCommits(t *testing.T) {
+	newCommit := func() *elton_v2.CommitRequest {
+		return &elton_v2.CommitRequest{
+			Info: &elton_v2.CommitInfo{
+				CreatedAt: ptypes.TimestampNow(),
+				Tree:      createEmptyTree(),
+			},
+		}
+	}
+
+	t.Run("should_receive_new_commits", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			vid, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{newCommit()})
+
+			client := elton_v2.NewCommitServiceClient(dial())
+			stream, err := client.WatchCommits(ctx, &elton_v2.WatchCommitsRequest{Id: vid})
+			if !assert.NoError(t, err) {
+				return
+			}
+			// The current latest commit is sent at first.
+			res, err := stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, commits[0].GetNumber(), res.GetId().GetNumber())
+
+			commit := newCommit()
+			commit.Id = vid
+			commit.Info.LeftParentID = commits[0]
+			cres, err := client.Commit(ctx, commit)
+			if !assert.NoError(t, err) {
+				return
+			}
+			res, err = stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, cres.GetId().GetNumber(), res.GetId().GetNumber())
+		})
+	})
+	t.Run("should_skip_commits_already_received", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			vid, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{newCommit()})
+
+			client := elton_v2.NewCommitServiceClient(dial())
+			stream, err := client.WatchCommits(ctx, &elton_v2.WatchCommitsRequest{
+				Id:    vid,
+				Since: commits[0],
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			commit := newCommit()
+			commit.Id = vid
+			commit.Info.LeftParentID = commits[0]
+			cres, err := client.Commit(ctx, commit)
+			if !assert.NoError(t, err) {
+				return
+			}
+			// The first response should be the new commit instead of commits[0].
+			res, err := stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, cres.GetId().GetNumber(), res.GetId().GetNumber())
+		})
+	})
+	t.Run("should_replay_commits_after_since", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			vid, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{
+				newCommit(), newCommit(), newCommit(),
+			})
+
+			client := elton_v2.NewCommitServiceClient(dial())
+			stream, err := client.WatchCommits(ctx, &elton_v2.WatchCommitsRequest{
+				Id:    vid,
+				Since: commits[0],
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			// Commits created while the client is disconnected should be sent in order.
+			for _, cid := range commits[1:] {
+				res, err := stream.Recv()
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, cid.GetNumber(), res.GetId().GetNumber())
+			}
+		})
+	})
+	t.Run("should_watch_branch", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			vid, commits := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{newCommit()})
+
+			client := elton_v2.NewCommitServiceClient(dial())
+			branch := &elton_v2.RefID{Id: vid, Name: "topic"}
+			_, err := client.CreateRef(ctx, &elton_v2.CreateRefRequest{
+				Id:  branch,
+				Ref: &elton_v2.Ref{Type: elton_v2.RefType_Branch, Commit: commits[0]},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			stream, err := client.WatchCommits(ctx, &elton_v2.WatchCommitsRequest{
+				Id:     vid,
+				Branch: branch.GetName(),
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			res, err := stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, commits[0].GetNumber(), res.GetId().GetNumber())
+
+			commit := newCommit()
+			commit.Id = vid
+			commit.Branch = branch.GetName()
+			commit.Info.LeftParentID = commits[0]
+			cres, err := client.Commit(ctx, commit)
+			if !assert.NoError(t, err) {
+				return
+			}
+			res, err = stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, cres.GetId().GetNumber(), res.GetId().GetNumber())
+
+			_, err = client.DeleteRef(ctx, &elton_v2.DeleteRefRequest{Id: branch})
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = stream.Recv()
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("should_stop_when_volume_deleted", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			vid, _ := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{newCommit()})
+
+			client := elton_v2.NewCommitServiceClient(dial())
+			stream, err := client.WatchCommits(ctx, &elton_v2.WatchCommitsRequest{Id: vid})
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = stream.Recv()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			vc := elton_v2.NewVolumeServiceClient(dial())
+			_, err = vc.DeleteVolume(ctx, &elton_v2.DeleteVolumeRequest{Id: vid})
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = stream.Recv()
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("should_fail_when_volume_not_found", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			client := elton_v2.NewCommitServiceClient(dial())
+			stream, err := client.WatchCommits(ctx, &elton_v2.WatchCommitsRequest{
+				Id: &elton_v2.VolumeID{Id: "not-found"},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = stream.Recv()
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("should_fail_when_since_is_other_volume", func(t *testing.T) {
+		utils.WithTestServer(&Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+			vid, _ := createCommits(t, dial, ctx, "test-volume", []*elton_v2.CommitRequest{newCommit()})
+
+			client := elton_v2.NewCommitServiceClient(dial())
+			stream, err := client.WatchCommits(ctx, &elton_v2.WatchCommitsRequest{
+				Id: vid,
+				Since: &elton_v2.CommitID{
+					Id:     &elton_v2.VolumeID{Id: "other"},
+					Number: 1,
+				},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = stream.Recv()
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+}
@@ -0,0 +1,249 @@
+package nodeagent
+
+import (
+	"context"
+	"github.com/golang/protobuf/proto"
+	"github.com/yuuki0xff/pathlib"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/idgen"
+	localStorage "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/storage/local"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultNodeIDFile        = "/var/lib/elton/node-id"
+	DefaultHeartbeatInterval = 10 * time.Second
+)
+
+// processStartedAt is used to calculate uptime of eltond.  Uptime is not reset when the agent is restarted by
+// ServerManager.
+var processStartedAt = time.Now()
+
+func NewAgent(roles []string) *Agent {
+	return &Agent{
+		Roles:             roles,
+		NodeIDFile:        DefaultNodeIDFile,
+		HeartbeatInterval: DefaultHeartbeatInterval,
+		NodeService:       elton_v2.NodeService,
+	}
+}
+
+// Agent registers this node to the controller and sends heartbeats periodically.  The node is unregistered when the
+// context is cancelled.
+type Agent struct {
+	// Roles of eltond.
+	Roles []string
+	// Addresses of this node.  If it is empty, addresses of all non-loopback interfaces are used.
+	Addresses []string
+	// Human readable name.  If it is empty, hostname is used.
+	NodeName string
+	// Arbitrary labels such as zone or rack.
+	Labels map[string]string
+	// Path to the object storage.  If it is not empty, disk usage of the storage is reported to the controller.
+	StorageDir string
+	// Path to the file that the node ID is stored.  The ID is generated at first start.
+	NodeIDFile        string
+	HeartbeatInterval time.Duration
+	// NodeService returns a client of the controller.  The returned client must implement io.Closer.
+	NodeService func() (elton_v2.NodeServiceClient, error)
+
+	id   *elton_v2.NodeID
+	node *elton_v2.Node
+}
+
+func (a *Agent) Name() string {
+	return "node-agent"
+}
+func (a *Agent) Configure() error {
+	id, err := loadOrCreateNodeID(a.NodeIDFile)
+	if err != nil {
+		return xerrors.Errorf("node id: %w", err)
+	}
+
+	name := a.NodeName
+	if name == "" {
+		name, err = os.Hostname()
+		if err != nil {
+			return xerrors.Errorf("hostname: %w", err)
+		}
+	}
+
+	addrs := a.Addresses
+	if len(addrs) == 0 {
+		addrs, err = localAddresses()
+		if err != nil {
+			return xerrors.Errorf("addresses: %w", err)
+		}
+	}
+
+	a.id = &elton_v2.NodeID{Id: id}
+	a.node = &elton_v2.Node{
+		Address: addrs,
+		Name:    name,
+		Roles:   a.Roles,
+		Labels:  a.Labels,
+		Version: subsystems.Version,
+	}
+	return nil
+}
+func (a *Agent) Listen() error {
+	// Do nothing.
+	return nil
+}
+func (a *Agent) SetListener(l net.Listener) {
+	// Do nothing.
+}
+func (a *Agent) Serve(ctx context.Context) error {
+	nc, err := a.NodeService()
+	if err != nil {
+		return xerrors.Errorf("api client: %w", err)
+	}
+	defer elton_v2.Close(nc)
+
+	if err := a.register(ctx, nc); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(a.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return a.unregister(nc)
+		case <-ticker.C:
+			if err := a.ping(ctx, nc); err != nil {
+				return err
+			}
+		}
+	}
+}
+func (a *Agent) register(ctx context.Context, nc elton_v2.NodeServiceClient) error {
+	_, err := nc.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+		Id:   a.id,
+		Node: a.nodeInfo(),
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		// eltond was restarted without unregistration.  The node is still alive, so continue sending heartbeats.
+		log.Printf("[INFO] node %s is already registered", a.id.GetId())
+		return a.ping(ctx, nc)
+	}
+	if err != nil {
+		return xerrors.Errorf("register node: %w", err)
+	}
+	return nil
+}
+func (a *Agent) unregister(nc elton_v2.NodeServiceClient) error {
+	ctx, cancel := context.WithTimeout(context.Background(), elton_v2.DefaultAPITimeout)
+	defer cancel()
+
+	_, err := nc.UnregisterNode(ctx, &elton_v2.UnregisterNodeRequest{Id: a.id})
+	if status.Code(err) == codes.NotFound {
+		// Already evicted by the controller.
+		return nil
+	}
+	if status.Code(err) == codes.FailedPrecondition {
+		// The storage node keeps registered until it is drained.  It will be registered again after restart.
+		log.Printf("[INFO] node %s is not unregistered: %s", a.id.GetId(), status.Convert(err).Message())
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf("unregister node: %w", err)
+	}
+	return nil
+}
+
+// ping sends a heartbeat.  It returns RestartRequest if the node was evicted by the controller, then ServerManager
+// registers the node again.  Other errors are ignored because the next heartbeat may succeed.
+func (a *Agent) ping(ctx context.Context, nc elton_v2.NodeServiceClient) error {
+	_, err := nc.Ping(ctx, &elton_v2.PingNodeRequest{
+		Id:     a.id,
+		Uptime: uint64(time.Since(processStartedAt).Seconds()),
+		Node:   a.nodeInfo(),
+	})
+	if status.Code(err) == codes.NotFound {
+		return subsystems.NewRestartRequest("node is not registered")
+	}
+	if err != nil {
+		log.Printf("[WARN] ping: %+v", err)
+	}
+	return nil
+}
+
+// nodeInfo returns the current node information with the latest disk usage.
+func (a *Agent) nodeInfo() *elton_v2.Node {
+	node := proto.Clone(a.node).(*elton_v2.Node)
+	if a.StorageDir != "" {
+		repo := localStorage.NewRepository(pathlib.New(a.StorageDir), nil, localStorage.DefaultMaxObjectSize)
+		usage, err := repo.Usage()
+		if err != nil {
+			log.Printf("[WARN] storage usage: %+v", err)
+		} else {
+			node.Capacity = &elton_v2.NodeCapacity{
+				TotalBytes: usage.TotalBytes,
+				FreeBytes:  usage.FreeBytes,
+				Objects:    usage.Objects,
+			}
+		}
+	}
+	return node
+}
+
+// loadOrCreateNodeID reads the node ID from the file.  If the file does not exist, a new ID is generated and saved to
+// the file.
+func loadOrCreateNodeID(file string) (string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err == nil {
+		id := strings.TrimSpace(string(data))
+		if id == "" {
+			return "", xerrors.Errorf("%s: empty node id", file)
+		}
+		return id, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", xerrors.Errorf("%s: %w", file, err)
+	}
+
+	id, err := idgen.Gen.NextStringID()
+	if err != nil {
+		return "", xerrors.Errorf("generate id: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return "", xerrors.Errorf("mkdir: %w", err)
+	}
+	// Write to the temporary file and rename it to prevent a broken ID file from being left.
+	tmp := file + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(id+"\n"), 0644); err != nil {
+		return "", xerrors.Errorf("%s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		return "", xerrors.Errorf("rename: %w", err)
+	}
+	return id, nil
+}
+
+// localAddresses returns IP addresses of all non-loopback interfaces.
+func localAddresses() ([]string, error) {
+	ifaddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+	var addrs []string
+	for _, ifaddr := range ifaddrs {
+		ipnet, ok := ifaddr.(*net.IPNet)
+		if !ok || ipnet.IP.IsLoopback() || ipnet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		addrs = append(addrs, ipnet.IP.String())
+	}
+	return addrs, nil
+}
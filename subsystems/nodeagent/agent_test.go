@@ -0,0 +1,168 @@
+package nodeagent
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/subsystems/controller/simple"
+	"gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/utils"
+	"google.golang.org/grpc"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type closableNodeServiceClient struct {
+	io.Closer
+	elton_v2.NodeServiceClient
+}
+
+func withAgent(t *testing.T, callback func(ctx context.Context, a *Agent, nc elton_v2.NodeServiceClient)) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	utils.WithTestServer(&simple.Server{}, func(ctx context.Context, dial func() *grpc.ClientConn) {
+		a := NewAgent([]string{"storage"})
+		a.Addresses = []string{"192.0.2.1"}
+		a.NodeName = "test-node"
+		a.Labels = map[string]string{"zone": "a"}
+		a.StorageDir = filepath.Join(dir, "storage")
+		a.NodeIDFile = filepath.Join(dir, "node-id")
+		a.HeartbeatInterval = 10 * time.Millisecond
+		a.NodeService = func() (elton_v2.NodeServiceClient, error) {
+			conn := dial()
+			return &closableNodeServiceClient{
+				Closer:            conn,
+				NodeServiceClient: elton_v2.NewNodeServiceClient(conn),
+			}, nil
+		}
+		if !assert.NoError(t, a.Configure()) {
+			return
+		}
+		callback(ctx, a, elton_v2.NewNodeServiceClient(dial()))
+	})
+}
+func listNodes(t *testing.T, ctx context.Context, nc elton_v2.NodeServiceClient) []*elton_v2.ListNodesResponse {
+	stream, err := nc.ListNodes(ctx, &elton_v2.ListNodesRequest{})
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	var nodes []*elton_v2.ListNodesResponse
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nodes
+		}
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		nodes = append(nodes, res)
+	}
+}
+
+func TestLoadOrCreateNodeID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "sub", "node-id")
+
+	id1, err := loadOrCreateNodeID(file)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id1)
+	// Should return the same ID after restart.
+	id2, err := loadOrCreateNodeID(file)
+	assert.NoError(t, err)
+	assert.Equal(t, id1, id2)
+}
+
+func TestAgent_Serve(t *testing.T) {
+	t.Run("should_register_and_unregister", func(t *testing.T) {
+		withAgent(t, func(ctx context.Context, a *Agent, nc elton_v2.NodeServiceClient) {
+			agentCtx, cancel := context.WithCancel(ctx)
+			done := make(chan error)
+			go func() {
+				done <- a.Serve(agentCtx)
+			}()
+
+			assert.Eventually(t, func() bool {
+				return len(listNodes(t, ctx, nc)) == 1
+			}, time.Second, 10*time.Millisecond)
+			nodes := listNodes(t, ctx, nc)
+			if assert.Len(t, nodes, 1) {
+				assert.Equal(t, a.id.GetId(), nodes[0].GetId().GetId())
+				assert.Equal(t, "test-node", nodes[0].GetNode().GetName())
+				assert.Equal(t, []string{"192.0.2.1"}, nodes[0].GetNode().GetAddress())
+				assert.Equal(t, []string{"storage"}, nodes[0].GetNode().GetRoles())
+				assert.Equal(t, map[string]string{"zone": "a"}, nodes[0].GetNode().GetLabels())
+				assert.Equal(t, subsystems.Version, nodes[0].GetNode().GetVersion())
+				assert.NotZero(t, nodes[0].GetNode().GetCapacity().GetTotalBytes())
+				assert.Equal(t, elton_v2.NodeLiveness_Alive, nodes[0].GetLiveness())
+			}
+
+			cancel()
+			assert.NoError(t, <-done)
+			// The storage node is not drained.  It should be kept registered.
+			assert.Len(t, listNodes(t, ctx, nc), 1)
+		})
+	})
+	t.Run("should_unregister_node_without_storage_role", func(t *testing.T) {
+		withAgent(t, func(ctx context.Context, a *Agent, nc elton_v2.NodeServiceClient) {
+			a.node.Roles = []string{"controller"}
+			agentCtx, cancel := context.WithCancel(ctx)
+			done := make(chan error)
+			go func() {
+				done <- a.Serve(agentCtx)
+			}()
+
+			assert.Eventually(t, func() bool {
+				return len(listNodes(t, ctx, nc)) == 1
+			}, time.Second, 10*time.Millisecond)
+			cancel()
+			assert.NoError(t, <-done)
+			assert.Len(t, listNodes(t, ctx, nc), 0)
+		})
+	})
+	t.Run("should_continue_when_already_registered", func(t *testing.T) {
+		withAgent(t, func(ctx context.Context, a *Agent, nc elton_v2.NodeServiceClient) {
+			_, err := nc.RegisterNode(ctx, &elton_v2.RegisterNodeRequest{
+				Id:   a.id,
+				Node: a.node,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			agentCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+			assert.NoError(t, a.Serve(agentCtx))
+		})
+	})
+	t.Run("should_request_restart_when_evicted", func(t *testing.T) {
+		withAgent(t, func(ctx context.Context, a *Agent, nc elton_v2.NodeServiceClient) {
+			done := make(chan error)
+			go func() {
+				done <- a.Serve(ctx)
+			}()
+
+			assert.Eventually(t, func() bool {
+				return len(listNodes(t, ctx, nc)) == 1
+			}, time.Second, 10*time.Millisecond)
+			_, err := nc.UnregisterNode(ctx, &elton_v2.UnregisterNodeRequest{Id: a.id, Force: true})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			err = <-done
+			assert.IsType(t, &subsystems.RestartRequest{}, err)
+		})
+	})
+}
@@ -8,10 +8,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/yuuki0xff/pathlib"
+	"golang.org/x/sys/unix"
 	"golang.org/x/xerrors"
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -27,6 +29,15 @@ type Repository struct {
 	initDir sync.Once
 	limit   ObjectLimitV1
 }
+// Usage is the disk usage of the repository.
+type Usage struct {
+	// Size of the filesystem that the repository is stored.
+	TotalBytes uint64
+	// Available bytes for unprivileged users.
+	FreeBytes uint64
+	// Number of stored objects.
+	Objects uint64
+}
 type Key struct {
 	ID string
 }
@@ -98,6 +109,39 @@ func (s *Repository) Create(body []byte, info Info) (Key, error) {
 	}
 	return key, nil
 }
+
+// Put saves an object with the specified key.  It is used when the key is decided by others (e.g. importing objects
+// from other clusters).  If the object already exists, it returns ObjectAlreadyExistsError.
+func (s *Repository) Put(key Key, body []byte, info Info) error {
+	if key.ID == "" || strings.ContainsAny(key.ID, "/\x00") || key.ID == "." || key.ID == ".." {
+		return NewInvalidObject("invalid key").Wrap(nil)
+	}
+	if err := s.fillInfo(body, &info); err != nil {
+		return err
+	}
+
+	if err := s.createDir(); err != nil {
+		return err
+	}
+
+	op := s.objectPath(key)
+	if op.Exists() {
+		return NewObjectAlreadyExistsError(key).Wrap(nil)
+	}
+	tmp := s.tmpObjectPath(key)
+	err := AtomicWrite(op, tmp, func(w io.Writer) error {
+		obj := NewObjectV1(body, &info, s.limit)
+		return obj.Save(w)
+	})
+	if err != nil {
+		if os.IsExist(err) {
+			// Other goroutine is writing the same object.
+			return NewObjectAlreadyExistsError(key).Wrap(err)
+		}
+		return err
+	}
+	return nil
+}
 func (s *Repository) Get(key Key, offset, size uint64) ([]byte, *Info, error) {
 	p := s.objectPath(key)
 
@@ -131,6 +175,61 @@ func (s *Repository) Delete(key Key) (bool, error) {
 	// Deleted the object.
 	return true, nil
 }
+// List calls fn for each stored object in ascending order of keys.  If after is not empty, only keys greater than after
+// are listed.  If fn returns an error, return immediately it.
+func (s *Repository) List(after string, fn func(key Key) error) error {
+	if err := s.createDir(); err != nil {
+		return err
+	}
+
+	names, err := s.objectNames()
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name <= after {
+			continue
+		}
+		if err := fn(Key{ID: name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (s *Repository) Usage() (*Usage, error) {
+	if err := s.createDir(); err != nil {
+		return nil, err
+	}
+
+	stat := &unix.Statfs_t{}
+	if err := unix.Statfs(s.BasePath.String(), stat); err != nil {
+		return nil, xerrors.Errorf("statfs: %w", err)
+	}
+
+	names, err := s.objectNames()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Usage{
+		TotalBytes: stat.Blocks * uint64(stat.Bsize),
+		FreeBytes:  stat.Bavail * uint64(stat.Bsize),
+		Objects:    uint64(len(names)),
+	}, nil
+}
+func (s *Repository) objectNames() ([]string, error) {
+	f, err := os.Open(s.BasePath.JoinPath("object").String())
+	if err != nil {
+		return nil, xerrors.Errorf("repository: %w", err)
+	}
+	defer f.Close()
+	names, err := f.Readdirnames(-1)
+	if err != nil {
+		return nil, xerrors.Errorf("repository: %w", err)
+	}
+	return names, nil
+}
 func (s *Repository) createDir() (err error) {
 	s.initDir.Do(func() {
 		if err = s.BasePath.JoinPath("object").MkDir(directoryMode, true); err != nil {
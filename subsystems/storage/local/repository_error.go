@@ -103,3 +103,27 @@ func (e *InvalidObject) Is(err error) bool {
 	var other *InvalidObject
 	return xerrors.As(err, &other)
 }
+
+type ObjectAlreadyExistsError struct {
+	werror.WrapError
+	key Key
+}
+
+func NewObjectAlreadyExistsError(key Key) *ObjectAlreadyExistsError {
+	err := &ObjectAlreadyExistsError{
+		key: key,
+	}
+	err.WrapError = werror.Wrap(err, nil, 2)
+	return err
+}
+func (e ObjectAlreadyExistsError) Wrap(next error) error {
+	e.WrapError = werror.Wrap(&e, next, 2)
+	return &e
+}
+func (e *ObjectAlreadyExistsError) Error() string {
+	return fmt.Sprintf("object already exists: key=%s", e.key)
+}
+func (e *ObjectAlreadyExistsError) Is(err error) bool {
+	var other *ObjectAlreadyExistsError
+	return xerrors.As(err, &other)
+}
@@ -7,6 +7,7 @@ import (
 	"golang.org/x/xerrors"
 	"io/ioutil"
 	"os"
+	"sort"
 	"testing"
 	"time"
 )
@@ -97,6 +98,35 @@ func TestRepository_Create(t *testing.T) {
 		})
 	})
 }
+func TestRepository_Put(t *testing.T) {
+	body := []byte("test")
+
+	t.Run("normal-case", func(t *testing.T) {
+		withTempRepo(10, func(repo *Repository) {
+			err := repo.Put(Key{"foo"}, body, Info{})
+			assert.NoError(t, err)
+
+			body2, _, err := repo.Get(Key{"foo"}, 0, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, body, body2)
+		})
+	})
+	t.Run("already-exists", func(t *testing.T) {
+		withTempRepo(10, func(repo *Repository) {
+			err := repo.Put(Key{"foo"}, body, Info{})
+			assert.NoError(t, err)
+
+			err = repo.Put(Key{"foo"}, []byte("other"), Info{})
+			assert.True(t, xerrors.Is(err, &ObjectAlreadyExistsError{}))
+		})
+	})
+	t.Run("invalid-key", func(t *testing.T) {
+		withTempRepo(10, func(repo *Repository) {
+			err := repo.Put(Key{"../foo"}, body, Info{})
+			assert.True(t, xerrors.Is(err, &InvalidObject{}))
+		})
+	})
+}
 func TestRepository_Get(t *testing.T) {
 	objs := [][]byte{
 		[]byte("test"),
@@ -139,3 +169,62 @@ func TestExists(t *testing.T) {
 		})
 	})
 }
+func TestRepository_Usage(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		withTempRepo(10, func(repo *Repository) {
+			usage, err := repo.Usage()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, uint64(0), usage.Objects)
+			assert.NotZero(t, usage.TotalBytes)
+			assert.True(t, usage.FreeBytes <= usage.TotalBytes)
+		})
+	})
+	t.Run("with-objects", func(t *testing.T) {
+		objs := [][]byte{
+			[]byte("foo"),
+			[]byte("bar"),
+		}
+		withTempRepoAndObject(10, objs, func(repo *Repository, keys []Key) {
+			usage, err := repo.Usage()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, uint64(2), usage.Objects)
+		})
+	})
+}
+func TestRepository_List(t *testing.T) {
+	objs := [][]byte{
+		[]byte("foo"),
+		[]byte("bar"),
+		[]byte("baz"),
+	}
+	list := func(repo *Repository, after string) []string {
+		var ids []string
+		err := repo.List(after, func(key Key) error {
+			ids = append(ids, key.ID)
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+		return ids
+	}
+
+	t.Run("all", func(t *testing.T) {
+		withTempRepoAndObject(10, objs, func(repo *Repository, keys []Key) {
+			ids := list(repo, "")
+			assert.Len(t, ids, 3)
+			assert.True(t, sort.StringsAreSorted(ids))
+		})
+	})
+	t.Run("after", func(t *testing.T) {
+		withTempRepoAndObject(10, objs, func(repo *Repository, keys []Key) {
+			all := list(repo, "")
+			assert.Equal(t, all[1:], list(repo, all[0]))
+			assert.Len(t, list(repo, all[2]), 0)
+		})
+	})
+}
@@ -2,6 +2,7 @@ package localStorage
 
 import (
 	"context"
+	"errors"
 	"github.com/golang/protobuf/ptypes"
 	elton_v2 "gitlab.t-lab.cs.teu.ac.jp/yuuki/elton/api/v2"
 	"google.golang.org/grpc/codes"
@@ -18,6 +19,25 @@ func (s *StorageService) CreateObject(ctx context.Context, req *elton_v2.CreateO
 	}
 
 	body := req.GetBody().GetContents()
+	if !req.GetKey().Empty() {
+		// Save the object with specified key.
+		key := Key{
+			ID: req.GetKey().GetId(),
+		}
+		if err := s.Repo.Put(key, body, Info{}); err != nil {
+			if errors.Is(err, &ObjectAlreadyExistsError{}) {
+				return nil, status.Errorf(codes.AlreadyExists, "%s", err.Error())
+			}
+			if errors.Is(err, &InvalidObject{}) {
+				return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+			}
+			return nil, status.Errorf(codes.Internal, "failed to create object: %s", err.Error())
+		}
+		return &elton_v2.CreateObjectResponse{
+			Key: req.GetKey(),
+		}, nil
+	}
+
 	key, err := s.Repo.Create(body, Info{})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create object: %s", err.Error())
@@ -77,3 +97,16 @@ func (s *StorageService) DeleteObject(ctx context.Context, req *elton_v2.DeleteO
 
 	return &elton_v2.DeleteObjectResponse{}, nil
 }
+func (s *StorageService) ListObjects(req *elton_v2.ListObjectsRequest, stream elton_v2.StorageService_ListObjectsServer) error {
+	err := s.Repo.List(req.GetAfter(), func(key Key) error {
+		return stream.Send(&elton_v2.ListObjectsResponse{
+			Key: &elton_v2.ObjectKey{
+				Id: key.ID,
+			},
+		})
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "local storage: failed to list objects: %s", err.Error())
+	}
+	return nil
+}
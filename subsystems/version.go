@@ -0,0 +1,4 @@
+package subsystems
+
+// Version of elton.  It is overwritten at build time by "-ldflags -X".
+var Version = "dev"
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"bytes"
+	"golang.org/x/xerrors"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxMergeEdits is the maximum edit distance between the base text and the changed text.  Merge3() gives up merging
+// if texts are changed too much.  matchLines() keeps O(maxMergeEdits^2) integers for backtracking, so it must be
+// small enough to merge large files on the controller.
+const maxMergeEdits = 500
+
+var ErrOverlappedChanges = xerrors.New("overlapped changes")
+var ErrTooManyChanges = xerrors.New("too many changes")
+
+// IsText reports whether the data looks like a text.  It is a valid UTF-8 string without NUL characters.
+func IsText(data []byte) bool {
+	return utf8.Valid(data) && bytes.IndexByte(data, 0) < 0
+}
+
+// Merge3 merges two texts changed from the base text by the line-based diff3 algorithm.  If both texts changed the
+// same lines or adjacent lines differently, it returns ErrOverlappedChanges.
+func Merge3(base, a, b []byte) ([]byte, error) {
+	o := splitLines(base)
+	as := splitLines(a)
+	bs := splitLines(b)
+	ma, ok := matchLines(o, as, maxMergeEdits)
+	if !ok {
+		return nil, ErrTooManyChanges
+	}
+	mb, ok := matchLines(o, bs, maxMergeEdits)
+	if !ok {
+		return nil, ErrTooManyChanges
+	}
+
+	var out bytes.Buffer
+	i, ia, ib := 0, 0, 0
+	for {
+		// Find the next line that is not changed in both texts.
+		next := i
+		for next < len(o) && (ma[next] < 0 || mb[next] < 0) {
+			next++
+		}
+		ja, jb := len(as), len(bs)
+		if next < len(o) {
+			ja, jb = ma[next], mb[next]
+		}
+
+		if next == i && ja == ia && jb == ib {
+			if next == len(o) {
+				break
+			}
+			// Stable line.
+			out.WriteString(o[i])
+			i++
+			ia++
+			ib++
+			continue
+		}
+
+		chunkO := o[i:next]
+		chunkA := as[ia:ja]
+		chunkB := bs[ib:jb]
+		switch {
+		case equalLines(chunkA, chunkO):
+			// Changed only in b.
+			writeLines(&out, chunkB)
+		case equalLines(chunkB, chunkO):
+			// Changed only in a.
+			writeLines(&out, chunkA)
+		case equalLines(chunkA, chunkB):
+			// Changed in the same way.
+			writeLines(&out, chunkA)
+		default:
+			return nil, ErrOverlappedChanges
+		}
+		i, ia, ib = next, ja, jb
+	}
+	return out.Bytes(), nil
+}
+
+// splitLines splits the text into lines.  Each line contains the trailing newline character.
+func splitLines(text []byte) []string {
+	lines := strings.SplitAfter(string(text), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+func writeLines(buff *bytes.Buffer, lines []string) {
+	for _, line := range lines {
+		buff.WriteString(line)
+	}
+}
+
+// matchLines finds the longest common subsequence of a and b by the Myers' algorithm.  match[i] is the index of the
+// line in b that matches a[i], or -1 if a[i] is not in the subsequence.  It returns false if the edit distance exceeds
+// maxEdits.
+func matchLines(a, b []string, maxEdits int) (match []int, ok bool) {
+	n, m := len(a), len(b)
+	maxD := n + m
+	if maxEdits < maxD {
+		maxD = maxEdits
+	}
+	offset := maxD + 1
+	v := make([]int, 2*offset+1)
+	// trace[d] keeps v[-d..d] before the step d.
+	var trace [][]int
+	for d := 0; d <= maxD; d++ {
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[offset-d:offset+d+1])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackLines(trace, n, m), true
+			}
+		}
+	}
+	return nil, false
+}
+func backtrackLines(trace [][]int, n, m int) []int {
+	match := make([]int, n)
+	for i := range match {
+		match[i] = -1
+	}
+
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		get := func(k int) int { return v[k+d] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && get(k-1) < get(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := get(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			match[x] = y
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		match[x] = y
+	}
+	return match
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMerge3(t *testing.T) {
+	lines := func(s ...string) []byte {
+		return []byte(strings.Join(s, "\n") + "\n")
+	}
+	base := lines("a", "b", "c", "d", "e")
+
+	tests := []struct {
+		name string
+		a    []byte
+		b    []byte
+		want []byte
+		err  error
+	}{
+		{
+			name: "no_changes",
+			a:    base,
+			b:    base,
+			want: base,
+		}, {
+			name: "changed_only_one_side",
+			a:    lines("a", "B", "c", "d", "e"),
+			b:    base,
+			want: lines("a", "B", "c", "d", "e"),
+		}, {
+			name: "changed_different_lines",
+			a:    lines("A", "b", "c", "d", "e"),
+			b:    lines("a", "b", "c", "d", "E"),
+			want: lines("A", "b", "c", "d", "E"),
+		}, {
+			name: "inserted_and_deleted",
+			a:    lines("a", "a2", "b", "c", "d", "e"),
+			b:    lines("a", "b", "c", "e"),
+			want: lines("a", "a2", "b", "c", "e"),
+		}, {
+			name: "changed_same_lines_in_same_way",
+			a:    lines("a", "B", "c", "d", "e"),
+			b:    lines("a", "B", "c", "d", "e"),
+			want: lines("a", "B", "c", "d", "e"),
+		}, {
+			name: "changed_same_lines_differently",
+			a:    lines("a", "B", "c", "d", "e"),
+			b:    lines("a", "X", "c", "d", "e"),
+			err:  ErrOverlappedChanges,
+		}, {
+			name: "appended_on_both_sides",
+			a:    lines("a", "b", "c", "d", "e", "f"),
+			b:    lines("a", "b", "c", "d", "e", "g"),
+			err:  ErrOverlappedChanges,
+		}, {
+			name: "no_trailing_newline",
+			a:    []byte("A\nb\nc\nd\ne\n"),
+			b:    []byte("a\nb\nc\nd\ne"),
+			want: []byte("A\nb\nc\nd\ne"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Merge3(base, tt.a, tt.b)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, string(tt.want), string(got))
+			}
+		})
+	}
+}
+func TestMerge3_TooManyChanges(t *testing.T) {
+	text := func(prefix string) []byte {
+		var buff strings.Builder
+		for i := 0; i < 100000; i++ {
+			buff.WriteString(prefix)
+			buff.WriteString(strconv.Itoa(i))
+			buff.WriteString("\n")
+		}
+		return []byte(buff.String())
+	}
+	base := text("base")
+
+	start := time.Now()
+	_, err := Merge3(base, text("a"), base)
+	assert.Equal(t, ErrTooManyChanges, err)
+	assert.True(t, time.Since(start) < 10*time.Second)
+}
+func TestMatchLines(t *testing.T) {
+	match, ok := matchLines(
+		[]string{"a", "b", "c", "a", "b", "b", "a"},
+		[]string{"c", "b", "a", "b", "a", "c"},
+		100,
+	)
+	assert.True(t, ok)
+	// The length of LCS is 4.
+	matched := 0
+	last := -1
+	for _, j := range match {
+		if j >= 0 {
+			assert.True(t, j > last)
+			last = j
+			matched++
+		}
+	}
+	assert.Equal(t, 4, matched)
+
+	_, ok = matchLines([]string{"a", "b"}, []string{"c", "d"}, 3)
+	assert.False(t, ok)
+}
+func TestIsText(t *testing.T) {
+	assert.True(t, IsText([]byte("foo\nbar\n")))
+	assert.False(t, IsText([]byte("foo\x00bar")))
+	assert.False(t, IsText([]byte{0xff, 0xfe}))
+}